@@ -0,0 +1,162 @@
+// Command migrate-state rewrites a Terraform state file created by the
+// terraform-plugin-sdk based releases of this provider (0.3.x and earlier) so
+// that it matches the schemas of the terraform-plugin-framework based
+// releases.
+//
+// Usage:
+//
+//	terraform state pull > old.tfstate
+//	go run github.com/umich-vci/terraform-provider-bluecat/cmd/migrate-state old.tfstate > new.tfstate
+//	terraform state push new.tfstate
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/umich-vci/terraform-provider-bluecat/internal/provider"
+)
+
+func main() {
+	var out string
+	var providerSource string
+
+	flag.StringVar(&out, "out", "", "write the migrated state to this file instead of stdout")
+	flag.StringVar(&providerSource, "provider", "umich-vci/bluecat", "only migrate resources that belong to a provider with this source address")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [state file]\n\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "The state file defaults to terraform.tfstate.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	in := "terraform.tfstate"
+	if flag.NArg() > 0 {
+		in = flag.Arg(0)
+	}
+
+	b, err := os.ReadFile(in)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	schemas, err := resourceSchemas(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var state map[string]interface{}
+	if err := json.Unmarshal(b, &state); err != nil {
+		log.Fatalf("failed to parse %s: %s", in, err)
+	}
+
+	count, err := migrateState(state, schemas, providerSource)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	b, err = json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	b = append(b, '\n')
+
+	if out == "" {
+		_, err = os.Stdout.Write(b)
+	} else {
+		err = os.WriteFile(out, b, 0600)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("migrated %d resource instances", count)
+}
+
+// resourceSchemas returns the schemas of the resources implemented by the provider.
+func resourceSchemas(ctx context.Context) (map[string]*tfprotov6.Schema, error) {
+	server := providerserver.NewProtocol6(provider.New("migrate-state")())()
+
+	resp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return nil, fmt.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	return resp.ResourceSchemas, nil
+}
+
+// migrateState rewrites the managed resources of providerSource in a version 4
+// state file in place and returns the number of resource instances migrated.
+func migrateState(state map[string]interface{}, schemas map[string]*tfprotov6.Schema, providerSource string) (int, error) {
+	if version, _ := state["version"].(float64); version != 4 {
+		return 0, fmt.Errorf("unsupported state file version %v, only version 4 is supported", state["version"])
+	}
+
+	resources, _ := state["resources"].([]interface{})
+	count := 0
+
+	for _, r := range resources {
+		res, ok := r.(map[string]interface{})
+		if !ok || res["mode"] != "managed" {
+			continue
+		}
+
+		if p, _ := res["provider"].(string); !strings.Contains(p, providerSource) {
+			continue
+		}
+
+		resourceType, _ := res["type"].(string)
+		schema, ok := schemas[resourceType]
+		if !ok {
+			log.Printf("skipping %s.%s, the resource type no longer exists", resourceType, res["name"])
+			continue
+		}
+
+		instances, _ := res["instances"].([]interface{})
+		for _, i := range instances {
+			instance, ok := i.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			attributes, _ := instance["attributes"].(map[string]interface{})
+			t, ok := schema.Block.ValueType().(tftypes.Object)
+			if !ok {
+				return count, fmt.Errorf("%s: the schema is not an object", resourceType)
+			}
+
+			migrated, err := provider.MigrateLegacyAttributes(attributes, t)
+			if err != nil {
+				return count, fmt.Errorf("%s.%s: %w", resourceType, res["name"], err)
+			}
+
+			instance["attributes"] = migrated
+			instance["schema_version"] = schema.Version
+			// the sdk stores its own metadata in private which the framework does not understand
+			delete(instance, "private")
+			instance["sensitive_attributes"] = []interface{}{}
+			count++
+		}
+	}
+
+	if count > 0 {
+		serial, _ := state["serial"].(float64)
+		state["serial"] = serial + 1
+	}
+
+	return count, nil
+}
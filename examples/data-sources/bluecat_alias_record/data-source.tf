@@ -0,0 +1,7 @@
+data "bluecat_alias_record" "www" {
+  absolute_name = "www.example.com"
+}
+
+output "bluecat_alias_target" {
+  value = data.bluecat_alias_record.www.linked_record_name
+}
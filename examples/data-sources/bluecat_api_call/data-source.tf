@@ -0,0 +1,9 @@
+# Requires enable_raw_api = true on the provider
+data "bluecat_api_call" "example" {
+  method     = "getEntities"
+  parameters = "parentId=100500&type=IP4Block&start=0&count=10"
+}
+
+output "blocks" {
+  value = data.bluecat_api_call.example.response
+}
@@ -0,0 +1,11 @@
+resource "bluecat_deployment" "www" {
+  entity_ids          = [bluecat_host_record.www.id]
+  wait_for_completion = false
+}
+
+# Wait for the deployment to finish before anything that depends on the
+# record resolving is created
+data "bluecat_deployment_status" "www" {
+  deployment_token = bluecat_deployment.www.deployment_token
+  timeout          = "5m"
+}
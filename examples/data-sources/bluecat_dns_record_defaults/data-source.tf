@@ -0,0 +1,11 @@
+data "bluecat_dns_record_defaults" "example" {
+  entity_id = data.bluecat_zone.example.id
+}
+
+resource "bluecat_host_record" "example" {
+  name      = "web"
+  dns_zone  = "example.com"
+  view_id   = 100880
+  addresses = ["10.0.0.10"]
+  ttl       = coalesce(data.bluecat_dns_record_defaults.example.default_ttl, 3600)
+}
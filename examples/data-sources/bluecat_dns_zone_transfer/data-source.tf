@@ -0,0 +1,11 @@
+data "bluecat_dns_zone_transfer" "example" {
+  view_id = 100500
+  fqdn    = "example.com"
+}
+
+output "zone_file" {
+  value = join("\n", [
+    for r in data.bluecat_dns_zone_transfer.example.records :
+    "${r.name}. IN ${r.type} ${r.rdata}"
+  ])
+}
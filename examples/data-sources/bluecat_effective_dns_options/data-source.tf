@@ -0,0 +1,7 @@
+data "bluecat_effective_dns_options" "network" {
+  entity_id = 100882
+}
+
+output "network_allow_query" {
+  value = [for o in data.bluecat_effective_dns_options.network.restrictions : o.value if o.name == "allow-query"]
+}
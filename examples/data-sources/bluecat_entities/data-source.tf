@@ -0,0 +1,8 @@
+data "bluecat_entities" "views" {
+  parent_id = 100881
+  type      = "View"
+}
+
+output "view_ids" {
+  value = { for v in data.bluecat_entities.views.entities : v.name => v.id }
+}
@@ -6,3 +6,10 @@ data "bluecat_entity" "config" {
 output "bluecat_config_id" {
   value = data.bluecat_entity.config.id
 }
+
+data "bluecat_entity" "network" {
+  name      = "NetworkName"
+  types     = ["IP4Network", "IP4Block"]
+  parent_id = data.bluecat_entity.config.id
+  recursive = true
+}
@@ -0,0 +1,7 @@
+data "bluecat_host_records" "web" {
+  hint = "^web*.example.com$"
+}
+
+output "web_records" {
+  value = { for r in data.bluecat_host_records.web.host_records : r.absolute_name => r.addresses }
+}
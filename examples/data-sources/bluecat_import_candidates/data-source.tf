@@ -0,0 +1,19 @@
+data "bluecat_import_candidates" "networks" {
+  parent_id      = data.bluecat_entity.block.id
+  type           = "IP4Network"
+  unmanaged_only = true
+}
+
+# Write import blocks for every unmanaged network to a file that can be
+# used with `terraform plan -generate-config-out=generated.tf`
+resource "local_file" "imports" {
+  filename = "${path.module}/imports.tf"
+  content  = <<-EOT
+    %{~for c in data.bluecat_import_candidates.networks.candidates~}
+    import {
+      id = "${c.id}"
+      to = ${c.resource_address}
+    }
+    %{~endfor~}
+  EOT
+}
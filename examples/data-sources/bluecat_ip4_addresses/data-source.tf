@@ -0,0 +1,8 @@
+data "bluecat_ip4_addresses" "vm" {
+  configuration_id = data.bluecat_entity.config.id
+  mac_address      = "00:50:56:01:02:03"
+}
+
+output "vm_addresses" {
+  value = [for a in data.bluecat_ip4_addresses.vm.addresses : a.address]
+}
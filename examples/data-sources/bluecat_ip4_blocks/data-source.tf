@@ -0,0 +1,12 @@
+data "bluecat_ip4_blocks" "campus" {
+  parent_id = 100881
+  max_depth = 3
+}
+
+output "free_in_blocks" {
+  value = {
+    for b in data.bluecat_ip4_blocks.campus.blocks : b.cidr => b.size - sum(concat([0], [
+      for n in data.bluecat_ip4_blocks.campus.networks : n.size if n.parent_id == tonumber(b.id)
+    ]))
+  }
+}
@@ -0,0 +1,10 @@
+data "bluecat_ip4_network_template" "office" {
+  name = "Office Networks"
+}
+
+resource "bluecat_ip4_network" "office" {
+  name      = "Office Network"
+  parent_id = 100881
+  size      = 256
+  template  = data.bluecat_ip4_network_template.office.id
+}
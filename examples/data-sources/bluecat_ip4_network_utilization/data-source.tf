@@ -0,0 +1,8 @@
+data "bluecat_ip4_network_utilization" "network" {
+  network_id = data.bluecat_ip4_network.network.id
+  threshold  = 80
+}
+
+output "bluecat_network_nearly_full" {
+  value = data.bluecat_ip4_network_utilization.network.over_threshold
+}
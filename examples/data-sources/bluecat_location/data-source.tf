@@ -0,0 +1,7 @@
+data "bluecat_location" "detroit" {
+  code = "US DTW"
+}
+
+data "bluecat_location" "office" {
+  name = "Ann Arbor Office 1"
+}
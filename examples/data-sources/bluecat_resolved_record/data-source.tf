@@ -0,0 +1,8 @@
+data "bluecat_resolved_record" "service" {
+  name     = "service.example.com"
+  max_hops = 5
+}
+
+output "service_addresses" {
+  value = data.bluecat_resolved_record.service.addresses
+}
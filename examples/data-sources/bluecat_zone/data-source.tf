@@ -0,0 +1,12 @@
+data "bluecat_zone" "example" {
+  view_id = 100500
+  fqdn    = "example.com"
+}
+
+output "zone_id" {
+  value = data.bluecat_zone.example.id
+}
+
+output "zone_host_records" {
+  value = data.bluecat_zone.example.record_counts["HostRecord"]
+}
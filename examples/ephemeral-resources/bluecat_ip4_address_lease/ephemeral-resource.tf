@@ -0,0 +1,5 @@
+ephemeral "bluecat_ip4_address_lease" "bootstrap" {
+  configuration_id = data.bluecat_entity.config.id
+  parent_id        = data.bluecat_ip4_network.example_net.id
+  name             = "bootstrap"
+}
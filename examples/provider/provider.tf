@@ -10,3 +10,25 @@ data "bluecat_entity" "config" {
   name = "Your Config"
   type = "Configuration"
 }
+
+// Manage objects in configurations owned by other service accounts
+provider "bluecat" {
+  alias            = "shared"
+  username         = "username"
+  password         = "password123"
+  bluecat_endpoint = "bam.example.com"
+
+  auth_profiles = {
+    team_a = {
+      username = "svc-team-a"
+      password = var.team_a_password
+    }
+  }
+}
+
+data "bluecat_entity" "team_a_config" {
+  provider     = bluecat.shared
+  auth_profile = "team_a"
+  name         = "Team A"
+  type         = "Configuration"
+}
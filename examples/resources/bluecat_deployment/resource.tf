@@ -0,0 +1,19 @@
+# Deploy a host record as soon as it changes
+resource "bluecat_deployment" "www" {
+  entity_ids = [bluecat_host_record.www.id]
+
+  triggers = {
+    addresses = join(",", bluecat_host_record.www.addresses)
+  }
+}
+
+# Deploy DNS to a server whenever any of the records change
+resource "bluecat_deployment" "dns_server" {
+  server_id    = data.bluecat_entity.dns_server.id
+  services     = ["DNS"]
+  wait_timeout = "15m"
+
+  triggers = {
+    records = join(",", [for r in bluecat_host_record.app : r.id])
+  }
+}
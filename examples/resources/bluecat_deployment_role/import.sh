@@ -0,0 +1,5 @@
+# Deployment roles can be imported using the service, the ID of the entity, and the ID of the server interface
+terraform import bluecat_deployment_role.zone_primary DNS:123456:654321
+
+# DNS deployment roles for a view on a block or network also include the ID of the view
+terraform import bluecat_deployment_role.network_dns DNS:123456:654321:111111
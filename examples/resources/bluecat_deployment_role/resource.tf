@@ -0,0 +1,22 @@
+resource "bluecat_deployment_role" "zone_primary" {
+  service             = "DNS"
+  type                = "MASTER"
+  entity_id           = data.bluecat_entity.zone.id
+  server_interface_id = data.bluecat_entity.dns_primary_interface.id
+}
+
+resource "bluecat_deployment_role" "network_dhcp" {
+  service             = "DHCP"
+  type                = "MASTER"
+  entity_id           = bluecat_ip4_network.example.id
+  server_interface_id = data.bluecat_entity.dhcp_interface.id
+}
+
+# a DHCP failover pair that serves the DHCP ranges of the network
+resource "bluecat_deployment_role" "network_dhcp_failover" {
+  service                       = "DHCP"
+  type                          = "MASTER"
+  entity_id                     = bluecat_ip4_network.failover.id
+  server_interface_id           = data.bluecat_entity.dhcp_primary_interface.id
+  secondary_server_interface_id = data.bluecat_entity.dhcp_secondary_interface.id
+}
@@ -0,0 +1,17 @@
+resource "bluecat_dhcp_client_option" "router" {
+  entity_id = bluecat_ip4_network.example.id
+  name      = "router"
+  values    = [bluecat_ip4_network.example.gateway]
+}
+
+resource "bluecat_dhcp_client_option" "domain_search" {
+  entity_id = bluecat_ip4_network.example.id
+  name      = "domain-search"
+  values    = ["example.com", "corp.example.com"]
+}
+
+resource "bluecat_dhcp_client_option" "vendor" {
+  entity_id = bluecat_ip4_network.example.id
+  name      = "vendor-encapsulated-options"
+  value     = "01:04:c0:a8:00:0a"
+}
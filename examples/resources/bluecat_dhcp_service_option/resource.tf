@@ -0,0 +1,5 @@
+resource "bluecat_dhcp_service_option" "lease_time" {
+  entity_id = bluecat_ip4_network.example.id
+  name      = "default-lease-time"
+  value     = "3600"
+}
@@ -0,0 +1,2 @@
+# Deployment options can be imported using the ID of the entity, the name of the option, and optionally the ID of the server
+terraform import bluecat_dns_option.example 123456:option-name
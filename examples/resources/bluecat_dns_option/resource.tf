@@ -0,0 +1,5 @@
+resource "bluecat_dns_option" "allow_query" {
+  entity_id = data.bluecat_entity.zone.id
+  name      = "allow-query"
+  values    = ["10.0.0.0/8", "192.168.0.0/16"]
+}
@@ -0,0 +1,2 @@
+# Entities can be imported using the object ID
+terraform import bluecat_entity.example 123456
@@ -0,0 +1,14 @@
+resource "bluecat_entity" "tag_group" {
+  parent_id = 0
+  type      = "TagGroup"
+  name      = "Environments"
+}
+
+resource "bluecat_entity" "tag" {
+  parent_id = bluecat_entity.tag_group.id
+  type      = "Tag"
+  name      = "Production"
+  properties = {
+    color = "red"
+  }
+}
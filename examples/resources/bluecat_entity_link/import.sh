@@ -0,0 +1,2 @@
+# Entity links can be imported using the IDs of both entities
+terraform import bluecat_entity_link.network_tag 123456:654321
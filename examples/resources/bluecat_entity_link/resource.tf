@@ -0,0 +1,10 @@
+data "bluecat_entity" "tag" {
+  name      = "production"
+  parent_id = data.bluecat_entity.tag_group.id
+  type      = "Tag"
+}
+
+resource "bluecat_entity_link" "network_tag" {
+  entity1_id = bluecat_ip4_network.example.id
+  entity2_id = data.bluecat_entity.tag.id
+}
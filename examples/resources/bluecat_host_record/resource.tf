@@ -8,3 +8,22 @@ resource "bluecat_host_record" "hostname" {
 output "bluecat_hostname_fqdn" {
   value = bluecat_host_record.hostname.absolute_name
 }
+
+# the same record in the internal and external views of split-horizon DNS
+resource "bluecat_host_record" "split_horizon" {
+  view_ids  = [data.bluecat_entity.internal_view.id, data.bluecat_entity.external_view.id]
+  name      = "www"
+  dns_zone  = "example.com"
+  addresses = ["192.168.1.101"]
+}
+
+# a record for an address managed by Terraform, which follows the address if it changes
+resource "bluecat_host_record" "app" {
+  view_id     = data.bluecat_entity.view.id
+  name        = "app"
+  dns_zone    = "example.com"
+  address_ids = [bluecat_ip4_address.app.id]
+
+  # CNAMEs pointing to the host record
+  aliases = ["api.example.com", "admin.example.com"]
+}
@@ -0,0 +1,9 @@
+resource "bluecat_host_record_set" "app" {
+  view_id  = data.bluecat_entity.view.id
+  dns_zone = "example.com"
+  records = {
+    web01 = ["192.168.1.100"]
+    web02 = ["192.168.1.101"]
+    db01  = ["192.168.1.110", "192.168.1.111"]
+  }
+}
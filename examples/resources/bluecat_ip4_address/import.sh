@@ -0,0 +1,5 @@
+# IPv4 addresses can be imported using the object ID
+terraform import bluecat_ip4_address.example 123456
+
+# or the address within a configuration, by configuration ID
+terraform import bluecat_ip4_address.example 100881:10.10.0.5
@@ -7,3 +7,18 @@ resource "bluecat_ip4_address" "addr" {
 output "allocated_address" {
   value = bluecat_ip4_address.addr.address
 }
+
+resource "bluecat_ip4_address" "overflow" {
+  configuration_id = data.bluecat_entity.config.id
+  name             = "IP Reserved in the first network with a free address"
+  parent_id_list   = [100881, 100882, 100883]
+}
+
+resource "bluecat_ip4_address" "with_dns" {
+  configuration_id = data.bluecat_entity.config.id
+  name             = "IP Reserved with a host record"
+  parent_id        = data.bluecat_ip4_network.example_net.id
+  hostname         = "server1.example.com"
+  view_id          = 100500
+  reverse_record   = true
+}
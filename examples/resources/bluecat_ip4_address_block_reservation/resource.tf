@@ -0,0 +1,11 @@
+resource "bluecat_ip4_address_block_reservation" "cluster" {
+  configuration_id = data.bluecat_entity.config.id
+  parent_id        = data.bluecat_ip4_network.example_net.id
+  address_count    = 5
+  consecutive      = true
+  name             = "cluster-node"
+}
+
+output "cluster_addresses" {
+  value = bluecat_ip4_address_block_reservation.cluster.addresses
+}
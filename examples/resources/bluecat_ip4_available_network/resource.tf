@@ -5,3 +5,12 @@ resource "bluecat_ip4_available_network" "network" {
 output "network_id" {
   value = bluecat_ip4_available_network.network.network_id
 }
+
+resource "bluecat_ip4_available_network" "prod" {
+  network_id_list    = [1234, 5678, 9101]
+  selection_strategy = "least_free"
+  min_free_addresses = 16
+  udf_filters = {
+    environment = "prod"
+  }
+}
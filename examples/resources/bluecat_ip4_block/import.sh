@@ -0,0 +1,11 @@
+# IPv4 blocks can be imported using the object ID
+terraform import bluecat_ip4_block.example 123456
+
+# or the CIDR within a configuration, by configuration name
+terraform import bluecat_ip4_block.example "Production:10.10.0.0/16"
+
+# or the CIDR within a configuration or block, by object ID
+terraform import bluecat_ip4_block.example 100881/10.10.0.0/16
+
+# blocks that do not form a valid CIDR can be imported by range instead
+terraform import bluecat_ip4_block.example "Production:10.10.48.0-10.10.48.99"
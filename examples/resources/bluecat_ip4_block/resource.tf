@@ -7,3 +7,16 @@ resource "bluecat_ip4_block" "block" {
 output "bluecat_ip4_block_cidr" {
   value = bluecat_ip4_block.block.cidr
 }
+
+resource "bluecat_ip4_block" "static" {
+  parent_id = data.bluecat_ip4_network-block-range.block.id
+  name      = "Static Block"
+  cidr      = "10.10.32.0/20"
+}
+
+resource "bluecat_ip4_block" "range" {
+  parent_id = data.bluecat_ip4_network-block-range.block.id
+  name      = "Range Block"
+  start     = "10.10.48.0"
+  end       = "10.10.48.99"
+}
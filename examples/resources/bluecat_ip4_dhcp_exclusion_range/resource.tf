@@ -0,0 +1,13 @@
+data "bluecat_ip4_nbr" "printer_scope" {
+  container_id = data.bluecat_entity.config.id
+  address      = "10.1.2.150"
+  type         = "DHCP4Range"
+}
+
+resource "bluecat_ip4_dhcp_exclusion_range" "printers" {
+  configuration_id = data.bluecat_entity.config.id
+  range_id         = data.bluecat_ip4_nbr.printer_scope.id
+  start            = "10.1.2.150"
+  end              = "10.1.2.159"
+  name             = "printer pool"
+}
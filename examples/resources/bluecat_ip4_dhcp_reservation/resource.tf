@@ -0,0 +1,12 @@
+resource "bluecat_ip4_dhcp_reservation" "vm" {
+  configuration_id = data.bluecat_entity.config.id
+  parent_id        = data.bluecat_ip4_network.example_net.id
+  mac_address      = "00:50:56:01:02:03"
+  name             = "example-vm"
+  hostname         = "example-vm.example.com"
+  view_id          = data.bluecat_entity.view.id
+}
+
+output "reserved_address" {
+  value = bluecat_ip4_dhcp_reservation.vm.address
+}
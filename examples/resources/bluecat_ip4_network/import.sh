@@ -0,0 +1,8 @@
+# IPv4 networks can be imported using the object ID
+terraform import bluecat_ip4_network.example 123456
+
+# or the CIDR within a configuration, by configuration name
+terraform import bluecat_ip4_network.example "Production:10.10.0.0/16"
+
+# or the CIDR within a configuration or block, by object ID
+terraform import bluecat_ip4_network.example 100881/10.10.0.0/16
@@ -7,3 +7,35 @@ resource "bluecat_ip4_network" "network" {
 output "bluecat_ip4_network_cidr" {
   value = bluecat_ip4_network.network.cidr
 }
+
+resource "bluecat_ip4_network" "static" {
+  parent_id = data.bluecat_ip4_network-block-range.block.id
+  name      = "Static Network"
+  cidr      = "10.10.20.0/24"
+}
+
+resource "bluecat_ip4_network" "overflow" {
+  # allocate in the first block with a /24 available
+  parent_block_ids = [100881, 100882, 100883]
+  name             = "Overflow Network"
+  size             = 256
+}
+
+resource "bluecat_ip4_network" "no_gateway" {
+  parent_id      = data.bluecat_ip4_network-block-range.block.id
+  name           = "Network Without Gateway"
+  size           = 256
+  create_gateway = false
+}
+
+output "bluecat_ip4_network_gateway_address_id" {
+  value = bluecat_ip4_network.network.gateway_address_id
+}
+
+resource "bluecat_ip4_network" "shared" {
+  parent_id             = data.bluecat_ip4_network-block-range.block.id
+  name                  = "Shared Network"
+  size                  = 256
+  shared_network_tag_id = 123456
+  dynamic_update        = true
+}
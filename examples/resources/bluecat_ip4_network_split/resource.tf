@@ -0,0 +1,8 @@
+resource "bluecat_ip4_network_split" "split" {
+  network_id      = 1234
+  number_of_parts = 2
+}
+
+output "network_ids" {
+  value = bluecat_ip4_network_split.split.network_ids
+}
@@ -0,0 +1,2 @@
+# IPv4 network templates can be imported using the object ID
+terraform import bluecat_ip4_network_template.office 123456
@@ -0,0 +1,17 @@
+resource "bluecat_ip4_network_template" "office" {
+  name     = "Office Networks"
+  comments = "Settings shared by office networks"
+}
+
+resource "bluecat_dhcp_client_option" "office_router" {
+  entity_id = bluecat_ip4_network_template.office.id
+  name      = "router"
+  values    = ["10.0.0.1"]
+}
+
+resource "bluecat_ip4_network" "office" {
+  name      = "Office Network"
+  parent_id = 100881
+  size      = 256
+  template  = bluecat_ip4_network_template.office.id
+}
@@ -0,0 +1,2 @@
+# Locations can be imported using the object ID
+terraform import bluecat_location.office 123456
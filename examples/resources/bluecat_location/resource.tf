@@ -0,0 +1,12 @@
+resource "bluecat_location" "office" {
+  code        = "US DTW OF1"
+  name        = "Ann Arbor Office 1"
+  description = "Main campus office"
+}
+
+resource "bluecat_ip4_network" "office" {
+  name          = "Office Network"
+  parent_id     = 100881
+  size          = 256
+  location_code = bluecat_location.office.code
+}
@@ -0,0 +1,2 @@
+# User-defined fields can be imported using the object type and name
+terraform import bluecat_user_defined_field.example IP4Network:environment
@@ -0,0 +1,14 @@
+resource "bluecat_user_defined_field" "environment" {
+  object_type       = "IP4Network"
+  name              = "environment"
+  display_name      = "Environment"
+  predefined_values = ["prod", "dev"]
+}
+
+resource "bluecat_ip4_network" "network" {
+  parent_id = 123456
+  size      = 256
+  user_defined_fields = {
+    (bluecat_user_defined_field.environment.name) = "prod"
+  }
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// allocationRetries is how many times an allocation is tried again after it
+// failed because another client allocated the same range or address at the
+// same time, such as another Terraform workspace.
+const allocationRetries = 3
+
+// allocationRetryDelay is the delay before the first retry of an allocation,
+// which doubles with every retry and has up to the same again added at random
+// so that clients that collided do not collide again.
+var allocationRetryDelay = 2 * time.Second
+
+// duplicateAllocationFaults are substrings of the errors returned when a
+// range or address was allocated by another client between finding it and
+// creating it.
+var duplicateAllocationFaults = []string{"duplicate", "already exists", "already in use", "already allocated", "already assigned", "overlaps", "conflicts with"}
+
+// reservedAllocationProperties are the properties of getNextAvailableIPRange
+// that the resources set themselves, so they cannot be set with
+// allocation_properties.
+var reservedAllocationProperties = []string{
+	"autoCreate",
+	"excludeDHCPRange",
+	"isLargerAllowed",
+	"offset",
+	"reuseExisting",
+	"traversalMethod",
+}
+
+// setAllocationProperties sets the optional properties that control where the
+// next available range is allocated: excludeDHCPRange, offset, and any
+// additional properties in allocationProperties.
+func setAllocationProperties(ctx context.Context, properties propertyMap, excludeDHCPRange types.Bool, offset ip4AddressValue, allocationProperties types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !excludeDHCPRange.IsNull() && !excludeDHCPRange.IsUnknown() {
+		properties.setBool("excludeDHCPRange", excludeDHCPRange.ValueBool())
+	}
+
+	if !offset.IsNull() && !offset.IsUnknown() {
+		properties.set("offset", offset.ValueIP4String())
+	}
+
+	if allocationProperties.IsNull() || allocationProperties.IsUnknown() {
+		return diags
+	}
+
+	extra := make(map[string]string, len(allocationProperties.Elements()))
+	diags.Append(allocationProperties.ElementsAs(ctx, &extra, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	properties.setAll(extra)
+
+	return diags
+}
+
+// isDuplicateAllocationError returns whether err is from allocating a range or
+// address that another client allocated at the same time.
+func isDuplicateAllocationError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fault := range duplicateAllocationFaults {
+		if strings.Contains(message, fault) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// retryAllocation calls allocate until it succeeds or fails with an error
+// other than a duplicate allocation, retrying up to allocationRetries times.
+func retryAllocation[T any](ctx context.Context, allocate func() (T, error)) (T, error) {
+	for attempt := 0; ; attempt++ {
+		allocated, err := allocate()
+		if err == nil || attempt >= allocationRetries || !isDuplicateAllocationError(err) {
+			return allocated, err
+		}
+
+		delay := retryDelay(allocationRetryDelay, attempt)
+		if delay > 0 {
+			delay += rand.N(delay)
+		}
+		tflog.Warn(ctx, fmt.Sprintf("Allocation collided with another client, retrying in %s: %s", delay, err))
+
+		if err := retryWait(ctx, delay); err != nil {
+			return allocated, err
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSetAllocationProperties(t *testing.T) {
+	properties := propertyMap{}
+	properties.set("traversalMethod", traversalMethodNone)
+
+	extra := types.MapValueMust(types.StringType, map[string]attr.Value{"startOffset": types.StringValue("10.0.128.0")})
+	diags := setAllocationProperties(context.Background(), properties, types.BoolValue(true), newIP4AddressValue("10.0.64.0"), extra)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := properties.String(); got != "excludeDHCPRange=true|offset=10.0.64.0|startOffset=10.0.128.0|traversalMethod=NO_TRAVERSAL|" {
+		t.Errorf("expected the allocation properties to be added, got %s", got)
+	}
+
+	properties = propertyMap{}
+	diags = setAllocationProperties(context.Background(), properties, types.BoolNull(), newIP4AddressNull(), types.MapNull(types.StringType))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(properties) != 0 {
+		t.Errorf("expected no properties when the arguments are not set, got %s", properties)
+	}
+}
+
+func TestIsDuplicateAllocationError(t *testing.T) {
+	if !isDuplicateAllocationError(errors.New("Duplicate of another item")) {
+		t.Error("expected a duplicate item fault to be a duplicate allocation")
+	}
+	if !isDuplicateAllocationError(errors.New("10.0.1.0/24 overlaps with 10.0.1.0/24")) {
+		t.Error("expected an overlap fault to be a duplicate allocation")
+	}
+	if isDuplicateAllocationError(errors.New("no network of size 256 is available")) {
+		t.Error("expected a full parent not to be a duplicate allocation")
+	}
+	if isDuplicateAllocationError(nil) {
+		t.Error("expected no error not to be a duplicate allocation")
+	}
+}
+
+func TestRetryAllocation(t *testing.T) {
+	defer func(delay time.Duration) { allocationRetryDelay = delay }(allocationRetryDelay)
+	allocationRetryDelay = 0
+
+	attempts := 0
+	id, err := retryAllocation(context.Background(), func() (int64, error) {
+		attempts++
+		if attempts < 3 {
+			return 0, errors.New("Duplicate of another item")
+		}
+		return 10, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 10 || attempts != 3 {
+		t.Errorf("expected the allocation to succeed on the third attempt, got %d after %d attempts", id, attempts)
+	}
+
+	attempts = 0
+	if _, err := retryAllocation(context.Background(), func() (int64, error) {
+		attempts++
+		return 0, errors.New("Duplicate of another item")
+	}); err == nil {
+		t.Error("expected an error when every attempt collides")
+	}
+	if attempts != allocationRetries+1 {
+		t.Errorf("expected %d attempts, got %d", allocationRetries+1, attempts)
+	}
+
+	attempts = 0
+	if _, err := retryAllocation(context.Background(), func() (int64, error) {
+		attempts++
+		return 0, errors.New("no network of size 256 is available")
+	}); err == nil {
+		t.Error("expected the error to be returned")
+	}
+	if attempts != 1 {
+		t.Errorf("expected other errors not to be retried, got %d attempts", attempts)
+	}
+}
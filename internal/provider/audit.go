@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// auditRedactedParameters are the API call parameters that are not written
+// to the audit log.
+var auditRedactedParameters = map[string]bool{
+	"password": true,
+}
+
+// auditEntry is a line of the audit log describing one API call.
+type auditEntry struct {
+	Time       string            `json:"time"`
+	Operation  string            `json:"operation"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	Status     int               `json:"status,omitempty"`
+	Result     string            `json:"result"`
+	Return     string            `json:"return,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	DurationMS int64             `json:"duration_ms"`
+}
+
+// auditLog writes audit entries as JSON lines. It is shared by all the API
+// clients of the provider, so writes are serialized.
+type auditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// openAuditLog opens the audit log at path for appending, creating it if it
+// does not exist. The file is not closed as the provider has no shutdown hook;
+// each entry is written with a single write so nothing is buffered.
+func openAuditLog(path string) (*auditLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auditLog{w: f}, nil
+}
+
+func (l *auditLog) write(entry auditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err = l.w.Write(append(line, '\n'))
+	return err
+}
+
+// auditTransport is a http.RoundTripper that writes an audit entry for each
+// BlueCat Address Manager API call.
+type auditTransport struct {
+	base http.RoundTripper
+	log  *auditLog
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	entry := auditEntry{
+		Time:      start.UTC().Format(time.RFC3339Nano),
+		Operation: req.Method + " " + req.URL.Path,
+	}
+
+	if action := req.Header.Get("SOAPAction"); action != "" {
+		entry.Operation = action[strings.LastIndex(action, "/")+1:]
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				entry.Parameters = soapRequestParameters(body)
+				body.Close()
+			}
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	entry.DurationMS = time.Since(start).Milliseconds()
+
+	switch {
+	case err != nil:
+		entry.Result = "error"
+		entry.Error = err.Error()
+	default:
+		entry.Status = resp.StatusCode
+		entry.Result = "success"
+		if resp.StatusCode >= http.StatusBadRequest {
+			entry.Result = "error"
+			entry.Error = resp.Status
+		}
+
+		// read the response so the result can be logged and replace it for the caller
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if readErr == nil && req.Header.Get("SOAPAction") != "" {
+			ret, fault := soapResponseResult(bytes.NewReader(body))
+			entry.Return = ret
+			if fault != "" {
+				entry.Result = "error"
+				entry.Error = fault
+			}
+		}
+	}
+
+	// a failure to write the audit log must not hide the result of the call
+	_ = t.log.write(entry)
+
+	return resp, err
+}
+
+// soapRequestParameters returns the parameters of a SOAP request. The fields
+// of object parameters, such as the entity passed to update, are returned as
+// <parameter>.<field>.
+func soapRequestParameters(body io.Reader) map[string]string {
+	params := make(map[string]string)
+	var names []string
+	var text bytes.Buffer
+
+	decoder := xml.NewDecoder(body)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return params
+			}
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			names = append(names, t.Name.Local)
+			text.Reset()
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			// parameters are below the operation element in the envelope
+			// body: Envelope > Body > operation > parameter
+			if len(names) >= 4 {
+				key := strings.Join(names[3:], ".")
+				value := strings.TrimSpace(text.String())
+				if auditRedactedParameters[names[len(names)-1]] {
+					value = "REDACTED"
+				}
+				if value != "" {
+					params[key] = value
+				}
+			}
+			names = names[:len(names)-1]
+			text.Reset()
+		}
+	}
+
+	return params
+}
+
+// soapResponseResult returns the value returned by a SOAP call, if it is a
+// simple value, and the fault string if the call failed.
+func soapResponseResult(body io.Reader) (string, string) {
+	var ret, fault string
+	var names []string
+	var text bytes.Buffer
+	children := 0
+
+	decoder := xml.NewDecoder(body)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			names = append(names, t.Name.Local)
+			text.Reset()
+			if len(names) > 4 {
+				children++
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			value := strings.TrimSpace(text.String())
+			switch {
+			case t.Name.Local == "faultstring":
+				fault = value
+			case t.Name.Local == "return" && len(names) == 4 && children == 0:
+				ret = value
+			}
+			names = names[:len(names)-1]
+			text.Reset()
+		}
+	}
+
+	return ret, fault
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testAuditUpdateRequest = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><tns:update><entity><id>42</id><name>net</name><properties>owner=ops|</properties><type>IP4Network</type></entity></tns:update></soap:Body></soap:Envelope>`
+
+func auditTestRequest(t *testing.T, action string, body string) *http.Request {
+	req, err := http.NewRequest(http.MethodPost, "https://bam.example.com/Services/API", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("SOAPAction", "http://api.proteus.bluecatnetworks.com/"+action)
+
+	return req
+}
+
+func readAuditEntries(t *testing.T, buf *bytes.Buffer) []auditEntry {
+	var entries []auditEntry
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry auditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("audit log line is not JSON: %s", line)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func TestAuditTransport(t *testing.T) {
+	var buf bytes.Buffer
+	base := &fakeRoundTripper{responses: map[string][]*http.Response{
+		"http://api.proteus.bluecatnetworks.com/update": {
+			fakeResponse(http.StatusOK, `<soap:Envelope><soap:Body><ns:updateResponse/></soap:Body></soap:Envelope>`),
+			fakeResponse(http.StatusInternalServerError, `<soap:Envelope><soap:Body><soap:Fault><faultstring>Object was not found</faultstring></soap:Fault></soap:Body></soap:Envelope>`),
+		},
+		"http://api.proteus.bluecatnetworks.com/login": {
+			fakeResponse(http.StatusOK, `<soap:Envelope><soap:Body><ns:loginResponse/></soap:Body></soap:Envelope>`),
+		},
+		"http://api.proteus.bluecatnetworks.com/addEntity": {
+			fakeResponse(http.StatusOK, `<soap:Envelope><soap:Body><ns:addEntityResponse><return>1234</return></ns:addEntityResponse></soap:Body></soap:Envelope>`),
+		},
+	}}
+	transport := &auditTransport{base: base, log: &auditLog{w: &buf}}
+
+	for _, req := range []*http.Request{
+		auditTestRequest(t, "update", testAuditUpdateRequest),
+		auditTestRequest(t, "update", testAuditUpdateRequest),
+		auditTestRequest(t, "login", `<soap:Envelope><soap:Body><tns:login><username>admin</username><password>secret</password></tns:login></soap:Body></soap:Envelope>`),
+		auditTestRequest(t, "addEntity", `<soap:Envelope><soap:Body><tns:addEntity><parentId>7</parentId></tns:addEntity></soap:Body></soap:Envelope>`),
+	} {
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the caller must still be able to read the response
+		if body, _ := io.ReadAll(resp.Body); len(body) == 0 {
+			t.Error("expected the response body to be readable after auditing")
+		}
+	}
+
+	entries := readAuditEntries(t, &buf)
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 audit entries, got %d", len(entries))
+	}
+
+	update := entries[0]
+	if update.Operation != "update" || update.Result != "success" || update.Status != http.StatusOK {
+		t.Errorf("unexpected update entry: %+v", update)
+	}
+	if update.Parameters["entity.id"] != "42" || update.Parameters["entity.properties"] != "owner=ops|" {
+		t.Errorf("unexpected update parameters: %v", update.Parameters)
+	}
+
+	if entries[1].Result != "error" || entries[1].Error != "Object was not found" {
+		t.Errorf("expected the fault to be logged, got %+v", entries[1])
+	}
+
+	if password := entries[2].Parameters["password"]; password != "REDACTED" {
+		t.Errorf("expected the password to be redacted, got %q", password)
+	}
+
+	if entries[3].Return != "1234" || entries[3].Parameters["parentId"] != "7" {
+		t.Errorf("expected the returned ID and parent to be logged, got %+v", entries[3])
+	}
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// authProfile is a named set of credentials defined in the provider block
+// that resources and data sources can use instead of the provider credentials.
+type authProfile struct {
+	Username   string
+	Password   string
+	AuthMethod string
+	Token      string
+}
+
+// AuthProfileModel describes an entry of the auth_profiles provider attribute.
+type AuthProfileModel struct {
+	Username   types.String `tfsdk:"username"`
+	Password   types.String `tfsdk:"password"`
+	AuthMethod types.String `tfsdk:"auth_method"`
+	Token      types.String `tfsdk:"token"`
+}
+
+// authProfilesSchemaAttribute returns the schema of the auth_profiles provider
+// attribute.
+func authProfilesSchemaAttribute() providerschema.MapNestedAttribute {
+	return providerschema.MapNestedAttribute{
+		MarkdownDescription: "Named credentials that resources and data sources can use instead of the provider credentials with their `auth_profile` argument, such as accounts scoped to a single configuration. The keys are the profile names.",
+		Optional:            true,
+		NestedObject: providerschema.NestedAttributeObject{
+			Attributes: map[string]providerschema.Attribute{
+				"username": providerschema.StringAttribute{
+					MarkdownDescription: "A BlueCat Address Manager username.",
+					Required:            true,
+				},
+				"password": providerschema.StringAttribute{
+					MarkdownDescription: "The BlueCat Address Manager password. Required if `auth_method` is `password`.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"auth_method": providerschema.StringAttribute{
+					MarkdownDescription: "How to authenticate with the profile, like the provider `auth_method`. " + enumDescription(authMethods) + " Defaults to `password`.",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(authMethods...),
+					},
+				},
+				"token": providerschema.StringAttribute{
+					MarkdownDescription: "A BlueCat Address Manager API token of `username`. Required if `auth_method` is `token`.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+			},
+		},
+	}
+}
+
+// authProfileSchemaAttribute returns the schema of the auth_profile attribute
+// of a resource.
+func authProfileSchemaAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The name of an auth profile defined in the provider `auth_profiles` to use for the API calls of this resource instead of the provider credentials.",
+		Optional:            true,
+		Validators: []validator.String{
+			stringvalidator.ConflictsWith(path.MatchRoot("credentials")),
+		},
+	}
+}
+
+// authProfileDataSourceSchemaAttribute returns the schema of the auth_profile
+// attribute of a data source.
+func authProfileDataSourceSchemaAttribute() dsschema.StringAttribute {
+	return dsschema.StringAttribute{
+		MarkdownDescription: "The name of an auth profile defined in the provider `auth_profiles` to use for the API calls of this data source instead of the provider credentials.",
+		Optional:            true,
+	}
+}
+
+// expandAuthProfiles returns the auth profiles of the auth_profiles provider
+// attribute, checking that each has the secret its auth_method needs.
+func expandAuthProfiles(ctx context.Context, profiles types.Map) (map[string]authProfile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if profiles.IsNull() || profiles.IsUnknown() {
+		return nil, diags
+	}
+
+	var models map[string]AuthProfileModel
+	diags.Append(profiles.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	expanded := make(map[string]authProfile, len(models))
+	for name, m := range models {
+		if m.Username.IsUnknown() || m.Password.IsUnknown() || m.AuthMethod.IsUnknown() || m.Token.IsUnknown() {
+			diags.AddAttributeError(
+				path.Root("auth_profiles").AtMapKey(name),
+				"Unknown BlueCat Auth Profile",
+				"The provider cannot create the BlueCat API client as there is an unknown configuration value for an auth profile. "+
+					"Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+			continue
+		}
+
+		profile := authProfile{
+			Username:   m.Username.ValueString(),
+			Password:   m.Password.ValueString(),
+			AuthMethod: m.AuthMethod.ValueString(),
+			Token:      m.Token.ValueString(),
+		}
+		if profile.AuthMethod == "" {
+			profile.AuthMethod = authMethodPassword
+		}
+
+		if profile.AuthMethod == authMethodPassword && profile.Password == "" {
+			diags.AddAttributeError(path.Root("auth_profiles").AtMapKey(name).AtName("password"), "Missing BlueCat Auth Profile Password", fmt.Sprintf("Auth profile %q uses password authentication but has no password.", name))
+		}
+		if profile.AuthMethod == authMethodToken && profile.Token == "" {
+			diags.AddAttributeError(path.Root("auth_profiles").AtMapKey(name).AtName("token"), "Missing BlueCat Auth Profile Token", fmt.Sprintf("Auth profile %q uses token authentication but has no token.", name))
+		}
+
+		expanded[name] = profile
+	}
+
+	return expanded, diags
+}
+
+// withAuthProfile returns a copy of loginClient that logs in with the auth
+// profile name instead of the provider credentials, or loginClient itself if
+// name is not set.
+func (c *loginClient) withAuthProfile(name types.String) (*loginClient, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if name.IsNull() || name.IsUnknown() {
+		return c, diags
+	}
+
+	profile, ok := c.AuthProfiles[name.ValueString()]
+	if !ok {
+		names := make([]string, 0, len(c.AuthProfiles))
+		for n := range c.AuthProfiles {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+
+		diags.AddAttributeError(
+			path.Root("auth_profile"),
+			"Unknown BlueCat Auth Profile",
+			fmt.Sprintf("No auth profile named %q is defined in the provider auth_profiles. Defined profiles: %s", name.ValueString(), strings.Join(names, ", ")),
+		)
+		return nil, diags
+	}
+
+	override := *c
+	override.Username = profile.Username
+	override.Password = profile.Password
+	override.AuthMethod = profile.AuthMethod
+	override.Token = profile.Token
+
+	return &override, diags
+}
+
+// clientLoginWithAuthProfile logs in like clientLogin, but uses the auth
+// profile name instead of the provider credentials if it is set.
+func clientLoginWithAuthProfile(ctx context.Context, loginClient *loginClient, name types.String) (gobam.ProteusAPI, diag.Diagnostics) {
+	profileClient, diags := loginClient.withAuthProfile(name)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if profileClient != loginClient {
+		tflog.Debug(ctx, "Using auth profile instead of provider credentials", map[string]interface{}{"auth_profile": name.ValueString(), "username": profileClient.Username})
+	}
+
+	return clientLogin(ctx, profileClient)
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestExpandAuthProfiles(t *testing.T) {
+	profileType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"username":    types.StringType,
+		"password":    types.StringType,
+		"auth_method": types.StringType,
+		"token":       types.StringType,
+	}}
+	profile := func(username, password, authMethod, token types.String) attr.Value {
+		return types.ObjectValueMust(profileType.AttrTypes, map[string]attr.Value{
+			"username":    username,
+			"password":    password,
+			"auth_method": authMethod,
+			"token":       token,
+		})
+	}
+
+	profiles := types.MapValueMust(profileType, map[string]attr.Value{
+		"team_a": profile(types.StringValue("a"), types.StringValue("secret"), types.StringNull(), types.StringNull()),
+		"team_b": profile(types.StringValue("b"), types.StringNull(), types.StringValue(authMethodToken), types.StringValue("token")),
+	})
+
+	expanded, diags := expandAuthProfiles(context.Background(), profiles)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if expanded["team_a"].AuthMethod != authMethodPassword || expanded["team_a"].Password != "secret" {
+		t.Errorf("expected team_a to default to password authentication, got %+v", expanded["team_a"])
+	}
+	if expanded["team_b"].AuthMethod != authMethodToken || expanded["team_b"].Token != "token" {
+		t.Errorf("expected team_b to use token authentication, got %+v", expanded["team_b"])
+	}
+
+	profiles = types.MapValueMust(profileType, map[string]attr.Value{
+		"no_password": profile(types.StringValue("a"), types.StringNull(), types.StringNull(), types.StringNull()),
+	})
+	if _, diags := expandAuthProfiles(context.Background(), profiles); !diags.HasError() {
+		t.Error("expected an error for a password profile without a password")
+	}
+}
+
+func TestLoginClientWithAuthProfile(t *testing.T) {
+	client := &loginClient{
+		Username:   "provider",
+		Password:   "provider-secret",
+		AuthMethod: authMethodPassword,
+		ReadOnly:   true,
+		AuthProfiles: map[string]authProfile{
+			"team_a": {Username: "a", AuthMethod: authMethodToken, Token: "token"},
+		},
+	}
+
+	same, diags := client.withAuthProfile(types.StringNull())
+	if diags.HasError() || same != client {
+		t.Errorf("expected the provider credentials without an auth profile")
+	}
+
+	profileClient, diags := client.withAuthProfile(types.StringValue("team_a"))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if profileClient.Username != "a" || profileClient.AuthMethod != authMethodToken || profileClient.Token != "token" || profileClient.Password != "" {
+		t.Errorf("expected the credentials of the auth profile, got %+v", profileClient)
+	}
+	if !profileClient.ReadOnly {
+		t.Error("expected the other provider settings to be kept")
+	}
+	if client.Username != "provider" {
+		t.Error("expected the provider credentials to be left unchanged")
+	}
+
+	if _, diags := client.withAuthProfile(types.StringValue("missing")); !diags.HasError() {
+		t.Error("expected an error for an auth profile that is not defined")
+	}
+}
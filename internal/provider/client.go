@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/fiorix/wsdl2go/soap"
+	"github.com/umich-vci/gobam"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tlsVersions maps the values of the tls_min_version argument of the provider
+// to TLS versions.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// clientConfig describes how to connect to BlueCat Address Manager.
+type clientConfig struct {
+	endpoint  string
+	sslVerify bool
+
+	// caCertificate is a PEM encoded CA bundle trusted in addition to the
+	// system CAs.
+	caCertificate string
+	tlsMinVersion uint16
+
+	// proxyURL is the URL of the HTTP proxy to connect through. If it is nil,
+	// the proxy is taken from the HTTPS_PROXY and NO_PROXY environment variables.
+	proxyURL *url.URL
+
+	// timeout limits how long an API call, including retries, may take.
+	// Zero means no limit.
+	timeout time.Duration
+
+	// If tracer is not nil, a span is emitted for each API call.
+	tracer trace.Tracer
+
+	// If auditLog is not nil, an entry is written to it for each API call.
+	auditLog *auditLog
+
+	// Calls that fail with a transient error are retried up to maxRetries
+	// times with an exponential backoff starting at retryDelay.
+	maxRetries int
+	retryDelay time.Duration
+
+	// If apiVersion is v2, the REST v2 API is used for the calls it supports.
+	apiVersion string
+}
+
+// clientFactory creates BlueCat Address Manager API clients. The clients
+// share a HTTP transport, but each keeps the session cookie returned by Login
+// in its own cookie jar so that resources can use the API concurrently.
+type clientFactory struct {
+	config    clientConfig
+	url       string
+	transport http.RoundTripper
+}
+
+// newClientFactory returns a clientFactory for config.
+func newClientFactory(config clientConfig) (*clientFactory, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: config.tlsMinVersion,
+	}
+
+	if !config.sslVerify {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if config.caCertificate != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(config.caCertificate)) {
+			return nil, fmt.Errorf("no PEM encoded certificates were found in the CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.Proxy = http.ProxyFromEnvironment
+	if config.proxyURL != nil {
+		transport.Proxy = http.ProxyURL(config.proxyURL)
+	}
+
+	var roundTripper http.RoundTripper = transport
+	if config.auditLog != nil {
+		roundTripper = &auditTransport{base: roundTripper, log: config.auditLog}
+	}
+	if config.tracer != nil {
+		roundTripper = &tracingTransport{base: roundTripper, tracer: config.tracer}
+	}
+
+	return &clientFactory{
+		config:    config,
+		url:       "https://" + config.endpoint + "/Services/API?wsdl",
+		transport: roundTripper,
+	}, nil
+}
+
+// newClient returns a BlueCat Address Manager API client with its own session.
+// The API calls of the client are canceled when ctx is done.
+func (f *clientFactory) newClient(ctx context.Context) (gobam.ProteusAPI, error) {
+	return f.newClientWithAuthorization(ctx, "")
+}
+
+// newTokenClient returns a BlueCat Address Manager API client that sends the
+// API token of username with each request instead of logging in.
+func (f *clientFactory) newTokenClient(ctx context.Context, username string, token string) (gobam.ProteusAPI, error) {
+	authorization := "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+token))
+
+	client, err := f.newClientWithAuthorization(ctx, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	if v2, ok := client.(*restV2Client); ok {
+		v2.auth = authorization
+	}
+
+	return tokenClient{client}, nil
+}
+
+// newClientWithAuthorization returns a BlueCat Address Manager API client
+// that sends authorization as the Authorization header of each request
+// unless it is empty.
+func (f *clientFactory) newClientWithAuthorization(ctx context.Context, authorization string) (gobam.ProteusAPI, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var transport http.RoundTripper = &retryTransport{base: f.transport, jar: jar, maxRetries: f.config.maxRetries, delay: f.config.retryDelay}
+	if authorization != "" {
+		transport = &authorizationTransport{base: transport, authorization: authorization}
+	}
+
+	cli := &soap.Client{
+		URL:       f.url,
+		Namespace: gobam.Namespace,
+		Config: &http.Client{
+			Jar:       jar,
+			Transport: &contextTransport{base: transport, ctx: ctx},
+			Timeout:   f.config.timeout,
+		},
+	}
+
+	var client gobam.ProteusAPI = &rawAPIClient{ProteusAPI: gobam.NewProteusAPI(cli), cli: cli}
+	if f.config.apiVersion == apiVersionV2 {
+		return newRestV2Client(client, f.config.endpoint, &contextTransport{base: f.transport, ctx: ctx}, f.config.timeout), nil
+	}
+
+	return client, nil
+}
+
+// tokenClient is a client authenticated with an API token instead of a
+// session, so logging in and out does nothing.
+type tokenClient struct {
+	gobam.ProteusAPI
+}
+
+func (c tokenClient) Login(username string, password string) error {
+	return nil
+}
+
+func (c tokenClient) Logout() error {
+	return nil
+}
+
+func (c tokenClient) setContext(ctx context.Context) {
+	setClientContext(c.ProteusAPI, ctx)
+}
+
+// authorizationTransport is a http.RoundTripper that sets the Authorization
+// header of each request.
+type authorizationTransport struct {
+	base          http.RoundTripper
+	authorization string
+}
+
+func (t *authorizationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", t.authorization)
+
+	return t.base.RoundTrip(req)
+}
+
+// contextTransport is a http.RoundTripper that sends each request with ctx,
+// so that requests are canceled when the Terraform operation that made them
+// is canceled or times out. The SOAP client does not take a context itself.
+// ctx can be replaced with setClientContext, so that cleanup such as logging
+// out still works after the operation timed out.
+type contextTransport struct {
+	base http.RoundTripper
+
+	mu  sync.Mutex
+	ctx context.Context
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	ctx := t.ctx
+	t.mu.Unlock()
+
+	return t.base.RoundTrip(req.WithContext(ctx))
+}
+
+func (t *contextTransport) setContext(ctx context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.ctx = ctx
+}
+
+// contextClient is a client whose requests are sent with a context that can
+// be replaced.
+type contextClient interface {
+	setContext(ctx context.Context)
+}
+
+// setClientContext sends the requests client makes from now on with ctx. It
+// does nothing if client was not created by a clientFactory, such as in
+// tests.
+func setClientContext(client gobam.ProteusAPI, ctx context.Context) {
+	if c, ok := client.(contextClient); ok {
+		c.setContext(ctx)
+	}
+}
+
+// cleanupTimeout limits how long the cleanup after an operation, such as
+// logging out or rolling back a create, may take.
+const cleanupTimeout = time.Minute
+
+// cleanupContext returns a context for the cleanup after an operation that
+// is not canceled when ctx is, since the operation may have run out of time.
+func cleanupContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewClientFactory(t *testing.T) {
+	for _, v := range tlsMinVersions {
+		if _, ok := tlsVersions[v]; !ok {
+			t.Errorf("tls_min_version %q has no TLS version", v)
+		}
+	}
+
+	f, err := newClientFactory(clientConfig{endpoint: "bam.example.com", sslVerify: true, tlsMinVersion: tls.VersionTLS13})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.url != "https://bam.example.com/Services/API?wsdl" {
+		t.Errorf("unexpected url %q", f.url)
+	}
+	transport, ok := f.transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", f.transport)
+	}
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS13 || transport.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("unexpected TLS config %+v", transport.TLSClientConfig)
+	}
+
+	proxyURL, _ := url.Parse("http://proxy.example.com:3128")
+	f, err = newClientFactory(clientConfig{endpoint: "bam.example.com", proxyURL: proxyURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodPost, f.url, nil)
+	proxy, err := f.transport.(*http.Transport).Proxy(req)
+	if err != nil || proxy.String() != proxyURL.String() {
+		t.Errorf("expected proxy %s, got %v", proxyURL, proxy)
+	}
+
+	if _, err := newClientFactory(clientConfig{endpoint: "bam.example.com", caCertificate: "not a certificate"}); err == nil {
+		t.Error("expected an error for a CA certificate that is not PEM encoded")
+	}
+}
+
+func TestTokenClient(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	caCertificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	f, err := newClientFactory(clientConfig{
+		endpoint:      strings.TrimPrefix(server.URL, "https://"),
+		sslVerify:     true,
+		caCertificate: string(caCertificate),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := f.newTokenClient(context.Background(), "terraform", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Login("terraform", ""); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = client.GetSystemInfo()
+	if err := client.Logout(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 1 {
+		t.Fatalf("expected only the GetSystemInfo request, got %d requests", len(requests))
+	}
+	if expected := "Basic dGVycmFmb3JtOnNlY3JldA=="; requests[0].Header.Get("Authorization") != expected {
+		t.Errorf("expected Authorization %q, got %q", expected, requests[0].Header.Get("Authorization"))
+	}
+}
+
+func TestClientContext(t *testing.T) {
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	caCertificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	f, err := newClientFactory(clientConfig{
+		endpoint:      strings.TrimPrefix(server.URL, "https://"),
+		sslVerify:     true,
+		caCertificate: string(caCertificate),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client, err := f.newClient(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetSystemInfo(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the call to be canceled with the context, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests to be sent, got %d", requests)
+	}
+}
+
+func TestClientContextCleanupAfterTimeout(t *testing.T) {
+	var mu sync.Mutex
+	var actions []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action := r.Header.Get("SOAPAction")
+		action = action[strings.LastIndex(action, "/")+1:]
+		mu.Lock()
+		actions = append(actions, action)
+		mu.Unlock()
+
+		// the allocation takes longer than the create timeout
+		if action == "GetNextAvailableIP4Network" {
+			time.Sleep(200 * time.Millisecond)
+		}
+
+		response := strings.ToLower(action[:1]) + action[1:] + "Response"
+		fmt.Fprintf(w, `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><ns2:%s xmlns:ns2="http://api.proteus.bluecatnetworks.com"/></soap:Body></soap:Envelope>`, response)
+	}))
+	defer server.Close()
+
+	caCertificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	f, err := newClientFactory(clientConfig{
+		endpoint:      strings.TrimPrefix(server.URL, "https://"),
+		sslVerify:     true,
+		caCertificate: string(caCertificate),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := withTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client, err := f.newClient(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetNextAvailableIP4Network(1, 256, false, true); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the allocation to time out, got %v", err)
+	}
+
+	// rolling back and logging out still work after the timeout
+	cleanupCtx, cleanupCancel := cleanupContext(ctx)
+	defer cleanupCancel()
+	setClientContext(client, cleanupCtx)
+
+	if err := rollbackIP4Create(client, 2, "IP4Network", nil); err != nil {
+		t.Errorf("expected the rollback to work after the timeout, got %v", err)
+	}
+	if diags := clientLogout(ctx, &client); diags.HasError() {
+		t.Errorf("expected the logout to work after the timeout, got %v", diags)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := strings.Join(actions, " "); got != "GetNextAvailableIP4Network Delete Logout" {
+		t.Errorf("unexpected requests %q", got)
+	}
+}
@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/umich-vci/gobam"
+)
+
+// restV2Collections maps the object types of the legacy API to the REST v2
+// collection they are listed in.
+var restV2Collections = map[string]string{
+	"IP4Block":   "blocks",
+	"IP4Network": "networks",
+	"IP4Address": "addresses",
+}
+
+// restV2ParentCollections maps the object types of the legacy API to the
+// REST v2 collections their parent may be in, in the order they are tried.
+var restV2ParentCollections = map[string][]string{
+	"IP4Block":   {"blocks", "configurations"},
+	"IP4Network": {"blocks"},
+	"IP4Address": {"networks"},
+}
+
+// restV2Types maps REST v2 resource types to the object types of the legacy API.
+var restV2Types = map[string]string{
+	"IPv4Block":   "IP4Block",
+	"IPv4Network": "IP4Network",
+	"IPv4Address": "IP4Address",
+}
+
+// restV2Client is a BlueCat Address Manager API client that uses the REST v2
+// API of BlueCat Integrity 9.5 and later for the calls it implements and the
+// legacy API for everything else. If the REST v2 API is not available, or a
+// REST v2 call fails, the legacy API is used instead.
+type restV2Client struct {
+	gobam.ProteusAPI
+
+	client  *http.Client
+	baseURL string
+
+	// auth is the Authorization header of the REST v2 session, or empty if
+	// there is no REST v2 session.
+	auth      string
+	sessionID int64
+}
+
+// restV2Session is a session returned by the REST v2 API.
+type restV2Session struct {
+	ID                             int64  `json:"id"`
+	BasicAuthenticationCredentials string `json:"basicAuthenticationCredentials"`
+}
+
+// restV2Page is a page of a REST v2 collection.
+type restV2Page struct {
+	Count int              `json:"count"`
+	Data  []restV2Resource `json:"data"`
+}
+
+// restV2Resource contains the fields of the REST v2 resources that are
+// converted to legacy API entities.
+type restV2Resource struct {
+	ID                int64          `json:"id"`
+	Type              string         `json:"type"`
+	Name              *string        `json:"name"`
+	Range             string         `json:"range"`
+	Address           string         `json:"address"`
+	Gateway           string         `json:"gateway"`
+	State             string         `json:"state"`
+	UserDefinedFields map[string]any `json:"userDefinedFields"`
+	MACAddress        *struct {
+		Address string `json:"address"`
+	} `json:"macAddress"`
+}
+
+// restV2Error is returned for REST v2 responses that are not successful.
+type restV2Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *restV2Error) Error() string {
+	return fmt.Sprintf("REST v2 API returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// newRestV2Client returns a restV2Client for endpoint that falls back to legacy.
+func newRestV2Client(legacy gobam.ProteusAPI, endpoint string, transport http.RoundTripper, timeout time.Duration) *restV2Client {
+	return &restV2Client{
+		ProteusAPI: legacy,
+		client:     &http.Client{Transport: transport, Timeout: timeout},
+		baseURL:    "https://" + endpoint + "/api/v2",
+	}
+}
+
+func (c *restV2Client) setContext(ctx context.Context) {
+	setClientContext(c.ProteusAPI, ctx)
+	if t, ok := c.client.Transport.(*contextTransport); ok {
+		t.setContext(ctx)
+	}
+}
+
+// Login logs in to the legacy API and, if it is available, the REST v2 API.
+func (c *restV2Client) Login(username string, password string) error {
+	if err := c.ProteusAPI.Login(username, password); err != nil {
+		return err
+	}
+
+	c.auth = ""
+	var session restV2Session
+	err := c.do(http.MethodPost, "/sessions", map[string]string{"username": username, "password": password}, &session)
+	if err != nil || session.BasicAuthenticationCredentials == "" {
+		// the REST v2 API is not available, so only the legacy API is used
+		return nil
+	}
+
+	c.auth = "Basic " + session.BasicAuthenticationCredentials
+	c.sessionID = session.ID
+
+	return nil
+}
+
+// Logout logs out of the REST v2 API session, if any, and the legacy API.
+func (c *restV2Client) Logout() error {
+	if c.auth != "" {
+		err := c.do(http.MethodPatch, fmt.Sprintf("/sessions/%d", c.sessionID), map[string]string{"state": "LOGGED_OUT"}, nil)
+		c.auth = ""
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.ProteusAPI.Logout()
+}
+
+// GetEntities lists IPv4 blocks, networks, and addresses with the REST v2 API.
+func (c *restV2Client) GetEntities(parentId int64, _type string, start int, count int) (*gobam.APIEntityArray, error) {
+	collection, ok := restV2Collections[_type]
+	if c.auth == "" || !ok {
+		return c.ProteusAPI.GetEntities(parentId, _type, start, count)
+	}
+
+	for _, parent := range restV2ParentCollections[_type] {
+		var page restV2Page
+		err := c.do(http.MethodGet, fmt.Sprintf("/%s/%d/%s?offset=%d&limit=%d", parent, parentId, collection, start, count), nil, &page)
+		if err != nil {
+			continue
+		}
+
+		entities := &gobam.APIEntityArray{}
+		for _, r := range page.Data {
+			entities.Item = append(entities.Item, r.entity())
+		}
+
+		return entities, nil
+	}
+
+	return c.ProteusAPI.GetEntities(parentId, _type, start, count)
+}
+
+// do sends a request to the REST v2 API and decodes the response into out
+// unless it is nil.
+func (c *restV2Client) do(method string, path string, in any, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.auth != "" {
+		req.Header.Set("Authorization", c.auth)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &restV2Error{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(message))}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// entity converts a REST v2 resource to a legacy API entity with the
+// properties the legacy API would return for it.
+func (r restV2Resource) entity() *gobam.APIEntity {
+	objectType, ok := restV2Types[r.Type]
+	if !ok {
+		objectType = r.Type
+	}
+
+	properties := propertyMap{}
+	if r.Range != "" {
+		if start, end, ok := strings.Cut(r.Range, "-"); ok {
+			properties.set("start", start)
+			properties.set("end", end)
+		} else {
+			properties.set("CIDR", r.Range)
+		}
+	}
+	if r.Address != "" {
+		properties.set("address", r.Address)
+	}
+	if r.Gateway != "" {
+		properties.set("gateway", r.Gateway)
+	}
+	if r.State != "" {
+		properties.set("state", r.State)
+	}
+	if r.MACAddress != nil && r.MACAddress.Address != "" {
+		properties.set("macAddress", r.MACAddress.Address)
+	}
+
+	for k, v := range r.UserDefinedFields {
+		properties.set(k, fmt.Sprint(v))
+	}
+
+	id := r.ID
+
+	return &gobam.APIEntity{
+		Id:         &id,
+		Name:       r.Name,
+		Type:       &objectType,
+		Properties: properties.stringPointer(),
+	}
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/umich-vci/gobam"
+)
+
+// legacyClientStub is the legacy API client the REST v2 client falls back to.
+type legacyClientStub struct {
+	gobam.ProteusAPI
+	getEntitiesCalls int
+}
+
+func (c *legacyClientStub) Login(username string, password string) error { return nil }
+
+func (c *legacyClientStub) Logout() error { return nil }
+
+func (c *legacyClientStub) GetEntities(parentId int64, _type string, start int, count int) (*gobam.APIEntityArray, error) {
+	c.getEntitiesCalls++
+	return &gobam.APIEntityArray{}, nil
+}
+
+func newRestV2TestServer(v2Available bool) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !v2Available {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/sessions":
+			_ = json.NewEncoder(w).Encode(restV2Session{ID: 7, BasicAuthenticationCredentials: "dXNlcjp0b2tlbg=="})
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v2/sessions/7":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/api/v2/blocks/100/networks":
+			if r.Header.Get("Authorization") != "Basic dXNlcjp0b2tlbg==" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"count":1,"data":[{"id":101,"type":"IPv4Network","name":"net","range":"10.0.0.0/24","gateway":"10.0.0.1","userDefinedFields":{"owner":"vci"}}]}`))
+		case r.URL.Path == "/api/v2/blocks/1/blocks":
+			http.NotFound(w, r)
+		case r.URL.Path == "/api/v2/configurations/1/blocks":
+			_, _ = w.Write([]byte(`{"count":1,"data":[{"id":100,"type":"IPv4Block","name":null,"range":"10.0.0.0-10.0.0.99"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestRestV2ClientGetEntities(t *testing.T) {
+	server := newRestV2TestServer(true)
+	defer server.Close()
+
+	legacy := &legacyClientStub{}
+	client := newRestV2Client(legacy, strings.TrimPrefix(server.URL, "https://"), server.Client().Transport, 0)
+
+	if err := client.Login("user", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	networks, err := client.GetEntities(100, "IP4Network", 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(networks.Item) != 1 {
+		t.Fatalf("expected 1 network, got %d", len(networks.Item))
+	}
+	network := networks.Item[0]
+	if *network.Id != 101 || *network.Name != "net" || *network.Type != "IP4Network" {
+		t.Errorf("unexpected network %d %s %s", *network.Id, *network.Name, *network.Type)
+	}
+	if expected := "CIDR=10.0.0.0/24|gateway=10.0.0.1|owner=vci|"; *network.Properties != expected {
+		t.Errorf("expected properties %q, got %q", expected, *network.Properties)
+	}
+
+	// blocks in a configuration are found after the blocks collection fails
+	blocks, err := client.GetEntities(1, "IP4Block", 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks.Item) != 1 || *blocks.Item[0].Properties != "end=10.0.0.99|start=10.0.0.0|" {
+		t.Errorf("unexpected blocks %+v", blocks.Item)
+	}
+
+	// types without a REST v2 collection use the legacy API
+	if _, err := client.GetEntities(100, "HostRecord", 0, 10); err != nil {
+		t.Fatal(err)
+	}
+	if legacy.getEntitiesCalls != 1 {
+		t.Errorf("expected 1 legacy call, got %d", legacy.getEntitiesCalls)
+	}
+
+	if err := client.Logout(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestV2ClientFallback(t *testing.T) {
+	server := newRestV2TestServer(false)
+	defer server.Close()
+
+	legacy := &legacyClientStub{}
+	client := newRestV2Client(legacy, strings.TrimPrefix(server.URL, "https://"), server.Client().Transport, 0)
+
+	if err := client.Login("user", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetEntities(100, "IP4Network", 0, 10); err != nil {
+		t.Fatal(err)
+	}
+	if legacy.getEntitiesCalls != 1 {
+		t.Errorf("expected the legacy API to be used, got %d legacy calls", legacy.getEntitiesCalls)
+	}
+
+	if err := client.Logout(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,14 +1,23 @@
 package provider
 
 import (
+	"context"
+	"encoding/binary"
 	"fmt"
+	"math/big"
+	"net"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/umich-vci/gobam"
 )
 
@@ -31,6 +40,8 @@ type IP4NetworkModel struct {
 	LocationCode              types.String
 	LocationInherited         types.Bool
 	SharedNetwork             types.String
+	DynamicUpdate             types.Bool
+	Comments                  types.String
 
 	// these are user defined fields that are not built-in
 	UserDefinedFields types.Map
@@ -60,122 +71,125 @@ func flattenIP4NetworkProperties(e *gobam.APIEntity) (*IP4NetworkModel, diag.Dia
 	dnsRestrictionsFound := false
 
 	if e.Properties != nil {
-		props := strings.Split(*e.Properties, "|")
-		for x := range props {
-			if len(props[x]) > 0 {
-				prop := strings.Split(props[x], "=")[0]
-				val := strings.Split(props[x], "=")[1]
-
-				switch prop {
-				case "name":
-					// we ignore the name because it is already a top level parameter
-				case "CIDR":
-					i.CIDR = types.StringValue(val)
-				case "template":
-					t, err := strconv.ParseInt(val, 10, 64)
+		for prop, val := range parseProperties(*e.Properties) {
+			switch prop {
+			case "name":
+				// we ignore the name because it is already a top level parameter
+			case "CIDR":
+				i.CIDR = types.StringValue(val)
+			case "template":
+				t, err := strconv.ParseInt(val, 10, 64)
+				if err != nil {
+					d.AddError("error parsing template to int64", err.Error())
+					break
+				}
+				i.Template = types.Int64Value(t)
+			case "gateway":
+				i.Gateway = types.StringValue(val)
+			case "defaultDomains":
+				defaultDomainsFound = true
+				var ddDiag diag.Diagnostics
+				defaultDomains := strings.Split(val, ",")
+				defaultDomainsList := []attr.Value{}
+				for x := range defaultDomains {
+					dID, err := strconv.ParseInt(defaultDomains[x], 10, 64)
 					if err != nil {
-						d.AddError("error parsing template to int64", err.Error())
+						d.AddError("error parsing defaultDomains to int64", err.Error())
 						break
 					}
-					i.Template = types.Int64Value(t)
-				case "gateway":
-					i.Gateway = types.StringValue(val)
-				case "defaultDomains":
-					defaultDomainsFound = true
-					var ddDiag diag.Diagnostics
-					defaultDomains := strings.Split(val, ",")
-					defaultDomainsList := []attr.Value{}
-					for x := range defaultDomains {
-						dID, err := strconv.ParseInt(defaultDomains[x], 10, 64)
-						if err != nil {
-							d.AddError("error parsing defaultDomains to int64", err.Error())
-							break
-						}
-						defaultDomainsList = append(defaultDomainsList, types.Int64Value(dID))
-					}
+					defaultDomainsList = append(defaultDomainsList, types.Int64Value(dID))
+				}
 
-					defaultDomainsSet, ddDiag = basetypes.NewSetValue(types.Int64Type, defaultDomainsList)
-					if ddDiag.HasError() {
-						d.Append(ddDiag...)
-						break
-					}
-				case "defaultView":
-					dv, err := strconv.ParseInt(val, 10, 64)
-					if err != nil {
-						d.AddError("error parsing defaultView to int64", err.Error())
-						break
-					}
-					i.DefaultView = types.Int64Value(dv)
-				case "dnsRestrictions":
-					dnsRestrictionsFound = true
-					var drDiag diag.Diagnostics
-					dnsRestrictions := strings.Split(val, ",")
-					didList := []attr.Value{}
-					for x := range dnsRestrictions {
-						dID, err := strconv.ParseInt(dnsRestrictions[x], 10, 64)
-						if err != nil {
-							d.AddError("error parsing dnsRestrictions to int64", err.Error())
-							break
-						}
-						didList = append(didList, types.Int64Value(dID))
-					}
-					dnsRestrictionsSet, drDiag = basetypes.NewSetValue(types.Int64Type, didList)
-					if drDiag.HasError() {
-						d.Append(drDiag...)
-					}
-				case "allowDuplicateHost":
-					i.AllowDuplicateHost = types.BoolPointerValue(enableDisableToBool(val))
-				case "pingBeforeAssign":
-					i.PingBeforeAssign = types.BoolPointerValue(enableDisableToBool(val))
-				case "inheritAllowDuplicateHost":
-					b, err := strconv.ParseBool(val)
-					if err != nil {
-						d.AddError("error parsing inheritAllowDuplicateHost to bool", err.Error())
-						break
-					}
-					i.InheritAllowDuplicateHost = types.BoolValue(b)
-				case "inheritPingBeforeAssign":
-					b, err := strconv.ParseBool(val)
-					if err != nil {
-						d.AddError("error parsing inheritPingBeforeAssign to bool", err.Error())
-						break
-					}
-					i.InheritPingBeforeAssign = types.BoolValue(b)
-				case "inheritDNSRestrictions":
-					b, err := strconv.ParseBool(val)
-					if err != nil {
-						d.AddError("error parsing inheritDNSRestrictions to bool", err.Error())
-						break
-					}
-					i.InheritDNSRestrictions = types.BoolValue(b)
-				case "inheritDefaultDomains":
-					b, err := strconv.ParseBool(val)
-					if err != nil {
-						d.AddError("error parsing inheritDefaultDomains to bool", err.Error())
-						break
-					}
-					i.InheritDefaultDomains = types.BoolValue(b)
-				case "inheritDefaultView":
-					b, err := strconv.ParseBool(val)
-					if err != nil {
-						d.AddError("error parsing inheritDefaultView to bool", err.Error())
-						break
-					}
-					i.InheritDefaultView = types.BoolValue(b)
-				case "locationCode":
-					i.LocationCode = types.StringValue(val)
-				case "locationInherited":
-					b, err := strconv.ParseBool(val)
+				defaultDomainsSet, ddDiag = basetypes.NewSetValue(types.Int64Type, defaultDomainsList)
+				if ddDiag.HasError() {
+					d.Append(ddDiag...)
+					break
+				}
+			case "defaultView":
+				dv, err := strconv.ParseInt(val, 10, 64)
+				if err != nil {
+					d.AddError("error parsing defaultView to int64", err.Error())
+					break
+				}
+				i.DefaultView = types.Int64Value(dv)
+			case "dnsRestrictions":
+				dnsRestrictionsFound = true
+				var drDiag diag.Diagnostics
+				dnsRestrictions := strings.Split(val, ",")
+				didList := []attr.Value{}
+				for x := range dnsRestrictions {
+					dID, err := strconv.ParseInt(dnsRestrictions[x], 10, 64)
 					if err != nil {
-						d.AddError("error parsing locationInherited to bool", err.Error())
+						d.AddError("error parsing dnsRestrictions to int64", err.Error())
 						break
 					}
-					i.LocationInherited = types.BoolValue(b)
-				case "sharedNetwork":
-					i.SharedNetwork = types.StringValue(val)
-				default:
-					udfMap[prop] = types.StringValue(val)
+					didList = append(didList, types.Int64Value(dID))
+				}
+				dnsRestrictionsSet, drDiag = basetypes.NewSetValue(types.Int64Type, didList)
+				if drDiag.HasError() {
+					d.Append(drDiag...)
+				}
+			case "allowDuplicateHost":
+				i.AllowDuplicateHost = types.BoolPointerValue(enableDisableToBool(val))
+			case "pingBeforeAssign":
+				i.PingBeforeAssign = types.BoolPointerValue(enableDisableToBool(val))
+			case "inheritAllowDuplicateHost":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing inheritAllowDuplicateHost to bool", err.Error())
+					break
+				}
+				i.InheritAllowDuplicateHost = types.BoolValue(b)
+			case "inheritPingBeforeAssign":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing inheritPingBeforeAssign to bool", err.Error())
+					break
+				}
+				i.InheritPingBeforeAssign = types.BoolValue(b)
+			case "inheritDNSRestrictions":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing inheritDNSRestrictions to bool", err.Error())
+					break
+				}
+				i.InheritDNSRestrictions = types.BoolValue(b)
+			case "inheritDefaultDomains":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing inheritDefaultDomains to bool", err.Error())
+					break
+				}
+				i.InheritDefaultDomains = types.BoolValue(b)
+			case "inheritDefaultView":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing inheritDefaultView to bool", err.Error())
+					break
 				}
+				i.InheritDefaultView = types.BoolValue(b)
+			case "locationCode":
+				i.LocationCode = types.StringValue(val)
+			case "locationInherited":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing locationInherited to bool", err.Error())
+					break
+				}
+				i.LocationInherited = types.BoolValue(b)
+			case "sharedNetwork":
+				i.SharedNetwork = types.StringValue(val)
+			case "dynamicUpdate":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing dynamicUpdate to bool", err.Error())
+					break
+				}
+				i.DynamicUpdate = types.BoolValue(b)
+			case "comments":
+				i.Comments = types.StringValue(val)
+			default:
+				udfMap[prop] = types.StringValue(val)
 			}
 		}
 	}
@@ -218,6 +232,7 @@ type IP4BlockModel struct {
 	InheritDefaultView        types.Bool
 	LocationCode              types.String
 	LocationInherited         types.Bool
+	Comments                  types.String
 
 	// these are user defined fields that are not built-in
 	UserDefinedFields types.Map
@@ -247,115 +262,111 @@ func flattenIP4BlockProperties(e *gobam.APIEntity) (*IP4BlockModel, diag.Diagnos
 	dnsRestrictionsFound := false
 
 	if e.Properties != nil {
-		props := strings.Split(*e.Properties, "|")
-		for x := range props {
-			if len(props[x]) > 0 {
-				prop := strings.Split(props[x], "=")[0]
-				val := strings.Split(props[x], "=")[1]
-
-				switch prop {
-				case "name":
-					// we ignore the name because it is already a top level parameter
-				case "CIDR":
-					i.CIDR = types.StringValue(val)
-				case "defaultDomains":
-					defaultDomainsFound = true
-					var ddDiag diag.Diagnostics
-					defaultDomains := strings.Split(val, ",")
-					defaultDomainsList := []attr.Value{}
-					for x := range defaultDomains {
-						dID, err := strconv.ParseInt(defaultDomains[x], 10, 64)
-						if err != nil {
-							d.AddError("error parsing defaultDomains to int64", err.Error())
-							break
-						}
-						defaultDomainsList = append(defaultDomainsList, types.Int64Value(dID))
-					}
-
-					defaultDomainsSet, ddDiag = basetypes.NewSetValue(types.Int64Type, defaultDomainsList)
-					if ddDiag.HasError() {
-						d.Append(ddDiag...)
-						break
-					}
-				case "start":
-					i.Start = types.StringValue(val)
-				case "end":
-					i.End = types.StringValue(val)
-				case "defaultView":
-					dv, err := strconv.ParseInt(val, 10, 64)
-					if err != nil {
-						d.AddError("error parsing defaultView to int64", err.Error())
-						break
-					}
-					i.DefaultView = types.Int64Value(dv)
-				case "dnsRestrictions":
-					dnsRestrictionsFound = true
-					var drDiag diag.Diagnostics
-					dnsRestrictions := strings.Split(val, ",")
-					didList := []attr.Value{}
-					for x := range dnsRestrictions {
-						dID, err := strconv.ParseInt(dnsRestrictions[x], 10, 64)
-						if err != nil {
-							d.AddError("error parsing dnsRestrictions to int64", err.Error())
-							break
-						}
-						didList = append(didList, types.Int64Value(dID))
-					}
-					dnsRestrictionsSet, drDiag = basetypes.NewSetValue(types.Int64Type, didList)
-					if drDiag.HasError() {
-						d.Append(drDiag...)
-					}
-				case "allowDuplicateHost":
-					i.AllowDuplicateHost = types.BoolPointerValue(enableDisableToBool(val))
-				case "pingBeforeAssign":
-					i.PingBeforeAssign = types.BoolPointerValue(enableDisableToBool(val))
-				case "inheritAllowDuplicateHost":
-					b, err := strconv.ParseBool(val)
-					if err != nil {
-						d.AddError("error parsing inheritAllowDuplicateHost to bool", err.Error())
-						break
-					}
-					i.InheritAllowDuplicateHost = types.BoolValue(b)
-				case "inheritPingBeforeAssign":
-					b, err := strconv.ParseBool(val)
-					if err != nil {
-						d.AddError("error parsing inheritPingBeforeAssign to bool", err.Error())
-						break
-					}
-					i.InheritPingBeforeAssign = types.BoolValue(b)
-				case "inheritDNSRestrictions":
-					b, err := strconv.ParseBool(val)
-					if err != nil {
-						d.AddError("error parsing inheritDNSRestrictions to bool", err.Error())
-						break
-					}
-					i.InheritDNSRestrictions = types.BoolValue(b)
-				case "inheritDefaultDomains":
-					b, err := strconv.ParseBool(val)
+		for prop, val := range parseProperties(*e.Properties) {
+			switch prop {
+			case "name":
+				// we ignore the name because it is already a top level parameter
+			case "CIDR":
+				i.CIDR = types.StringValue(val)
+			case "defaultDomains":
+				defaultDomainsFound = true
+				var ddDiag diag.Diagnostics
+				defaultDomains := strings.Split(val, ",")
+				defaultDomainsList := []attr.Value{}
+				for x := range defaultDomains {
+					dID, err := strconv.ParseInt(defaultDomains[x], 10, 64)
 					if err != nil {
-						d.AddError("error parsing inheritDefaultDomains to bool", err.Error())
+						d.AddError("error parsing defaultDomains to int64", err.Error())
 						break
 					}
-					i.InheritDefaultDomains = types.BoolValue(b)
-				case "inheritDefaultView":
-					b, err := strconv.ParseBool(val)
-					if err != nil {
-						d.AddError("error parsing inheritDefaultView to bool", err.Error())
-						break
-					}
-					i.InheritDefaultView = types.BoolValue(b)
-				case "locationCode":
-					i.LocationCode = types.StringValue(val)
-				case "locationInherited":
-					b, err := strconv.ParseBool(val)
+					defaultDomainsList = append(defaultDomainsList, types.Int64Value(dID))
+				}
+
+				defaultDomainsSet, ddDiag = basetypes.NewSetValue(types.Int64Type, defaultDomainsList)
+				if ddDiag.HasError() {
+					d.Append(ddDiag...)
+					break
+				}
+			case "start":
+				i.Start = types.StringValue(val)
+			case "end":
+				i.End = types.StringValue(val)
+			case "defaultView":
+				dv, err := strconv.ParseInt(val, 10, 64)
+				if err != nil {
+					d.AddError("error parsing defaultView to int64", err.Error())
+					break
+				}
+				i.DefaultView = types.Int64Value(dv)
+			case "dnsRestrictions":
+				dnsRestrictionsFound = true
+				var drDiag diag.Diagnostics
+				dnsRestrictions := strings.Split(val, ",")
+				didList := []attr.Value{}
+				for x := range dnsRestrictions {
+					dID, err := strconv.ParseInt(dnsRestrictions[x], 10, 64)
 					if err != nil {
-						d.AddError("error parsing locationInherited to bool", err.Error())
+						d.AddError("error parsing dnsRestrictions to int64", err.Error())
 						break
 					}
-					i.LocationInherited = types.BoolValue(b)
-				default:
-					udfMap[prop] = types.StringValue(val)
+					didList = append(didList, types.Int64Value(dID))
+				}
+				dnsRestrictionsSet, drDiag = basetypes.NewSetValue(types.Int64Type, didList)
+				if drDiag.HasError() {
+					d.Append(drDiag...)
+				}
+			case "allowDuplicateHost":
+				i.AllowDuplicateHost = types.BoolPointerValue(enableDisableToBool(val))
+			case "pingBeforeAssign":
+				i.PingBeforeAssign = types.BoolPointerValue(enableDisableToBool(val))
+			case "inheritAllowDuplicateHost":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing inheritAllowDuplicateHost to bool", err.Error())
+					break
+				}
+				i.InheritAllowDuplicateHost = types.BoolValue(b)
+			case "inheritPingBeforeAssign":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing inheritPingBeforeAssign to bool", err.Error())
+					break
+				}
+				i.InheritPingBeforeAssign = types.BoolValue(b)
+			case "inheritDNSRestrictions":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing inheritDNSRestrictions to bool", err.Error())
+					break
+				}
+				i.InheritDNSRestrictions = types.BoolValue(b)
+			case "inheritDefaultDomains":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing inheritDefaultDomains to bool", err.Error())
+					break
 				}
+				i.InheritDefaultDomains = types.BoolValue(b)
+			case "inheritDefaultView":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing inheritDefaultView to bool", err.Error())
+					break
+				}
+				i.InheritDefaultView = types.BoolValue(b)
+			case "locationCode":
+				i.LocationCode = types.StringValue(val)
+			case "locationInherited":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing locationInherited to bool", err.Error())
+					break
+				}
+				i.LocationInherited = types.BoolValue(b)
+			case "comments":
+				i.Comments = types.StringValue(val)
+			default:
+				udfMap[prop] = types.StringValue(val)
 			}
 		}
 	}
@@ -380,6 +391,197 @@ func flattenIP4BlockProperties(e *gobam.APIEntity) (*IP4BlockModel, diag.Diagnos
 	return i, d
 }
 
+// cidrToSize returns the number of addresses contained in an IPv4 CIDR.
+func cidrToSize(cidr string) (int64, error) {
+	parts := strings.Split(cidr, "/")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("%q is not a valid CIDR", cidr)
+	}
+
+	cidrNetmask, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	var size, e = big.NewInt(2), big.NewInt(32 - cidrNetmask)
+	size.Exp(size, e, nil)
+
+	return size.Int64(), nil
+}
+
+// ip4CIDRRange returns the first and last address of an IPv4 CIDR.
+func ip4CIDRRange(cidr string) (string, string, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", "", err
+	}
+
+	first := network.IP.To4()
+	if first == nil {
+		return "", "", fmt.Errorf("%q is not a valid IPv4 CIDR", cidr)
+	}
+
+	last := make(net.IP, net.IPv4len)
+	for i := range first {
+		last[i] = first[i] | ^network.Mask[i]
+	}
+
+	return first.String(), last.String(), nil
+}
+
+// ip4CIDREqual reports whether a and b are the same IPv4 network, so
+// 10.0.0.0/24 does not match 10.0.0.0/25 even though both have the same
+// network address.
+func ip4CIDREqual(a, b string) bool {
+	_, networkA, err := net.ParseCIDR(a)
+	if err != nil {
+		return false
+	}
+	_, networkB, err := net.ParseCIDR(b)
+	if err != nil {
+		return false
+	}
+
+	return networkA.String() == networkB.String()
+}
+
+// ip4DefaultGateway returns the address BlueCat Address Manager uses as the
+// gateway of a new IPv4 network, the first address after the network address.
+func ip4DefaultGateway(cidr string) (string, error) {
+	first, _, err := ip4CIDRRange(cidr)
+	if err != nil {
+		return "", err
+	}
+
+	ip := net.ParseIP(first).To4()
+	binary.BigEndian.PutUint32(ip, binary.BigEndian.Uint32(ip)+1)
+
+	return ip.String(), nil
+}
+
+// ip4RangeSize returns the number of addresses between start and end inclusive.
+func ip4RangeSize(start, end string) (int64, error) {
+	startIP := net.ParseIP(start).To4()
+	if startIP == nil {
+		return 0, fmt.Errorf("%q is not a valid IPv4 address", start)
+	}
+
+	endIP := net.ParseIP(end).To4()
+	if endIP == nil {
+		return 0, fmt.Errorf("%q is not a valid IPv4 address", end)
+	}
+
+	startInt := int64(binary.BigEndian.Uint32(startIP))
+	endInt := int64(binary.BigEndian.Uint32(endIP))
+	if endInt < startInt {
+		return 0, fmt.Errorf("range end %s is before range start %s", end, start)
+	}
+
+	return endInt - startInt + 1, nil
+}
+
+// ip4EntitySize returns the number of addresses contained in an IP4Block or IP4Network entity
+// using the CIDR property, or the start and end properties if the entity does not form a valid CIDR.
+func ip4EntitySize(e *gobam.APIEntity) (int64, error) {
+	if e == nil || e.Properties == nil {
+		return 0, fmt.Errorf("entity has no properties")
+	}
+
+	props := parseProperties(*e.Properties)
+	if cidr := props["CIDR"]; cidr != "" {
+		return cidrToSize(cidr)
+	}
+
+	start, startOK := props["start"]
+	end, endOK := props["end"]
+	if startOK && endOK {
+		return ip4RangeSize(start, end)
+	}
+
+	return 0, fmt.Errorf("entity has neither a CIDR nor a start and end")
+}
+
+// removeManagedUDF removes the UDF that marks an object as managed by terraform from a map
+// of user-defined fields so that it is not reported as drift.
+func removeManagedUDF(udfs types.Map, loginClient *loginClient) types.Map {
+	if loginClient == nil || loginClient.ManagedUDF == "" || udfs.IsNull() || udfs.IsUnknown() {
+		return udfs
+	}
+
+	elements := udfs.Elements()
+	if _, ok := elements[loginClient.ManagedUDF]; !ok {
+		return udfs
+	}
+
+	filtered := make(map[string]attr.Value, len(elements)-1)
+	for k, v := range elements {
+		if k != loginClient.ManagedUDF {
+			filtered[k] = v
+		}
+	}
+
+	return types.MapValueMust(types.StringType, filtered)
+}
+
+// userDefinedFieldsState returns the user-defined fields read from an object
+// to save in state. The managed UDF is removed, and if the provider only
+// manages configured keys, keys that are not in prior are removed too so that
+// fields set outside of terraform are not reported as drift. prior is the
+// planned value when creating or updating and the state when reading. If it is
+// null, such as when importing, every field is kept.
+func userDefinedFieldsState(prior, udfs types.Map, loginClient *loginClient) types.Map {
+	udfs = removeManagedUDF(udfs, loginClient)
+	if loginClient == nil || !loginClient.ManagedUDFKeys || prior.IsNull() || prior.IsUnknown() || udfs.IsNull() || udfs.IsUnknown() {
+		return udfs
+	}
+
+	keys := prior.Elements()
+	filtered := make(map[string]attr.Value, len(keys))
+	for k, v := range udfs.Elements() {
+		if _, ok := keys[k]; ok {
+			filtered[k] = v
+		}
+	}
+
+	return types.MapValueMust(types.StringType, filtered)
+}
+
+// userDefinedFieldsUpdateProperties returns the properties that update the
+// user-defined fields of an object from state to plan. Keys that are no longer
+// in the plan are set to an empty string to clear them, unless the provider
+// only manages configured keys.
+func userDefinedFieldsUpdateProperties(ctx context.Context, plan, state types.Map, loginClient *loginClient) (propertyMap, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if plan.Equal(state) {
+		return propertyMap{}, diags
+	}
+
+	var udfs, oldudfs map[string]string
+	diags.Append(plan.ElementsAs(ctx, &udfs, false)...)
+	diags.Append(state.ElementsAs(ctx, &oldudfs, false)...)
+
+	if loginClient != nil && loginClient.ManagedUDFKeys {
+		for k := range oldudfs {
+			if _, ok := udfs[k]; !ok {
+				delete(oldudfs, k)
+			}
+		}
+	}
+
+	return diffProperties(udfs, oldudfs), diags
+}
+
+// isManaged returns whether an object is marked as managed by terraform.
+func isManaged(e *gobam.APIEntity, loginClient *loginClient) bool {
+	if e == nil || e.Properties == nil || loginClient == nil || loginClient.ManagedUDF == "" {
+		return false
+	}
+
+	managed, _ := strconv.ParseBool(parseProperties(*e.Properties)[loginClient.ManagedUDF])
+	return managed
+}
+
 func enableDisableToBool(s string) *bool {
 	var val *bool
 
@@ -424,6 +626,7 @@ type IP4AddressModel struct {
 	VendorClassIdentifier types.String
 	LocationCode          types.String
 	LocationInherited     types.Bool
+	Comments              types.String
 
 	// these are user defined fields that are not built-in
 	UserDefinedFields types.Map
@@ -448,45 +651,41 @@ func flattenIP4AddressProperties(e *gobam.APIEntity) (*IP4AddressModel, diag.Dia
 	udfMap := make(map[string]attr.Value)
 
 	if e.Properties != nil {
-		props := strings.Split(*e.Properties, "|")
-		for x := range props {
-			if len(props[x]) > 0 {
-				prop := strings.Split(props[x], "=")[0]
-				val := strings.Split(props[x], "=")[1]
-
-				switch prop {
-				case "address":
-					i.Address = types.StringValue(val)
-				case "state":
-					i.State = types.StringValue(val)
-				case "macAddress":
-					i.MACAddress = types.StringValue(val)
-				case "routerPortInfo":
-					i.RouterPortInfo = types.StringValue(val)
-				case "switchPortInfo":
-					i.SwitchPortInfo = types.StringValue(val)
-				case "vlanInfo":
-					i.VLANInfo = types.StringValue(val)
-				case "leaseTime":
-					i.LeaseTime = types.StringValue(val)
-				case "expiryTime":
-					i.ExpiryTime = types.StringValue(val)
-				case "parameterRequestList":
-					i.ParameterRequestList = types.StringValue(val)
-				case "vendorClassIdentifier":
-					i.VendorClassIdentifier = types.StringValue(val)
-				case "locationCode":
-					i.LocationCode = types.StringValue(val)
-				case "locationInherited":
-					b, err := strconv.ParseBool(val)
-					if err != nil {
-						d.AddError("error parsing locationInherited to bool", err.Error())
-						break
-					}
-					i.LocationInherited = types.BoolValue(b)
-				default:
-					udfMap[prop] = types.StringValue(val)
+		for prop, val := range parseProperties(*e.Properties) {
+			switch prop {
+			case "address":
+				i.Address = types.StringValue(val)
+			case "state":
+				i.State = types.StringValue(val)
+			case "macAddress":
+				i.MACAddress = types.StringValue(val)
+			case "routerPortInfo":
+				i.RouterPortInfo = types.StringValue(val)
+			case "switchPortInfo":
+				i.SwitchPortInfo = types.StringValue(val)
+			case "vlanInfo":
+				i.VLANInfo = types.StringValue(val)
+			case "leaseTime":
+				i.LeaseTime = types.StringValue(val)
+			case "expiryTime":
+				i.ExpiryTime = types.StringValue(val)
+			case "parameterRequestList":
+				i.ParameterRequestList = types.StringValue(val)
+			case "vendorClassIdentifier":
+				i.VendorClassIdentifier = types.StringValue(val)
+			case "locationCode":
+				i.LocationCode = types.StringValue(val)
+			case "locationInherited":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing locationInherited to bool", err.Error())
+					break
 				}
+				i.LocationInherited = types.BoolValue(b)
+			case "comments":
+				i.Comments = types.StringValue(val)
+			default:
+				udfMap[prop] = types.StringValue(val)
 			}
 		}
 	}
@@ -507,6 +706,7 @@ type HostRecordModel struct {
 	AbsoluteName  types.String
 	Addresses     types.Set
 	ReverseRecord types.Bool
+	Comments      types.String
 
 	// these are user defined fields that are not built-in
 	UserDefinedFields types.Map
@@ -544,73 +744,69 @@ func flattenHostRecordProperties(e *gobam.APIEntity) (*HostRecordModel, diag.Dia
 	var addressIDsSet basetypes.SetValue
 
 	if e.Properties != nil {
-		props := strings.Split(*e.Properties, "|")
-		for x := range props {
-			if len(props[x]) > 0 {
-				prop := strings.Split(props[x], "=")[0]
-				val := strings.Split(props[x], "=")[1]
-
-				switch prop {
-				case "ttl":
-					t, err := strconv.ParseInt(val, 10, 64)
-					if err != nil {
-						d.AddError("error parsing ttl to int64", err.Error())
-						break
-					}
-					ttl = t
-				case "absoluteName":
-					h.AbsoluteName = types.StringValue(val)
-				case "addresses":
-					addressesFound = true
-					var aDiag diag.Diagnostics
-					addresses := strings.Split(val, ",")
-					addressesList := []attr.Value{}
-					for x := range addresses {
-						addressesList = append(addressesList, types.StringValue(addresses[x]))
-					}
+		for prop, val := range parseProperties(*e.Properties) {
+			switch prop {
+			case "ttl":
+				t, err := strconv.ParseInt(val, 10, 64)
+				if err != nil {
+					d.AddError("error parsing ttl to int64", err.Error())
+					break
+				}
+				ttl = t
+			case "absoluteName":
+				h.AbsoluteName = types.StringValue(val)
+			case "addresses":
+				addressesFound = true
+				var aDiag diag.Diagnostics
+				addresses := strings.Split(val, ",")
+				addressesList := []attr.Value{}
+				for x := range addresses {
+					addressesList = append(addressesList, types.StringValue(addresses[x]))
+				}
 
-					addressesSet, aDiag = basetypes.NewSetValue(types.StringType, addressesList)
-					if aDiag.HasError() {
-						d.Append(aDiag...)
-						break
-					}
-				case "addressIds":
-					addressIDsFound = true
-					var aDiag diag.Diagnostics
-					addressIDs := strings.Split(val, ",")
-					addressIDsList := []attr.Value{}
-					for x := range addressIDs {
-						addressID, err := strconv.ParseInt(addressIDs[x], 10, 64)
-						if err != nil {
-							d.AddError("error parsing addressIds to int64", err.Error())
-							break
-						}
-						addressIDsList = append(addressIDsList, types.Int64Value(addressID))
-					}
-					addressIDsSet, aDiag = basetypes.NewSetValue(types.Int64Type, addressIDsList)
-					if aDiag.HasError() {
-						d.Append(aDiag...)
-						break
-					}
-				case "parentId":
-					pid, err := strconv.ParseInt(val, 10, 64)
-					if err != nil {
-						d.AddError("error parsing parentId to int64", err.Error())
-						break
-					}
-					h.ParentID = types.Int64Value(pid)
-				case "parentType":
-					h.ParentType = types.StringValue(val)
-				case "reverseRecord":
-					b, err := strconv.ParseBool(val)
+				addressesSet, aDiag = basetypes.NewSetValue(types.StringType, addressesList)
+				if aDiag.HasError() {
+					d.Append(aDiag...)
+					break
+				}
+			case "addressIds":
+				addressIDsFound = true
+				var aDiag diag.Diagnostics
+				addressIDs := strings.Split(val, ",")
+				addressIDsList := []attr.Value{}
+				for x := range addressIDs {
+					addressID, err := strconv.ParseInt(addressIDs[x], 10, 64)
 					if err != nil {
-						d.AddError("error parsing reverseRecord to bool", err.Error())
+						d.AddError("error parsing addressIds to int64", err.Error())
 						break
 					}
-					h.ReverseRecord = types.BoolValue(b)
-				default:
-					udfMap[prop] = types.StringValue(val)
+					addressIDsList = append(addressIDsList, types.Int64Value(addressID))
+				}
+				addressIDsSet, aDiag = basetypes.NewSetValue(types.Int64Type, addressIDsList)
+				if aDiag.HasError() {
+					d.Append(aDiag...)
+					break
+				}
+			case "parentId":
+				pid, err := strconv.ParseInt(val, 10, 64)
+				if err != nil {
+					d.AddError("error parsing parentId to int64", err.Error())
+					break
 				}
+				h.ParentID = types.Int64Value(pid)
+			case "parentType":
+				h.ParentType = types.StringValue(val)
+			case "reverseRecord":
+				b, err := strconv.ParseBool(val)
+				if err != nil {
+					d.AddError("error parsing reverseRecord to bool", err.Error())
+					break
+				}
+				h.ReverseRecord = types.BoolValue(b)
+			case "comments":
+				h.Comments = types.StringValue(val)
+			default:
+				udfMap[prop] = types.StringValue(val)
 			}
 		}
 	}
@@ -637,3 +833,260 @@ func flattenHostRecordProperties(e *gobam.APIEntity) (*HostRecordModel, diag.Dia
 
 	return h, d
 }
+
+// AliasRecordModel describes the data model the built-in properties for an Alias Record object.
+type AliasRecordModel struct {
+	// These are exposed via the entity properties field for objects of type AliasRecord
+	TTL              types.Int64
+	AbsoluteName     types.String
+	LinkedRecordName types.String
+	Comments         types.String
+
+	// these are user defined fields that are not built-in
+	UserDefinedFields types.Map
+
+	// these are returned by the API with a hint based search but do not appear in the documentation
+	ParentID   types.Int64
+	ParentType types.String
+}
+
+func flattenAliasRecordProperties(e *gobam.APIEntity) (*AliasRecordModel, diag.Diagnostics) {
+	var d diag.Diagnostics
+
+	if e == nil {
+		d.AddError("invalid input to flattenAliasRecordProperties", "entity passed was nil")
+		return nil, d
+	}
+	if e.Type == nil {
+		d.AddError("invalid input to flattenAliasRecordProperties", "type of entity passed was nil")
+		return nil, d
+	} else if *e.Type != "AliasRecord" {
+		d.AddError("invalid input to flattenAliasRecordProperties", fmt.Sprintf("type of entity passed was %s", *e.Type))
+		return nil, d
+	}
+
+	a := &AliasRecordModel{}
+	udfMap := make(map[string]attr.Value)
+
+	var ttl int64 = -1
+
+	if e.Properties != nil {
+		for prop, val := range parseProperties(*e.Properties) {
+			switch prop {
+			case "ttl":
+				t, err := strconv.ParseInt(val, 10, 64)
+				if err != nil {
+					d.AddError("error parsing ttl to int64", err.Error())
+					break
+				}
+				ttl = t
+			case "absoluteName":
+				a.AbsoluteName = types.StringValue(val)
+			case "linkedRecordName":
+				a.LinkedRecordName = types.StringValue(val)
+			case "parentId":
+				pid, err := strconv.ParseInt(val, 10, 64)
+				if err != nil {
+					d.AddError("error parsing parentId to int64", err.Error())
+					break
+				}
+				a.ParentID = types.Int64Value(pid)
+			case "parentType":
+				a.ParentType = types.StringValue(val)
+			case "comments":
+				a.Comments = types.StringValue(val)
+			default:
+				udfMap[prop] = types.StringValue(val)
+			}
+		}
+	}
+
+	a.TTL = types.Int64Value(ttl)
+
+	userDefinedFields, udfDiag := basetypes.NewMapValue(types.StringType, udfMap)
+	if udfDiag.HasError() {
+		d.Append(udfDiag...)
+	}
+	a.UserDefinedFields = userDefinedFields
+
+	return a, d
+}
+
+// dnsLabelRegexp matches a DNS label of 1 to 63 letters, digits, hyphens, or
+// underscores that does not start or end with a hyphen.
+var dnsLabelRegexp = regexp.MustCompile(`^[A-Za-z0-9_]([A-Za-z0-9_-]{0,61}[A-Za-z0-9_])?$`)
+
+// validateDNSName returns an error if name is not a relative DNS name made of
+// valid labels. The first label may be a "*" wildcard. A trailing dot is not
+// allowed as BlueCat Address Manager builds the absolute name itself.
+func validateDNSName(name string) error {
+	if strings.HasSuffix(name, ".") {
+		return fmt.Errorf("%q must not end with a dot", name)
+	}
+
+	if len(name) > 253 {
+		return fmt.Errorf("%q is longer than 253 characters", name)
+	}
+
+	for i, label := range strings.Split(name, ".") {
+		if i == 0 && label == "*" {
+			continue
+		}
+
+		if !dnsLabelRegexp.MatchString(label) {
+			return fmt.Errorf("%q is not a valid DNS label in %q: labels must be 1 to 63 letters, digits, hyphens, or underscores and cannot start or end with a hyphen", label, name)
+		}
+	}
+
+	return nil
+}
+
+// caseInsensitiveValue returns prior if it equals value ignoring case and
+// value otherwise, so that DNS names BlueCat Address Manager stores in a
+// different case than they were configured in do not cause a diff.
+func caseInsensitiveValue(prior types.String, value *string) types.String {
+	if value != nil && !prior.IsNull() && !prior.IsUnknown() && strings.EqualFold(prior.ValueString(), *value) {
+		return prior
+	}
+
+	return types.StringPointerValue(value)
+}
+
+// macAddressRegexp matches a MAC address written as six pairs of hex digits
+// separated by colons or dashes, three groups of four hex digits separated by
+// dots, or twelve hex digits.
+var macAddressRegexp = regexp.MustCompile(`^([0-9A-Fa-f]{2}(:[0-9A-Fa-f]{2}){5}|[0-9A-Fa-f]{2}(-[0-9A-Fa-f]{2}){5}|[0-9A-Fa-f]{4}(\.[0-9A-Fa-f]{4}){2}|[0-9A-Fa-f]{12})$`)
+
+// macAddressRegexpMessage is the error of a MAC address that does not match
+// macAddressRegexp.
+const macAddressRegexpMessage = "must be a MAC address such as `00:50:56:01:02:03`, `00-50-56-01-02-03`, `0050.5601.0203`, or `005056010203`"
+
+// normalizeMACAddress returns mac as lower case hex digits without separators.
+func normalizeMACAddress(mac string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", "-", "", ".", "").Replace(mac))
+}
+
+// macAddressValue returns prior if it is the same MAC address as value and
+// value otherwise, so that MAC addresses BlueCat Address Manager returns with
+// dashes do not cause a diff when they were configured in another format.
+func macAddressValue(prior types.String, value types.String) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() && !value.IsNull() && !value.IsUnknown() && normalizeMACAddress(prior.ValueString()) == normalizeMACAddress(value.ValueString()) {
+		return prior
+	}
+
+	return value
+}
+
+// ip4AddressHostInfo returns the hostInfo argument of the address assignment
+// API calls, which is the fqdn, view id, reverse record flag, and sameAsZone
+// flag of the host record to create with the address. It is empty when no
+// hostname is set.
+func ip4AddressHostInfo(hostname types.String, viewID types.Int64, reverseRecord types.Bool) string {
+	if hostname.IsNull() || hostname.IsUnknown() {
+		return ""
+	}
+
+	return fmt.Sprintf("%s,%d,%t,false", hostname.ValueString(), viewID.ValueInt64(), reverseRecord.ValueBool())
+}
+
+// findLinkedHostRecord returns the object ID of the host record named hostname
+// that is linked to the address with addressID, or null if there is none.
+func findLinkedHostRecord(client gobam.ProteusAPI, addressID int64, hostname string) (types.Int64, error) {
+	hostRecords, err := client.GetLinkedEntities(addressID, "HostRecord", 0, 100)
+	if err != nil {
+		return types.Int64Null(), err
+	}
+
+	for _, hostRecord := range hostRecords.Item {
+		if hostRecord.Properties == nil {
+			continue
+		}
+		props := parseProperties(*hostRecord.Properties)
+		if props["absoluteName"] == hostname {
+			return types.Int64PointerValue(hostRecord.Id), nil
+		}
+	}
+
+	return types.Int64Null(), nil
+}
+
+// entityTypeMatches returns false and a warning if entity is not one of
+// objectTypes. This happens when the object of a resource was deleted outside
+// of Terraform and its ID was reused by a new object, which should be treated
+// as the resource being deleted instead of read as the wrong type.
+func entityTypeMatches(entity *gobam.APIEntity, objectTypes ...string) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if entity.Type != nil && slices.Contains(objectTypes, *entity.Type) {
+		return true, diags
+	}
+
+	actual := "unknown"
+	if entity.Type != nil {
+		actual = *entity.Type
+	}
+
+	diags.AddWarning(
+		"Object has a different type",
+		fmt.Sprintf("Object %d is a %s but a %s was expected. The object was likely deleted outside of Terraform and its ID reused, so it has been removed from the state and will be created again.",
+			*entity.Id, actual, strings.Join(objectTypes, " or ")),
+	)
+
+	return false, diags
+}
+
+// ip4ReverseNames returns the name of the PTR record of an IPv4 address and
+// the reverse zone that contains it, which is the /24 zone under
+// in-addr.arpa. Both are null if address is not a valid IPv4 address.
+func ip4ReverseNames(address types.String) (types.String, types.String) {
+	ip := net.ParseIP(address.ValueString()).To4()
+	if ip == nil {
+		return types.StringNull(), types.StringNull()
+	}
+
+	reverseZone := fmt.Sprintf("%d.%d.%d.in-addr.arpa", ip[2], ip[1], ip[0])
+	ptrName := fmt.Sprintf("%d.%s", ip[3], reverseZone)
+
+	return types.StringValue(ptrName), types.StringValue(reverseZone)
+}
+
+// setTaintedState saves data as the state of a resource that was created but
+// could not be fully configured. Values that are still unknown are saved as
+// null, and an error is returned so Terraform marks the resource as tainted
+// and replaces it on the next apply rather than losing track of it.
+func setTaintedState(ctx context.Context, state *tfsdk.State, data any, objectType, id string) diag.Diagnostics {
+	diags := state.Set(ctx, data)
+	if diags.HasError() {
+		return diags
+	}
+
+	raw, err := tftypes.Transform(state.Raw, func(_ *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsKnown() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		diags.AddError("Failed to save the state of the created "+objectType, err.Error())
+		return diags
+	}
+	state.Raw = raw
+
+	diags.AddError(
+		fmt.Sprintf("%s %s was created but not fully configured", objectType, id),
+		fmt.Sprintf("The %s was saved to the state as tainted, so Terraform will replace it on the next apply. "+
+			"Resolve the errors above, or untaint the resource if it should be kept as is.", objectType),
+	)
+
+	return diags
+}
+
+// withTimeout returns a copy of ctx that is canceled after timeout, or ctx
+// itself if timeout is zero because no timeout was configured.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// CredentialsModel describes the credentials attribute of a resource.
+type CredentialsModel struct {
+	Username   types.String `tfsdk:"username"`
+	Password   types.String `tfsdk:"password"`
+	PasswordWO types.String `tfsdk:"password_wo"`
+}
+
+// credentialsAttributeTypes are the attribute types of the credentials attribute.
+var credentialsAttributeTypes = map[string]attr.Type{
+	"username":    types.StringType,
+	"password":    types.StringType,
+	"password_wo": types.StringType,
+}
+
+// credentialsSchemaAttribute returns the schema of the credentials attribute
+// that allows a resource to override the provider credentials.
+func credentialsSchemaAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Credentials to use for the API calls of this resource instead of the provider credentials. Useful when an operation requires a BlueCat Address Manager account with different rights. Exactly one of `password` or `password_wo` must be set.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "A BlueCat Address Manager username.",
+				Required:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The BlueCat Address Manager password. The password is stored in the Terraform state.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("password_wo")),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "The BlueCat Address Manager password, which is never stored in the Terraform plan or state. As the password is only available when the resource is created or updated, the provider credentials are used to refresh and delete the resource, with a warning. Set `auth_profile` instead of `credentials` if refreshing or deleting the resource also requires the other account. Requires Terraform 1.11 or later.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+		},
+	}
+}
+
+// clientLoginWithCredentials logs in like clientLogin, but uses the auth
+// profile authProfile or credentials instead of the provider credentials if
+// they are set. A password_wo is only set when credentials come from the
+// configuration, so credentials read from the plan or state without a password
+// fall back to the provider credentials with a warning.
+func clientLoginWithCredentials(ctx context.Context, loginClient *loginClient, authProfile types.String, credentials types.Object) (gobam.ProteusAPI, diag.Diagnostics) {
+	if credentials.IsNull() || credentials.IsUnknown() {
+		return clientLoginWithAuthProfile(ctx, loginClient, authProfile)
+	}
+
+	var creds CredentialsModel
+	diag := credentials.As(ctx, &creds, basetypes.ObjectAsOptions{})
+	if diag.HasError() {
+		return nil, diag
+	}
+
+	password := creds.Password
+	if password.IsNull() {
+		password = creds.PasswordWO
+	}
+	if password.IsNull() || password.IsUnknown() {
+		tflog.Debug(ctx, "Using provider credentials as the write-only resource password is not available", map[string]interface{}{"username": creds.Username.ValueString()})
+		client, diag := clientLogin(ctx, loginClient)
+		diag.AddWarning(
+			"Using provider credentials instead of resource credentials",
+			fmt.Sprintf("The credentials of the resource for user %s use password_wo, which is only available when the resource is created or updated, so the provider credentials were used to refresh or delete it. "+
+				"Use auth_profile instead of credentials if refreshing or deleting the resource requires the account of %s.", creds.Username.ValueString(), creds.Username.ValueString()),
+		)
+		return client, diag
+	}
+
+	override := *loginClient
+	override.Username = creds.Username.ValueString()
+	override.Password = password.ValueString()
+	override.AuthMethod = authMethodPassword
+
+	tflog.Debug(ctx, "Using resource credentials instead of provider credentials", map[string]interface{}{"username": override.Username})
+
+	return clientLogin(ctx, &override)
+}
+
+// clientLoginWithConfigCredentials logs in like clientLoginWithCredentials
+// with the auth_profile and credentials in config, which unlike the plan
+// includes a write-only password.
+func clientLoginWithConfigCredentials(ctx context.Context, loginClient *loginClient, config tfsdk.Config) (gobam.ProteusAPI, diag.Diagnostics) {
+	authProfile, credentials, diag := configCredentials(ctx, config)
+	if diag.HasError() {
+		return nil, diag
+	}
+
+	return clientLoginWithCredentials(ctx, loginClient, authProfile, credentials)
+}
+
+// configCredentials returns the auth_profile and credentials attributes of
+// config.
+func configCredentials(ctx context.Context, config tfsdk.Config) (types.String, types.Object, diag.Diagnostics) {
+	var authProfile types.String
+	var credentials types.Object
+	diag := config.GetAttribute(ctx, path.Root("auth_profile"), &authProfile)
+	diag.Append(config.GetAttribute(ctx, path.Root("credentials"), &credentials)...)
+
+	return authProfile, credentials, diag
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestClientLoginWithCredentialsWriteOnly(t *testing.T) {
+	ctx := context.Background()
+
+	f, err := newClientFactory(clientConfig{endpoint: "bam.example.com", sslVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loginClient := &loginClient{Clients: f, AuthMethod: authMethodToken, Username: "terraform", Token: "token"}
+
+	// credentials read from the state, where password_wo is always null
+	credentials := types.ObjectValueMust(credentialsAttributeTypes, map[string]attr.Value{
+		"username":    types.StringValue("admin"),
+		"password":    types.StringNull(),
+		"password_wo": types.StringNull(),
+	})
+
+	_, diags := clientLoginWithCredentials(ctx, loginClient, types.StringNull(), credentials)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diags.WarningsCount() != 1 {
+		t.Errorf("expected a warning that the provider credentials were used, got %v", diags)
+	}
+}
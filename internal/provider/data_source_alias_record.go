@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AliasRecordDataSource{}
+
+func NewAliasRecordDataSource() datasource.DataSource {
+	return &AliasRecordDataSource{}
+}
+
+// AliasRecordDataSource defines the data source implementation.
+type AliasRecordDataSource struct {
+	client *loginClient
+}
+
+// AliasRecordDataSourceModel describes the data source data model.
+type AliasRecordDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	AbsoluteName      types.String `tfsdk:"absolute_name"`
+	LinkedRecordName  types.String `tfsdk:"linked_record_name"`
+	Comments          types.String `tfsdk:"comments"`
+	UserDefinedFields types.Map    `tfsdk:"user_defined_fields"`
+	Name              types.String `tfsdk:"name"`
+	ParentID          types.Int64  `tfsdk:"parent_id"`
+	ParentType        types.String `tfsdk:"parent_type"`
+	Properties        types.String `tfsdk:"properties"`
+	TTL               types.Int64  `tfsdk:"ttl"`
+	Type              types.String `tfsdk:"type"`
+	MaxSearchResults  types.Int64  `tfsdk:"max_search_results"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+func (d *AliasRecordDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alias_record"
+}
+
+func (d *AliasRecordDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to find an alias (CNAME) record by its absolute name.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Entity identifier",
+				Computed:            true,
+			},
+			"absolute_name": schema.StringAttribute{
+				MarkdownDescription: "The absolute name/fqdn of the alias record.",
+				Required:            true,
+			},
+			"linked_record_name": schema.StringAttribute{
+				MarkdownDescription: "The absolute name of the record the alias record points to.",
+				Computed:            true,
+			},
+			"user_defined_fields": schema.MapAttribute{
+				MarkdownDescription: "A map of all user-defined fields associated with the alias record.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The short name of the alias record.",
+				Computed:            true,
+			},
+			"parent_id": schema.Int64Attribute{
+				MarkdownDescription: "The ID of the parent of the alias record.",
+				Computed:            true,
+			},
+			"parent_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the parent of the alias record.",
+				Computed:            true,
+			},
+			"comments": schema.StringAttribute{
+				MarkdownDescription: "Comments about the alias record.",
+				Computed:            true,
+			},
+			"properties": schema.StringAttribute{
+				MarkdownDescription: "The properties of the alias record as returned by the API (pipe delimited).",
+				Computed:            true,
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "The TTL of the alias record.",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the resource.",
+				Computed:            true,
+			},
+			"max_search_results": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of alias records returned by the hint search to look through for `absolute_name`. Defaults to `10000`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (d *AliasRecordDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AliasRecordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AliasRecordDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	maxResults := hintMaxSearchResults
+	if !data.MaxSearchResults.IsNull() {
+		maxResults = int(data.MaxSearchResults.ValueInt64())
+	}
+
+	absoluteName := data.AbsoluteName.ValueString()
+	options := fmt.Sprintf("hint=^%s$|retrieveFields=true", absoluteName)
+
+	// stop at a second match as the data source only supports 1
+	aliasRecords, err := searchByHint(func(start int, count int) (*gobam.APIEntityArray, error) {
+		return client.GetAliasesByHint(start, count, options)
+	}, maxResults, 2, func(e *gobam.APIEntity) (*gobam.APIEntity, error) {
+		if e.Properties == nil || parseProperties(*e.Properties)["absoluteName"] != absoluteName {
+			return nil, nil
+		}
+		return e, nil
+	})
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get Alias Records by hint", err, "")
+
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("GetAliasesByHint returned %s matches", strconv.Itoa(len(aliasRecords))))
+
+	if len(aliasRecords) != 1 {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError(
+			"No exact alias record match found for hint",
+			fmt.Sprintf("No exact alias record match found for hint: %s. Number of matches was: %d", absoluteName, len(aliasRecords)),
+		)
+		return
+	}
+
+	aliasRecord := aliasRecords[0]
+	data.ID = types.StringValue(strconv.FormatInt(*aliasRecord.Id, 10))
+	data.Name = types.StringValue(*aliasRecord.Name)
+	data.Properties = types.StringValue(*aliasRecord.Properties)
+	data.Type = types.StringValue(*aliasRecord.Type)
+
+	aliasRecordProperties, diag := flattenAliasRecordProperties(aliasRecord)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		return
+	}
+
+	data.AbsoluteName = aliasRecordProperties.AbsoluteName
+	data.ParentID = aliasRecordProperties.ParentID
+	data.ParentType = aliasRecordProperties.ParentType
+	data.LinkedRecordName = aliasRecordProperties.LinkedRecordName
+	data.Comments = aliasRecordProperties.Comments
+	data.UserDefinedFields = aliasRecordProperties.UserDefinedFields
+	data.TTL = aliasRecordProperties.TTL
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
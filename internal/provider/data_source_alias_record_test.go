@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccAliasRecordDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAliasRecordDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.bluecat_alias_record.test", "id", validateObjectID),
+					resource.TestCheckResourceAttrSet("data.bluecat_alias_record.test", "linked_record_name"),
+				),
+			},
+		},
+	})
+}
+
+const testAccAliasRecordDataSourceConfig = `
+variable "alias_absolute_name" {
+	type = string
+}
+
+data "bluecat_alias_record" "test" {
+	absolute_name = var.alias_absolute_name
+}
+`
+
+func TestFlattenAliasRecordProperties(t *testing.T) {
+	objectType := "AliasRecord"
+	properties := "absoluteName=www.example.com|linkedRecordName=web.example.com|ttl=300|parentId=5|parentType=Zone|owner=netops|"
+
+	alias, diags := flattenAliasRecordProperties(&gobam.APIEntity{Type: &objectType, Properties: &properties})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if alias.AbsoluteName.ValueString() != "www.example.com" || alias.LinkedRecordName.ValueString() != "web.example.com" {
+		t.Errorf("unexpected names: %s %s", alias.AbsoluteName, alias.LinkedRecordName)
+	}
+	if alias.TTL.ValueInt64() != 300 || alias.ParentID.ValueInt64() != 5 || alias.ParentType.ValueString() != "Zone" {
+		t.Errorf("unexpected ttl or parent: %s %s %s", alias.TTL, alias.ParentID, alias.ParentType)
+	}
+	if !alias.Comments.IsNull() {
+		t.Errorf("expected comments to be null, got %s", alias.Comments)
+	}
+	if udfs := alias.UserDefinedFields.Elements(); len(udfs) != 1 || udfs["owner"].String() != `"netops"` {
+		t.Errorf("expected only owner to be a user-defined field, got %s", alias.UserDefinedFields)
+	}
+
+	hostType := "HostRecord"
+	if _, diags := flattenAliasRecordProperties(&gobam.APIEntity{Type: &hostType, Properties: &properties}); !diags.HasError() {
+		t.Error("expected an error for a host record")
+	}
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &APICallDataSource{}
+
+func NewAPICallDataSource() datasource.DataSource {
+	return &APICallDataSource{}
+}
+
+// APICallDataSource defines the data source implementation.
+type APICallDataSource struct {
+	client *loginClient
+}
+
+// APICallDataSourceModel describes the data source data model.
+type APICallDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Method     types.String `tfsdk:"method"`
+	Parameters types.String `tfsdk:"parameters"`
+	Response   types.String `tfsdk:"response"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+func (d *APICallDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_call"
+}
+
+func (d *APICallDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to send a read-only BlueCat Address Manager API call that the provider does not otherwise support and return the raw response. " +
+			"It is a stop-gap until the provider models the API, and requires `enable_raw_api` to be set on the provider. " +
+			"Only methods whose names start with `get`, `search`, `customSearch`, or `is` may be called, except for methods starting with `getNextAvailable`, which may create objects. " +
+			"Since it cannot change data, it can also be used when the provider is `read_only`.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The name of the API method that was called.",
+				Computed:            true,
+			},
+			"method": schema.StringAttribute{
+				MarkdownDescription: "The name of the API method to call, such as `getEntitiesByName`.",
+				Required:            true,
+			},
+			"parameters": schema.StringAttribute{
+				MarkdownDescription: "The parameters of the API method, either as a JSON object, such as `{\"parentId\": 1, \"type\": \"IP4Block\"}`, or form encoded, such as `parentId=1&type=IP4Block`. " +
+					"The parameters are sent in the order they are given, which must be the order the API method expects. `jsonencode` sorts the keys of an object, so use the form encoding when that is not the right order.",
+				Optional: true,
+			},
+			"response": schema.StringAttribute{
+				MarkdownDescription: "The body of the SOAP response as XML.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *APICallDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *APICallDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data APICallDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil || !d.client.EnableRawAPI {
+		resp.Diagnostics.AddError(
+			"Raw API calls are disabled",
+			"The bluecat_api_call data source requires enable_raw_api or the BLUECAT_ENABLE_RAW_API environment variable to be set to true on the provider.",
+		)
+		return
+	}
+
+	method := data.Method.ValueString()
+	if err := validateRawAPIMethod(method); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("method"), "Invalid API method", err.Error())
+		return
+	}
+
+	parameters, err := parseRawAPIParameters(data.Parameters.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("parameters"), "Invalid API parameters", err.Error())
+		return
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	caller, ok := rawAPICaller(client)
+	if !ok {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Raw API calls are not supported", "The API client does not support raw API calls. Please report this issue to the provider developers.")
+		return
+	}
+
+	response, err := caller.call(method, parameters)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, fmt.Sprintf("API call %s failed", method), err, "")
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	data.ID = types.StringValue(method)
+	data.Response = types.StringValue(response)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
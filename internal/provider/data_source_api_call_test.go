@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAPICallDataSource(t *testing.T) {
+	t.Setenv("BLUECAT_ENABLE_RAW_API", "true")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAPICallDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bluecat_api_call.test", "id", "getSystemInfo"),
+					resource.TestCheckResourceAttrSet("data.bluecat_api_call.test", "response"),
+				),
+			},
+		},
+	})
+}
+
+const testAccAPICallDataSourceConfig = `
+data "bluecat_api_call" "test" {
+  method = "getSystemInfo"
+}
+`
+
+func TestValidateRawAPIMethod(t *testing.T) {
+	for _, method := range []string{"getEntityById", "searchByObjectTypes", "customSearch", "isAddressAllocated"} {
+		if err := validateRawAPIMethod(method); err != nil {
+			t.Errorf("expected %s to be allowed, got %s", method, err)
+		}
+	}
+
+	for _, method := range []string{"delete", "assignNextAvailableIP4Address", "getNextAvailableIP4Network", "getNextAvailableIPRange", "getNextAvailableIPRanges", "login", "logout", "GetEntityById", "get><evil", ""} {
+		if err := validateRawAPIMethod(method); err == nil {
+			t.Errorf("expected %q to be rejected", method)
+		}
+	}
+}
+
+func TestParseRawAPIParameters(t *testing.T) {
+	expected := []rawAPIParameter{{name: "parentId", value: "1"}, {name: "type", value: "IP4Block"}, {name: "start", value: "0"}}
+
+	for _, parameters := range []string{
+		"parentId=1&type=IP4Block&start=0",
+		`{"parentId": 1, "type": "IP4Block", "start": 0}`,
+	} {
+		parsed, err := parseRawAPIParameters(parameters)
+		if err != nil {
+			t.Fatalf("%s: %s", parameters, err)
+		}
+		if !reflect.DeepEqual(parsed, expected) {
+			t.Errorf("%s: expected %v, got %v", parameters, expected, parsed)
+		}
+	}
+
+	parsed, err := parseRawAPIParameters("")
+	if err != nil || len(parsed) != 0 {
+		t.Errorf("expected no parameters, got %v, %v", parsed, err)
+	}
+
+	for _, parameters := range []string{
+		`{"parentId": {"id": 1}}`,
+		`{"parentId": 1} extra`,
+		"parent%zzId=1",
+		"<x>=1",
+	} {
+		if _, err := parseRawAPIParameters(parameters); err == nil {
+			t.Errorf("expected an error for %q", parameters)
+		}
+	}
+}
+
+func TestRawAPIClientCall(t *testing.T) {
+	var action, body string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action = r.Header.Get("SOAPAction")
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		_, _ = io.WriteString(w, `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><ns2:getEntitiesResponse xmlns:ns2="http://api.proteus.bluecatnetworks.com"><return/></ns2:getEntitiesResponse></soap:Body></soap:Envelope>`)
+	}))
+	defer server.Close()
+
+	caCertificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	f, err := newClientFactory(clientConfig{
+		endpoint:      strings.TrimPrefix(server.URL, "https://"),
+		sslVerify:     true,
+		caCertificate: string(caCertificate),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := f.newTokenClient(context.Background(), "terraform", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caller, ok := rawAPICaller(client)
+	if !ok {
+		t.Fatalf("expected a raw API client in %T", client)
+	}
+
+	response, err := caller.call("getEntities", []rawAPIParameter{{name: "parentId", value: "1"}, {name: "type", value: "<IP4Block>"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasSuffix(action, "/GetEntities") {
+		t.Errorf("unexpected SOAPAction %q", action)
+	}
+	if !strings.Contains(body, "<tns:getEntities><parentId>1</parentId><type>&lt;IP4Block&gt;</type></tns:getEntities>") {
+		t.Errorf("unexpected request body %s", body)
+	}
+	if !strings.HasPrefix(response, "<ns2:getEntitiesResponse") {
+		t.Errorf("unexpected response %q", response)
+	}
+}
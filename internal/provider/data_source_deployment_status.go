@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DeploymentStatusDataSource{}
+
+func NewDeploymentStatusDataSource() datasource.DataSource {
+	return &DeploymentStatusDataSource{}
+}
+
+// DeploymentStatusDataSource defines the data source implementation.
+type DeploymentStatusDataSource struct {
+	client *loginClient
+}
+
+// DeploymentStatusDataSourceModel describes the data source data model.
+type DeploymentStatusDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	DeploymentToken   types.String `tfsdk:"deployment_token"`
+	ServerID          types.Int64  `tfsdk:"server_id"`
+	WaitForCompletion types.Bool   `tfsdk:"wait_for_completion"`
+	Timeout           types.String `tfsdk:"timeout"`
+	Status            types.String `tfsdk:"status"`
+	Completed         types.Bool   `tfsdk:"completed"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+func (d *DeploymentStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment_status"
+}
+
+func (d *DeploymentStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to get the status of a deployment, by default waiting until it completes. Resources that depend on this data source are not created until the deployment has succeeded, which can be used to make sure DNS changes are live before they are used.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The deployment token or server ID.",
+				Computed:            true,
+			},
+			"deployment_token": schema.StringAttribute{
+				MarkdownDescription: "The token of a selective deployment task, such as the `deployment_token` of a `bluecat_deployment` resource. Exactly one of `deployment_token` or `server_id` must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("server_id")),
+				},
+			},
+			"server_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of a server to get the most recent deployment status of.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the deployment to complete and fail if it does not succeed. Defaults to `true`.",
+				Optional:            true,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the deployment to complete, as a duration such as `30s` or `10m`. Defaults to `10m`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(durationRegexp, "must be a duration such as 30s or 10m"),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the deployment.",
+				Computed:            true,
+			},
+			"completed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the deployment has completed.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DeploymentStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DeploymentStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeploymentStatusDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wait := true
+	if !data.WaitForCompletion.IsNull() {
+		wait = data.WaitForCompletion.ValueBool()
+	}
+
+	timeout := 10 * time.Minute
+	if !data.Timeout.IsNull() {
+		var err error
+		timeout, err = time.ParseDuration(data.Timeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Failed to parse timeout", err.Error())
+			return
+		}
+	}
+
+	session := newDeploymentSession(ctx, d.client, data.AuthProfile, types.ObjectNull(credentialsAttributeTypes))
+
+	var status string
+	var pending []string
+	var err error
+
+	if !data.DeploymentToken.IsNull() {
+		token := data.DeploymentToken.ValueString()
+		data.ID = types.StringValue(token)
+		pending = selectiveDeploymentPending
+
+		if wait {
+			status, err = waitForSelectiveDeployment(ctx, session, token, timeout)
+		} else {
+			err = session(func(client gobam.ProteusAPI) error {
+				var err error
+				status, _, err = selectiveDeploymentStatus(client, token)
+				return err
+			})
+		}
+	} else {
+		serverID := data.ServerID.ValueInt64()
+		data.ID = types.StringValue(fmt.Sprintf("%d", serverID))
+		pending = serverDeploymentPending
+
+		if wait {
+			status, err = waitForServerDeployment(ctx, session, serverID, timeout)
+		} else {
+			err = session(func(client gobam.ProteusAPI) error {
+				var err error
+				status, err = serverDeploymentStatus(client, serverID)
+				return err
+			})
+		}
+	}
+
+	if err != nil {
+		addBAMError(&resp.Diagnostics, "Failed to get deployment status", err, "")
+		return
+	}
+
+	data.Status = types.StringValue(status)
+	data.Completed = types.BoolValue(!slices.Contains(pending, status))
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
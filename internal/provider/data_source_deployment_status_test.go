@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDeploymentStatusDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDeploymentStatusDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bluecat_deployment_status.test", "completed", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDeploymentStatusDataSourceConfig = `
+variable "server_id" {
+	type = number
+}
+
+data "bluecat_deployment_status" "test" {
+	server_id = var.server_id
+}
+`
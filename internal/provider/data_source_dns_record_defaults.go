@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// dnsDefaultTTLOption is the DNS deployment option with the TTL of records
+// that do not set their own.
+const dnsDefaultTTLOption = "zone-default-ttl"
+
+// dnsAllowUpdateOption is the DNS deployment option with the clients allowed
+// to send dynamic updates.
+const dnsAllowUpdateOption = "allow-update"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DNSRecordDefaultsDataSource{}
+
+func NewDNSRecordDefaultsDataSource() datasource.DataSource {
+	return &DNSRecordDefaultsDataSource{}
+}
+
+// DNSRecordDefaultsDataSource defines the data source implementation.
+type DNSRecordDefaultsDataSource struct {
+	client *loginClient
+}
+
+// DNSRecordDefaultsDataSourceModel describes the data source data model.
+type DNSRecordDefaultsDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	EntityID    types.Int64  `tfsdk:"entity_id"`
+	ServerID    types.Int64  `tfsdk:"server_id"`
+	DefaultTTL  types.Int64  `tfsdk:"default_ttl"`
+	AllowUpdate types.String `tfsdk:"allow_update"`
+	Options     types.Map    `tfsdk:"options"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+func (d *DNSRecordDefaultsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record_defaults"
+}
+
+func (d *DNSRecordDefaultsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to get the defaults that apply to the DNS records of a view or zone from the DNS deployment options in effect for it, including the options inherited from its parents. Use it to set the `ttl` of record resources to the default instead of `-1`.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the data source, which is the `entity_id`.",
+				Computed:            true,
+			},
+			"entity_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the view or zone to get the record defaults of.",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"server_id": schema.Int64Attribute{
+				MarkdownDescription: "Get the defaults on the server with this object ID, so that options limited to it override the options that apply to all servers. By default only the options that apply to all servers are used.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"default_ttl": schema.Int64Attribute{
+				MarkdownDescription: "The TTL in seconds of records that do not set their own, from the `" + dnsDefaultTTLOption + "` option. Null if the option is not set.",
+				Computed:            true,
+			},
+			"allow_update": schema.StringAttribute{
+				MarkdownDescription: "The clients allowed to send dynamic updates, from the `" + dnsAllowUpdateOption + "` option as returned by the API. Null if the option is not set.",
+				Computed:            true,
+			},
+			"options": schema.MapAttribute{
+				MarkdownDescription: "The values of all DNS deployment options in effect, by option name.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *DNSRecordDefaultsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DNSRecordDefaultsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DNSRecordDefaultsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	options, err := getEffectiveDeploymentOptions(client, data.EntityID.ValueInt64(), "DNSOption", data.ServerID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get effective DNS deployment options", err, "")
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	values := dnsRecordDefaultValues(options, data.ServerID.ValueInt64())
+
+	data.ID = types.StringValue(strconv.FormatInt(data.EntityID.ValueInt64(), 10))
+	data.DefaultTTL = types.Int64Null()
+	if v, ok := values[dnsDefaultTTLOption]; ok {
+		ttl, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to parse default TTL", fmt.Sprintf("The %s option has a value of %q that is not a number of seconds: %s", dnsDefaultTTLOption, v, err))
+			return
+		}
+		data.DefaultTTL = types.Int64Value(ttl)
+	}
+	data.AllowUpdate = types.StringNull()
+	if v, ok := values[dnsAllowUpdateOption]; ok {
+		data.AllowUpdate = types.StringValue(v)
+	}
+
+	elements := make(map[string]attr.Value, len(values))
+	for k, v := range values {
+		elements[k] = types.StringValue(v)
+	}
+	data.Options = types.MapValueMust(types.StringType, elements)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// dnsRecordDefaultValues returns the value of each effective option by name.
+// An option limited to serverID overrides an option that applies to all
+// servers, and options limited to other servers are ignored.
+func dnsRecordDefaultValues(options []effectiveDeploymentOption, serverID int64) map[string]string {
+	values := make(map[string]string)
+	for _, o := range options {
+		if o.serverID != 0 && o.serverID != serverID {
+			continue
+		}
+
+		if _, ok := values[*o.option.Name]; ok && o.serverID == 0 {
+			continue
+		}
+
+		values[*o.option.Name] = ""
+		if o.option.Value != nil {
+			values[*o.option.Name] = *o.option.Value
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccDNSRecordDefaultsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDNSRecordDefaultsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.bluecat_dns_record_defaults.test", "id"),
+					resource.TestCheckResourceAttrSet("data.bluecat_dns_record_defaults.test", "options.%"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDNSRecordDefaultsDataSourceConfig = `
+variable "dns_zone_id" {
+	type = number
+}
+
+data "bluecat_dns_record_defaults" "test" {
+	entity_id = var.dns_zone_id
+}
+`
+
+func TestDNSRecordDefaultValues(t *testing.T) {
+	option := func(name, value string, serverID int64) effectiveDeploymentOption {
+		return effectiveDeploymentOption{option: &gobam.APIDeploymentOption{Name: &name, Value: &value}, serverID: serverID}
+	}
+
+	// sorted by name and server like getEffectiveDeploymentOptions
+	options := []effectiveDeploymentOption{
+		option("allow-update", "none", 0),
+		option("zone-default-ttl", "3600", 0),
+		option("zone-default-ttl", "300", 98),
+		option("zone-default-ttl", "60", 99),
+	}
+
+	values := dnsRecordDefaultValues(options, 0)
+	if values["zone-default-ttl"] != "3600" || values["allow-update"] != "none" {
+		t.Errorf("expected the options for all servers, got %v", values)
+	}
+
+	values = dnsRecordDefaultValues(options, 98)
+	if values["zone-default-ttl"] != "300" {
+		t.Errorf("expected the option limited to the server to win, got %v", values)
+	}
+	if len(values) != 2 {
+		t.Errorf("expected 2 options, got %v", values)
+	}
+}
@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// zoneTransferRecordTypes are the object types of the resource records
+// exported by the bluecat_dns_zone_transfer data source. External host
+// records are not exported as they are not served by the zone.
+var zoneTransferRecordTypes = []string{
+	"HostRecord",
+	"AliasRecord",
+	"MXRecord",
+	"TXTRecord",
+	"SRVRecord",
+	"HINFORecord",
+	"NAPTRRecord",
+	"GenericRecord",
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DNSZoneTransferDataSource{}
+
+func NewDNSZoneTransferDataSource() datasource.DataSource {
+	return &DNSZoneTransferDataSource{}
+}
+
+// DNSZoneTransferDataSource defines the data source implementation.
+type DNSZoneTransferDataSource struct {
+	client *loginClient
+}
+
+// DNSZoneTransferDataSourceModel describes the data source data model.
+type DNSZoneTransferDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	ViewID          types.Int64  `tfsdk:"view_id"`
+	FQDN            types.String `tfsdk:"fqdn"`
+	IncludeSubzones types.Bool   `tfsdk:"include_subzones"`
+	Records         types.List   `tfsdk:"records"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+// zoneTransferRecord is a resource record exported from a zone.
+type zoneTransferRecord struct {
+	ID         types.Int64  `tfsdk:"id"`
+	ObjectType types.String `tfsdk:"object_type"`
+	Type       types.String `tfsdk:"type"`
+	Name       types.String `tfsdk:"name"`
+	RData      types.String `tfsdk:"rdata"`
+	TTL        types.Int64  `tfsdk:"ttl"`
+}
+
+// zoneTransferRecordAttributeTypes are the attribute types of an exported
+// resource record.
+var zoneTransferRecordAttributeTypes = map[string]attr.Type{
+	"id":          types.Int64Type,
+	"object_type": types.StringType,
+	"type":        types.StringType,
+	"name":        types.StringType,
+	"rdata":       types.StringType,
+	"ttl":         types.Int64Type,
+}
+
+func (d *DNSZoneTransferDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_transfer"
+}
+
+func (d *DNSZoneTransferDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to export the resource records of a DNS zone, for example to migrate the zone or to compare it with another DNS service. " +
+			"Host records are exported as an `A` or `AAAA` record for each of their addresses. External host records are not exported.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The object ID of the zone.",
+				Computed:            true,
+			},
+			"view_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the view that contains the zone.",
+				Required:            true,
+			},
+			"fqdn": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified domain name of the zone, such as `example.com`.",
+				Required:            true,
+			},
+			"include_subzones": schema.BoolAttribute{
+				MarkdownDescription: "Also export the records of the zones below the zone. Defaults to `false`.",
+				Optional:            true,
+			},
+			"records": schema.ListNestedAttribute{
+				MarkdownDescription: "The resource records of the zone, sorted by name, type, and data.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "The object ID of the record in BlueCat Address Manager. The records exported from a host record share its ID.",
+							Computed:            true,
+						},
+						"object_type": schema.StringAttribute{
+							MarkdownDescription: "The object type of the record in BlueCat Address Manager, such as `HostRecord`.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The DNS type of the record, such as `A` or `CNAME`.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The fully qualified domain name of the record, without a trailing dot.",
+							Computed:            true,
+						},
+						"rdata": schema.StringAttribute{
+							MarkdownDescription: "The data of the record in zone file format. Domain names in it are fully qualified with a trailing dot.",
+							Computed:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "The TTL of the record in seconds, or null if the record uses the default TTL of the zone.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DNSZoneTransferDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DNSZoneTransferDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DNSZoneTransferDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	viewID := data.ViewID.ValueInt64()
+	fqdn := data.FQDN.ValueString()
+
+	zone, err := getZoneByFQDN(client, viewID, fqdn)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get Zone by name", err, "")
+		return
+	}
+
+	if zone == nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Zone not found", fmt.Sprintf("No zone %s was found in view %d", fqdn, viewID))
+		return
+	}
+
+	records, err := getZoneTransferRecords(client, *zone.Id, data.IncludeSubzones.ValueBool())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to export the records of Zone", err, bamEntity("Zone", strconv.FormatInt(*zone.Id, 10)))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	data.ID = types.StringValue(strconv.FormatInt(*zone.Id, 10))
+
+	recordsList, diag := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: zoneTransferRecordAttributeTypes}, records)
+	resp.Diagnostics.Append(diag...)
+	data.Records = recordsList
+
+	tflog.Info(ctx, fmt.Sprintf("Exported %d records from zone %s", len(records), fqdn))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// getZoneTransferRecords returns the resource records of the zone zoneID, and
+// of the zones below it if includeSubzones is true, sorted by name, type, and
+// data.
+func getZoneTransferRecords(client gobam.ProteusAPI, zoneID int64, includeSubzones bool) ([]zoneTransferRecord, error) {
+	records := []zoneTransferRecord{}
+
+	zoneIDs := []int64{zoneID}
+	for len(zoneIDs) > 0 {
+		id := zoneIDs[0]
+		zoneIDs = zoneIDs[1:]
+
+		for _, recordType := range zoneTransferRecordTypes {
+			entities, err := getAllEntities(client, id, recordType)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, e := range entities {
+				records = append(records, newZoneTransferRecords(e)...)
+			}
+		}
+
+		if !includeSubzones {
+			break
+		}
+
+		subzones, err := getAllEntities(client, id, "Zone")
+		if err != nil {
+			return nil, err
+		}
+		for _, subzone := range subzones {
+			if subzone != nil && subzone.Id != nil && *subzone.Id != 0 {
+				zoneIDs = append(zoneIDs, *subzone.Id)
+			}
+		}
+	}
+
+	slices.SortStableFunc(records, func(a, b zoneTransferRecord) int {
+		if c := strings.Compare(a.Name.ValueString(), b.Name.ValueString()); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Type.ValueString(), b.Type.ValueString()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.RData.ValueString(), b.RData.ValueString())
+	})
+
+	return records, nil
+}
+
+// newZoneTransferRecords returns the resource records of the record entity e,
+// which is a single record except for host records with several addresses.
+func newZoneTransferRecords(e *gobam.APIEntity) []zoneTransferRecord {
+	if e == nil || e.Id == nil || *e.Id == 0 || e.Type == nil {
+		return nil
+	}
+
+	properties := map[string]string{}
+	if e.Properties != nil {
+		properties = parseProperties(*e.Properties)
+	}
+
+	record := zoneTransferRecord{
+		ID:         types.Int64Value(*e.Id),
+		ObjectType: types.StringValue(*e.Type),
+		Name:       types.StringValue(strings.TrimSuffix(properties["absoluteName"], ".")),
+		TTL:        types.Int64Null(),
+	}
+	if ttl, err := strconv.ParseInt(properties["ttl"], 10, 64); err == nil && ttl >= 0 {
+		record.TTL = types.Int64Value(ttl)
+	}
+
+	newRecord := func(recordType string, rdata string) zoneTransferRecord {
+		r := record
+		r.Type = types.StringValue(recordType)
+		r.RData = types.StringValue(rdata)
+		return r
+	}
+
+	switch *e.Type {
+	case "HostRecord":
+		records := []zoneTransferRecord{}
+		for _, address := range strings.Split(properties["addresses"], ",") {
+			address = strings.TrimSpace(address)
+			if address == "" {
+				continue
+			}
+			recordType := "A"
+			if strings.Contains(address, ":") {
+				recordType = "AAAA"
+			}
+			records = append(records, newRecord(recordType, address))
+		}
+		return records
+	case "AliasRecord":
+		return []zoneTransferRecord{newRecord("CNAME", zoneTransferFQDN(properties["linkedRecordName"]))}
+	case "MXRecord":
+		return []zoneTransferRecord{newRecord("MX", fmt.Sprintf("%s %s", properties["priority"], zoneTransferFQDN(properties["linkedRecordName"])))}
+	case "TXTRecord":
+		return []zoneTransferRecord{newRecord("TXT", zoneTransferQuote(properties["txt"]))}
+	case "SRVRecord":
+		return []zoneTransferRecord{newRecord("SRV", fmt.Sprintf("%s %s %s %s", properties["priority"], properties["weight"], properties["port"], zoneTransferFQDN(properties["linkedRecordName"])))}
+	case "HINFORecord":
+		return []zoneTransferRecord{newRecord("HINFO", fmt.Sprintf("%s %s", zoneTransferQuote(properties["cpu"]), zoneTransferQuote(properties["os"])))}
+	case "NAPTRRecord":
+		return []zoneTransferRecord{newRecord("NAPTR", fmt.Sprintf("%s %s %s %s %s %s",
+			properties["order"],
+			properties["preference"],
+			zoneTransferQuote(properties["flags"]),
+			zoneTransferQuote(properties["service"]),
+			zoneTransferQuote(properties["regexp"]),
+			zoneTransferFQDN(properties["replacement"]),
+		))}
+	case "GenericRecord":
+		return []zoneTransferRecord{newRecord(properties["type"], properties["rdata"])}
+	}
+
+	return nil
+}
+
+// zoneTransferFQDN returns name fully qualified with a trailing dot, or "." for
+// the root as used by e.g. a NAPTR record without a replacement.
+func zoneTransferFQDN(name string) string {
+	if name == "" || name == "." {
+		return "."
+	}
+
+	return strings.TrimSuffix(name, ".") + "."
+}
+
+// zoneTransferQuote returns s as a quoted character string in zone file
+// format, escaping backslashes and double quotes.
+func zoneTransferQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
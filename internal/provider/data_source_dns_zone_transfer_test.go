@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccDNSZoneTransferDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDNSZoneTransferDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.bluecat_dns_zone_transfer.test", "id", validateObjectID),
+					resource.TestCheckResourceAttrSet("data.bluecat_dns_zone_transfer.test", "records.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDNSZoneTransferDataSourceConfig = `
+variable "view_id" {
+	type = number
+}
+
+variable "dns_zone" {
+	type = string
+}
+
+data "bluecat_dns_zone_transfer" "test" {
+  view_id = var.view_id
+  fqdn    = var.dns_zone
+}
+`
+
+func TestNewZoneTransferRecords(t *testing.T) {
+	record := func(objectType, properties string) *gobam.APIEntity {
+		id := int64(10)
+		return &gobam.APIEntity{Id: &id, Type: &objectType, Properties: &properties}
+	}
+
+	tests := map[string]struct {
+		entity   *gobam.APIEntity
+		expected []string
+	}{
+		"host": {
+			entity:   record("HostRecord", "absoluteName=www.example.com|addresses=10.0.0.1,2001:db8::1|ttl=300|"),
+			expected: []string{"www.example.com A 10.0.0.1", "www.example.com AAAA 2001:db8::1"},
+		},
+		"alias": {
+			entity:   record("AliasRecord", "absoluteName=web.example.com|linkedRecordName=www.example.com|"),
+			expected: []string{"web.example.com CNAME www.example.com."},
+		},
+		"mx": {
+			entity:   record("MXRecord", "absoluteName=example.com|linkedRecordName=mail.example.com|priority=10|"),
+			expected: []string{"example.com MX 10 mail.example.com."},
+		},
+		"txt": {
+			entity:   record("TXTRecord", `absoluteName=example.com|txt=v=spf1 include:"_spf" \\ -all|`),
+			expected: []string{`example.com TXT "v=spf1 include:\"_spf\" \\ -all"`},
+		},
+		"srv": {
+			entity:   record("SRVRecord", "absoluteName=_ldap._tcp.example.com|linkedRecordName=dc.example.com|priority=0|weight=5|port=389|"),
+			expected: []string{"_ldap._tcp.example.com SRV 0 5 389 dc.example.com."},
+		},
+		"naptr": {
+			entity:   record("NAPTRRecord", "absoluteName=example.com|order=100|preference=10|flags=U|service=E2U+sip|regexp=!^.*$!sip:info@example.com!|"),
+			expected: []string{`example.com NAPTR 100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`},
+		},
+		"generic": {
+			entity:   record("GenericRecord", "absoluteName=example.com|type=CAA|rdata=0 issue \"letsencrypt.org\"|"),
+			expected: []string{`example.com CAA 0 issue "letsencrypt.org"`},
+		},
+		"external host": {
+			entity: record("ExternalHostRecord", "absoluteName=ext.example.com|"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			records := newZoneTransferRecords(tc.entity)
+			if len(records) != len(tc.expected) {
+				t.Fatalf("expected %d records, got %d", len(tc.expected), len(records))
+			}
+			for i, r := range records {
+				if got := r.Name.ValueString() + " " + r.Type.ValueString() + " " + r.RData.ValueString(); got != tc.expected[i] {
+					t.Errorf("expected %q, got %q", tc.expected[i], got)
+				}
+			}
+		})
+	}
+
+	records := newZoneTransferRecords(record("AliasRecord", "absoluteName=web.example.com|linkedRecordName=www.example.com|ttl=3600|"))
+	if records[0].TTL.ValueInt64() != 3600 || records[0].ID.ValueInt64() != 10 || records[0].ObjectType.ValueString() != "AliasRecord" {
+		t.Errorf("unexpected record %+v", records[0])
+	}
+	records = newZoneTransferRecords(record("AliasRecord", "absoluteName=web.example.com|linkedRecordName=www.example.com|"))
+	if !records[0].TTL.IsNull() {
+		t.Errorf("expected a null TTL for a record that uses the zone default, got %s", records[0].TTL)
+	}
+}
+
+func TestGetZoneTransferRecords(t *testing.T) {
+	client := newEntityTreeClient()
+	add := func(id, parent int64, name, objectType, properties string) {
+		client.entities[id] = &gobam.APIEntity{Id: &id, Name: &name, Type: &objectType, Properties: &properties}
+		client.parents[id] = parent
+	}
+	add(10, 4, "example", "Zone", "absoluteName=example.com|")
+	add(11, 10, "www", "HostRecord", "absoluteName=www.example.com|addresses=10.0.0.1|")
+	add(12, 10, "", "MXRecord", "absoluteName=example.com|linkedRecordName=mail.example.com|priority=10|")
+	add(13, 10, "sub", "Zone", "absoluteName=sub.example.com|")
+	add(14, 13, "app", "AliasRecord", "absoluteName=app.sub.example.com|linkedRecordName=www.example.com|")
+
+	records, err := getZoneTransferRecords(client, 10, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Name.ValueString() != "example.com" || records[1].Name.ValueString() != "www.example.com" {
+		t.Errorf("expected the records of the zone sorted by name, got %v", records)
+	}
+
+	records, err = getZoneTransferRecords(client, 10, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 || records[0].Name.ValueString() != "app.sub.example.com" {
+		t.Errorf("expected the records of the subzone to be included, got %v", records)
+	}
+}
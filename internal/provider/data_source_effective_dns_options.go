@@ -0,0 +1,304 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// dnsRestrictionOptions are the DNS deployment options that restrict which
+// clients can use a DNS server.
+var dnsRestrictionOptions = []string{
+	"allow-notify",
+	"allow-query",
+	"allow-query-cache",
+	"allow-recursion",
+	"allow-transfer",
+	"allow-update",
+	"allow-update-forwarding",
+	"blackhole",
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EffectiveDNSOptionsDataSource{}
+
+func NewEffectiveDNSOptionsDataSource() datasource.DataSource {
+	return &EffectiveDNSOptionsDataSource{}
+}
+
+// EffectiveDNSOptionsDataSource defines the data source implementation.
+type EffectiveDNSOptionsDataSource struct {
+	client *loginClient
+}
+
+// EffectiveDNSOptionsDataSourceModel describes the data source data model.
+type EffectiveDNSOptionsDataSourceModel struct {
+	ID           types.String                          `tfsdk:"id"`
+	EntityID     types.Int64                           `tfsdk:"entity_id"`
+	ServerID     types.Int64                           `tfsdk:"server_id"`
+	Options      []EffectiveDNSOptionsDataSourceOption `tfsdk:"options"`
+	Restrictions []EffectiveDNSOptionsDataSourceOption `tfsdk:"restrictions"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+// EffectiveDNSOptionsDataSourceOption describes a single effective option.
+type EffectiveDNSOptionsDataSourceOption struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Value      types.String `tfsdk:"value"`
+	ServerID   types.Int64  `tfsdk:"server_id"`
+	SourceID   types.Int64  `tfsdk:"source_id"`
+	SourceType types.String `tfsdk:"source_type"`
+	Inherited  types.Bool   `tfsdk:"inherited"`
+}
+
+func (d *EffectiveDNSOptionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_effective_dns_options"
+}
+
+func (d *EffectiveDNSOptionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	optionAttributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The object ID of the deployment option.",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The name of the deployment option, for example `allow-query`.",
+			Computed:            true,
+		},
+		"value": schema.StringAttribute{
+			MarkdownDescription: "The value of the deployment option as returned by the API.",
+			Computed:            true,
+		},
+		"server_id": schema.Int64Attribute{
+			MarkdownDescription: "The object ID of the server the option is limited to, or `0` if it applies to all servers.",
+			Computed:            true,
+		},
+		"source_id": schema.Int64Attribute{
+			MarkdownDescription: "The object ID of the entity the option is set on.",
+			Computed:            true,
+		},
+		"source_type": schema.StringAttribute{
+			MarkdownDescription: "The type of the entity the option is set on.",
+			Computed:            true,
+		},
+		"inherited": schema.BoolAttribute{
+			MarkdownDescription: "Whether the option is inherited from a parent of `entity_id` instead of set on it.",
+			Computed:            true,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to get the DNS deployment options that are in effect for an entity, including the options inherited from its parents up to the configuration. An option set on an entity overrides an option with the same name and server set on its parents.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the data source, which is the `entity_id`.",
+				Computed:            true,
+			},
+			"entity_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the entity, such as a network, block, zone, or view, to get the effective options of.",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"server_id": schema.Int64Attribute{
+				MarkdownDescription: "Only return the options that apply to the server with this object ID, which are the options limited to it and the options that apply to all servers. By default the options for every server are returned.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"options": schema.ListNestedAttribute{
+				MarkdownDescription: "The effective DNS deployment options, sorted by name and server.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: optionAttributes,
+				},
+			},
+			"restrictions": schema.ListNestedAttribute{
+				MarkdownDescription: "The effective DNS deployment options that restrict access to the DNS server, such as `allow-query` and `allow-transfer`. These are also included in `options`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: optionAttributes,
+				},
+			},
+		},
+	}
+}
+
+func (d *EffectiveDNSOptionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *EffectiveDNSOptionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EffectiveDNSOptionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	options, err := getEffectiveDeploymentOptions(client, data.EntityID.ValueInt64(), "DNSOption", data.ServerID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get effective DNS deployment options", err, "")
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	data.ID = types.StringValue(strconv.FormatInt(data.EntityID.ValueInt64(), 10))
+	data.Options = []EffectiveDNSOptionsDataSourceOption{}
+	data.Restrictions = []EffectiveDNSOptionsDataSourceOption{}
+	for _, o := range options {
+		option := EffectiveDNSOptionsDataSourceOption{
+			ID:         types.StringValue(strconv.FormatInt(*o.option.Id, 10)),
+			Name:       types.StringPointerValue(o.option.Name),
+			Value:      types.StringPointerValue(o.option.Value),
+			ServerID:   types.Int64Value(o.serverID),
+			SourceID:   types.Int64Value(*o.source.Id),
+			SourceType: types.StringPointerValue(o.source.Type),
+			Inherited:  types.BoolValue(*o.source.Id != data.EntityID.ValueInt64()),
+		}
+
+		data.Options = append(data.Options, option)
+		if slices.Contains(dnsRestrictionOptions, option.Name.ValueString()) {
+			data.Restrictions = append(data.Restrictions, option)
+		}
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// effectiveDeploymentOption is a deployment option and the entity it is set on.
+type effectiveDeploymentOption struct {
+	option   *gobam.APIDeploymentOption
+	serverID int64
+	source   *gobam.APIEntity
+}
+
+// getEffectiveDeploymentOptions returns the deployment options of optionType
+// set on entityID and its parents up to the configuration, keeping the option
+// closest to entityID for each name and server. If serverID is not 0, only the
+// options that apply to that server are returned.
+func getEffectiveDeploymentOptions(client gobam.ProteusAPI, entityID int64, optionType string, serverID int64) ([]effectiveDeploymentOption, error) {
+	entity, err := client.GetEntityById(entityID)
+	if err != nil {
+		return nil, err
+	}
+	if entity.Id == nil || *entity.Id == 0 {
+		return nil, fmt.Errorf("entity %d was not found", entityID)
+	}
+
+	chain := []*gobam.APIEntity{entity}
+	for current := entity; len(chain) < entityMaxDepth && (current.Type == nil || *current.Type != "Configuration"); {
+		parent, err := client.GetParent(*current.Id)
+		if err != nil {
+			return nil, err
+		}
+		if parent.Id == nil || *parent.Id == 0 {
+			break
+		}
+		chain = append(chain, parent)
+		current = parent
+	}
+
+	type optionKey struct {
+		name     string
+		serverID int64
+	}
+	effective := make(map[optionKey]effectiveDeploymentOption)
+
+	// walk down from the configuration so options closer to the entity win
+	for i := len(chain) - 1; i >= 0; i-- {
+		// a negative server ID returns the options for all servers
+		options, err := client.GetDeploymentOptions(*chain[i].Id, optionType, -1)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range options.Item {
+			if o == nil || o.Id == nil || o.Name == nil {
+				continue
+			}
+
+			var optionServerID int64
+			if o.Properties != nil {
+				if server, ok := parseProperties(*o.Properties)["server"]; ok {
+					optionServerID, err = strconv.ParseInt(server, 10, 64)
+					if err != nil {
+						return nil, fmt.Errorf("failed to parse server of deployment option %d: %w", *o.Id, err)
+					}
+				}
+			}
+			if serverID != 0 && optionServerID != 0 && optionServerID != serverID {
+				continue
+			}
+
+			k := optionKey{name: *o.Name, serverID: optionServerID}
+			// the API may also return inherited options, which keep their source
+			if existing, ok := effective[k]; ok && *existing.option.Id == *o.Id {
+				continue
+			}
+			effective[k] = effectiveDeploymentOption{option: o, serverID: optionServerID, source: chain[i]}
+		}
+	}
+
+	result := make([]effectiveDeploymentOption, 0, len(effective))
+	for _, o := range effective {
+		result = append(result, o)
+	}
+	slices.SortFunc(result, func(a, b effectiveDeploymentOption) int {
+		if c := cmp.Compare(*a.option.Name, *b.option.Name); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.serverID, b.serverID)
+	})
+
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccEffectiveDNSOptionsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccEffectiveDNSOptionsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.bluecat_effective_dns_options.test", "options.#"),
+					resource.TestCheckResourceAttrSet("data.bluecat_effective_dns_options.test", "restrictions.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccEffectiveDNSOptionsDataSourceConfig = `
+variable "ip4_block_parent_id" {
+	type = number
+}
+
+data "bluecat_effective_dns_options" "test" {
+	entity_id = var.ip4_block_parent_id
+}
+`
+
+// deploymentOptionTreeClient serves the deployment options set on a chain of
+// entities.
+type deploymentOptionTreeClient struct {
+	gobam.ProteusAPI
+	entities map[int64]*gobam.APIEntity
+	parents  map[int64]int64
+	options  map[int64][]*gobam.APIDeploymentOption
+}
+
+func (c *deploymentOptionTreeClient) GetEntityById(id int64) (*gobam.APIEntity, error) {
+	if entity, ok := c.entities[id]; ok {
+		return entity, nil
+	}
+	return &gobam.APIEntity{}, nil
+}
+
+func (c *deploymentOptionTreeClient) GetParent(entityId int64) (*gobam.APIEntity, error) {
+	return c.GetEntityById(c.parents[entityId])
+}
+
+func (c *deploymentOptionTreeClient) GetDeploymentOptions(entityId int64, optionTypes string, serverId int64) (*gobam.APIDeploymentOptionArray, error) {
+	return &gobam.APIDeploymentOptionArray{Item: c.options[entityId]}, nil
+}
+
+func testDeploymentOption(id int64, name, value, properties string) *gobam.APIDeploymentOption {
+	return &gobam.APIDeploymentOption{Id: &id, Name: &name, Value: &value, Properties: &properties}
+}
+
+func TestGetEffectiveDeploymentOptions(t *testing.T) {
+	client := &deploymentOptionTreeClient{
+		entities: map[int64]*gobam.APIEntity{},
+		parents:  map[int64]int64{2: 1, 3: 2},
+		options: map[int64][]*gobam.APIDeploymentOption{
+			1: {
+				testDeploymentOption(10, "allow-query", "any", ""),
+				testDeploymentOption(11, "allow-transfer", "none", ""),
+				testDeploymentOption(12, "forwarders", "10.0.0.1", "server=99|"),
+			},
+			2: {
+				testDeploymentOption(20, "allow-query", "10.0.0.0/8", ""),
+			},
+			// the inherited option is returned again on the network
+			3: {
+				testDeploymentOption(11, "allow-transfer", "none", ""),
+				testDeploymentOption(30, "forwarders", "10.0.0.2", "server=98|"),
+			},
+		},
+	}
+	for id, objectType := range map[int64]string{1: "Configuration", 2: "IP4Block", 3: "IP4Network"} {
+		client.entities[id] = &gobam.APIEntity{Id: &id, Type: &objectType}
+	}
+
+	options, err := getEffectiveDeploymentOptions(client, 3, "DNSOption", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		id       int64
+		serverID int64
+		sourceID int64
+	}{
+		{20, 0, 2},
+		{11, 0, 1},
+		{30, 98, 3},
+		{12, 99, 1},
+	}
+	if len(options) != len(expected) {
+		t.Fatalf("expected %d options, got %d", len(expected), len(options))
+	}
+	for i, e := range expected {
+		o := options[i]
+		if *o.option.Id != e.id || o.serverID != e.serverID || *o.source.Id != e.sourceID {
+			t.Errorf("option %d: expected id %d server %d source %d, got id %d server %d source %d", i, e.id, e.serverID, e.sourceID, *o.option.Id, o.serverID, *o.source.Id)
+		}
+	}
+
+	options, err = getEffectiveDeploymentOptions(client, 3, "DNSOption", 98)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(options) != 3 {
+		t.Errorf("expected the option for another server to be excluded, got %d options", len(options))
+	}
+
+	if _, err := getEffectiveDeploymentOptions(client, 4, "DNSOption", 0); err == nil {
+		t.Error("expected an error for an entity that does not exist")
+	}
+}
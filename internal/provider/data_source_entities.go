@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EntitiesDataSource{}
+
+func NewEntitiesDataSource() datasource.DataSource {
+	return &EntitiesDataSource{}
+}
+
+// EntitiesDataSource defines the data source implementation.
+type EntitiesDataSource struct {
+	client *loginClient
+}
+
+// EntitiesDataSourceModel describes the data source data model.
+type EntitiesDataSourceModel struct {
+	ID       types.String               `tfsdk:"id"`
+	ParentID types.Int64                `tfsdk:"parent_id"`
+	Type     types.String               `tfsdk:"type"`
+	Entities []EntitiesDataSourceEntity `tfsdk:"entities"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+// EntitiesDataSourceEntity describes a single child entity.
+type EntitiesDataSourceEntity struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Properties        types.Map    `tfsdk:"properties"`
+	UserDefinedFields types.Map    `tfsdk:"user_defined_fields"`
+}
+
+func (d *EntitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entities"
+}
+
+func (d *EntitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to list the child entities of a type under a parent entity.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the data source, in the form `<parent_id>:<type>`.",
+				Computed:            true,
+			},
+			"parent_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the entity to list the children of. Configurations are stored in ID `0`.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the child entities to list.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(gobam.ObjectTypes...),
+				},
+			},
+			"entities": schema.ListNestedAttribute{
+				MarkdownDescription: "The child entities that were found, in the order returned by the API.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The object ID.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name.",
+							Computed:            true,
+						},
+						"properties": schema.MapAttribute{
+							MarkdownDescription: "The properties of the entity that are not user-defined fields.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"user_defined_fields": schema.MapAttribute{
+							MarkdownDescription: "The user-defined fields of the entity that are set.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EntitiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *EntitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EntitiesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	parentID := data.ParentID.ValueInt64()
+	objType := data.Type.ValueString()
+
+	entities, err := getAllEntities(client, parentID, objType)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, fmt.Sprintf("Failed to list %s entities", objType), err, "")
+		return
+	}
+
+	definitions, err := d.client.UDFDefinitions.get(client, objType)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, fmt.Sprintf("Failed to get the user-defined fields of %s", objType), err, "")
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	udfNames := make(map[string]bool, len(definitions))
+	for _, definition := range definitions {
+		if definition != nil && definition.Name != nil {
+			udfNames[*definition.Name] = true
+		}
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d:%s", parentID, objType))
+	data.Entities = []EntitiesDataSourceEntity{}
+	for _, e := range entities {
+		entity, diags := newEntitiesDataSourceEntity(ctx, e, udfNames)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Entities = append(data.Entities, entity)
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// newEntitiesDataSourceEntity flattens an entity, splitting its properties
+// into user-defined fields and other properties using udfNames.
+func newEntitiesDataSourceEntity(ctx context.Context, e *gobam.APIEntity, udfNames map[string]bool) (EntitiesDataSourceEntity, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	properties := map[string]string{}
+	udfs := map[string]string{}
+	if e.Properties != nil {
+		for k, v := range parseProperties(*e.Properties) {
+			if udfNames[k] {
+				udfs[k] = v
+			} else {
+				properties[k] = v
+			}
+		}
+	}
+
+	propertiesMap, d := types.MapValueFrom(ctx, types.StringType, properties)
+	diags.Append(d...)
+	udfsMap, d := types.MapValueFrom(ctx, types.StringType, udfs)
+	diags.Append(d...)
+
+	return EntitiesDataSourceEntity{
+		ID:                types.StringValue(strconv.FormatInt(*e.Id, 10)),
+		Name:              types.StringPointerValue(e.Name),
+		Properties:        propertiesMap,
+		UserDefinedFields: udfsMap,
+	}, diags
+}
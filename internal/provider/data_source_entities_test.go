@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccEntitiesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccEntitiesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.bluecat_entities.test", "entities.#"),
+					resource.TestCheckResourceAttrWith("data.bluecat_entities.test", "entities.0.id", validateObjectID),
+				),
+			},
+		},
+	})
+}
+
+const testAccEntitiesDataSourceConfig = `
+data "bluecat_entities" "test" {
+	parent_id = 0
+	type      = "Configuration"
+}
+`
+
+func TestNewEntitiesDataSourceEntity(t *testing.T) {
+	id, name, properties := int64(42), "net", "CIDR=10.0.0.0/24|owner=ops|defaultView=7|"
+	e := &gobam.APIEntity{Id: &id, Name: &name, Properties: &properties}
+
+	entity, diags := newEntitiesDataSourceEntity(context.Background(), e, map[string]bool{"owner": true})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if entity.ID.ValueString() != "42" || entity.Name.ValueString() != "net" {
+		t.Errorf("unexpected id or name: %s %s", entity.ID, entity.Name)
+	}
+
+	props := entity.Properties.Elements()
+	if len(props) != 2 || props["CIDR"].String() != `"10.0.0.0/24"` || props["defaultView"].String() != `"7"` {
+		t.Errorf("unexpected properties: %v", props)
+	}
+
+	udfs := entity.UserDefinedFields.Elements()
+	if len(udfs) != 1 || udfs["owner"].String() != `"ops"` {
+		t.Errorf("unexpected user-defined fields: %v", udfs)
+	}
+}
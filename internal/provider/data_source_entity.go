@@ -4,16 +4,25 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/umich-vci/gobam"
 )
 
+// entitySearchPageSize is the number of entities requested at a time when searching recursively.
+const entitySearchPageSize = 1000
+
+// entityMaxDepth limits how many parents are walked when checking if an entity is below another.
+const entityMaxDepth = 64
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &entityDataSource{}
 
@@ -31,8 +40,16 @@ type EntityDataSourceModel struct {
 	Id         types.String `tfsdk:"id"`
 	Name       types.String `tfsdk:"name"`
 	Type       types.String `tfsdk:"type"`
+	Types      types.List   `tfsdk:"types"`
+	Recursive  types.Bool   `tfsdk:"recursive"`
 	ParentID   types.Int64  `tfsdk:"parent_id"`
 	Properties types.String `tfsdk:"properties"`
+
+	// this is calculated from the managed UDF configured on the provider
+	ManagedByTerraform types.Bool `tfsdk:"managed_by_terraform"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
 }
 
 func (d *entityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -45,6 +62,7 @@ func (d *entityDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 		MarkdownDescription: "Data source to access the attributes of a BlueCat entity.",
 
 		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Entity identifier",
 				Computed:            true,
@@ -54,20 +72,41 @@ func (d *entityDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Required:            true,
 			},
 			"type": schema.StringAttribute{
-				MarkdownDescription: "The type of the entity you want to retrieve.",
-				Required:            true,
+				MarkdownDescription: "The type of the entity you want to retrieve. Exactly one of `type` or `types` must be set. When `types` is set, this is the type of the entity that was found.",
+				Optional:            true,
+				Computed:            true,
 				Validators: []validator.String{
 					stringvalidator.OneOf(gobam.ObjectTypes...),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("types")),
+				},
+			},
+			"types": schema.ListAttribute{
+				MarkdownDescription: "The types of the entity you want to retrieve, in order of preference. The first type with an entity named `name` is used.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(gobam.ObjectTypes...)),
 				},
 			},
 			"parent_id": schema.Int64Attribute{
 				MarkdownDescription: "The object ID of the parent object that contains the entity. Configurations are stored in ID `0`.",
 				Required:            true,
 			},
+			"recursive": schema.BoolAttribute{
+				MarkdownDescription: "Search for the entity anywhere below `parent_id` instead of only its direct children. An error is returned if more than one entity of the same type matches. Defaults to `false`.",
+				Optional:            true,
+			},
 			"properties": schema.StringAttribute{
 				MarkdownDescription: "The properties of the entity as returned by the API (pipe delimited).",
 				Computed:            true,
 			},
+			"managed_by_terraform": schema.BoolAttribute{
+				MarkdownDescription: "If the entity is marked as managed by Terraform with the user-defined field configured by the provider `managed_udf` argument. If set, the entity must match the value or an error is returned.",
+				Optional:            true,
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -102,7 +141,7 @@ func (d *entityDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	client, diag := clientLogin(ctx, d.client, mutex)
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -111,26 +150,55 @@ func (d *entityDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	parentID := data.ParentID.ValueInt64()
 
 	name := data.Name.ValueString()
-	objType := data.Type.ValueString()
+	objTypes := []string{data.Type.ValueString()}
+	if !data.Types.IsNull() {
+		resp.Diagnostics.Append(data.Types.ElementsAs(ctx, &objTypes, false)...)
+		if resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			return
+		}
+	}
 
-	entity, err := client.GetEntityByName(parentID, name, objType)
+	entity, err := findEntityByName(client, parentID, name, objTypes, data.Recursive.ValueBool())
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to get entity by name", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get entity by name", err, "")
 		return
 	}
 
-	if *entity.Id == 0 {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Entity not found", "Entity ID returned was 0")
+	if entity == nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Entity not found", fmt.Sprintf("No entity named %q of type %s was found", name, strings.Join(objTypes, " or ")))
+
+		return
+	}
+
+	if !data.ManagedByTerraform.IsNull() && d.client.ManagedUDF == "" {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddAttributeError(
+			path.Root("managed_by_terraform"),
+			"Provider managed_udf is not configured",
+			"The managed_by_terraform filter requires the managed_udf argument or BLUECAT_MANAGED_UDF environment variable to be set on the provider.",
+		)
+		return
+	}
 
+	managed := isManaged(entity, d.client)
+	if !data.ManagedByTerraform.IsNull() && data.ManagedByTerraform.ValueBool() != managed {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError(
+			"Entity not found",
+			fmt.Sprintf("Entity %s was found but managed_by_terraform is %t", name, managed),
+		)
 		return
 	}
 
 	data.Id = types.StringValue(strconv.FormatInt(*entity.Id, 10))
+	data.Type = types.StringPointerValue(entity.Type)
 	data.Properties = types.StringValue(*entity.Properties)
+	data.ManagedByTerraform = types.BoolValue(managed)
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -139,3 +207,84 @@ func (d *entityDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// findEntityByName returns the entity named name of the first of objTypes that
+// has one under parentID, or nil if none is found. If recursive is true, the
+// entity can be anywhere below parentID instead of a direct child.
+func findEntityByName(client gobam.ProteusAPI, parentID int64, name string, objTypes []string, recursive bool) (*gobam.APIEntity, error) {
+	for _, objType := range objTypes {
+		if !recursive {
+			entity, err := client.GetEntityByName(parentID, name, objType)
+			if err != nil {
+				return nil, err
+			}
+			if entity.Id != nil && *entity.Id != 0 {
+				return entity, nil
+			}
+			continue
+		}
+
+		var found []*gobam.APIEntity
+		for start := 0; ; start += entitySearchPageSize {
+			entities, err := client.SearchByObjectTypes(name, objType, start, entitySearchPageSize)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, entity := range entities.Item {
+				if entity.Id == nil || entity.Name == nil || *entity.Name != name {
+					continue
+				}
+
+				below, err := entityIsBelow(client, *entity.Id, parentID)
+				if err != nil {
+					return nil, err
+				}
+				if below {
+					found = append(found, entity)
+				}
+			}
+
+			if len(entities.Item) < entitySearchPageSize {
+				break
+			}
+		}
+
+		if len(found) > 1 {
+			ids := make([]string, 0, len(found))
+			for _, entity := range found {
+				ids = append(ids, strconv.FormatInt(*entity.Id, 10))
+			}
+			return nil, fmt.Errorf("%d entities named %q of type %s were found below %d: %s", len(found), name, objType, parentID, strings.Join(ids, ", "))
+		}
+		if len(found) == 1 {
+			return found[0], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// entityIsBelow returns whether the entity id is a descendant of ancestorID.
+// Every entity is below ID 0, where configurations are stored.
+func entityIsBelow(client gobam.ProteusAPI, id int64, ancestorID int64) (bool, error) {
+	if ancestorID == 0 {
+		return true, nil
+	}
+
+	for depth := 0; depth < entityMaxDepth; depth++ {
+		parent, err := client.GetParent(id)
+		if err != nil {
+			return false, err
+		}
+		if parent.Id == nil || *parent.Id == 0 {
+			return false, nil
+		}
+		if *parent.Id == ancestorID {
+			return true, nil
+		}
+		id = *parent.Id
+	}
+
+	return false, nil
+}
@@ -1,9 +1,12 @@
 package provider
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
 )
 
 func TestAccEntityDataSource(t *testing.T) {
@@ -33,3 +36,113 @@ data "bluecat_entity" "config" {
 	type      = "Configuration"
 }
 `
+
+// entityTreeClient serves entities from a tree keyed by object ID.
+type entityTreeClient struct {
+	gobam.ProteusAPI
+	entities map[int64]*gobam.APIEntity
+	parents  map[int64]int64
+}
+
+func (c *entityTreeClient) GetEntityByName(parentId int64, name string, _type string) (*gobam.APIEntity, error) {
+	for id, entity := range c.entities {
+		if c.parents[id] == parentId && *entity.Name == name && *entity.Type == _type {
+			return entity, nil
+		}
+	}
+	return &gobam.APIEntity{}, nil
+}
+
+func (c *entityTreeClient) SearchByObjectTypes(keyword string, types string, start int, count int) (*gobam.APIEntityArray, error) {
+	result := &gobam.APIEntityArray{}
+	for _, entity := range c.entities {
+		if strings.Contains(*entity.Name, keyword) && *entity.Type == types {
+			result.Item = append(result.Item, entity)
+		}
+	}
+	return result, nil
+}
+
+func (c *entityTreeClient) GetEntities(parentId int64, _type string, start int, count int) (*gobam.APIEntityArray, error) {
+	var ids []int64
+	for id, entity := range c.entities {
+		if c.parents[id] == parentId && *entity.Type == _type {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	result := &gobam.APIEntityArray{}
+	for _, id := range ids[min(start, len(ids)):min(start+count, len(ids))] {
+		result.Item = append(result.Item, c.entities[id])
+	}
+	return result, nil
+}
+
+func (c *entityTreeClient) GetParent(entityId int64) (*gobam.APIEntity, error) {
+	id := c.parents[entityId]
+	return &gobam.APIEntity{Id: &id}, nil
+}
+
+func newEntityTreeClient() *entityTreeClient {
+	client := &entityTreeClient{entities: map[int64]*gobam.APIEntity{}, parents: map[int64]int64{}}
+	add := func(id, parent int64, name, objectType string) {
+		client.entities[id] = &gobam.APIEntity{Id: &id, Name: &name, Type: &objectType}
+		client.parents[id] = parent
+	}
+	add(1, 0, "config", "Configuration")
+	add(2, 1, "block", "IP4Block")
+	add(3, 2, "nested", "IP4Network")
+	add(4, 1, "web", "View")
+	add(5, 4, "nested", "Zone")
+	add(6, 1, "other", "IP4Block")
+	add(7, 6, "nested-two", "IP4Network")
+	return client
+}
+
+func TestFindEntityByName(t *testing.T) {
+	client := newEntityTreeClient()
+
+	entity, err := findEntityByName(client, 1, "block", []string{"IP4Network", "IP4Block"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entity == nil || *entity.Id != 2 {
+		t.Errorf("expected the block to be found by its second type, got %v", entity)
+	}
+
+	entity, err = findEntityByName(client, 1, "nested", []string{"IP4Network"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entity != nil {
+		t.Errorf("expected a nested entity to not be found without recursive, got %d", *entity.Id)
+	}
+
+	entity, err = findEntityByName(client, 1, "nested", []string{"IP4Network"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entity == nil || *entity.Id != 3 {
+		t.Errorf("expected the nested network to be found, got %v", entity)
+	}
+
+	entity, err = findEntityByName(client, 6, "nested", []string{"IP4Network", "Zone"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entity != nil {
+		t.Errorf("expected entities outside the parent to not be found, got %d", *entity.Id)
+	}
+}
+
+func TestFindEntityByNameAmbiguous(t *testing.T) {
+	client := newEntityTreeClient()
+	id, parent, name, objectType := int64(8), int64(6), "nested", "IP4Network"
+	client.entities[id] = &gobam.APIEntity{Id: &id, Name: &name, Type: &objectType}
+	client.parents[id] = parent
+
+	if _, err := findEntityByName(client, 0, "nested", []string{"IP4Network"}, true); err == nil {
+		t.Error("expected an error when more than one entity matches")
+	}
+}
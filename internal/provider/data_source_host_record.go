@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -30,6 +32,7 @@ type HostRecordDataSourceModel struct {
 	AbsoluteName      types.String `tfsdk:"absolute_name"`
 	Addresses         types.Set    `tfsdk:"addresses"`
 	AddressIDs        types.Set    `tfsdk:"address_ids"`
+	Comments          types.String `tfsdk:"comments"`
 	UserDefinedFields types.Map    `tfsdk:"user_defined_fields"`
 	Name              types.String `tfsdk:"name"`
 	ParentID          types.Int64  `tfsdk:"parent_id"`
@@ -38,6 +41,10 @@ type HostRecordDataSourceModel struct {
 	ReverseRecord     types.Bool   `tfsdk:"reverse_record"`
 	TTL               types.Int64  `tfsdk:"ttl"`
 	Type              types.String `tfsdk:"type"`
+	MaxSearchResults  types.Int64  `tfsdk:"max_search_results"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
 }
 
 func (d *HostRecordDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -50,6 +57,7 @@ func (d *HostRecordDataSource) Schema(ctx context.Context, req datasource.Schema
 		MarkdownDescription: "Example data source",
 
 		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Entity identifier",
 				Computed:            true,
@@ -85,6 +93,10 @@ func (d *HostRecordDataSource) Schema(ctx context.Context, req datasource.Schema
 				MarkdownDescription: "The type of the parent of the host record.",
 				Computed:            true,
 			},
+			"comments": schema.StringAttribute{
+				MarkdownDescription: "Comments about the host record.",
+				Computed:            true,
+			},
 			"properties": schema.StringAttribute{
 				MarkdownDescription: "The properties of the host record as returned by the API (pipe delimited).",
 				Computed:            true,
@@ -101,6 +113,13 @@ func (d *HostRecordDataSource) Schema(ctx context.Context, req datasource.Schema
 				MarkdownDescription: "The type of the resource.",
 				Computed:            true,
 			},
+			"max_search_results": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of host records returned by the hint search to look through for `absolute_name`. Defaults to `10000`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -135,73 +154,57 @@ func (d *HostRecordDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	client, diag := clientLogin(ctx, d.client, mutex)
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	start := 0
-	count := 10
+	maxResults := hintMaxSearchResults
+	if !data.MaxSearchResults.IsNull() {
+		maxResults = int(data.MaxSearchResults.ValueInt64())
+	}
+
 	absoluteName := data.AbsoluteName.ValueString()
 	options := fmt.Sprintf("hint=^%s$|retrieveFields=true", absoluteName)
 
-	hostRecords, err := client.GetHostRecordsByHint(start, count, options)
+	// stop at a second match as the data source only supports 1
+	hostRecords, err := searchByHint(func(start int, count int) (*gobam.APIEntityArray, error) {
+		return client.GetHostRecordsByHint(start, count, options)
+	}, maxResults, 2, func(e *gobam.APIEntity) (*gobam.APIEntity, error) {
+		if e.Properties == nil || parseProperties(*e.Properties)["absoluteName"] != absoluteName {
+			return nil, nil
+		}
+		return e, nil
+	})
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to get Host Records by hint", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get Host Records by hint", err, "")
 
 		return
 	}
 
-	resultCount := len(hostRecords.Item)
-
-	if resultCount == 0 {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"No host records returned by GetHostRecordsByHint",
-			fmt.Sprintf("No host records returned with options: %s", options),
-		)
-		return
-	}
-
-	tflog.Info(ctx, fmt.Sprintf("GetHostRecordsByHint returned %s results", strconv.Itoa(resultCount)))
-
-	matches := 0
-	matchLocation := -1
-	for x := range hostRecords.Item {
-		properties := *hostRecords.Item[x].Properties
-		props := strings.Split(properties, "|")
-		for y := range props {
-			if len(props[y]) > 0 {
-				prop := strings.Split(props[y], "=")[0]
-				val := strings.Split(props[y], "=")[1]
-				if prop == "absoluteName" && val == absoluteName {
-					matches++
-					matchLocation = x
-				}
-			}
-		}
-	}
+	tflog.Info(ctx, fmt.Sprintf("GetHostRecordsByHint returned %s matches", strconv.Itoa(len(hostRecords))))
 
-	if matches == 0 || matches > 1 {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	if len(hostRecords) != 1 {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError(
 			"No exact host record match found for hint",
-			fmt.Sprintf("No exact host record match found for hint: %s. Number of matches was: %d", absoluteName, matches),
+			fmt.Sprintf("No exact host record match found for hint: %s. Number of matches was: %d", absoluteName, len(hostRecords)),
 		)
 		return
 	}
 
-	data.ID = types.StringValue(strconv.FormatInt(*hostRecords.Item[matchLocation].Id, 10))
-	data.Name = types.StringValue(*hostRecords.Item[matchLocation].Name)
-	data.Properties = types.StringValue(*hostRecords.Item[matchLocation].Properties)
-	data.Type = types.StringValue(*hostRecords.Item[matchLocation].Type)
+	hostRecord := hostRecords[0]
+	data.ID = types.StringValue(strconv.FormatInt(*hostRecord.Id, 10))
+	data.Name = types.StringValue(*hostRecord.Name)
+	data.Properties = types.StringValue(*hostRecord.Properties)
+	data.Type = types.StringValue(*hostRecord.Type)
 
-	hostRecordProperties, diag := flattenHostRecordProperties(hostRecords.Item[matchLocation])
+	hostRecordProperties, diag := flattenHostRecordProperties(hostRecord)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		return
 	}
 
@@ -209,12 +212,13 @@ func (d *HostRecordDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	data.ParentID = hostRecordProperties.ParentID
 	data.ParentType = hostRecordProperties.ParentType
 	data.ReverseRecord = hostRecordProperties.ReverseRecord
+	data.Comments = hostRecordProperties.Comments
 	data.Addresses = hostRecordProperties.Addresses
 	data.AddressIDs = hostRecordProperties.AddressIDs
 	data.UserDefinedFields = hostRecordProperties.UserDefinedFields
 	data.TTL = hostRecordProperties.TTL
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
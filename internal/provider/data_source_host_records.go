@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+const hostRecordsPageSize = 1000
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HostRecordsDataSource{}
+
+func NewHostRecordsDataSource() datasource.DataSource {
+	return &HostRecordsDataSource{}
+}
+
+// HostRecordsDataSource defines the data source implementation.
+type HostRecordsDataSource struct {
+	client *loginClient
+}
+
+// HostRecordsDataSourceModel describes the data source data model.
+type HostRecordsDataSourceModel struct {
+	ID          types.String                 `tfsdk:"id"`
+	ZoneID      types.Int64                  `tfsdk:"zone_id"`
+	Hint        types.String                 `tfsdk:"hint"`
+	MaxResults  types.Int64                  `tfsdk:"max_results"`
+	HostRecords []HostRecordsDataSourceEntry `tfsdk:"host_records"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+// HostRecordsDataSourceEntry describes a single host record returned by the data source.
+type HostRecordsDataSourceEntry struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	AbsoluteName  types.String `tfsdk:"absolute_name"`
+	Addresses     types.Set    `tfsdk:"addresses"`
+	AddressIDs    types.Set    `tfsdk:"address_ids"`
+	ReverseRecord types.Bool   `tfsdk:"reverse_record"`
+	TTL           types.Int64  `tfsdk:"ttl"`
+}
+
+func (d *HostRecordsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_records"
+}
+
+func (d *HostRecordsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to list the host records in a zone or the host records that match a hint.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the data source, which is the `zone_id` or `hint` used.",
+				Computed:            true,
+			},
+			"zone_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of a zone to list the host records of. Host records in sub-zones are not included. Exactly one of `zone_id` or `hint` must be set.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("hint")),
+				},
+			},
+			"hint": schema.StringAttribute{
+				MarkdownDescription: "A hint to search for host records with, as accepted by the BlueCat Address Manager `getHostRecordsByHint` API call. `^` matches the start and `$` the end of the absolute name and `*` matches any characters, for example `^web*.example.com$`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"max_results": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of host records to return. Defaults to `10000`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"host_records": schema.ListNestedAttribute{
+				MarkdownDescription: "The host records that were found.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Host record identifier.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The short name of the host record.",
+							Computed:            true,
+						},
+						"absolute_name": schema.StringAttribute{
+							MarkdownDescription: "The absolute name/fqdn of the host record.",
+							Computed:            true,
+						},
+						"addresses": schema.SetAttribute{
+							MarkdownDescription: "A set of all addresses associated with the host record.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"address_ids": schema.SetAttribute{
+							MarkdownDescription: "A set of all address ids associated with the host record.",
+							Computed:            true,
+							ElementType:         types.Int64Type,
+						},
+						"reverse_record": schema.BoolAttribute{
+							MarkdownDescription: "A boolean that represents if the host record should set reverse records.",
+							Computed:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "The TTL of the host record.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HostRecordsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *HostRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostRecordsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxResults := 10000
+	if !data.MaxResults.IsNull() {
+		maxResults = int(data.MaxResults.ValueInt64())
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	var page func(start int, count int) (*gobam.APIEntityArray, error)
+	if !data.ZoneID.IsNull() {
+		zoneID := data.ZoneID.ValueInt64()
+		data.ID = types.StringValue(strconv.FormatInt(zoneID, 10))
+		page = func(start int, count int) (*gobam.APIEntityArray, error) {
+			return client.GetEntities(zoneID, "HostRecord", start, count)
+		}
+	} else {
+		hint := data.Hint.ValueString()
+		data.ID = types.StringValue(hint)
+		options := fmt.Sprintf("hint=%s|retrieveFields=true", hint)
+		page = func(start int, count int) (*gobam.APIEntityArray, error) {
+			return client.GetHostRecordsByHint(start, count, options)
+		}
+	}
+
+	hostRecords := []HostRecordsDataSourceEntry{}
+	for start := 0; len(hostRecords) < maxResults; start += hostRecordsPageSize {
+		count := min(hostRecordsPageSize, maxResults-len(hostRecords))
+
+		entities, err := page(start, count)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get Host Records", err, "")
+			return
+		}
+
+		for _, e := range entities.Item {
+			hostRecordProperties, diag := flattenHostRecordProperties(e)
+			if diag.HasError() {
+				resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+				resp.Diagnostics.Append(diag...)
+				return
+			}
+
+			hostRecords = append(hostRecords, HostRecordsDataSourceEntry{
+				ID:            types.StringValue(strconv.FormatInt(*e.Id, 10)),
+				Name:          types.StringPointerValue(e.Name),
+				AbsoluteName:  hostRecordProperties.AbsoluteName,
+				Addresses:     hostRecordProperties.Addresses,
+				AddressIDs:    hostRecordProperties.AddressIDs,
+				ReverseRecord: hostRecordProperties.ReverseRecord,
+				TTL:           hostRecordProperties.TTL,
+			})
+		}
+
+		if len(entities.Item) < count {
+			break
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Found %d host records", len(hostRecords)))
+
+	data.HostRecords = hostRecords
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
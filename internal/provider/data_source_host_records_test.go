@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHostRecordsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccHostRecordsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bluecat_host_records.test", "host_records.#", "1"),
+					resource.TestCheckResourceAttrWith("data.bluecat_host_records.test", "host_records.0.id", validateObjectID),
+				),
+			},
+		},
+	})
+}
+
+const testAccHostRecordsDataSourceConfig = `
+variable "absolute_name" {
+	type = string
+}
+
+data "bluecat_host_records" "test" {
+	hint        = "^${var.absolute_name}$"
+	max_results = 1
+}
+`
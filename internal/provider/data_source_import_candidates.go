@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/exp/maps"
+)
+
+const importCandidatesPageSize = 1000
+
+// importCandidateResourceTypes maps the object types that can be imported to
+// the resource type that manages them.
+var importCandidateResourceTypes = map[string]string{
+	"HostRecord": "bluecat_host_record",
+	"IP4Address": "bluecat_ip4_address",
+	"IP4Block":   "bluecat_ip4_block",
+	"IP4Network": "bluecat_ip4_network",
+}
+
+var resourceNameInvalidCharacters = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ImportCandidatesDataSource{}
+
+func NewImportCandidatesDataSource() datasource.DataSource {
+	return &ImportCandidatesDataSource{}
+}
+
+// ImportCandidatesDataSource defines the data source implementation.
+type ImportCandidatesDataSource struct {
+	client *loginClient
+}
+
+// ImportCandidatesDataSourceModel describes the data source data model.
+type ImportCandidatesDataSourceModel struct {
+	ID            types.String           `tfsdk:"id"`
+	ParentID      types.Int64            `tfsdk:"parent_id"`
+	Type          types.String           `tfsdk:"type"`
+	UnmanagedOnly types.Bool             `tfsdk:"unmanaged_only"`
+	Candidates    []ImportCandidateModel `tfsdk:"candidates"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+// ImportCandidateModel describes a single object that can be imported.
+type ImportCandidateModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	ResourceType    types.String `tfsdk:"resource_type"`
+	ResourceName    types.String `tfsdk:"resource_name"`
+	ResourceAddress types.String `tfsdk:"resource_address"`
+}
+
+func (d *ImportCandidatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_import_candidates"
+}
+
+func (d *ImportCandidatesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	objectTypes := maps.Keys(importCandidateResourceTypes)
+	slices.Sort(objectTypes)
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to list the objects of a type directly under a parent in BlueCat Address Manager together with a suggested resource address for each. The result is intended to be fed to `templatefile` to generate `import` blocks when bringing existing objects under management.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the data source in the form `<parent_id>:<type>`.",
+				Computed:            true,
+			},
+			"parent_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the parent to list the objects of.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The object type to list. " + enumDescription(objectTypes),
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(objectTypes...),
+				},
+			},
+			"unmanaged_only": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, only objects that are not marked as managed by Terraform with the provider `managed_udf` are returned. The provider `managed_udf` must be set to use this argument.",
+				Optional:            true,
+			},
+			"candidates": schema.ListNestedAttribute{
+				MarkdownDescription: "The objects that can be imported.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID to import the object with.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the object.",
+							Computed:            true,
+						},
+						"resource_type": schema.StringAttribute{
+							MarkdownDescription: "The resource type that manages the object.",
+							Computed:            true,
+						},
+						"resource_name": schema.StringAttribute{
+							MarkdownDescription: "A suggested resource name derived from the object name. Names are unique within the result.",
+							Computed:            true,
+						},
+						"resource_address": schema.StringAttribute{
+							MarkdownDescription: "The suggested resource address, `<resource_type>.<resource_name>`, to use as the `to` argument of an `import` block.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ImportCandidatesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ImportCandidatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ImportCandidatesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unmanagedOnly := data.UnmanagedOnly.ValueBool()
+	if unmanagedOnly && d.client.ManagedUDF == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("unmanaged_only"),
+			"Provider managed_udf is not set",
+			"unmanaged_only can only be used when the provider managed_udf argument is set.",
+		)
+		return
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	parentID := data.ParentID.ValueInt64()
+	objType := data.Type.ValueString()
+	resourceType := importCandidateResourceTypes[objType]
+
+	candidates := []ImportCandidateModel{}
+	usedNames := make(map[string]bool)
+
+	for start := 0; ; start += importCandidatesPageSize {
+		entities, err := client.GetEntities(parentID, objType, start, importCandidatesPageSize)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get entities", err, "")
+			return
+		}
+
+		for _, e := range entities.Item {
+			if e.Id == nil || *e.Id == 0 {
+				continue
+			}
+
+			if unmanagedOnly && isManaged(e, d.client) {
+				continue
+			}
+
+			name := ""
+			if e.Name != nil {
+				name = *e.Name
+			}
+
+			resourceName := importCandidateResourceName(name, objType, *e.Id, usedNames)
+
+			candidates = append(candidates, ImportCandidateModel{
+				ID:              types.StringValue(strconv.FormatInt(*e.Id, 10)),
+				Name:            types.StringValue(name),
+				ResourceType:    types.StringValue(resourceType),
+				ResourceName:    types.StringValue(resourceName),
+				ResourceAddress: types.StringValue(resourceType + "." + resourceName),
+			})
+		}
+
+		if len(entities.Item) < importCandidatesPageSize {
+			break
+		}
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d:%s", parentID, objType))
+	data.Candidates = candidates
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// importCandidateResourceName returns a valid Terraform resource name derived
+// from name that is not already in use. used is updated with the result.
+func importCandidateResourceName(name string, objType string, id int64, used map[string]bool) string {
+	resourceName := resourceNameInvalidCharacters.ReplaceAllString(strings.ToLower(name), "_")
+	resourceName = strings.Trim(resourceName, "_-")
+
+	if resourceName == "" {
+		resourceName = strings.ToLower(objType)
+	}
+
+	// resource names must start with a letter or underscore
+	if c := resourceName[0]; c < 'a' || c > 'z' {
+		resourceName = "_" + resourceName
+	}
+
+	if used[resourceName] {
+		resourceName = fmt.Sprintf("%s_%d", resourceName, id)
+	}
+
+	used[resourceName] = true
+
+	return resourceName
+}
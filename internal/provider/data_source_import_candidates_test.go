@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccImportCandidatesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccImportCandidatesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.bluecat_import_candidates.test", "candidates.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestImportCandidateResourceName(t *testing.T) {
+	used := make(map[string]bool)
+
+	tests := []struct {
+		name string
+		id   int64
+		want string
+	}{
+		{name: "Web Servers", id: 1, want: "web_servers"},
+		{name: "web-servers", id: 2, want: "web-servers"},
+		{name: "Web  Servers!", id: 3, want: "web_servers_3"},
+		{name: "10.0.0.0/24", id: 4, want: "_10_0_0_0_24"},
+		{name: "", id: 5, want: "ip4network"},
+		{name: "", id: 6, want: "ip4network_6"},
+	}
+
+	for _, tc := range tests {
+		got := importCandidateResourceName(tc.name, "IP4Network", tc.id, used)
+		if got != tc.want {
+			t.Errorf("importCandidateResourceName(%q) = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+const testAccImportCandidatesDataSourceConfig = `
+variable "ip4_block_id" {
+	type = number
+}
+
+data "bluecat_import_candidates" "test" {
+	parent_id = var.ip4_block_id
+	type      = "IP4Network"
+}
+`
@@ -34,21 +34,26 @@ type IP4AddressDataSourceModel struct {
 	ContainerID types.Int64 `tfsdk:"container_id"`
 
 	// These are exposed via the entity properties field for objects of type IP4Address
-	Address               types.String `tfsdk:"address"`
-	State                 types.String `tfsdk:"state"`
-	MACAddress            types.String `tfsdk:"mac_address"`
-	RouterPortInfo        types.String `tfsdk:"router_port_info"`
-	SwitchPortInfo        types.String `tfsdk:"switch_port_info"`
-	VLANInfo              types.String `tfsdk:"vlan_info"`
-	LeaseTime             types.String `tfsdk:"lease_time"`
-	ExpiryTime            types.String `tfsdk:"expiry_time"`
-	ParameterRequestList  types.String `tfsdk:"parameter_request_list"`
-	VendorClassIdentifier types.String `tfsdk:"vendor_class_identifier"`
-	LocationCode          types.String `tfsdk:"location_code"`
-	LocationInherited     types.Bool   `tfsdk:"location_inherited"`
+	Address               ip4AddressValue `tfsdk:"address"`
+	PTRName               types.String    `tfsdk:"ptr_name"`
+	ReverseZone           types.String    `tfsdk:"reverse_zone"`
+	State                 types.String    `tfsdk:"state"`
+	MACAddress            types.String    `tfsdk:"mac_address"`
+	RouterPortInfo        types.String    `tfsdk:"router_port_info"`
+	SwitchPortInfo        types.String    `tfsdk:"switch_port_info"`
+	VLANInfo              types.String    `tfsdk:"vlan_info"`
+	LeaseTime             types.String    `tfsdk:"lease_time"`
+	ExpiryTime            types.String    `tfsdk:"expiry_time"`
+	ParameterRequestList  types.String    `tfsdk:"parameter_request_list"`
+	VendorClassIdentifier types.String    `tfsdk:"vendor_class_identifier"`
+	LocationCode          types.String    `tfsdk:"location_code"`
+	LocationInherited     types.Bool      `tfsdk:"location_inherited"`
 
 	// these are user defined fields that are not built-in
 	UserDefinedFields types.Map `tfsdk:"user_defined_fields"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
 }
 
 func (d *IP4AddressDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -61,6 +66,7 @@ func (d *IP4AddressDataSource) Schema(ctx context.Context, req datasource.Schema
 		MarkdownDescription: "Data source to access the attributes of an IPv4 address.",
 
 		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "IP4 Address identifier",
 				Computed:            true,
@@ -68,6 +74,15 @@ func (d *IP4AddressDataSource) Schema(ctx context.Context, req datasource.Schema
 			"address": schema.StringAttribute{
 				MarkdownDescription: "The IPv4 address to get data for.",
 				Required:            true,
+				CustomType:          ip4AddressType{},
+			},
+			"ptr_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the PTR record of the address, such as `5.1.168.192.in-addr.arpa`.",
+				Computed:            true,
+			},
+			"reverse_zone": schema.StringAttribute{
+				MarkdownDescription: "The reverse zone that contains `ptr_name`, which is the zone for the /24 network of the address, such as `1.168.192.in-addr.arpa`.",
+				Computed:            true,
 			},
 			"container_id": schema.Int64Attribute{
 				MarkdownDescription: "The object ID of the container that has the specified `address`.  This can be a Configuration, IPv4 Block, IPv4 Network, or DHCP range.",
@@ -132,19 +147,19 @@ func (d *IP4AddressDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	client, diag := clientLogin(ctx, d.client, mutex)
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
 	containerID := data.ContainerID.ValueInt64()
-	address := data.Address.ValueString()
+	address := data.Address.ValueIP4String()
 
 	ip4Address, err := client.GetIP4Address(containerID, address)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to get IP4 Address", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Address", err, "")
 		return
 	}
 
@@ -155,11 +170,12 @@ func (d *IP4AddressDataSource) Read(ctx context.Context, req datasource.ReadRequ
 
 	addressProperties, diag := flattenIP4AddressProperties(ip4Address)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
-	data.Address = addressProperties.Address
+	data.Address = newIP4AddressFromString(addressProperties.Address)
+	data.PTRName, data.ReverseZone = ip4ReverseNames(data.Address.StringValue)
 	data.State = addressProperties.State
 	data.MACAddress = addressProperties.MACAddress
 	data.RouterPortInfo = addressProperties.RouterPortInfo
@@ -173,7 +189,7 @@ func (d *IP4AddressDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	data.LocationInherited = addressProperties.LocationInherited
 	data.UserDefinedFields = addressProperties.UserDefinedFields
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const ip4AddressesPageSize = 1000
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &IP4AddressesDataSource{}
+
+func NewIP4AddressesDataSource() datasource.DataSource {
+	return &IP4AddressesDataSource{}
+}
+
+// IP4AddressesDataSource defines the data source implementation.
+type IP4AddressesDataSource struct {
+	client *loginClient
+}
+
+// IP4AddressesDataSourceModel describes the data source data model.
+type IP4AddressesDataSourceModel struct {
+	ID              types.String                  `tfsdk:"id"`
+	ConfigurationID types.Int64                   `tfsdk:"configuration_id"`
+	MACAddress      types.String                  `tfsdk:"mac_address"`
+	MACAddressID    types.Int64                   `tfsdk:"mac_address_id"`
+	Addresses       []IP4AddressesDataSourceEntry `tfsdk:"addresses"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+// IP4AddressesDataSourceEntry describes a single IPv4 address returned by the data source.
+type IP4AddressesDataSourceEntry struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Address    types.String `tfsdk:"address"`
+	State      types.String `tfsdk:"state"`
+	Properties types.String `tfsdk:"properties"`
+}
+
+func (d *IP4AddressesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip4_addresses"
+}
+
+func (d *IP4AddressesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to find all IPv4 addresses in a configuration that are linked to a MAC address.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the data source in the form `<configuration_id>:<mac_address>`.",
+				Computed:            true,
+			},
+			"configuration_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the Configuration to search." + defaultConfigurationDescription,
+				Optional:            true,
+				Computed:            true,
+			},
+			"mac_address": schema.StringAttribute{
+				MarkdownDescription: "The MAC address to find the IPv4 addresses of. Any format accepted by BlueCat Address Manager can be used, such as `00:50:56:01:02:03` or `00-50-56-01-02-03`.",
+				Required:            true,
+			},
+			"mac_address_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the MAC address, or `0` if the MAC address does not exist in the configuration.",
+				Computed:            true,
+			},
+			"addresses": schema.ListNestedAttribute{
+				MarkdownDescription: "The IPv4 addresses linked to the MAC address. The list is empty if the MAC address does not exist in the configuration.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "IP4 Address identifier.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name assigned to the IPv4 address. This is not related to DNS.",
+							Computed:            true,
+						},
+						"address": schema.StringAttribute{
+							MarkdownDescription: "The IPv4 address.",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "The state of the IPv4 address.",
+							Computed:            true,
+						},
+						"properties": schema.StringAttribute{
+							MarkdownDescription: "The properties of the IPv4 address as returned by the API (pipe delimited).",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *IP4AddressesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *IP4AddressesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data IP4AddressesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	configID, diag := d.client.configurationID(client, data.ConfigurationID)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	data.ConfigurationID = types.Int64Value(configID)
+
+	macAddress := data.MACAddress.ValueString()
+
+	mac, err := client.GetMACAddress(configID, macAddress)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get MAC Address", err, "")
+		return
+	}
+
+	addresses := []IP4AddressesDataSourceEntry{}
+	macID := int64(0)
+	if mac.Id != nil {
+		macID = *mac.Id
+	}
+
+	for start := 0; macID != 0; start += ip4AddressesPageSize {
+		linked, err := client.GetLinkedEntities(macID, "IP4Address", start, ip4AddressesPageSize)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get IP4 Addresses linked to MAC Address", err, "")
+			return
+		}
+
+		for _, e := range linked.Item {
+			addressProperties, diag := flattenIP4AddressProperties(e)
+			if diag.HasError() {
+				resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+				resp.Diagnostics.Append(diag...)
+				return
+			}
+
+			addresses = append(addresses, IP4AddressesDataSourceEntry{
+				ID:         types.StringValue(strconv.FormatInt(*e.Id, 10)),
+				Name:       types.StringPointerValue(e.Name),
+				Address:    addressProperties.Address,
+				State:      addressProperties.State,
+				Properties: types.StringPointerValue(e.Properties),
+			})
+		}
+
+		if len(linked.Item) < ip4AddressesPageSize {
+			break
+		}
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d:%s", configID, macAddress))
+	data.MACAddressID = types.Int64Value(macID)
+	data.Addresses = addresses
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIP4AddressesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccIP4AddressesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bluecat_ip4_addresses.test", "addresses.#", "1"),
+					resource.TestCheckResourceAttrPair("data.bluecat_ip4_addresses.test", "addresses.0.id", "bluecat_ip4_dhcp_reservation.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccIP4AddressesDataSourceConfig = testAccIP4DHCPReservationResourceConfig + `
+data "bluecat_ip4_addresses" "test" {
+	configuration_id = data.bluecat_entity.config.id
+	mac_address      = bluecat_ip4_dhcp_reservation.test.mac_address
+}
+`
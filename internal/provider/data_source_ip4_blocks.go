@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+const ip4BlocksPageSize = 1000
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &IP4BlocksDataSource{}
+
+func NewIP4BlocksDataSource() datasource.DataSource {
+	return &IP4BlocksDataSource{}
+}
+
+// IP4BlocksDataSource defines the data source implementation.
+type IP4BlocksDataSource struct {
+	client *loginClient
+}
+
+// IP4BlocksDataSourceModel describes the data source data model.
+type IP4BlocksDataSourceModel struct {
+	ID              types.String              `tfsdk:"id"`
+	ParentID        types.Int64               `tfsdk:"parent_id"`
+	MaxDepth        types.Int64               `tfsdk:"max_depth"`
+	IncludeNetworks types.Bool                `tfsdk:"include_networks"`
+	Blocks          []IP4BlocksDataSourceNode `tfsdk:"blocks"`
+	Networks        []IP4BlocksDataSourceNode `tfsdk:"networks"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+// IP4BlocksDataSourceNode describes a single block or network in the tree.
+type IP4BlocksDataSourceNode struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	ParentID types.Int64  `tfsdk:"parent_id"`
+	Depth    types.Int64  `tfsdk:"depth"`
+	CIDR     types.String `tfsdk:"cidr"`
+	Start    types.String `tfsdk:"start"`
+	End      types.String `tfsdk:"end"`
+	Size     types.Int64  `tfsdk:"size"`
+}
+
+func (d *IP4BlocksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip4_blocks"
+}
+
+func (d *IP4BlocksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	nodeAttributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The object ID.",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The name.",
+			Computed:            true,
+		},
+		"parent_id": schema.Int64Attribute{
+			MarkdownDescription: "The object ID of the block or configuration that contains this object.",
+			Computed:            true,
+		},
+		"depth": schema.Int64Attribute{
+			MarkdownDescription: "How many levels below `parent_id` of the data source the object is. Direct children have a depth of `1`.",
+			Computed:            true,
+		},
+		"cidr": schema.StringAttribute{
+			MarkdownDescription: "The CIDR notation of the object. Empty for blocks defined by a range that is not a CIDR.",
+			Computed:            true,
+		},
+		"start": schema.StringAttribute{
+			MarkdownDescription: "The first address of the object.",
+			Computed:            true,
+		},
+		"end": schema.StringAttribute{
+			MarkdownDescription: "The last address of the object.",
+			Computed:            true,
+		},
+		"size": schema.Int64Attribute{
+			MarkdownDescription: "The number of addresses in the object.",
+			Computed:            true,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to list the IPv4 blocks and networks under a configuration or IPv4 block, recursively.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the data source, which is the `parent_id`.",
+				Computed:            true,
+			},
+			"parent_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the configuration or IPv4 block to list the children of.",
+				Required:            true,
+			},
+			"max_depth": schema.Int64Attribute{
+				MarkdownDescription: "The number of levels of blocks to descend into. `1` only lists the direct children of `parent_id`. Defaults to `10`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"include_networks": schema.BoolAttribute{
+				MarkdownDescription: "Whether to list the networks in the blocks as well. Defaults to `true`.",
+				Optional:            true,
+			},
+			"blocks": schema.ListNestedAttribute{
+				MarkdownDescription: "The IPv4 blocks that were found, in depth first order.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: nodeAttributes,
+				},
+			},
+			"networks": schema.ListNestedAttribute{
+				MarkdownDescription: "The IPv4 networks that were found, in depth first order.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: nodeAttributes,
+				},
+			},
+		},
+	}
+}
+
+func (d *IP4BlocksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *IP4BlocksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data IP4BlocksDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxDepth := int64(10)
+	if !data.MaxDepth.IsNull() {
+		maxDepth = data.MaxDepth.ValueInt64()
+	}
+
+	includeNetworks := true
+	if !data.IncludeNetworks.IsNull() {
+		includeNetworks = data.IncludeNetworks.ValueBool()
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	tree := &ip4BlockTree{
+		client:          client,
+		maxDepth:        maxDepth,
+		includeNetworks: includeNetworks,
+		blocks:          []IP4BlocksDataSourceNode{},
+		networks:        []IP4BlocksDataSourceNode{},
+	}
+
+	err := tree.walk(data.ParentID.ValueInt64(), 1)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to list IPv4 blocks", err, "")
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(data.ParentID.ValueInt64(), 10))
+	data.Blocks = tree.blocks
+	data.Networks = tree.networks
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ip4BlockTree collects the blocks and networks under a parent.
+type ip4BlockTree struct {
+	client          gobam.ProteusAPI
+	maxDepth        int64
+	includeNetworks bool
+	blocks          []IP4BlocksDataSourceNode
+	networks        []IP4BlocksDataSourceNode
+}
+
+// walk adds the children of parentID at depth to the tree and descends into
+// the child blocks until maxDepth is reached.
+func (t *ip4BlockTree) walk(parentID int64, depth int64) error {
+	blocks, err := getAllEntities(t.client, parentID, "IP4Block")
+	if err != nil {
+		return err
+	}
+
+	for _, b := range blocks {
+		node, err := newIP4BlocksDataSourceNode(b, parentID, depth)
+		if err != nil {
+			return err
+		}
+		t.blocks = append(t.blocks, node)
+
+		if depth < t.maxDepth {
+			if err := t.walk(*b.Id, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !t.includeNetworks {
+		return nil
+	}
+
+	networks, err := getAllEntities(t.client, parentID, "IP4Network")
+	if err != nil {
+		return err
+	}
+
+	for _, n := range networks {
+		node, err := newIP4BlocksDataSourceNode(n, parentID, depth)
+		if err != nil {
+			return err
+		}
+		t.networks = append(t.networks, node)
+	}
+
+	return nil
+}
+
+// getAllEntities pages through all child entities of a type.
+func getAllEntities(client gobam.ProteusAPI, parentID int64, objectType string) ([]*gobam.APIEntity, error) {
+	entities := []*gobam.APIEntity{}
+
+	for start := 0; ; start += ip4BlocksPageSize {
+		page, err := client.GetEntities(parentID, objectType, start, ip4BlocksPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, page.Item...)
+
+		if len(page.Item) < ip4BlocksPageSize {
+			return entities, nil
+		}
+	}
+}
+
+func newIP4BlocksDataSourceNode(e *gobam.APIEntity, parentID int64, depth int64) (IP4BlocksDataSourceNode, error) {
+	size, err := ip4EntitySize(e)
+	if err != nil {
+		return IP4BlocksDataSourceNode{}, fmt.Errorf("failed to calculate size of %s %d: %w", *e.Type, *e.Id, err)
+	}
+
+	properties := map[string]string{}
+	if e.Properties != nil {
+		properties = parseProperties(*e.Properties)
+	}
+
+	start, end := properties["start"], properties["end"]
+	if cidr, ok := properties["CIDR"]; ok {
+		start, end, err = ip4CIDRRange(cidr)
+		if err != nil {
+			return IP4BlocksDataSourceNode{}, err
+		}
+	}
+
+	return IP4BlocksDataSourceNode{
+		ID:       types.StringValue(strconv.FormatInt(*e.Id, 10)),
+		Name:     types.StringPointerValue(e.Name),
+		ParentID: types.Int64Value(parentID),
+		Depth:    types.Int64Value(depth),
+		CIDR:     types.StringValue(properties["CIDR"]),
+		Start:    types.StringValue(start),
+		End:      types.StringValue(end),
+		Size:     types.Int64Value(size),
+	}, nil
+}
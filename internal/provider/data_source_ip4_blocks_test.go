@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIP4BlocksDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccIP4BlocksDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.bluecat_ip4_blocks.test", "blocks.#"),
+					resource.TestCheckResourceAttrSet("data.bluecat_ip4_blocks.test", "networks.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccIP4BlocksDataSourceConfig = `
+variable "ip4_block_parent_id" {
+	type = number
+}
+
+data "bluecat_ip4_blocks" "test" {
+	parent_id = var.ip4_block_parent_id
+	max_depth = 1
+}
+`
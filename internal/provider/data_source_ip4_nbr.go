@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"math"
 	"strconv"
 	"strings"
 
@@ -33,30 +32,43 @@ type IP4NBRDataSource struct {
 
 // IP4NBRDataSourceModel describes the data source data model.
 type IP4NBRDataSourceModel struct {
-	ID                        types.String `tfsdk:"id"`
-	Address                   types.String `tfsdk:"address"`
-	ContainerID               types.Int64  `tfsdk:"container_id"`
-	Type                      types.String `tfsdk:"type"`
-	AddressesFree             types.Int64  `tfsdk:"addresses_free"`
-	AddressesInUse            types.Int64  `tfsdk:"addresses_in_use"`
-	AllowDuplicateHost        types.String `tfsdk:"allow_duplicate_host"`
-	CIDR                      types.String `tfsdk:"cidr"`
-	CustomProperties          types.Map    `tfsdk:"custom_properties"`
-	DefaultDomains            types.Set    `tfsdk:"default_domains"`
-	DefaultView               types.Int64  `tfsdk:"default_view"`
-	DNSRestrictions           types.Set    `tfsdk:"dns_restrictions"`
-	Gateway                   types.String `tfsdk:"gateway"`
-	InheritAllowDuplicateHost types.Bool   `tfsdk:"inherit_allow_duplicate_host"`
-	InheritDefaultDomains     types.Bool   `tfsdk:"inherit_default_domains"`
-	InheritDefaultView        types.Bool   `tfsdk:"inherit_default_view"`
-	InheritDNSRestrictions    types.Bool   `tfsdk:"inherit_dns_restrictions"`
-	InheritPingBeforeAssign   types.Bool   `tfsdk:"inherit_ping_before_assign"`
-	LocationCode              types.String `tfsdk:"location_code"`
-	LocationInherited         types.Bool   `tfsdk:"location_inherited"`
-	Name                      types.String `tfsdk:"name"`
-	PingBeforeAssign          types.String `tfsdk:"ping_before_assign"`
-	Properties                types.String `tfsdk:"properties"`
-	Template                  types.Int64  `tfsdk:"template"`
+	ID                        types.String             `tfsdk:"id"`
+	Address                   ip4AddressValue          `tfsdk:"address"`
+	ContainerID               types.Int64              `tfsdk:"container_id"`
+	Type                      types.String             `tfsdk:"type"`
+	AddressesFree             types.Int64              `tfsdk:"addresses_free"`
+	AddressesInUse            types.Int64              `tfsdk:"addresses_in_use"`
+	AllowDuplicateHost        types.String             `tfsdk:"allow_duplicate_host"`
+	CIDR                      types.String             `tfsdk:"cidr"`
+	CustomProperties          types.Map                `tfsdk:"custom_properties"`
+	DefaultDomains            types.Set                `tfsdk:"default_domains"`
+	DefaultView               types.Int64              `tfsdk:"default_view"`
+	DNSRestrictions           types.Set                `tfsdk:"dns_restrictions"`
+	Gateway                   types.String             `tfsdk:"gateway"`
+	InheritAllowDuplicateHost types.Bool               `tfsdk:"inherit_allow_duplicate_host"`
+	InheritDefaultDomains     types.Bool               `tfsdk:"inherit_default_domains"`
+	InheritDefaultView        types.Bool               `tfsdk:"inherit_default_view"`
+	InheritDNSRestrictions    types.Bool               `tfsdk:"inherit_dns_restrictions"`
+	InheritPingBeforeAssign   types.Bool               `tfsdk:"inherit_ping_before_assign"`
+	LocationCode              types.String             `tfsdk:"location_code"`
+	LocationInherited         types.Bool               `tfsdk:"location_inherited"`
+	Name                      types.String             `tfsdk:"name"`
+	PingBeforeAssign          types.String             `tfsdk:"ping_before_assign"`
+	Properties                types.String             `tfsdk:"properties"`
+	Template                  types.Int64              `tfsdk:"template"`
+	IncludeParents            types.Bool               `tfsdk:"include_parents"`
+	Parents                   []IP4NBRDataSourceParent `tfsdk:"parents"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+// IP4NBRDataSourceParent describes an object in the chain of objects that contain the address.
+type IP4NBRDataSourceParent struct {
+	ID   types.String `tfsdk:"id"`
+	Type types.String `tfsdk:"type"`
+	Name types.String `tfsdk:"name"`
+	CIDR types.String `tfsdk:"cidr"`
 }
 
 func (d *IP4NBRDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -69,6 +81,7 @@ func (d *IP4NBRDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 		MarkdownDescription: "Data source to access the attributes of an IPv4 network, IPv4 Block, or DHCPv4 Range from an IPv4 address.",
 
 		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Example identifier",
 				Computed:            true,
@@ -76,6 +89,7 @@ func (d *IP4NBRDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 			"address": schema.StringAttribute{
 				MarkdownDescription: "IP address to find the IPv4 network, IPv4 Block, or DHCPv4 Range of.",
 				Required:            true,
+				CustomType:          ip4AddressType{},
 			},
 			"container_id": schema.Int64Attribute{
 				MarkdownDescription: "The object ID of a container that contains the specified IPv4 network, block, or range.",
@@ -171,6 +185,34 @@ func (d *IP4NBRDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				MarkdownDescription: "TODO",
 				Computed:            true,
 			},
+			"include_parents": schema.BoolAttribute{
+				MarkdownDescription: "Whether to set `parents` to the chain of objects that contain the address. Defaults to `false`.",
+				Optional:            true,
+			},
+			"parents": schema.ListNestedAttribute{
+				MarkdownDescription: "The chain of objects that contain the address, from the configuration to the object that was found, such as the configuration, the IPv4 blocks, and the IPv4 network. Only set if `include_parents` is `true`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The object ID.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the object, such as `Configuration`, `IP4Block`, or `IP4Network`.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the object.",
+							Computed:            true,
+						},
+						"cidr": schema.StringAttribute{
+							MarkdownDescription: "The CIDR notation of the object. Null for objects that are not defined by a CIDR, such as the configuration.",
+							Computed:            true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -205,7 +247,7 @@ func (d *IP4NBRDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	client, diag := clientLogin(ctx, d.client, mutex)
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -213,12 +255,12 @@ func (d *IP4NBRDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	containerID := data.ContainerID.ValueInt64()
 	otype := data.Type.ValueString()
-	address := data.Address.ValueString()
+	address := data.Address.ValueIP4String()
 
 	ipRange, err := client.GetIPRangedByIP(containerID, otype, address)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to get IP4 Networks by hint", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Networks by hint", err, "")
 		return
 	}
 
@@ -230,7 +272,7 @@ func (d *IP4NBRDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	tflog.Info(ctx, fmt.Sprintf("parsing properties: %s", *ipRange.Properties))
 	networkProperties, diag := parseIP4NetworkProperties(*ipRange.Properties)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
@@ -252,16 +294,26 @@ func (d *IP4NBRDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	data.LocationInherited = networkProperties.locationInherited
 	data.CustomProperties = networkProperties.customProperties
 
-	addressesInUse, addressesFree, err := getIP4NetworkAddressUsage(*ipRange.Id, networkProperties.cidr.ValueString(), client)
+	addressesInUse, addressesFree, err := getIP4NetworkAddressUsage(client, d.client.NetworkUsage, *ipRange.Id, networkProperties.cidr.ValueString())
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Error calculating network usage", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Error calculating network usage", err, "")
 		return
 	}
 	data.AddressesInUse = types.Int64Value(addressesInUse)
 	data.AddressesFree = types.Int64Value(addressesFree)
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	if data.IncludeParents.ValueBool() {
+		parents, err := getIP4ParentChain(client, ipRange)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get the parents of "+data.ID.ValueString(), err, "")
+			return
+		}
+		data.Parents = parents
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -301,118 +353,112 @@ func parseIP4NetworkProperties(properties string) (ip4NetworkProperties, diag.Di
 	var diag diag.Diagnostics
 	cpMap := make(map[string]attr.Value)
 
-	props := strings.Split(properties, "|")
-	for x := range props {
-		if len(props[x]) > 0 {
-			prop := strings.Split(props[x], "=")[0]
-			val := strings.Split(props[x], "=")[1]
-
-			switch prop {
-			case "name":
-				networkProperties.name = types.StringValue(val)
-			case "CIDR":
-				networkProperties.cidr = types.StringValue(val)
-			case "template":
-				t, err := strconv.ParseInt(val, 10, 64)
-				if err != nil {
-					diag.AddError("error parsing template to int64", err.Error())
-					break
-				}
-				networkProperties.template = types.Int64Value(t)
-			case "gateway":
-				networkProperties.gateway = types.StringValue(val)
-			case "defaultDomains":
-				defaultDomains := strings.Split(val, ",")
-				defaultDomainsList := []attr.Value{}
-				for i := range defaultDomains {
-					dID, err := strconv.ParseInt(defaultDomains[i], 10, 64)
-					if err != nil {
-						diag.AddError("error parsing defaultDomains to int64", err.Error())
-						break
-					}
-					defaultDomainsList = append(defaultDomainsList, types.Int64Value(dID))
-				}
-				defaultDomainsSet, d := basetypes.NewSetValue(types.Int64Type, defaultDomainsList)
-				if d.HasError() {
-					diag.Append(d...)
-					break
-				}
-				networkProperties.defaultDomains = defaultDomainsSet
-			case "defaultView":
-				dv, err := strconv.ParseInt(val, 10, 64)
-				if err != nil {
-					diag.AddError("error parsing defaultView to int64", err.Error())
-					break
-				}
-				networkProperties.defaultView = types.Int64Value(dv)
-			case "dnsRestrictions":
-				dnsRestrictions := strings.Split(val, ",")
-				didList := []attr.Value{}
-				for i := range dnsRestrictions {
-					dID, err := strconv.ParseInt(dnsRestrictions[i], 10, 64)
-					if err != nil {
-						diag.AddError("error parsing dnsRestrictions to int64", err.Error())
-						break
-					}
-					didList = append(didList, types.Int64Value(dID))
-					var didSet basetypes.SetValue
-					didSet, diag = basetypes.NewSetValue(types.Int64Type, didList)
-					if diag.HasError() {
-						break
-					}
-					networkProperties.dnsRestrictions = didSet
-				}
-			case "allowDuplicateHost":
-				networkProperties.allowDuplicateHost = types.StringValue(val)
-			case "pingBeforeAssign":
-				networkProperties.pingBeforeAssign = types.StringValue(val)
-			case "inheritAllowDuplicateHost":
-				b, err := strconv.ParseBool(val)
-				if err != nil {
-					diag.AddError("error parsing inheritAllowDuplicateHost to bool", err.Error())
-					break
-				}
-				networkProperties.inheritAllowDuplicateHost = types.BoolValue(b)
-			case "inheritPingBeforeAssign":
-				b, err := strconv.ParseBool(val)
-				if err != nil {
-					diag.AddError("error parsing inheritPingBeforeAssign to bool", err.Error())
-					break
-				}
-				networkProperties.inheritAllowDuplicateHost = types.BoolValue(b)
-			case "inheritDNSRestrictions":
-				b, err := strconv.ParseBool(val)
-				if err != nil {
-					diag.AddError("error parsing inheritDNSRestrictions to bool", err.Error())
-					break
-				}
-				networkProperties.inheritDNSRestrictions = types.BoolValue(b)
-			case "inheritDefaultDomains":
-				b, err := strconv.ParseBool(val)
+	for prop, val := range parseProperties(properties) {
+		switch prop {
+		case "name":
+			networkProperties.name = types.StringValue(val)
+		case "CIDR":
+			networkProperties.cidr = types.StringValue(val)
+		case "template":
+			t, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				diag.AddError("error parsing template to int64", err.Error())
+				break
+			}
+			networkProperties.template = types.Int64Value(t)
+		case "gateway":
+			networkProperties.gateway = types.StringValue(val)
+		case "defaultDomains":
+			defaultDomains := strings.Split(val, ",")
+			defaultDomainsList := []attr.Value{}
+			for i := range defaultDomains {
+				dID, err := strconv.ParseInt(defaultDomains[i], 10, 64)
 				if err != nil {
-					diag.AddError("error parsing inheritDefaultDomains to bool", err.Error())
+					diag.AddError("error parsing defaultDomains to int64", err.Error())
 					break
 				}
-				networkProperties.inheritDefaultDomains = types.BoolValue(b)
-			case "inheritDefaultView":
-				b, err := strconv.ParseBool(val)
+				defaultDomainsList = append(defaultDomainsList, types.Int64Value(dID))
+			}
+			defaultDomainsSet, d := basetypes.NewSetValue(types.Int64Type, defaultDomainsList)
+			if d.HasError() {
+				diag.Append(d...)
+				break
+			}
+			networkProperties.defaultDomains = defaultDomainsSet
+		case "defaultView":
+			dv, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				diag.AddError("error parsing defaultView to int64", err.Error())
+				break
+			}
+			networkProperties.defaultView = types.Int64Value(dv)
+		case "dnsRestrictions":
+			dnsRestrictions := strings.Split(val, ",")
+			didList := []attr.Value{}
+			for i := range dnsRestrictions {
+				dID, err := strconv.ParseInt(dnsRestrictions[i], 10, 64)
 				if err != nil {
-					diag.AddError("error parsing inheritDefaultView to bool", err.Error())
+					diag.AddError("error parsing dnsRestrictions to int64", err.Error())
 					break
 				}
-				networkProperties.inheritDefaultView = types.BoolValue(b)
-			case "locationCode":
-				networkProperties.locationCode = types.StringValue(val)
-			case "locationInherited":
-				b, err := strconv.ParseBool(val)
-				if err != nil {
-					diag.AddError("error parsing locationInherited to bool", err.Error())
+				didList = append(didList, types.Int64Value(dID))
+				var didSet basetypes.SetValue
+				didSet, diag = basetypes.NewSetValue(types.Int64Type, didList)
+				if diag.HasError() {
 					break
 				}
-				networkProperties.locationInherited = types.BoolValue(b)
-			default:
-				cpMap[prop] = types.StringValue(val)
+				networkProperties.dnsRestrictions = didSet
+			}
+		case "allowDuplicateHost":
+			networkProperties.allowDuplicateHost = types.StringValue(val)
+		case "pingBeforeAssign":
+			networkProperties.pingBeforeAssign = types.StringValue(val)
+		case "inheritAllowDuplicateHost":
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				diag.AddError("error parsing inheritAllowDuplicateHost to bool", err.Error())
+				break
+			}
+			networkProperties.inheritAllowDuplicateHost = types.BoolValue(b)
+		case "inheritPingBeforeAssign":
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				diag.AddError("error parsing inheritPingBeforeAssign to bool", err.Error())
+				break
+			}
+			networkProperties.inheritAllowDuplicateHost = types.BoolValue(b)
+		case "inheritDNSRestrictions":
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				diag.AddError("error parsing inheritDNSRestrictions to bool", err.Error())
+				break
 			}
+			networkProperties.inheritDNSRestrictions = types.BoolValue(b)
+		case "inheritDefaultDomains":
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				diag.AddError("error parsing inheritDefaultDomains to bool", err.Error())
+				break
+			}
+			networkProperties.inheritDefaultDomains = types.BoolValue(b)
+		case "inheritDefaultView":
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				diag.AddError("error parsing inheritDefaultView to bool", err.Error())
+				break
+			}
+			networkProperties.inheritDefaultView = types.BoolValue(b)
+		case "locationCode":
+			networkProperties.locationCode = types.StringValue(val)
+		case "locationInherited":
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				diag.AddError("error parsing locationInherited to bool", err.Error())
+				break
+			}
+			networkProperties.locationInherited = types.BoolValue(b)
+		default:
+			cpMap[prop] = types.StringValue(val)
 		}
 	}
 
@@ -425,22 +471,38 @@ func parseIP4NetworkProperties(properties string) (ip4NetworkProperties, diag.Di
 	return networkProperties, diag
 }
 
-func getIP4NetworkAddressUsage(id int64, cidr string, client gobam.ProteusAPI) (int64, int64, error) {
-
-	netmask, err := strconv.ParseFloat(strings.Split(cidr, "/")[1], 64)
-	if err != nil {
-		mutex.Unlock()
-		return 0, 0, fmt.Errorf("error parsing netmask from cidr string")
+// getIP4ParentChain returns the objects from the configuration down to and
+// including entity, which contains an address.
+func getIP4ParentChain(client gobam.ProteusAPI, entity *gobam.APIEntity) ([]IP4NBRDataSourceParent, error) {
+	chain := []*gobam.APIEntity{entity}
+	for current := entity; len(chain) < entityMaxDepth && (current.Type == nil || *current.Type != "Configuration"); {
+		parent, err := client.GetParent(*current.Id)
+		if err != nil {
+			return nil, err
+		}
+		if parent.Id == nil || *parent.Id == 0 {
+			break
+		}
+		chain = append(chain, parent)
+		current = parent
 	}
-	addressCount := int(math.Pow(2, (32 - netmask)))
 
-	resp, err := client.GetEntities(id, "IP4Address", 0, addressCount)
-	if err != nil {
-		return 0, 0, err
-	}
+	parents := make([]IP4NBRDataSourceParent, 0, len(chain))
+	for i := len(chain) - 1; i >= 0; i-- {
+		cidr := types.StringNull()
+		if chain[i].Properties != nil {
+			if c, ok := parseProperties(*chain[i].Properties)["CIDR"]; ok {
+				cidr = types.StringValue(c)
+			}
+		}
 
-	addressesInUse := int64(len(resp.Item))
-	addressesFree := int64(addressCount) - addressesInUse
+		parents = append(parents, IP4NBRDataSourceParent{
+			ID:   types.StringValue(strconv.FormatInt(*chain[i].Id, 10)),
+			Type: types.StringPointerValue(chain[i].Type),
+			Name: types.StringPointerValue(chain[i].Name),
+			CIDR: cidr,
+		})
+	}
 
-	return addressesInUse, addressesFree, nil
+	return parents, nil
 }
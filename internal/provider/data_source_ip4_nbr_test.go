@@ -1,9 +1,11 @@
 package provider
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
 )
 
 func TestAccIP4NBRDataSource(t *testing.T) {
@@ -16,6 +18,7 @@ func TestAccIP4NBRDataSource(t *testing.T) {
 				Config: testAccIP4NBRDataSourceConfig,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrWith("data.bluecat_ip4_nbr.test", "id", validateObjectID),
+					resource.TestCheckResourceAttr("data.bluecat_ip4_nbr.test", "parents.0.type", "Configuration"),
 				),
 			},
 		},
@@ -31,5 +34,36 @@ data "bluecat_ip4_nbr" "test" {
 	container_id = data.bluecat_entity.config.id
 	address      = var.ip4_address
 	type         = "IP4Network"
+
+	include_parents = true
   }
 `
+
+func TestGetIP4ParentChain(t *testing.T) {
+	entity := func(id int64, objectType, properties string) *gobam.APIEntity {
+		return &gobam.APIEntity{Id: &id, Type: &objectType, Properties: &properties}
+	}
+	network := entity(4, "IP4Network", "CIDR=10.0.1.0/24|")
+	client := &parentChainClient{parents: map[int64]*gobam.APIEntity{
+		4: entity(3, "IP4Block", "CIDR=10.0.0.0/16|"),
+		3: entity(2, "IP4Block", "CIDR=10.0.0.0/8|"),
+		2: entity(1, "Configuration", "description=test|"),
+	}}
+
+	parents, err := getIP4ParentChain(client, network)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var chain []string
+	for _, p := range parents {
+		chain = append(chain, p.Type.ValueString()+":"+p.CIDR.ValueString())
+	}
+	want := "Configuration: IP4Block:10.0.0.0/8 IP4Block:10.0.0.0/16 IP4Network:10.0.1.0/24"
+	if got := strings.Join(chain, " "); got != want {
+		t.Errorf("expected the chain %q, got %q", want, got)
+	}
+	if !parents[0].CIDR.IsNull() {
+		t.Errorf("expected a null cidr for the configuration, got %s", parents[0].CIDR)
+	}
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -32,7 +38,7 @@ type IP4NetworkDataSourceModel struct {
 	Properties types.String `tfsdk:"properties"`
 
 	// These are exposed via the entity properties field for objects of type IP4Network
-	CIDR                      types.String `tfsdk:"cidr"`
+	CIDR                      ip4CIDRValue `tfsdk:"cidr"`
 	Template                  types.Int64  `tfsdk:"template"`
 	Gateway                   types.String `tfsdk:"gateway"`
 	DefaultDomains            types.Set    `tfsdk:"default_domains"`
@@ -46,15 +52,22 @@ type IP4NetworkDataSourceModel struct {
 	InheritDefaultDomains     types.Bool   `tfsdk:"inherit_default_domains"`
 	InheritDefaultView        types.Bool   `tfsdk:"inherit_default_view"`
 	LocationCode              types.String `tfsdk:"location_code"`
+	Comments                  types.String `tfsdk:"comments"`
 	LocationInherited         types.Bool   `tfsdk:"location_inherited"`
 	SharedNetwork             types.String `tfsdk:"shared_network"`
+	DynamicUpdate             types.Bool   `tfsdk:"dynamic_update"`
 
 	// these are user defined fields that are not built-in
 	UserDefinedFields types.Map `tfsdk:"user_defined_fields"`
 
 	// these exist only for the data source to find the network
-	ContainerID types.Int64  `tfsdk:"container_id"`
-	Hint        types.String `tfsdk:"hint"`
+	ContainerID        types.Int64  `tfsdk:"container_id"`
+	Hint               types.String `tfsdk:"hint"`
+	ManagedByTerraform types.Bool   `tfsdk:"managed_by_terraform"`
+	MaxSearchResults   types.Int64  `tfsdk:"max_search_results"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
 }
 
 func (d *IP4NetworkDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -64,16 +77,32 @@ func (d *IP4NetworkDataSource) Metadata(ctx context.Context, req datasource.Meta
 func (d *IP4NetworkDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Data source to access the attributes of an IPv4 network from a hint based search.",
+		MarkdownDescription: "Data source to access the attributes of an IPv4 network by its exact CIDR or from a hint based search.",
 
 		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
 			"container_id": schema.Int64Attribute{
 				MarkdownDescription: "The object ID of a container that contains the specified IPv4 network.",
 				Required:            true,
 			},
 			"hint": schema.StringAttribute{
-				MarkdownDescription: "Hint to find the IP4Network",
-				Required:            true,
+				MarkdownDescription: "Hint to find the IP4Network. The hint must match exactly one network, so a hint that is the address of networks of different sizes cannot be used. Prefer `cidr` to look up a network by its CIDR. Exactly one of `hint` or `cidr` must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("cidr")),
+				},
+			},
+			"managed_by_terraform": schema.BoolAttribute{
+				MarkdownDescription: "If the network is marked as managed by Terraform with the user-defined field configured by the provider `managed_udf` argument. If set, only networks matching the hint with this value are considered.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"max_search_results": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of networks returned by the hint search to look through. Defaults to `10000`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The ID assigned to the IP4Network.",
@@ -92,8 +121,10 @@ func (d *IP4NetworkDataSource) Schema(ctx context.Context, req datasource.Schema
 				Computed:            true,
 			},
 			"cidr": schema.StringAttribute{
-				MarkdownDescription: "The CIDR address of the IP4Network.",
+				MarkdownDescription: "The CIDR address of the IP4Network. If set, the network with exactly this CIDR is looked up, so `10.0.0.0/24` does not match `10.0.0.0/25`.",
+				Optional:            true,
 				Computed:            true,
+				CustomType:          ip4CIDRType{},
 			},
 			"template": schema.Int64Attribute{
 				MarkdownDescription: "The ID of the linked template",
@@ -145,6 +176,10 @@ func (d *IP4NetworkDataSource) Schema(ctx context.Context, req datasource.Schema
 				MarkdownDescription: "The default DNS View is inherited.",
 				Computed:            true,
 			},
+			"comments": schema.StringAttribute{
+				MarkdownDescription: "Comments about the network.",
+				Computed:            true,
+			},
 			"location_code": schema.StringAttribute{
 				MarkdownDescription: "The location code of the network.",
 				Computed:            true,
@@ -157,6 +192,10 @@ func (d *IP4NetworkDataSource) Schema(ctx context.Context, req datasource.Schema
 				MarkdownDescription: "The name of the shared network tag associated with the IP4 Network.",
 				Computed:            true,
 			},
+			"dynamic_update": schema.BoolAttribute{
+				MarkdownDescription: "Whether DHCP clients in the IP4 Network have their DNS records dynamically updated.",
+				Computed:            true,
+			},
 			"user_defined_fields": schema.MapAttribute{
 				MarkdownDescription: "A map of all user-definied fields associated with the entity.",
 				Computed:            true,
@@ -196,7 +235,7 @@ func (d *IP4NetworkDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	client, diag := clientLogin(ctx, d.client, mutex)
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -204,49 +243,87 @@ func (d *IP4NetworkDataSource) Read(ctx context.Context, req datasource.ReadRequ
 
 	containerID := data.ContainerID.ValueInt64()
 	hint := data.Hint.ValueString()
-	options := "hint=" + hint
-
-	hintResp, err := client.GetIP4NetworksByHint(containerID, 0, 1, options)
-	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to get IP4 Networks by hint", err.Error())
-		return
+	cidr := data.CIDR.ValueIP4String()
+	if cidr != "" {
+		// the network address matches every network that starts with it,
+		// which are then filtered on the exact CIDR
+		hint = strings.Split(cidr, "/")[0]
 	}
+	options := "hint=" + hint
 
-	if len(hintResp.Item) > 1 || len(hintResp.Item) == 0 {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Network lookup error",
-			fmt.Sprintf("Hint %s returned %d networks but the data source only supports 1", hint, len(hintResp.Item)),
+	if !data.ManagedByTerraform.IsNull() && d.client.ManagedUDF == "" {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddAttributeError(
+			path.Root("managed_by_terraform"),
+			"Provider managed_udf is not configured",
+			"The managed_by_terraform filter requires the managed_udf argument or BLUECAT_MANAGED_UDF environment variable to be set on the provider.",
 		)
 		return
 	}
 
-	data.ID = types.StringValue(strconv.FormatInt(*hintResp.Item[0].Id, 10))
+	maxResults := hintMaxSearchResults
+	if !data.MaxSearchResults.IsNull() {
+		maxResults = int(data.MaxSearchResults.ValueInt64())
+	}
 
-	// GetIP4NetworksByHint doesn't seem to return all properties so use the ID returned by it to call GetEntityById
-	entity, err := client.GetEntityById(*hintResp.Item[0].Id)
+	// stop at a second match as the data source only supports 1
+	entities, err := searchByHint(func(start int, count int) (*gobam.APIEntityArray, error) {
+		return client.GetIP4NetworksByHint(containerID, start, count, options)
+	}, maxResults, 2, func(n *gobam.APIEntity) (*gobam.APIEntity, error) {
+		// GetIP4NetworksByHint doesn't seem to return all properties so use the IDs returned by it to call GetEntityById
+		entity, err := client.GetEntityById(*n.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get IP4 Network via Entity ID: %w", err)
+		}
+
+		if cidr != "" && (entity.Properties == nil || !ip4CIDREqual(parseProperties(*entity.Properties)["CIDR"], cidr)) {
+			return nil, nil
+		}
+
+		if !data.ManagedByTerraform.IsNull() && data.ManagedByTerraform.ValueBool() != isManaged(entity, d.client) {
+			return nil, nil
+		}
+
+		return entity, nil
+	})
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Networks by hint", err, "")
+		return
+	}
+
+	if len(entities) != 1 {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		if cidr != "" {
+			resp.Diagnostics.AddError(
+				"Network lookup error",
+				fmt.Sprintf("CIDR %s matched %d networks but the data source only supports 1", cidr, len(entities)),
+			)
+			return
+		}
 		resp.Diagnostics.AddError(
-			"Failed to get IP4 Network via Entity ID",
-			err.Error(),
+			"Network lookup error",
+			fmt.Sprintf("Hint %s returned %d networks but the data source only supports 1. Use cidr to look up a network by its exact CIDR.", hint, len(entities)),
 		)
 		return
 	}
 
+	entity := entities[0]
+	data.ID = types.StringValue(strconv.FormatInt(*entity.Id, 10))
+	data.ManagedByTerraform = types.BoolValue(isManaged(entity, d.client))
+
 	data.Name = types.StringPointerValue(entity.Name)
 	data.Properties = types.StringPointerValue(entity.Properties)
 	data.Type = types.StringPointerValue(entity.Type)
 
 	networkProperties, diag := flattenIP4NetworkProperties(entity)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	data.CIDR = networkProperties.CIDR
+	data.CIDR = newIP4CIDRFromString(networkProperties.CIDR)
 	data.Template = networkProperties.Template
 	data.Gateway = networkProperties.Gateway
 	data.DefaultDomains = networkProperties.DefaultDomains
@@ -260,11 +337,13 @@ func (d *IP4NetworkDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	data.InheritDefaultDomains = networkProperties.InheritDefaultDomains
 	data.InheritDefaultView = networkProperties.InheritDefaultView
 	data.LocationCode = networkProperties.LocationCode
+	data.Comments = networkProperties.Comments
 	data.LocationInherited = networkProperties.LocationInherited
 	data.SharedNetwork = networkProperties.SharedNetwork
+	data.DynamicUpdate = networkProperties.DynamicUpdate
 	data.UserDefinedFields = networkProperties.UserDefinedFields
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &IP4NetworkTemplateDataSource{}
+
+func NewIP4NetworkTemplateDataSource() datasource.DataSource {
+	return &IP4NetworkTemplateDataSource{}
+}
+
+// IP4NetworkTemplateDataSource defines the data source implementation.
+type IP4NetworkTemplateDataSource struct {
+	client *loginClient
+}
+
+// IP4NetworkTemplateDataSourceModel describes the data source data model.
+type IP4NetworkTemplateDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	ConfigurationID types.Int64  `tfsdk:"configuration_id"`
+	Name            types.String `tfsdk:"name"`
+	Comments        types.String `tfsdk:"comments"`
+	Properties      types.String `tfsdk:"properties"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+func (d *IP4NetworkTemplateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip4_network_template"
+}
+
+func (d *IP4NetworkTemplateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to find an IPv4 network template by name, such as to set the `template` argument of `bluecat_ip4_network`.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "IPv4 network template identifier.",
+				Computed:            true,
+			},
+			"configuration_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the Configuration that holds the template." + defaultConfigurationDescription,
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the template.",
+				Required:            true,
+			},
+			"comments": schema.StringAttribute{
+				MarkdownDescription: "Comments about the template.",
+				Computed:            true,
+			},
+			"properties": schema.StringAttribute{
+				MarkdownDescription: "The properties of the template as returned by the API (pipe delimited).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *IP4NetworkTemplateDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *IP4NetworkTemplateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data IP4NetworkTemplateDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	configID, diag := d.client.configurationID(client, data.ConfigurationID)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	data.ConfigurationID = types.Int64Value(configID)
+
+	template, err := client.GetEntityByName(configID, data.Name.ValueString(), "IP4NetworkTemplate")
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Network Template by name", err, "")
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	if template.Id == nil || *template.Id == 0 {
+		resp.Diagnostics.AddError("IP4 Network Template not found", fmt.Sprintf("No IPv4 network template named %q was found in configuration %d", data.Name.ValueString(), configID))
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(*template.Id, 10))
+	data.Properties = types.StringPointerValue(template.Properties)
+
+	props := parseProperties(data.Properties.ValueString())
+	data.Comments = types.StringValue(props["comments"])
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
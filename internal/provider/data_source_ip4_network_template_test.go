@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIP4NetworkTemplateDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccIP4NetworkTemplateDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.bluecat_ip4_network_template.test", "id", validateObjectID),
+					resource.TestCheckResourceAttr("data.bluecat_ip4_network_template.test", "name", "Terraform Acceptance Test"),
+					resource.TestCheckResourceAttrSet("data.bluecat_ip4_network_template.test", "configuration_id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccIP4NetworkTemplateDataSourceConfig = `
+data "bluecat_ip4_network_template" "test" {
+	name = "Terraform Acceptance Test"
+}
+`
@@ -32,3 +32,24 @@ data "bluecat_ip4_network" "test" {
 	hint         = var.ip4_address
   }
 `
+
+func TestIP4CIDREqual(t *testing.T) {
+	tests := map[string]struct {
+		a, b     string
+		expected bool
+	}{
+		"same":             {a: "10.0.0.0/24", b: "10.0.0.0/24", expected: true},
+		"host bits":        {a: "10.0.0.0/24", b: "10.0.0.5/24", expected: true},
+		"different prefix": {a: "10.0.0.0/24", b: "10.0.0.0/25", expected: false},
+		"different range":  {a: "10.0.0.0/24", b: "10.0.1.0/24", expected: false},
+		"invalid":          {a: "", b: "10.0.0.0/24", expected: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := ip4CIDREqual(tc.a, tc.b); got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
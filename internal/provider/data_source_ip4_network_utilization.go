@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &IP4NetworkUtilizationDataSource{}
+
+func NewIP4NetworkUtilizationDataSource() datasource.DataSource {
+	return &IP4NetworkUtilizationDataSource{}
+}
+
+// IP4NetworkUtilizationDataSource defines the data source implementation.
+type IP4NetworkUtilizationDataSource struct {
+	client *loginClient
+}
+
+// IP4NetworkUtilizationDataSourceModel describes the data source data model.
+type IP4NetworkUtilizationDataSourceModel struct {
+	ID              types.String  `tfsdk:"id"`
+	NetworkID       types.Int64   `tfsdk:"network_id"`
+	Threshold       types.Float64 `tfsdk:"threshold"`
+	CIDR            types.String  `tfsdk:"cidr"`
+	Size            types.Int64   `tfsdk:"size"`
+	AddressesInUse  types.Int64   `tfsdk:"addresses_in_use"`
+	AddressesFree   types.Int64   `tfsdk:"addresses_free"`
+	PercentUtilized types.Float64 `tfsdk:"percent_utilized"`
+	OverThreshold   types.Bool    `tfsdk:"over_threshold"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+func (d *IP4NetworkUtilizationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip4_network_utilization"
+}
+
+func (d *IP4NetworkUtilizationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to get how many addresses of an IPv4 network are in use. The count of addresses in use reported by the server is used if it is available, so the addresses of the network are only listed on servers that do not report it.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the data source, which is the `network_id`.",
+				Computed:            true,
+			},
+			"network_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the IPv4 network.",
+				Required:            true,
+			},
+			"threshold": schema.Float64Attribute{
+				MarkdownDescription: "The percentage of addresses in use, from `0` to `100`, above which `over_threshold` is `true`.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.Between(0, 100),
+				},
+			},
+			"cidr": schema.StringAttribute{
+				MarkdownDescription: "The CIDR address of the IPv4 network.",
+				Computed:            true,
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "The number of addresses in the IPv4 network.",
+				Computed:            true,
+			},
+			"addresses_in_use": schema.Int64Attribute{
+				MarkdownDescription: "The number of addresses allocated/in use on the network.",
+				Computed:            true,
+			},
+			"addresses_free": schema.Int64Attribute{
+				MarkdownDescription: "The number of addresses unallocated/free on the network.",
+				Computed:            true,
+			},
+			"percent_utilized": schema.Float64Attribute{
+				MarkdownDescription: "The percentage of the addresses of the network that are in use, from `0` to `100`.",
+				Computed:            true,
+			},
+			"over_threshold": schema.BoolAttribute{
+				MarkdownDescription: "Whether `percent_utilized` is greater than `threshold`. Null if `threshold` is not set.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *IP4NetworkUtilizationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *IP4NetworkUtilizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data IP4NetworkUtilizationDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	networkID := data.NetworkID.ValueInt64()
+
+	entity, err := client.GetEntityById(networkID)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Network by Id", err, "")
+		return
+	}
+
+	if entity.Id == nil || *entity.Id == 0 || entity.Type == nil || *entity.Type != "IP4Network" || entity.Properties == nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("IP4 Network not found", fmt.Sprintf("No IPv4 network with ID %d was found", networkID))
+		return
+	}
+
+	cidr := parseProperties(*entity.Properties)["CIDR"]
+	size, err := cidrToSize(cidr)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to calculate the size of the IP4 Network", err, "")
+		return
+	}
+
+	inUse, free, err := getIP4NetworkAddressUsage(client, d.client.NetworkUsage, networkID, cidr)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Error calculating network usage", err, "")
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	data.ID = types.StringValue(strconv.FormatInt(networkID, 10))
+	data.CIDR = types.StringValue(cidr)
+	data.Size = types.Int64Value(size)
+	data.AddressesInUse = types.Int64Value(inUse)
+	data.AddressesFree = types.Int64Value(free)
+
+	percent := ip4PercentUtilized(inUse, size)
+	data.PercentUtilized = types.Float64Value(percent)
+	data.OverThreshold = types.BoolNull()
+	if !data.Threshold.IsNull() {
+		data.OverThreshold = types.BoolValue(percent > data.Threshold.ValueFloat64())
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ip4PercentUtilized returns the percentage of size that inUse is.
+func ip4PercentUtilized(inUse, size int64) float64 {
+	if size <= 0 {
+		return 0
+	}
+
+	return float64(inUse) * 100 / float64(size)
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIP4NetworkUtilizationDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccIP4NetworkUtilizationDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.bluecat_ip4_network_utilization.test", "id", validateObjectID),
+					resource.TestCheckResourceAttrSet("data.bluecat_ip4_network_utilization.test", "percent_utilized"),
+					resource.TestCheckResourceAttrSet("data.bluecat_ip4_network_utilization.test", "over_threshold"),
+				),
+			},
+		},
+	})
+}
+
+const testAccIP4NetworkUtilizationDataSourceConfig = testAccIP4NetworkDataSourceConfig + `
+data "bluecat_ip4_network_utilization" "test" {
+	network_id = data.bluecat_ip4_network.test.id
+	threshold  = 80
+  }
+`
+
+func TestIP4PercentUtilized(t *testing.T) {
+	if got := ip4PercentUtilized(64, 256); got != 25 {
+		t.Errorf("expected 25, got %v", got)
+	}
+	if got := ip4PercentUtilized(0, 0); got != 0 {
+		t.Errorf("expected 0 for an empty network, got %v", got)
+	}
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &LocationDataSource{}
+
+func NewLocationDataSource() datasource.DataSource {
+	return &LocationDataSource{}
+}
+
+// LocationDataSource defines the data source implementation.
+type LocationDataSource struct {
+	client *loginClient
+}
+
+// LocationDataSourceModel describes the data source data model.
+type LocationDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Code        types.String `tfsdk:"code"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Latitude    types.String `tfsdk:"latitude"`
+	Longitude   types.String `tfsdk:"longitude"`
+	ParentID    types.Int64  `tfsdk:"parent_id"`
+	Properties  types.String `tfsdk:"properties"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+func (d *LocationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_location"
+}
+
+func (d *LocationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to find a location by its code or name.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Location identifier.",
+				Computed:            true,
+			},
+			"code": schema.StringAttribute{
+				MarkdownDescription: "The hierarchical code of the location, such as `US DTW`. Exactly one of `code` or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(locationCodeRegexp, locationCodeRegexpMessage),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the location. An error is returned if more than one location has the name.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the location.",
+				Computed:            true,
+			},
+			"latitude": schema.StringAttribute{
+				MarkdownDescription: "The latitude of the location.",
+				Computed:            true,
+			},
+			"longitude": schema.StringAttribute{
+				MarkdownDescription: "The longitude of the location.",
+				Computed:            true,
+			},
+			"parent_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the location that contains this location.",
+				Computed:            true,
+			},
+			"properties": schema.StringAttribute{
+				MarkdownDescription: "The properties of the location as returned by the API (pipe delimited).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *LocationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *LocationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LocationDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	var location *gobam.APIEntity
+	var search string
+	var err error
+	if !data.Code.IsNull() {
+		search = "code " + data.Code.ValueString()
+		location, err = getLocationByCode(client, data.Code.ValueString())
+	} else {
+		search = "name " + data.Name.ValueString()
+		// locations are nested below each other so search all of them
+		location, err = findEntityByName(client, 0, data.Name.ValueString(), []string{"Location"}, true)
+	}
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get location by "+search, err, "")
+		return
+	}
+
+	if location == nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Location not found", fmt.Sprintf("No location with %s was found", search))
+		return
+	}
+
+	parent, err := client.GetParent(*location.Id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get parent location", err, "")
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(*location.Id, 10))
+	data.Name = types.StringPointerValue(location.Name)
+	data.Properties = types.StringPointerValue(location.Properties)
+	data.ParentID = types.Int64PointerValue(parent.Id)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	props := parseProperties(data.Properties.ValueString())
+	data.Code = types.StringValue(props["code"])
+	data.Description = types.StringValue(props["description"])
+	data.Latitude = types.StringValue(props["latitude"])
+	data.Longitude = types.StringValue(props["longitude"])
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccLocationDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccLocationDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.bluecat_location.test", "id", validateObjectID),
+					resource.TestCheckResourceAttr("data.bluecat_location.test", "code", "US DTW"),
+					resource.TestCheckResourceAttrSet("data.bluecat_location.test", "name"),
+				),
+			},
+		},
+	})
+}
+
+const testAccLocationDataSourceConfig = `
+data "bluecat_location" "test" {
+	code = "US DTW"
+}
+`
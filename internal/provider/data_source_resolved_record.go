@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ResolvedRecordDataSource{}
+
+func NewResolvedRecordDataSource() datasource.DataSource {
+	return &ResolvedRecordDataSource{}
+}
+
+// ResolvedRecordDataSource defines the data source implementation.
+type ResolvedRecordDataSource struct {
+	client *loginClient
+}
+
+// ResolvedRecordDataSourceModel describes the data source data model.
+type ResolvedRecordDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	MaxHops      types.Int64  `tfsdk:"max_hops"`
+	Chain        types.List   `tfsdk:"chain"`
+	Hops         types.Int64  `tfsdk:"hops"`
+	AbsoluteName types.String `tfsdk:"absolute_name"`
+	Addresses    types.Set    `tfsdk:"addresses"`
+	AddressIDs   types.Set    `tfsdk:"address_ids"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+func (d *ResolvedRecordDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resolved_record"
+}
+
+func (d *ResolvedRecordDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to follow a chain of alias (CNAME) records in BlueCat Address Manager to the host record it terminates at.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the host record the chain terminates at.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The absolute name/fqdn of the alias or host record to resolve.",
+				Required:            true,
+			},
+			"max_hops": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of alias records to follow. Defaults to `10`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"chain": schema.ListAttribute{
+				MarkdownDescription: "The absolute names of the records that were followed, in order, starting with `name` and ending with the host record.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"hops": schema.Int64Attribute{
+				MarkdownDescription: "The number of alias records that were followed.",
+				Computed:            true,
+			},
+			"absolute_name": schema.StringAttribute{
+				MarkdownDescription: "The absolute name/fqdn of the host record the chain terminates at.",
+				Computed:            true,
+			},
+			"addresses": schema.SetAttribute{
+				MarkdownDescription: "A set of all addresses associated with the host record the chain terminates at.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"address_ids": schema.SetAttribute{
+				MarkdownDescription: "A set of all address ids associated with the host record the chain terminates at.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+		},
+	}
+}
+
+func (d *ResolvedRecordDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ResolvedRecordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ResolvedRecordDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxHops := int64(10)
+	if !data.MaxHops.IsNull() {
+		maxHops = data.MaxHops.ValueInt64()
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	name := data.Name.ValueString()
+	chain := []string{name}
+	visited := map[string]bool{strings.ToLower(name): true}
+
+	var hostRecord *gobam.APIEntity
+	for {
+		var err error
+		hostRecord, err = getRecordByAbsoluteName(client.GetHostRecordsByHint, name)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get Host Records by hint", err, "")
+			return
+		}
+
+		if hostRecord != nil {
+			break
+		}
+
+		alias, err := getRecordByAbsoluteName(client.GetAliasesByHint, name)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get Alias Records by hint", err, "")
+			return
+		}
+
+		if alias == nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.AddError(
+				"Record not found",
+				fmt.Sprintf("No host record or alias record named %s was found while resolving %s. Chain followed: %s", name, data.Name.ValueString(), strings.Join(chain, " -> ")),
+			)
+			return
+		}
+
+		if int64(len(chain)) > maxHops {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.AddError(
+				"Too many alias records",
+				fmt.Sprintf("Resolving %s exceeded max_hops of %d. Chain followed: %s", data.Name.ValueString(), maxHops, strings.Join(chain, " -> ")),
+			)
+			return
+		}
+
+		name = parseProperties(*alias.Properties)["linkedRecordName"]
+		if visited[strings.ToLower(name)] {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.AddError(
+				"Alias record loop",
+				fmt.Sprintf("Resolving %s found a loop at %s. Chain followed: %s", data.Name.ValueString(), name, strings.Join(chain, " -> ")),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Alias %s links to %s", chain[len(chain)-1], name))
+		visited[strings.ToLower(name)] = true
+		chain = append(chain, name)
+	}
+
+	hostRecordProperties, diag := flattenHostRecordProperties(hostRecord)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	chainList, diag := types.ListValueFrom(ctx, types.StringType, chain)
+	resp.Diagnostics.Append(diag...)
+
+	data.ID = types.StringValue(strconv.FormatInt(*hostRecord.Id, 10))
+	data.Chain = chainList
+	data.Hops = types.Int64Value(int64(len(chain) - 1))
+	data.AbsoluteName = hostRecordProperties.AbsoluteName
+	data.Addresses = hostRecordProperties.Addresses
+	data.AddressIDs = hostRecordProperties.AddressIDs
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// getRecordByAbsoluteName uses a ByHint API call to find the single resource record with
+// an absoluteName of name. A nil entity is returned if there is no match.
+func getRecordByAbsoluteName(byHint func(start int, count int, options string) (*gobam.APIEntityArray, error), name string) (*gobam.APIEntity, error) {
+	options := fmt.Sprintf("hint=^%s$|retrieveFields=true", name)
+
+	records, err := byHint(0, 10, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *gobam.APIEntity
+	for _, record := range records.Item {
+		if record.Properties == nil {
+			continue
+		}
+
+		if strings.EqualFold(parseProperties(*record.Properties)["absoluteName"], name) {
+			if match != nil {
+				return nil, fmt.Errorf("more than one record named %s was found", name)
+			}
+			match = record
+		}
+	}
+
+	return match, nil
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResolvedRecordDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccResolvedRecordDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.bluecat_resolved_record.test", "id", validateObjectID),
+					resource.TestCheckResourceAttrSet("data.bluecat_resolved_record.test", "absolute_name"),
+				),
+			},
+		},
+	})
+}
+
+const testAccResolvedRecordDataSourceConfig = `
+variable "alias_absolute_name" {
+	type = string
+}
+
+data "bluecat_resolved_record" "test" {
+	name = var.alias_absolute_name
+}
+`
@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// zoneRecordTypes are the object types counted in the record_counts of the
+// bluecat_zone data source.
+var zoneRecordTypes = []string{
+	"HostRecord",
+	"AliasRecord",
+	"MXRecord",
+	"TXTRecord",
+	"SRVRecord",
+	"HINFORecord",
+	"NAPTRRecord",
+	"GenericRecord",
+	"ExternalHostRecord",
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ZoneDataSource{}
+
+func NewZoneDataSource() datasource.DataSource {
+	return &ZoneDataSource{}
+}
+
+// ZoneDataSource defines the data source implementation.
+type ZoneDataSource struct {
+	client *loginClient
+}
+
+// ZoneDataSourceModel describes the data source data model.
+type ZoneDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	ViewID          types.Int64  `tfsdk:"view_id"`
+	FQDN            types.String `tfsdk:"fqdn"`
+	Name            types.String `tfsdk:"name"`
+	AbsoluteName    types.String `tfsdk:"absolute_name"`
+	Deployable      types.Bool   `tfsdk:"deployable"`
+	DeploymentRoles types.List   `tfsdk:"deployment_roles"`
+	RecordCounts    types.Map    `tfsdk:"record_counts"`
+	RecordCount     types.Int64  `tfsdk:"record_count"`
+	SubzoneCount    types.Int64  `tfsdk:"subzone_count"`
+	Properties      types.String `tfsdk:"properties"`
+
+	// this overrides the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+}
+
+// ZoneDataSourceDeploymentRole describes a deployment role of a zone.
+type ZoneDataSourceDeploymentRole struct {
+	ID                types.Int64  `tfsdk:"id"`
+	Type              types.String `tfsdk:"type"`
+	Service           types.String `tfsdk:"service"`
+	ServerInterfaceID types.Int64  `tfsdk:"server_interface_id"`
+}
+
+// zoneDataSourceDeploymentRoleAttributeTypes are the attribute types of a
+// deployment role of a zone.
+var zoneDataSourceDeploymentRoleAttributeTypes = map[string]attr.Type{
+	"id":                  types.Int64Type,
+	"type":                types.StringType,
+	"service":             types.StringType,
+	"server_interface_id": types.Int64Type,
+}
+
+func (d *ZoneDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone"
+}
+
+func (d *ZoneDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source to find a DNS zone by its fully qualified domain name within a view, along with its deployment roles and how many records it holds.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileDataSourceSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The object ID of the zone.",
+				Computed:            true,
+			},
+			"view_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the view that contains the zone.",
+				Required:            true,
+			},
+			"fqdn": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified domain name of the zone, such as `example.com`.",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the zone, which is the first label of `fqdn`.",
+				Computed:            true,
+			},
+			"absolute_name": schema.StringAttribute{
+				MarkdownDescription: "The absolute name of the zone as returned by the API.",
+				Computed:            true,
+			},
+			"deployable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the zone is deployable. Zones created only to hold a deployable subzone are not.",
+				Computed:            true,
+			},
+			"deployment_roles": schema.ListNestedAttribute{
+				MarkdownDescription: "The deployment roles assigned directly to the zone. Roles inherited from the view or a parent zone are not included.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "The object ID of the deployment role.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the deployment role, such as `MASTER` or `SLAVE`.",
+							Computed:            true,
+						},
+						"service": schema.StringAttribute{
+							MarkdownDescription: "The service of the deployment role, such as `DNS`.",
+							Computed:            true,
+						},
+						"server_interface_id": schema.Int64Attribute{
+							MarkdownDescription: "The object ID of the server interface of the deployment role.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"record_counts": schema.MapAttribute{
+				MarkdownDescription: "The number of records directly in the zone by object type, such as `HostRecord` and `AliasRecord`. Records in subzones are not counted.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+			"record_count": schema.Int64Attribute{
+				MarkdownDescription: "The total number of records directly in the zone.",
+				Computed:            true,
+			},
+			"subzone_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of zones directly below the zone.",
+				Computed:            true,
+			},
+			"properties": schema.StringAttribute{
+				MarkdownDescription: "The properties of the zone as returned by the API (pipe delimited).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ZoneDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ZoneDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithAuthProfile(ctx, d.client, data.AuthProfile)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	viewID := data.ViewID.ValueInt64()
+	fqdn := data.FQDN.ValueString()
+
+	zone, err := getZoneByFQDN(client, viewID, fqdn)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get Zone by name", err, "")
+		return
+	}
+
+	if zone == nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Zone not found", fmt.Sprintf("No zone %s was found in view %d", fqdn, viewID))
+		return
+	}
+
+	roles, err := client.GetDeploymentRoles(*zone.Id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get deployment roles of Zone", err, "")
+		return
+	}
+
+	counts := make(map[string]int64, len(zoneRecordTypes))
+	var total int64
+	for _, recordType := range zoneRecordTypes {
+		count, err := countEntities(client, *zone.Id, recordType)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, fmt.Sprintf("Failed to count %s objects in Zone", recordType), err, "")
+			return
+		}
+		counts[recordType] = count
+		total += count
+	}
+
+	subzones, err := countEntities(client, *zone.Id, "Zone")
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to count subzones of Zone", err, "")
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	properties := map[string]string{}
+	data.Properties = types.StringNull()
+	if zone.Properties != nil {
+		properties = parseProperties(*zone.Properties)
+		data.Properties = types.StringValue(*zone.Properties)
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(*zone.Id, 10))
+	data.Name = types.StringPointerValue(zone.Name)
+	data.AbsoluteName = types.StringValue(properties["absoluteName"])
+	data.Deployable = types.BoolValue(properties["deployable"] == "true")
+	data.RecordCount = types.Int64Value(total)
+	data.SubzoneCount = types.Int64Value(subzones)
+
+	recordCounts, diag := types.MapValueFrom(ctx, types.Int64Type, counts)
+	resp.Diagnostics.Append(diag...)
+	data.RecordCounts = recordCounts
+
+	deploymentRoles := []ZoneDataSourceDeploymentRole{}
+	if roles != nil {
+		for _, role := range roles.Item {
+			if role == nil || role.Id == nil {
+				continue
+			}
+			deploymentRoles = append(deploymentRoles, ZoneDataSourceDeploymentRole{
+				ID:                types.Int64PointerValue(role.Id),
+				Type:              types.StringPointerValue(role.Type),
+				Service:           types.StringPointerValue(role.Service),
+				ServerInterfaceID: types.Int64PointerValue(role.ServerInterfaceId),
+			})
+		}
+	}
+
+	rolesList, diag := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: zoneDataSourceDeploymentRoleAttributeTypes}, deploymentRoles)
+	resp.Diagnostics.Append(diag...)
+	data.DeploymentRoles = rolesList
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// getZoneByFQDN returns the zone fqdn in the view viewID by looking up each
+// of its labels from the top level zone down, or nil if there is no such zone.
+func getZoneByFQDN(client gobam.ProteusAPI, viewID int64, fqdn string) (*gobam.APIEntity, error) {
+	labels := strings.Split(strings.TrimSuffix(fqdn, "."), ".")
+
+	parentID := viewID
+	var zone *gobam.APIEntity
+	for i := len(labels) - 1; i >= 0; i-- {
+		entity, err := client.GetEntityByName(parentID, labels[i], "Zone")
+		if err != nil {
+			return nil, err
+		}
+		if entity.Id == nil || *entity.Id == 0 {
+			return nil, nil
+		}
+
+		zone = entity
+		parentID = *entity.Id
+	}
+
+	return zone, nil
+}
+
+// countEntities returns the number of objects of objectType directly below
+// parentID, counting them a page at a time.
+func countEntities(client gobam.ProteusAPI, parentID int64, objectType string) (int64, error) {
+	var count int64
+
+	for start := 0; ; start += ip4BlocksPageSize {
+		page, err := client.GetEntities(parentID, objectType, start, ip4BlocksPageSize)
+		if err != nil {
+			return 0, err
+		}
+
+		count += int64(len(page.Item))
+
+		if len(page.Item) < ip4BlocksPageSize {
+			return count, nil
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccZoneDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccZoneDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.bluecat_zone.test", "id", validateObjectID),
+					resource.TestCheckResourceAttrSet("data.bluecat_zone.test", "record_count"),
+					resource.TestCheckResourceAttrSet("data.bluecat_zone.test", "record_counts.HostRecord"),
+				),
+			},
+		},
+	})
+}
+
+const testAccZoneDataSourceConfig = `
+variable "view_id" {
+	type = number
+}
+
+variable "dns_zone" {
+	type = string
+}
+
+data "bluecat_zone" "test" {
+  view_id = var.view_id
+  fqdn    = var.dns_zone
+}
+`
+
+func TestGetZoneByFQDN(t *testing.T) {
+	client := newEntityTreeClient()
+	add := func(id, parent int64, name, objectType string) {
+		client.entities[id] = &gobam.APIEntity{Id: &id, Name: &name, Type: &objectType}
+		client.parents[id] = parent
+	}
+	add(10, 4, "com", "Zone")
+	add(11, 10, "example", "Zone")
+	add(12, 11, "www", "HostRecord")
+	add(13, 11, "mail", "HostRecord")
+	add(14, 11, "www2", "AliasRecord")
+
+	zone, err := getZoneByFQDN(client, 4, "example.com.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zone == nil || *zone.Id != 11 {
+		t.Fatalf("expected zone 11, got %v", zone)
+	}
+
+	if zone, err := getZoneByFQDN(client, 4, "missing.example.com"); err != nil || zone != nil {
+		t.Errorf("expected no zone for a name that does not exist, got %v, %v", zone, err)
+	}
+
+	if zone, err := getZoneByFQDN(client, 1, "example.com"); err != nil || zone != nil {
+		t.Errorf("expected no zone in a different view, got %v, %v", zone, err)
+	}
+
+	count, err := countEntities(client, 11, "HostRecord")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 host records, got %d", count)
+	}
+}
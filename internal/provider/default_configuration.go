@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umich-vci/gobam"
+)
+
+// defaultConfigurationDescription is appended to the description of the
+// configuration_id attributes that fall back to the provider default.
+const defaultConfigurationDescription = " Defaults to the provider `default_configuration_id` or `default_configuration_name`."
+
+// defaultConfiguration is the configuration resources and data sources use
+// when their configuration_id is not set. A configuration given by name is
+// looked up the first time it is needed.
+type defaultConfiguration struct {
+	mu   sync.Mutex
+	id   int64
+	name string
+}
+
+// get returns the object ID of the default configuration, or 0 if there is no
+// default configuration.
+func (c *defaultConfiguration) get(client gobam.ProteusAPI) (int64, error) {
+	if c == nil {
+		return 0, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.id != 0 || c.name == "" {
+		return c.id, nil
+	}
+
+	entity, err := client.GetEntityByName(0, c.name, "Configuration")
+	if err != nil {
+		return 0, err
+	}
+	if entity.Id == nil || *entity.Id == 0 {
+		return 0, fmt.Errorf("no configuration named %q was found", c.name)
+	}
+
+	c.id = *entity.Id
+
+	return c.id, nil
+}
+
+// configurationID returns configID if it is set, otherwise the object ID of
+// the provider default configuration.
+func (c *loginClient) configurationID(client gobam.ProteusAPI, configID types.Int64) (int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !configID.IsNull() && !configID.IsUnknown() {
+		return configID.ValueInt64(), diags
+	}
+
+	id, err := c.DefaultConfiguration.get(client)
+	if err != nil {
+		diags.AddAttributeError(path.Root("configuration_id"), "Failed to get the default configuration", err.Error())
+		return 0, diags
+	}
+	if id == 0 {
+		diags.AddAttributeError(
+			path.Root("configuration_id"),
+			"Missing Configuration",
+			"configuration_id must be set when the provider has no default_configuration_id or default_configuration_name.",
+		)
+		return 0, diags
+	}
+
+	return id, diags
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLoginClientConfigurationID(t *testing.T) {
+	client := newEntityTreeClient()
+	configID := int64(1)
+
+	c := &loginClient{}
+	if id, diags := c.configurationID(client, types.Int64Value(5)); diags.HasError() || id != 5 {
+		t.Errorf("expected the configuration_id that was set, got %d", id)
+	}
+	if _, diags := c.configurationID(client, types.Int64Unknown()); !diags.HasError() {
+		t.Error("expected an error without configuration_id or a default configuration")
+	}
+
+	c.DefaultConfiguration = &defaultConfiguration{id: 20}
+	if id, diags := c.configurationID(client, types.Int64Null()); diags.HasError() || id != 20 {
+		t.Errorf("expected the default configuration ID, got %d", id)
+	}
+
+	c.DefaultConfiguration = &defaultConfiguration{name: "config"}
+	if id, diags := c.configurationID(client, types.Int64Unknown()); diags.HasError() || id != configID {
+		t.Errorf("expected the default configuration to be looked up by name, got %d", id)
+	}
+	delete(client.entities, configID)
+	if id, diags := c.configurationID(client, types.Int64Unknown()); diags.HasError() || id != configID {
+		t.Errorf("expected the configuration found by name to be cached, got %d", id)
+	}
+
+	c.DefaultConfiguration = &defaultConfiguration{name: "Missing"}
+	if _, diags := c.configurationID(client, types.Int64Null()); !diags.HasError() {
+		t.Error("expected an error when the default configuration is not found")
+	}
+}
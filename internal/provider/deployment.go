@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umich-vci/gobam"
+)
+
+// durationRegexp matches the durations accepted by time.ParseDuration.
+var durationRegexp = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`)
+
+// deploymentPollInterval is how often deployment status is checked while
+// waiting for a deployment to complete.
+const deploymentPollInterval = 5 * time.Second
+
+// serverDeploymentStatuses maps the codes returned by getServerDeploymentStatus
+// to their names.
+var serverDeploymentStatuses = map[int]string{
+	-1: "EXECUTING",
+	0:  "INITIALIZING",
+	1:  "QUEUED",
+	2:  "CANCELLED",
+	3:  "FAILED",
+	4:  "NOT_DEPLOYED",
+	5:  "WARNING",
+	6:  "INVALID",
+	7:  "DONE",
+	8:  "NO_RECENT_DEPLOYMENT",
+}
+
+// serverDeploymentPending contains the server deployment statuses of a
+// deployment that has not completed yet.
+var serverDeploymentPending = []string{"EXECUTING", "INITIALIZING", "QUEUED"}
+
+// serverDeploymentFailed contains the server deployment statuses of a
+// deployment that did not succeed.
+var serverDeploymentFailed = []string{"CANCELLED", "FAILED", "INVALID"}
+
+// selectiveDeploymentPending contains the statuses of a selective deployment
+// task that has not completed yet.
+var selectiveDeploymentPending = []string{"QUEUED", "STARTED", "INITIALIZING"}
+
+// selectiveDeploymentFailed contains the statuses of a selective deployment
+// task that did not succeed.
+var selectiveDeploymentFailed = []string{"CANCELLED", "FAILED", "ERROR"}
+
+// selectiveDeploymentTaskStatus is the JSON document returned by
+// getDeploymentTaskStatus.
+type selectiveDeploymentTaskStatus struct {
+	Status   string `json:"status"`
+	Response struct {
+		Status string   `json:"status"`
+		Errors []string `json:"errors"`
+	} `json:"response"`
+}
+
+// serverDeploymentStatus returns the name of the deployment status of a server.
+func serverDeploymentStatus(client gobam.ProteusAPI, serverID int64) (string, error) {
+	code, err := client.GetServerDeploymentStatus(serverID, "")
+	if err != nil {
+		return "", err
+	}
+
+	status, ok := serverDeploymentStatuses[code]
+	if !ok {
+		return "", fmt.Errorf("unknown server deployment status %d", code)
+	}
+
+	return status, nil
+}
+
+// selectiveDeploymentStatus returns the status of a selective deployment task.
+// The status of the deployment response is preferred once the task has finished.
+func selectiveDeploymentStatus(client gobam.ProteusAPI, token string) (string, []string, error) {
+	s, err := client.GetDeploymentTaskStatus(token)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var status selectiveDeploymentTaskStatus
+	if err := json.Unmarshal([]byte(s), &status); err != nil {
+		return "", nil, fmt.Errorf("failed to parse deployment task status %q: %w", s, err)
+	}
+
+	if status.Response.Status != "" && !slices.Contains(selectiveDeploymentPending, status.Status) {
+		return status.Response.Status, status.Response.Errors, nil
+	}
+
+	return status.Status, status.Response.Errors, nil
+}
+
+// deploymentSession runs f with a logged in client. Waiting for a deployment
+// logs in for each poll so that a session is not held open for the whole wait.
+type deploymentSession func(f func(client gobam.ProteusAPI) error) error
+
+// newDeploymentSession returns a deploymentSession that logs in with
+// authProfile or credentials, or the provider credentials if both are null.
+func newDeploymentSession(ctx context.Context, loginClient *loginClient, authProfile types.String, credentials types.Object) deploymentSession {
+	return func(f func(client gobam.ProteusAPI) error) error {
+		client, diags := clientLoginWithCredentials(ctx, loginClient, authProfile, credentials)
+		if diags.HasError() {
+			return diagnosticsError(diags)
+		}
+
+		err := f(client)
+
+		diags = clientLogout(ctx, &client)
+		if err != nil {
+			return err
+		}
+		if diags.HasError() {
+			return diagnosticsError(diags)
+		}
+
+		return nil
+	}
+}
+
+// waitForServerDeployment polls the deployment status of a server until the
+// deployment completes, fails, or timeout elapses and returns the final status.
+func waitForServerDeployment(ctx context.Context, session deploymentSession, serverID int64, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		var status string
+		err := session(func(client gobam.ProteusAPI) error {
+			var err error
+			status, err = serverDeploymentStatus(client, serverID)
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if slices.Contains(serverDeploymentFailed, status) {
+			return status, fmt.Errorf("deployment to server %d ended with status %s", serverID, status)
+		}
+
+		if !slices.Contains(serverDeploymentPending, status) {
+			return status, nil
+		}
+
+		if err := deploymentPollWait(ctx, deadline); err != nil {
+			return status, fmt.Errorf("deployment to server %d did not complete: %w", serverID, err)
+		}
+	}
+}
+
+// waitForSelectiveDeployment polls the status of a selective deployment task
+// until it completes, fails, or timeout elapses and returns the final status.
+func waitForSelectiveDeployment(ctx context.Context, session deploymentSession, token string, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		var status string
+		var taskErrors []string
+		err := session(func(client gobam.ProteusAPI) error {
+			var err error
+			status, taskErrors, err = selectiveDeploymentStatus(client, token)
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if slices.Contains(selectiveDeploymentFailed, status) {
+			return status, fmt.Errorf("selective deployment %s ended with status %s: %v", token, status, taskErrors)
+		}
+
+		if !slices.Contains(selectiveDeploymentPending, status) {
+			return status, nil
+		}
+
+		if err := deploymentPollWait(ctx, deadline); err != nil {
+			return status, fmt.Errorf("selective deployment %s did not complete: %w", token, err)
+		}
+	}
+}
+
+// deploymentPollWait waits for deploymentPollInterval unless the context is
+// cancelled or the deadline would be passed first.
+func deploymentPollWait(ctx context.Context, deadline time.Time) error {
+	if time.Now().Add(deploymentPollInterval).After(deadline) {
+		return fmt.Errorf("timed out")
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(deploymentPollInterval):
+		return nil
+	}
+}
+
+// diagnosticsError returns the error diagnostics in diags as an error.
+func diagnosticsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+
+	return errors.Join(errs...)
+}
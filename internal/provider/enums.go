@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Values accepted by the traversal_method argument of the resources that
+// allocate the next available block or network.
+const (
+	traversalMethodNone         = "NO_TRAVERSAL"
+	traversalMethodDepthFirst   = "DEPTH_FIRST"
+	traversalMethodBreadthFirst = "BREADTH_FIRST"
+)
+
+// traversalMethods contains all valid values for traversal_method.
+var traversalMethods = []string{
+	traversalMethodNone,
+	traversalMethodDepthFirst,
+	traversalMethodBreadthFirst,
+}
+
+// Values accepted by the action argument of the resources that assign
+// IPv4 addresses.
+const (
+	ipAssignmentActionStatic       = "MAKE_STATIC"
+	ipAssignmentActionReserved     = "MAKE_RESERVED"
+	ipAssignmentActionDHCPReserved = "MAKE_DHCP_RESERVED"
+)
+
+// ipAssignmentActions contains all valid values for action.
+var ipAssignmentActions = []string{
+	ipAssignmentActionStatic,
+	ipAssignmentActionReserved,
+	ipAssignmentActionDHCPReserved,
+}
+
+// enumDescription returns a sentence listing the valid values of an enum
+// for use in a MarkdownDescription.
+func enumDescription(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+
+	if len(quoted) < 2 {
+		return fmt.Sprintf("Must be %s.", strings.Join(quoted, ""))
+	}
+
+	if len(quoted) == 2 {
+		return fmt.Sprintf("Must be %s or %s.", quoted[0], quoted[1])
+	}
+
+	return fmt.Sprintf("Must be one of %s, or %s.", strings.Join(quoted[:len(quoted)-1], ", "), quoted[len(quoted)-1])
+}
+
+// Values accepted by the service argument of bluecat_deployment_role.
+const (
+	deploymentServiceDNS  = "DNS"
+	deploymentServiceDHCP = "DHCP"
+)
+
+// deploymentServices contains all valid values for service.
+var deploymentServices = []string{
+	deploymentServiceDNS,
+	deploymentServiceDHCP,
+}
+
+// dnsDeploymentRoleTypes contains all valid DNS deployment role types.
+var dnsDeploymentRoleTypes = []string{
+	"NONE",
+	"MASTER",
+	"MASTER_HIDDEN",
+	"SLAVE",
+	"SLAVE_STEALTH",
+	"FORWARDER",
+	"STUB",
+	"RECURSION",
+	"AD_MASTER",
+}
+
+// dhcpDeploymentRoleTypes contains all valid DHCP deployment role types.
+var dhcpDeploymentRoleTypes = []string{
+	"NONE",
+	"MASTER",
+}
+
+// selectiveDeploymentScopes contains all valid values for the scope of a
+// selective deployment.
+var selectiveDeploymentScopes = []string{
+	"related",
+	"recursive",
+}
+
+// serverDeploymentServices contains all valid services for a server deployment.
+var serverDeploymentServices = []string{
+	"DNS",
+	"DHCP",
+	"DHCPv6",
+	"TFTP",
+}
+
+// Values accepted by the api_version argument of the provider.
+const (
+	apiVersionV1 = "v1"
+	apiVersionV2 = "v2"
+)
+
+// apiVersions contains all valid values for api_version.
+var apiVersions = []string{
+	apiVersionV1,
+	apiVersionV2,
+}
+
+// tlsMinVersions contains all valid values for tls_min_version.
+var tlsMinVersions = []string{
+	"1.0",
+	"1.1",
+	"1.2",
+	"1.3",
+}
+
+// Values accepted by the auth_method argument of the provider.
+const (
+	authMethodPassword = "password"
+	authMethodToken    = "token"
+)
+
+// authMethods contains all valid values for auth_method.
+var authMethods = []string{
+	authMethodPassword,
+	authMethodToken,
+}
+
+// Values accepted by the selection_strategy argument of
+// bluecat_ip4_available_network.
+const (
+	selectionStrategyMostFree  = "most_free"
+	selectionStrategyLeastFree = "least_free"
+	selectionStrategyRandom    = "random"
+	selectionStrategyFirst     = "first"
+)
+
+// selectionStrategies contains all valid values for selection_strategy.
+var selectionStrategies = []string{
+	selectionStrategyMostFree,
+	selectionStrategyLeastFree,
+	selectionStrategyRandom,
+	selectionStrategyFirst,
+}
+
+// Values accepted by the free_address_accounting argument of
+// bluecat_ip4_available_network.
+const (
+	freeAddressAccountingTotal      = "total"
+	freeAddressAccountingAssignable = "assignable"
+	freeAddressAccountingStatic     = "static"
+)
+
+// freeAddressAccountings contains all valid values for free_address_accounting.
+var freeAddressAccountings = []string{
+	freeAddressAccountingTotal,
+	freeAddressAccountingAssignable,
+	freeAddressAccountingStatic,
+}
+
+// Values accepted by the data_type argument of bluecat_user_defined_field.
+const (
+	udfTypeText    = "TEXT"
+	udfTypeInteger = "INTEGER"
+	udfTypeBoolean = "BOOLEAN"
+	udfTypeDate    = "DATE"
+	udfTypeEmail   = "EMAIL"
+	udfTypeURL     = "URL"
+)
+
+// udfTypes contains all valid values for data_type.
+var udfTypes = []string{
+	udfTypeText,
+	udfTypeInteger,
+	udfTypeBoolean,
+	udfTypeDate,
+	udfTypeEmail,
+	udfTypeURL,
+}
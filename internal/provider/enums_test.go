@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/umich-vci/gobam"
+)
+
+func TestEnumsMatchGobam(t *testing.T) {
+	tests := map[string]struct {
+		provider []string
+		gobam    []string
+	}{
+		"traversal_method": {provider: traversalMethods, gobam: gobam.TraversalMethodology},
+		"action":           {provider: ipAssignmentActions, gobam: gobam.IPAssignmentActions},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, v := range tc.gobam {
+				if !slices.Contains(tc.provider, v) {
+					t.Errorf("gobam value %q is not accepted by the provider schema", v)
+				}
+			}
+			for _, v := range tc.provider {
+				if !slices.Contains(tc.gobam, v) {
+					t.Errorf("provider value %q is not known to gobam", v)
+				}
+			}
+		})
+	}
+}
+
+func TestEnumDescription(t *testing.T) {
+	got := enumDescription(traversalMethods)
+	want := `Must be one of "NO_TRAVERSAL", "DEPTH_FIRST", or "BREADTH_FIRST".`
+	if got != want {
+		t.Errorf("enumDescription() = %s, want %s", got, want)
+	}
+}
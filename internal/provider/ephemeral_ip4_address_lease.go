@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ip4AddressLeasePrivateKey is the private data key that holds the object ID
+// of a leased address until it is released.
+const ip4AddressLeasePrivateKey = "address_id"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &IP4AddressLeaseEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &IP4AddressLeaseEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &IP4AddressLeaseEphemeralResource{}
+)
+
+func NewIP4AddressLeaseEphemeralResource() ephemeral.EphemeralResource {
+	return &IP4AddressLeaseEphemeralResource{}
+}
+
+// IP4AddressLeaseEphemeralResource defines the ephemeral resource implementation.
+type IP4AddressLeaseEphemeralResource struct {
+	client *loginClient
+}
+
+// IP4AddressLeaseEphemeralResourceModel describes the ephemeral resource data model.
+type IP4AddressLeaseEphemeralResourceModel struct {
+	ConfigurationID types.Int64  `tfsdk:"configuration_id"`
+	ParentID        types.Int64  `tfsdk:"parent_id"`
+	Name            types.String `tfsdk:"name"`
+	Action          types.String `tfsdk:"action"`
+	AddressID       types.Int64  `tfsdk:"address_id"`
+	Address         types.String `tfsdk:"address"`
+}
+
+// ip4AddressLeasePrivateData is the private data of a leased address.
+type ip4AddressLeasePrivateData struct {
+	AddressID int64 `json:"address_id"`
+}
+
+func (r *IP4AddressLeaseEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip4_address_lease"
+}
+
+func (r *IP4AddressLeaseEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Ephemeral resource to check out the next available IPv4 address for the duration of a Terraform run, such as for a bootstrap VM. The address is released when the run ends and is never saved to state. Requires Terraform 1.10 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"configuration_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the Configuration that will hold the address." + defaultConfigurationDescription,
+				Optional:            true,
+				Computed:            true,
+			},
+			"parent_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the Configuration, Block, or Network to find the next available IPv4 address in.",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name assigned to the address while it is checked out.",
+				Optional:            true,
+			},
+			"action": schema.StringAttribute{
+				MarkdownDescription: "The action to take on the address. Must be one of `MAKE_STATIC` or `MAKE_RESERVED`. Defaults to `MAKE_STATIC`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ipAssignmentActionStatic, ipAssignmentActionReserved),
+				},
+			},
+			"address_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the address that was checked out.",
+				Computed:            true,
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "The IPv4 address that was checked out.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *IP4AddressLeaseEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IP4AddressLeaseEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "open")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data IP4AddressLeaseEphemeralResourceModel
+
+	// Read Terraform config data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLogin(ctx, r.client)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	configID, diag := r.client.configurationID(client, data.ConfigurationID)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	data.ConfigurationID = types.Int64Value(configID)
+
+	action := ipAssignmentActionStatic
+	if !data.Action.IsNull() {
+		action = data.Action.ValueString()
+	}
+
+	properties := propertyMap{}
+	if !data.Name.IsNull() {
+		properties.set("name", data.Name.ValueString())
+	}
+	properties.setManaged(r.client)
+
+	allocationMutex.Lock()
+	ip, err := client.AssignNextAvailableIP4Address(configID, data.ParentID.ValueInt64(), "", "", action, properties.String())
+	allocationMutex.Unlock()
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("AssignNextAvailableIP4Address failed", err.Error())
+		return
+	}
+
+	if ip.Id == nil || *ip.Id == 0 {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("AssignNextAvailableIP4Address failed", "No address is available")
+		return
+	}
+
+	// remember the address before anything else can fail so that Close
+	// always releases it
+	private, err := json.Marshal(ip4AddressLeasePrivateData{AddressID: *ip.Id})
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Failed to encode the leased IP4 Address", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ip4AddressLeasePrivateKey, private)...)
+
+	entity, err := client.GetEntityById(*ip.Id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Failed to get IP4 Address by Id", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	data.AddressID = types.Int64Value(*ip.Id)
+	data.Address = types.StringNull()
+	if entity.Properties != nil {
+		data.Address = types.StringValue(parseProperties(*entity.Properties)["address"])
+	}
+
+	tflog.Trace(ctx, "opened an ephemeral resource", map[string]interface{}{"address_id": *ip.Id})
+
+	// Save data into ephemeral result data
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *IP4AddressLeaseEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	private, diags := req.Private.GetKey(ctx, ip4AddressLeasePrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || private == nil {
+		return
+	}
+
+	var lease ip4AddressLeasePrivateData
+	if err := json.Unmarshal(private, &lease); err != nil {
+		resp.Diagnostics.AddError("Failed to decode the leased IP4 Address", err.Error())
+		return
+	}
+
+	client, diag := clientLogin(ctx, r.client)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	if err := client.Delete(lease.AddressID); err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Failed to release the leased IP4 Address", fmt.Sprintf("IP4 Address %d must be deleted manually: %s", lease.AddressID, err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	tflog.Trace(ctx, "closed an ephemeral resource", map[string]interface{}{"address_id": lease.AddressID})
+}
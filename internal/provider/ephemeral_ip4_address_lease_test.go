@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccIP4AddressLeaseEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"bluecat": testAccProtoV6ProviderFactories["bluecat"],
+			"echo":    echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIP4AddressLeaseEphemeralResourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("address"), knownvalue.StringRegexp(regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`))),
+				},
+			},
+		},
+	})
+}
+
+const testAccIP4AddressLeaseEphemeralResourceConfig = testAccEntityDataSourceConfig + `
+variable "ip4_network_id" {
+	type = number
+}
+
+ephemeral "bluecat_ip4_address_lease" "test" {
+  configuration_id = data.bluecat_entity.config.id
+  parent_id        = var.ip4_network_id
+  name             = "tfacc-lease"
+}
+
+provider "echo" {
+  data = ephemeral.bluecat_ip4_address_lease.test
+}
+
+resource "echo" "test" {}
+`
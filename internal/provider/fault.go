@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fiorix/wsdl2go/soap"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// bamFaultCategory is the kind of failure a BlueCat Address Manager API error
+// is, so failures can be told apart without reading the message.
+type bamFaultCategory string
+
+const (
+	bamFaultAuth       bamFaultCategory = "auth"
+	bamFaultNotFound   bamFaultCategory = "not found"
+	bamFaultDuplicate  bamFaultCategory = "duplicate"
+	bamFaultValidation bamFaultCategory = "validation"
+	bamFaultOther      bamFaultCategory = "other"
+)
+
+// bamFaultCategories are substrings of the fault messages of each category,
+// checked in order so that e.g. an invalid password is an auth fault and not a
+// validation fault.
+var bamFaultCategories = []struct {
+	category bamFaultCategory
+	faults   []string
+}{
+	{bamFaultAuth, append([]string{"username or password", "authentication", "unauthorized", "not authorized", "permission", "access denied", "access right", "api access"}, sessionExpiredFaults...)},
+	{bamFaultNotFound, []string{"not found", "does not exist", "doesn't exist", "no such", "cannot find", "could not find", "unknown object"}},
+	{bamFaultDuplicate, duplicateAllocationFaults},
+	{bamFaultValidation, []string{"invalid", "not valid", "illegal", "must be", "must not", "is required", "missing", "malformed", "out of range", "not allowed", "cannot be"}},
+}
+
+// bamFault is an error returned by the BlueCat Address Manager API.
+type bamFault struct {
+	// Code is the SOAP fault code, if the error was a SOAP fault.
+	Code string
+	// Message is the SOAP fault string, or the error if it was not a SOAP
+	// fault.
+	Message  string
+	Category bamFaultCategory
+}
+
+// parseBAMFault returns the fault code, message, and category of err.
+func parseBAMFault(err error) bamFault {
+	fault := bamFault{Message: err.Error()}
+
+	var httpErr *soap.HTTPError
+	if errors.As(err, &httpErr) {
+		code, message := soapFault(httpErr.Msg)
+		if message != "" {
+			fault.Code = code
+			fault.Message = message
+		}
+		if httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden {
+			fault.Category = bamFaultAuth
+			return fault
+		}
+	}
+
+	fault.Category = classifyBAMFault(fault.Message)
+
+	return fault
+}
+
+// classifyBAMFault returns the category of a fault message.
+func classifyBAMFault(message string) bamFaultCategory {
+	lower := strings.ToLower(message)
+	for _, c := range bamFaultCategories {
+		for _, s := range c.faults {
+			if strings.Contains(lower, s) {
+				return c.category
+			}
+		}
+	}
+
+	return bamFaultOther
+}
+
+// soapFault returns the fault code and fault string of a SOAP fault response.
+func soapFault(body string) (string, string) {
+	var code, message string
+	var text strings.Builder
+
+	decoder := xml.NewDecoder(strings.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			text.Reset()
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "faultcode":
+				code = strings.TrimSpace(text.String())
+			case "faultstring":
+				message = strings.TrimSpace(text.String())
+			}
+			text.Reset()
+		}
+	}
+
+	return code, message
+}
+
+// detail returns the diagnostic detail of the fault, naming entity if it is
+// not empty.
+func (f bamFault) detail(entity string) string {
+	var b strings.Builder
+
+	b.WriteString(f.Message)
+	b.WriteString("\n\nCategory: " + string(f.Category))
+	if f.Code != "" {
+		b.WriteString("\nFault code: " + f.Code)
+	}
+	if entity != "" {
+		b.WriteString("\nEntity: " + entity)
+	}
+
+	return b.String()
+}
+
+// bamEntity describes the entity of objectType with id for a diagnostic, or
+// returns an empty string if the ID is not known yet.
+func bamEntity(objectType, id string) string {
+	if id == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %s", objectType, id)
+}
+
+// summary returns s with the category of the fault added, unless the fault
+// could not be classified.
+func (f bamFault) summary(s string) string {
+	if f.Category == bamFaultOther {
+		return s
+	}
+
+	return fmt.Sprintf("%s (%s)", s, f.Category)
+}
+
+// addBAMError adds an error to diags for err, which was returned by the
+// BlueCat Address Manager API while working with entity. The category of the
+// fault is added to summary, and the fault code, category, and entity to the
+// detail. entity may be empty if it is not known.
+func addBAMError(diags *diag.Diagnostics, summary string, err error, entity string) {
+	fault := parseBAMFault(err)
+	diags.AddError(fault.summary(summary), fault.detail(entity))
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/fiorix/wsdl2go/soap"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestParseBAMFault(t *testing.T) {
+	body := `<?xml version="1.0" ?><env:Envelope xmlns:env="http://schemas.xmlsoap.org/soap/envelope/"><env:Body><env:Fault><faultcode>env:Server</faultcode><faultstring>Object was not found</faultstring></env:Fault></env:Body></env:Envelope>`
+	err := fmt.Errorf("update: %w", &soap.HTTPError{StatusCode: 500, Status: "500 Internal Server Error", Msg: body})
+
+	fault := parseBAMFault(err)
+	if fault.Code != "env:Server" || fault.Message != "Object was not found" || fault.Category != bamFaultNotFound {
+		t.Errorf("unexpected fault %+v", fault)
+	}
+
+	fault = parseBAMFault(&soap.HTTPError{StatusCode: 401, Status: "401 Unauthorized", Msg: "Unauthorized"})
+	if fault.Category != bamFaultAuth || fault.Code != "" {
+		t.Errorf("expected an auth fault without a code, got %+v", fault)
+	}
+
+	fault = parseBAMFault(errors.New("connection refused"))
+	if fault.Category != bamFaultOther || fault.Message != "connection refused" {
+		t.Errorf("unexpected fault %+v", fault)
+	}
+}
+
+func TestClassifyBAMFault(t *testing.T) {
+	for message, expected := range map[string]bamFaultCategory{
+		"Invalid username or password":              bamFaultAuth,
+		"Session expired":                           bamFaultAuth,
+		"User does not have permission to do this":  bamFaultAuth,
+		"Object with id 1234 does not exist":        bamFaultNotFound,
+		"Duplicate of another item":                 bamFaultDuplicate,
+		"10.0.0.0/24 overlaps with 10.0.0.0/16":     bamFaultDuplicate,
+		"Invalid IP address":                        bamFaultValidation,
+		"The name must be a valid DNS name":         bamFaultValidation,
+		"Server encountered an unexpected response": bamFaultOther,
+	} {
+		if category := classifyBAMFault(message); category != expected {
+			t.Errorf("%q: expected %q, got %q", message, expected, category)
+		}
+	}
+}
+
+func TestAddBAMError(t *testing.T) {
+	var diags diag.Diagnostics
+	addBAMError(&diags, "Update failed", errors.New("Duplicate of another item"), bamEntity("IP4 Block", "1234"))
+	addBAMError(&diags, "Create failed", errors.New("timeout"), bamEntity("IP4 Block", ""))
+
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected 2 errors, got %v", diags)
+	}
+
+	duplicate := diags.Errors()[0]
+	if duplicate.Summary() != "Update failed (duplicate)" {
+		t.Errorf("unexpected summary %q", duplicate.Summary())
+	}
+	if !strings.Contains(duplicate.Detail(), "Category: duplicate") || !strings.HasSuffix(duplicate.Detail(), "Entity: IP4 Block 1234") {
+		t.Errorf("unexpected detail %q", duplicate.Detail())
+	}
+
+	other := diags.Errors()[1]
+	if other.Summary() != "Create failed" || strings.Contains(other.Detail(), "Entity:") {
+		t.Errorf("unexpected error %q: %q", other.Summary(), other.Detail())
+	}
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"github.com/umich-vci/gobam"
+)
+
+// hintPageSize is the number of objects requested at a time from the API
+// calls that search by hint.
+const hintPageSize = 100
+
+// hintMaxSearchResults is the default number of objects a hint based search
+// looks through before giving up.
+const hintMaxSearchResults = 10000
+
+// searchByHint pages through the objects returned by a hint based search and
+// returns the objects match returns for them. match returns nil for objects
+// that do not match, or the object to return, which may be a more complete
+// copy of the object fetched by its ID. The search stops once limit objects
+// have been matched, the search is exhausted, or maxResults objects have been
+// looked at. A limit of 0 does not stop the search on the number of matches.
+func searchByHint(page func(start int, count int) (*gobam.APIEntityArray, error), maxResults int, limit int, match func(*gobam.APIEntity) (*gobam.APIEntity, error)) ([]*gobam.APIEntity, error) {
+	matches := []*gobam.APIEntity{}
+
+	for start := 0; start < maxResults; start += hintPageSize {
+		count := min(hintPageSize, maxResults-start)
+
+		entities, err := page(start, count)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range entities.Item {
+			if e == nil || e.Id == nil || *e.Id == 0 {
+				continue
+			}
+
+			m, err := match(e)
+			if err != nil {
+				return nil, err
+			}
+			if m == nil {
+				continue
+			}
+
+			matches = append(matches, m)
+			if limit > 0 && len(matches) == limit {
+				return matches, nil
+			}
+		}
+
+		if len(entities.Item) < count {
+			break
+		}
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/umich-vci/gobam"
+)
+
+// hintPages returns a page function over total objects with IDs starting at 1
+// and records the pages that were requested.
+func hintPages(total int, requested *[]string) func(start int, count int) (*gobam.APIEntityArray, error) {
+	return func(start int, count int) (*gobam.APIEntityArray, error) {
+		*requested = append(*requested, fmt.Sprintf("%d+%d", start, count))
+		entities := &gobam.APIEntityArray{}
+		for i := start; i < total && i < start+count; i++ {
+			id := int64(i + 1)
+			entities.Item = append(entities.Item, &gobam.APIEntity{Id: &id})
+		}
+		return entities, nil
+	}
+}
+
+func TestSearchByHint(t *testing.T) {
+	matchIDs := func(ids ...int64) func(*gobam.APIEntity) (*gobam.APIEntity, error) {
+		return func(e *gobam.APIEntity) (*gobam.APIEntity, error) {
+			for _, id := range ids {
+				if *e.Id == id {
+					return e, nil
+				}
+			}
+			return nil, nil
+		}
+	}
+
+	tests := map[string]struct {
+		total      int
+		maxResults int
+		limit      int
+		ids        []int64
+		matched    int
+		pages      string
+	}{
+		"match on a later page":    {total: 250, maxResults: 1000, limit: 2, ids: []int64{150}, matched: 1, pages: "[0+100 100+100 200+100]"},
+		"stop at the limit":        {total: 250, maxResults: 1000, limit: 2, ids: []int64{5, 120, 130}, matched: 2, pages: "[0+100 100+100]"},
+		"stop at max results":      {total: 250, maxResults: 150, limit: 0, ids: []int64{5, 200}, matched: 1, pages: "[0+100 100+50]"},
+		"exhausted on a full page": {total: 100, maxResults: 1000, limit: 0, ids: []int64{1, 100}, matched: 2, pages: "[0+100 100+100]"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requested []string
+			matches, err := searchByHint(hintPages(tc.total, &requested), tc.maxResults, tc.limit, matchIDs(tc.ids...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(matches) != tc.matched {
+				t.Errorf("expected %d matches, got %d", tc.matched, len(matches))
+			}
+			if got := fmt.Sprint(requested); got != tc.pages {
+				t.Errorf("expected pages %s, got %s", tc.pages, got)
+			}
+		})
+	}
+}
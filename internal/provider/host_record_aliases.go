@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umich-vci/gobam"
+)
+
+// hostRecordAliasesPageSize is the number of alias records requested at a
+// time when listing the aliases of a host record.
+const hostRecordAliasesPageSize = 100
+
+// getHostRecordAliases returns the object IDs of the alias records linked to
+// the host record hostID, keyed by their lower case absolute name.
+func getHostRecordAliases(client gobam.ProteusAPI, hostID int64) (map[string]int64, error) {
+	aliases := make(map[string]int64)
+
+	for start := 0; ; start += hostRecordAliasesPageSize {
+		page, err := client.GetLinkedEntities(hostID, "AliasRecord", start, hostRecordAliasesPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, alias := range page.Item {
+			if alias == nil || alias.Id == nil || alias.Properties == nil {
+				continue
+			}
+			name := parseProperties(*alias.Properties)["absoluteName"]
+			if name == "" {
+				continue
+			}
+			aliases[strings.ToLower(name)] = *alias.Id
+		}
+
+		if len(page.Item) < hostRecordAliasesPageSize {
+			return aliases, nil
+		}
+	}
+}
+
+// updateHostRecordAliases deletes the alias records named in remove that
+// point to the host record hostID and adds alias records named in add that
+// point to it in viewID. Aliases in add that already exist are left alone.
+func updateHostRecordAliases(client gobam.ProteusAPI, loginClient *loginClient, viewID int64, hostID int64, hostName string, remove []string, add []string) error {
+	existing, err := getHostRecordAliases(client, hostID)
+	if err != nil {
+		return fmt.Errorf("failed to get the aliases of host record %d: %w", hostID, err)
+	}
+
+	for _, name := range remove {
+		id, ok := existing[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+		if err := client.Delete(id); err != nil {
+			return fmt.Errorf("failed to delete alias %s: %w", name, err)
+		}
+		delete(existing, strings.ToLower(name))
+	}
+
+	properties := propertyMap{}
+	properties.setManaged(loginClient)
+
+	for _, name := range add {
+		if _, ok := existing[strings.ToLower(name)]; ok {
+			continue
+		}
+		if _, err := client.AddAliasRecord(viewID, name, hostName, -1, properties.String()); err != nil {
+			return fmt.Errorf("failed to add alias %s in view %d: %w", name, viewID, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteHostRecordWithAliases deletes the alias records named in aliases that
+// point to the host record hostID and then the host record, since it cannot be
+// deleted while aliases point to it.
+func deleteHostRecordWithAliases(client gobam.ProteusAPI, loginClient *loginClient, hostID int64, aliases []string) error {
+	if err := updateHostRecordAliases(client, loginClient, 0, hostID, "", aliases, nil); err != nil {
+		return err
+	}
+
+	return client.Delete(hostID)
+}
+
+// hostRecordAliasChanges returns the aliases in prior that are not in plan
+// and the aliases in plan that are not in prior, ignoring differences in case.
+func hostRecordAliasChanges(prior []string, plan []string) ([]string, []string) {
+	contains := func(names []string, name string) bool {
+		return slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, name) })
+	}
+
+	var remove, add []string
+	for _, name := range prior {
+		if !contains(plan, name) {
+			remove = append(remove, name)
+		}
+	}
+	for _, name := range plan {
+		if !contains(prior, name) {
+			add = append(add, name)
+		}
+	}
+
+	return remove, add
+}
+
+// hostRecordAliasesValue returns the aliases of a host record as a set,
+// keeping the case of the names in prior.
+func hostRecordAliasesValue(ctx context.Context, prior []string, aliases map[string]int64) (types.Set, diag.Diagnostics) {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		if i := slices.IndexFunc(prior, func(n string) bool { return strings.EqualFold(n, name) }); i >= 0 {
+			name = prior[i]
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return types.SetValueFrom(ctx, types.StringType, names)
+}
+
+// aliases returns the aliases of the host record, or nil if aliases is not set.
+func (m *HostRecordResourceModel) aliases(ctx context.Context) ([]string, diag.Diagnostics) {
+	var aliases []string
+	if m.Aliases.IsNull() || m.Aliases.IsUnknown() {
+		return aliases, nil
+	}
+
+	diags := m.Aliases.ElementsAs(ctx, &aliases, false)
+
+	return aliases, diags
+}
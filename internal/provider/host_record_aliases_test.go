@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umich-vci/gobam"
+)
+
+// aliasRecordClient is a gobam.ProteusAPI that holds the alias records
+// linked to a single host record.
+type aliasRecordClient struct {
+	gobam.ProteusAPI
+
+	aliases map[int64]string
+	nextID  int64
+	added   []string
+	deleted []int64
+}
+
+func (c *aliasRecordClient) GetLinkedEntities(entityId int64, _type string, start int, count int) (*gobam.APIEntityArray, error) {
+	ids := make([]int64, 0, len(c.aliases))
+	for id := range c.aliases {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	page := &gobam.APIEntityArray{}
+	for _, id := range ids[min(start, len(ids)):min(start+count, len(ids))] {
+		properties := "absoluteName=" + c.aliases[id] + "|linkedRecordName=host.example.com|"
+		page.Item = append(page.Item, &gobam.APIEntity{Id: &id, Properties: &properties})
+	}
+
+	return page, nil
+}
+
+func (c *aliasRecordClient) Delete(objectId int64) error {
+	if _, ok := c.aliases[objectId]; !ok && len(c.aliases) > 0 {
+		return fmt.Errorf("object %d is linked to alias records", objectId)
+	}
+	delete(c.aliases, objectId)
+	c.deleted = append(c.deleted, objectId)
+	return nil
+}
+
+func (c *aliasRecordClient) AddAliasRecord(viewId int64, absoluteName string, linkedRecordName string, ttl int64, properties string) (int64, error) {
+	c.nextID++
+	c.aliases[c.nextID] = absoluteName
+	c.added = append(c.added, absoluteName)
+	return c.nextID, nil
+}
+
+func TestUpdateHostRecordAliases(t *testing.T) {
+	client := &aliasRecordClient{aliases: map[int64]string{1: "www.example.com", 2: "Old.example.com", 3: "other.example.com"}, nextID: 10}
+
+	if err := updateHostRecordAliases(client, nil, 5, 100, "host.example.com", []string{"old.example.com"}, []string{"WWW.example.com", "new.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(client.added, []string{"new.example.com"}) {
+		t.Errorf("expected only new.example.com to be added, got %v", client.added)
+	}
+
+	aliases, err := getHostRecordAliases(client, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]int64{"www.example.com": 1, "other.example.com": 3, "new.example.com": 11}
+	if !reflect.DeepEqual(aliases, expected) {
+		t.Errorf("expected %v, got %v", expected, aliases)
+	}
+}
+
+func TestDeleteHostRecordWithAliases(t *testing.T) {
+	// a host record whose aliases were only partly added before a failure
+	client := &aliasRecordClient{aliases: map[int64]string{1: "www.example.com"}}
+
+	if err := deleteHostRecordWithAliases(client, nil, 100, []string{"www.example.com", "api.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(client.deleted, []int64{1, 100}) {
+		t.Errorf("expected the alias to be deleted before the host record, got %v", client.deleted)
+	}
+}
+
+func TestHostRecordAliasChanges(t *testing.T) {
+	remove, add := hostRecordAliasChanges([]string{"a.example.com", "B.example.com"}, []string{"b.example.com", "c.example.com"})
+
+	if !reflect.DeepEqual(remove, []string{"a.example.com"}) {
+		t.Errorf("unexpected aliases to remove %v", remove)
+	}
+	if !reflect.DeepEqual(add, []string{"c.example.com"}) {
+		t.Errorf("unexpected aliases to add %v", add)
+	}
+}
+
+func TestHostRecordAliasesValue(t *testing.T) {
+	value, diags := hostRecordAliasesValue(context.Background(), []string{"WWW.example.com"}, map[string]int64{"www.example.com": 1, "api.example.com": 2})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	expected := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("WWW.example.com"), types.StringValue("api.example.com")})
+	if !value.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, value)
+	}
+}
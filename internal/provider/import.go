@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/umich-vci/gobam"
+)
+
+// ip4RangeImportID is a parsed import identifier of an IPv4 block or network.
+// Either id is set or one of cidr, or start and end, is set together with one
+// of configurationName or containerID.
+type ip4RangeImportID struct {
+	id                int64
+	configurationName string
+	containerID       int64
+	cidr              string
+	start             string
+	end               string
+}
+
+// parseIP4RangeImportID parses an import identifier in the form <id>,
+// <configuration_name>:<cidr>, or <container_id>/<cidr>. A range in the
+// form <start>-<end> may be used in place of the CIDR for blocks that do
+// not form a valid CIDR.
+func parseIP4RangeImportID(importID string) (ip4RangeImportID, error) {
+	if id, err := strconv.ParseInt(importID, 10, 64); err == nil {
+		return ip4RangeImportID{id: id}, nil
+	}
+
+	var parsed ip4RangeImportID
+	var addresses string
+	if i := strings.LastIndex(importID, ":"); i >= 0 {
+		parsed.configurationName = importID[:i]
+		addresses = importID[i+1:]
+		if parsed.configurationName == "" {
+			return parsed, fmt.Errorf("configuration name must not be empty")
+		}
+	} else if container, rest, ok := strings.Cut(importID, "/"); ok {
+		containerID, err := strconv.ParseInt(container, 10, 64)
+		if err != nil {
+			return parsed, fmt.Errorf("%q is not a valid container ID", container)
+		}
+		parsed.containerID = containerID
+		addresses = rest
+	} else {
+		return parsed, fmt.Errorf("expected <id>, <configuration_name>:<cidr>, or <container_id>/<cidr>")
+	}
+
+	if start, end, ok := strings.Cut(addresses, "-"); ok {
+		if _, err := ip4RangeSize(start, end); err != nil {
+			return parsed, fmt.Errorf("%q is not a valid IPv4 range: %w", addresses, err)
+		}
+		parsed.start = start
+		parsed.end = end
+
+		return parsed, nil
+	}
+
+	parsed.cidr = addresses
+	ip, network, err := net.ParseCIDR(parsed.cidr)
+	if err != nil || ip.To4() == nil {
+		return parsed, fmt.Errorf("%q is not a valid IPv4 CIDR", parsed.cidr)
+	}
+	if !ip.Equal(network.IP) {
+		return parsed, fmt.Errorf("%q is not the network address of the CIDR, did you mean %s?", parsed.cidr, network.String())
+	}
+
+	return parsed, nil
+}
+
+// importIP4Range imports an IPv4 block or network of objectType by object ID,
+// or by CIDR within a configuration or container.
+func importIP4Range(ctx context.Context, loginClient *loginClient, objectType string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importID, err := parseIP4RangeImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Failed to parse import identifier %q: %s", req.ID, err.Error()),
+		)
+		return
+	}
+
+	if importID.id != 0 {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	if importID.cidr == "" && objectType != "IP4Block" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Failed to parse import identifier %q: only IPv4 blocks can be imported by range", req.ID),
+		)
+		return
+	}
+
+	client, diag := clientLogin(ctx, loginClient)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	id, err := findIP4Range(client, objectType, importID)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to find %s %s", objectType, importID.addresses()), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.FormatInt(id, 10))...)
+}
+
+// findIP4Range returns the object ID of the IPv4 block or network of
+// objectType with exactly the CIDR or range in importID.
+func findIP4Range(client gobam.ProteusAPI, objectType string, importID ip4RangeImportID) (int64, error) {
+	containerID := importID.containerID
+	if importID.configurationName != "" {
+		config, err := client.GetEntityByName(0, importID.configurationName, "Configuration")
+		if err != nil {
+			return 0, err
+		}
+		if config.Id == nil || *config.Id == 0 {
+			return 0, fmt.Errorf("configuration %q was not found", importID.configurationName)
+		}
+		containerID = *config.Id
+	}
+
+	address := importID.start
+	if importID.cidr != "" {
+		address, _, _ = strings.Cut(importID.cidr, "/")
+	}
+
+	entity, err := client.GetIPRangedByIP(containerID, objectType, address)
+	if err != nil {
+		return 0, err
+	}
+
+	// The most specific range containing the address is returned, so walk up
+	// through parent blocks until the CIDR or range matches.
+	for entity != nil && entity.Id != nil && *entity.Id != 0 && entity.Type != nil && *entity.Type == objectType {
+		if entity.Properties != nil && importID.matches(parseProperties(*entity.Properties)) {
+			return *entity.Id, nil
+		}
+
+		entity, err = client.GetParent(*entity.Id)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return 0, fmt.Errorf("no %s with %s was found in container %d", objectType, importID.addresses(), containerID)
+}
+
+// matches returns true if the properties of an IPv4 block or network are
+// for exactly the CIDR or range in the import identifier.
+func (i ip4RangeImportID) matches(properties map[string]string) bool {
+	if i.cidr != "" {
+		return properties["CIDR"] == i.cidr
+	}
+
+	start, end := properties["start"], properties["end"]
+	if cidr, ok := properties["CIDR"]; ok {
+		var err error
+		if start, end, err = ip4CIDRRange(cidr); err != nil {
+			return false
+		}
+	}
+
+	return start == i.start && end == i.end
+}
+
+// addresses returns the CIDR or range of the import identifier for use in messages.
+func (i ip4RangeImportID) addresses() string {
+	if i.cidr != "" {
+		return "CIDR " + i.cidr
+	}
+
+	return "range " + i.start + "-" + i.end
+}
+
+// ip4AddressImportID is a parsed import identifier of an IPv4 address. Either
+// id is set or both configurationID and address are set.
+type ip4AddressImportID struct {
+	id              int64
+	configurationID int64
+	address         string
+}
+
+// parseIP4AddressImportID parses an import identifier in the form <id> or
+// <configuration_id>:<address>.
+func parseIP4AddressImportID(importID string) (ip4AddressImportID, error) {
+	if id, err := strconv.ParseInt(importID, 10, 64); err == nil {
+		return ip4AddressImportID{id: id}, nil
+	}
+
+	config, address, ok := strings.Cut(importID, ":")
+	if !ok {
+		return ip4AddressImportID{}, fmt.Errorf("expected <id> or <configuration_id>:<address>")
+	}
+
+	configID, err := strconv.ParseInt(config, 10, 64)
+	if err != nil || configID <= 0 {
+		return ip4AddressImportID{}, fmt.Errorf("%q is not a valid configuration ID", config)
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil || ip.To4() == nil || strings.Contains(address, ":") {
+		return ip4AddressImportID{}, fmt.Errorf("%q is not a valid IPv4 address", address)
+	}
+
+	return ip4AddressImportID{configurationID: configID, address: address}, nil
+}
+
+// getConfigurationID returns the object ID of the configuration that contains
+// the entity id.
+func getConfigurationID(client gobam.ProteusAPI, entityID int64) (int64, error) {
+	id := entityID
+	for range entityMaxDepth {
+		parent, err := client.GetParent(id)
+		if err != nil {
+			return 0, err
+		}
+		if parent.Id == nil || *parent.Id == 0 {
+			break
+		}
+		if parent.Type != nil && *parent.Type == "Configuration" {
+			return *parent.Id, nil
+		}
+		id = *parent.Id
+	}
+
+	return 0, fmt.Errorf("no configuration contains entity %d", entityID)
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/umich-vci/gobam"
+)
+
+func TestParseIP4RangeImportID(t *testing.T) {
+	tests := map[string]struct {
+		importID string
+		expected ip4RangeImportID
+		wantErr  bool
+	}{
+		"id":                     {importID: "123456", expected: ip4RangeImportID{id: 123456}},
+		"configuration name":     {importID: "Production:10.10.0.0/16", expected: ip4RangeImportID{configurationName: "Production", cidr: "10.10.0.0/16"}},
+		"configuration with ':'": {importID: "Lab:East:10.10.0.0/16", expected: ip4RangeImportID{configurationName: "Lab:East", cidr: "10.10.0.0/16"}},
+		"container id":           {importID: "100881/10.10.0.0/16", expected: ip4RangeImportID{containerID: 100881, cidr: "10.10.0.0/16"}},
+		"range":                  {importID: "Production:10.10.48.0-10.10.48.99", expected: ip4RangeImportID{configurationName: "Production", start: "10.10.48.0", end: "10.10.48.99"}},
+		"range in container":     {importID: "100881/10.10.48.0-10.10.48.99", expected: ip4RangeImportID{containerID: 100881, start: "10.10.48.0", end: "10.10.48.99"}},
+		"reversed range":         {importID: "Production:10.10.48.99-10.10.48.0", wantErr: true},
+		"cidr only":              {importID: "10.10.0.0/16", wantErr: true},
+		"empty configuration":    {importID: ":10.10.0.0/16", wantErr: true},
+		"invalid cidr":           {importID: "Production:10.10.0.0", wantErr: true},
+		"host bits set":          {importID: "Production:10.10.1.0/16", wantErr: true},
+		"ipv6":                   {importID: "Production:2001:db8::/32", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseIP4RangeImportID(tc.importID)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestIP4RangeImportIDMatches(t *testing.T) {
+	cidr := ip4RangeImportID{cidr: "10.10.0.0/24"}
+	if !cidr.matches(map[string]string{"CIDR": "10.10.0.0/24"}) {
+		t.Error("expected the CIDR to match")
+	}
+
+	byRange := ip4RangeImportID{start: "10.10.0.0", end: "10.10.0.255"}
+	if !byRange.matches(map[string]string{"CIDR": "10.10.0.0/24"}) {
+		t.Error("expected a range covering the CIDR to match")
+	}
+	if !byRange.matches(map[string]string{"start": "10.10.0.0", "end": "10.10.0.255"}) {
+		t.Error("expected the range to match")
+	}
+	if byRange.matches(map[string]string{"start": "10.10.0.0", "end": "10.10.0.99"}) {
+		t.Error("expected a different range to not match")
+	}
+}
+
+func TestParseIP4AddressImportID(t *testing.T) {
+	tests := map[string]struct {
+		importID string
+		expected ip4AddressImportID
+		wantErr  bool
+	}{
+		"id":                    {importID: "123456", expected: ip4AddressImportID{id: 123456}},
+		"configuration address": {importID: "100881:10.10.0.5", expected: ip4AddressImportID{configurationID: 100881, address: "10.10.0.5"}},
+		"configuration name":    {importID: "Production:10.10.0.5", wantErr: true},
+		"address only":          {importID: "10.10.0.5", wantErr: true},
+		"cidr":                  {importID: "100881:10.10.0.0/16", wantErr: true},
+		"ipv6":                  {importID: "100881:2001:db8::1", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseIP4AddressImportID(tc.importID)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+// parentChainClient returns the parents in a chain of typed entities.
+type parentChainClient struct {
+	gobam.ProteusAPI
+	parents map[int64]*gobam.APIEntity
+}
+
+func (c *parentChainClient) GetParent(entityId int64) (*gobam.APIEntity, error) {
+	if parent, ok := c.parents[entityId]; ok {
+		return parent, nil
+	}
+	return &gobam.APIEntity{}, nil
+}
+
+func TestGetConfigurationID(t *testing.T) {
+	entity := func(id int64, objectType string) *gobam.APIEntity {
+		return &gobam.APIEntity{Id: &id, Type: &objectType}
+	}
+	client := &parentChainClient{parents: map[int64]*gobam.APIEntity{
+		4: entity(3, "IP4Network"),
+		3: entity(2, "IP4Block"),
+		2: entity(1, "Configuration"),
+		9: entity(8, "IP4Network"),
+	}}
+
+	configID, err := getConfigurationID(client, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if configID != 1 {
+		t.Errorf("expected configuration 1, got %d", configID)
+	}
+
+	if _, err := getConfigurationID(client, 9); err == nil {
+		t.Error("expected an error when no parent is a configuration")
+	}
+}
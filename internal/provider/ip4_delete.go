@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/umich-vci/gobam"
+)
+
+// ip4DeleteBlockingChildrenListed is the number of blocking children that are
+// listed in the error when an IPv4 block or network cannot be deleted.
+const ip4DeleteBlockingChildrenListed = 10
+
+// ip4DeleteChildOptions are the options used to delete the addresses in an
+// IPv4 network when it is deleted with force_delete. Host records that are only
+// linked to the address are deleted with it.
+const ip4DeleteChildOptions = "deleteOrphanedIPAddresses=true|"
+
+// ip4ChildTypes returns the types of the children of an IPv4 block or network
+// that prevent it from being deleted.
+func ip4ChildTypes(objectType string) []string {
+	if objectType == "IP4Network" {
+		return []string{"IP4Address"}
+	}
+
+	return []string{"IP4Block", "IP4Network"}
+}
+
+// getIP4BlockingChildren returns the children of the IPv4 block or network id
+// that prevent it from being deleted. The gateway of a network is deleted with
+// it, so it is not returned.
+func getIP4BlockingChildren(client gobam.ProteusAPI, id int64, objectType string) ([]*gobam.APIEntity, error) {
+	children := []*gobam.APIEntity{}
+
+	for _, childType := range ip4ChildTypes(objectType) {
+		entities, err := getAllEntities(client, id, childType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range entities {
+			if e == nil || e.Id == nil || *e.Id == 0 {
+				continue
+			}
+			if e.Properties != nil && parseProperties(*e.Properties)["state"] == "GATEWAY" {
+				continue
+			}
+			children = append(children, e)
+		}
+	}
+
+	return children, nil
+}
+
+// deleteIP4Children deletes the children of the IPv4 block or network id,
+// starting with the most deeply nested.
+func deleteIP4Children(client gobam.ProteusAPI, id int64, objectType string) error {
+	children, err := getIP4BlockingChildren(client, id, objectType)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if *child.Type == "IP4Address" {
+			if err := client.DeleteWithOptions(*child.Id, ip4DeleteChildOptions); err != nil {
+				return fmt.Errorf("failed to delete %s: %w", describeIP4Child(child), err)
+			}
+			continue
+		}
+
+		if err := deleteIP4Children(client, *child.Id, *child.Type); err != nil {
+			return err
+		}
+		if err := client.Delete(*child.Id); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", describeIP4Child(child), err)
+		}
+	}
+
+	return nil
+}
+
+// getIP4ChildIDs returns the IDs of the children of the IPv4 block or network
+// id that prevent it from being deleted.
+func getIP4ChildIDs(client gobam.ProteusAPI, id int64, objectType string) (map[int64]bool, error) {
+	children, err := getIP4BlockingChildren(client, id, objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[int64]bool, len(children))
+	for _, child := range children {
+		ids[*child.Id] = true
+	}
+
+	return ids, nil
+}
+
+// rollbackIP4Create deletes the IPv4 block or network id that was allocated
+// by a Create that then failed to configure it. existing holds the IDs of the
+// children it had when it was allocated, such as the networks in the range a
+// block was created over. Only the children that are not in existing, which
+// were created by the Create such as the addresses of a network template, are
+// deleted first, so that the delete fails instead of deleting objects that
+// were not created by the Create. If existing is nil, no children are deleted.
+func rollbackIP4Create(client gobam.ProteusAPI, id int64, objectType string, existing map[int64]bool) error {
+	if existing != nil {
+		children, err := getIP4BlockingChildren(client, id, objectType)
+		if err != nil {
+			return err
+		}
+
+		for _, child := range children {
+			if existing[*child.Id] {
+				continue
+			}
+
+			if *child.Type == "IP4Address" {
+				err = client.DeleteWithOptions(*child.Id, ip4DeleteChildOptions)
+			} else {
+				err = client.Delete(*child.Id)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to delete %s: %w", describeIP4Child(child), err)
+			}
+		}
+	}
+
+	return client.Delete(id)
+}
+
+// ip4BlockingChildrenDetail describes the children that prevent an IPv4 block
+// or network from being deleted for the diagnostic of a failed delete.
+func ip4BlockingChildrenDetail(objectType string, children []*gobam.APIEntity) string {
+	var detail strings.Builder
+	fmt.Fprintf(&detail, "The %s contains %d objects that must be deleted first:\n", objectType, len(children))
+
+	for i, child := range children {
+		if i == ip4DeleteBlockingChildrenListed {
+			fmt.Fprintf(&detail, "  ... and %d more\n", len(children)-i)
+			break
+		}
+		fmt.Fprintf(&detail, "  - %s\n", describeIP4Child(child))
+	}
+
+	detail.WriteString("Delete them, or set force_delete to true to delete them with the " + objectType + ".")
+	return detail.String()
+}
+
+// describeIP4Child returns a short description of a child of an IPv4 block or
+// network, such as `IP4Network 10.0.0.0/24 "servers" (1234)`.
+func describeIP4Child(e *gobam.APIEntity) string {
+	description := *e.Type
+
+	if e.Properties != nil {
+		props := parseProperties(*e.Properties)
+		switch {
+		case props["CIDR"] != "":
+			description += " " + props["CIDR"]
+		case props["address"] != "":
+			description += " " + props["address"]
+		case props["start"] != "":
+			description += " " + props["start"] + "-" + props["end"]
+		}
+	}
+
+	if e.Name != nil && *e.Name != "" {
+		description += fmt.Sprintf(" %q", *e.Name)
+	}
+
+	return fmt.Sprintf("%s (%d)", description, *e.Id)
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/umich-vci/gobam"
+)
+
+// ip4ChildrenClient returns the children in a tree of IPv4 blocks, networks,
+// and addresses and records the objects that were deleted.
+type ip4ChildrenClient struct {
+	gobam.ProteusAPI
+	children map[int64][]*gobam.APIEntity
+	deleted  []string
+}
+
+func (c *ip4ChildrenClient) add(parentID, id int64, objectType, properties string) {
+	if c.children == nil {
+		c.children = make(map[int64][]*gobam.APIEntity)
+	}
+	c.children[parentID] = append(c.children[parentID], &gobam.APIEntity{Id: &id, Type: &objectType, Properties: &properties})
+}
+
+func (c *ip4ChildrenClient) GetEntities(parentId int64, _type string, start int, count int) (*gobam.APIEntityArray, error) {
+	entities := &gobam.APIEntityArray{}
+	for _, e := range c.children[parentId] {
+		if *e.Type == _type {
+			entities.Item = append(entities.Item, e)
+		}
+	}
+	return entities, nil
+}
+
+func (c *ip4ChildrenClient) Delete(objectId int64) error {
+	c.deleted = append(c.deleted, fmt.Sprint(objectId))
+	return nil
+}
+
+func (c *ip4ChildrenClient) DeleteWithOptions(objectId int64, options string) error {
+	c.deleted = append(c.deleted, fmt.Sprintf("%d:%s", objectId, options))
+	return nil
+}
+
+func newIP4ChildrenClient() *ip4ChildrenClient {
+	client := &ip4ChildrenClient{}
+	client.add(1, 2, "IP4Block", "CIDR=10.0.0.0/16|")
+	client.add(2, 3, "IP4Network", "CIDR=10.0.0.0/24|")
+	client.add(3, 4, "IP4Address", "address=10.0.0.1|state=GATEWAY|")
+	client.add(3, 5, "IP4Address", "address=10.0.0.5|state=STATIC|")
+	client.add(1, 6, "IP4Network", "CIDR=10.1.0.0/24|")
+	return client
+}
+
+func TestGetIP4BlockingChildren(t *testing.T) {
+	client := newIP4ChildrenClient()
+
+	children, err := getIP4BlockingChildren(client, 1, "IP4Block")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(children) != 2 || *children[0].Id != 2 || *children[1].Id != 6 {
+		t.Errorf("expected the block and network in the block, got %d children", len(children))
+	}
+
+	children, err = getIP4BlockingChildren(client, 3, "IP4Network")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(children) != 1 || *children[0].Id != 5 {
+		t.Errorf("expected the address in the network other than the gateway, got %d children", len(children))
+	}
+}
+
+func TestDeleteIP4Children(t *testing.T) {
+	client := newIP4ChildrenClient()
+
+	if err := deleteIP4Children(client, 1, "IP4Block"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "5:" + ip4DeleteChildOptions + " 3 2 6"
+	if got := strings.Join(client.deleted, " "); got != want {
+		t.Errorf("expected children to be deleted deepest first as %q, got %q", want, got)
+	}
+}
+
+func TestRollbackIP4Create(t *testing.T) {
+	// a network that got its gateway when it was allocated and an address from
+	// a template after
+	client := newIP4ChildrenClient()
+	existing, err := getIP4ChildIDs(client, 3, "IP4Network")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.add(3, 7, "IP4Address", "address=10.0.0.7|state=STATIC|")
+
+	if err := rollbackIP4Create(client, 3, "IP4Network", existing); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "7:" + ip4DeleteChildOptions + " 3"
+	if got := strings.Join(client.deleted, " "); got != want {
+		t.Errorf("expected only the created address to be deleted before the network as %q, got %q", want, got)
+	}
+}
+
+func TestRollbackIP4CreateExistingChildren(t *testing.T) {
+	// a block created over a range that already holds a block and a network
+	client := newIP4ChildrenClient()
+	existing, err := getIP4ChildIDs(client, 1, "IP4Block")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rollbackIP4Create(client, 1, "IP4Block", existing); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(client.deleted, " "); got != "1" {
+		t.Errorf("expected only the block to be deleted, got %q", got)
+	}
+
+	client.deleted = nil
+	if err := rollbackIP4Create(client, 1, "IP4Block", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(client.deleted, " "); got != "1" {
+		t.Errorf("expected only the block to be deleted when the children are not known, got %q", got)
+	}
+}
+
+func TestIP4BlockingChildrenDetail(t *testing.T) {
+	client := &ip4ChildrenClient{}
+	for i := range int64(12) {
+		client.add(1, 100+i, "IP4Address", fmt.Sprintf("address=10.0.0.%d|state=STATIC|", 10+i))
+	}
+
+	detail := ip4BlockingChildrenDetail("IP4Network", client.children[1])
+	if !strings.Contains(detail, "contains 12 objects") {
+		t.Errorf("expected the number of children in %q", detail)
+	}
+	if !strings.Contains(detail, "IP4Address 10.0.0.10 (100)") || strings.Contains(detail, "10.0.0.21") {
+		t.Errorf("expected only the first %d children to be listed in %q", ip4DeleteBlockingChildrenListed, detail)
+	}
+	if !strings.Contains(detail, "and 2 more") || !strings.Contains(detail, "force_delete") {
+		t.Errorf("expected the remaining count and force_delete hint in %q", detail)
+	}
+}
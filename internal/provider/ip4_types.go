@@ -0,0 +1,393 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the custom types fully satisfy framework interfaces.
+var (
+	_ basetypes.StringTypable                    = ip4AddressType{}
+	_ basetypes.StringValuableWithSemanticEquals = ip4AddressValue{}
+	_ xattr.ValidateableAttribute                = ip4AddressValue{}
+	_ basetypes.StringTypable                    = ip4CIDRType{}
+	_ basetypes.StringValuableWithSemanticEquals = ip4CIDRValue{}
+	_ xattr.ValidateableAttribute                = ip4CIDRValue{}
+)
+
+// parseIP4Address parses an IPv4 address in dotted decimal notation. Unlike
+// net.ParseIP, octets with leading zeros are accepted and read as decimal,
+// so 10.0.0.01 is the same address as 10.0.0.1.
+func parseIP4Address(s string) ([4]byte, error) {
+	var ip [4]byte
+
+	octets := strings.Split(s, ".")
+	if len(octets) != 4 {
+		return ip, fmt.Errorf("%q is not an IPv4 address", s)
+	}
+
+	for i, octet := range octets {
+		if octet == "" || len(octet) > 3 || strings.TrimLeft(octet, "0123456789") != "" {
+			return ip, fmt.Errorf("%q is not an IPv4 address", s)
+		}
+
+		n, err := strconv.Atoi(octet)
+		if err != nil || n > 255 {
+			return ip, fmt.Errorf("%q is not an IPv4 address: octet %s is larger than 255", s, octet)
+		}
+		ip[i] = byte(n)
+	}
+
+	return ip, nil
+}
+
+// parseIP4CIDR parses an IPv4 CIDR such as 10.0.0.0/24 and returns its
+// network address and prefix length. The address may have leading zeros like
+// in parseIP4Address.
+func parseIP4CIDR(s string) ([4]byte, int, error) {
+	address, prefix, ok := strings.Cut(s, "/")
+	if !ok {
+		return [4]byte{}, 0, fmt.Errorf("%q is not an IPv4 CIDR: the prefix length is missing", s)
+	}
+
+	ip, err := parseIP4Address(address)
+	if err != nil {
+		return ip, 0, fmt.Errorf("%q is not an IPv4 CIDR: %w", s, err)
+	}
+
+	length, err := strconv.Atoi(prefix)
+	if err != nil || length < 0 || length > 32 || strings.TrimLeft(prefix, "0123456789") != "" {
+		return ip, 0, fmt.Errorf("%q is not an IPv4 CIDR: the prefix length must be between 0 and 32", s)
+	}
+
+	return ip, length, nil
+}
+
+// formatIP4Address returns ip in dotted decimal notation.
+func formatIP4Address(ip [4]byte) string {
+	return fmt.Sprintf("%d.%d.%d.%d", ip[0], ip[1], ip[2], ip[3])
+}
+
+// ip4AddressType is the type of attributes that hold an IPv4 address.
+type ip4AddressType struct {
+	basetypes.StringType
+}
+
+func (t ip4AddressType) Equal(o attr.Type) bool {
+	other, ok := o.(ip4AddressType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t ip4AddressType) String() string {
+	return "ip4AddressType"
+}
+
+func (t ip4AddressType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return ip4AddressValue{StringValue: in}, nil
+}
+
+func (t ip4AddressType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return ip4AddressValue{StringValue: stringValue}, nil
+}
+
+func (t ip4AddressType) ValueType(ctx context.Context) attr.Value {
+	return ip4AddressValue{}
+}
+
+// ip4AddressValue is an IPv4 address. Addresses that only differ in leading
+// zeros are semantically equal, and invalid addresses are rejected when
+// validating the configuration.
+type ip4AddressValue struct {
+	basetypes.StringValue
+}
+
+// newIP4AddressValue returns a known ip4AddressValue of s.
+func newIP4AddressValue(s string) ip4AddressValue {
+	return ip4AddressValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+// newIP4AddressNull returns a null ip4AddressValue.
+func newIP4AddressNull() ip4AddressValue {
+	return ip4AddressValue{StringValue: basetypes.NewStringNull()}
+}
+
+// newIP4AddressFromString returns the ip4AddressValue of a string value.
+func newIP4AddressFromString(s basetypes.StringValue) ip4AddressValue {
+	return ip4AddressValue{StringValue: s}
+}
+
+func (v ip4AddressValue) Equal(o attr.Value) bool {
+	other, ok := o.(ip4AddressValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v ip4AddressValue) Type(ctx context.Context) attr.Type {
+	return ip4AddressType{}
+}
+
+func (v ip4AddressValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(ip4AddressValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	prior, err := parseIP4Address(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	proposed, err := parseIP4Address(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	return prior == proposed, diags
+}
+
+func (v ip4AddressValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := parseIP4Address(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IPv4 Address", err.Error())
+	}
+}
+
+// ValueIP4String returns the address without leading zeros as it is sent to
+// the API, or the value as is if it is not a valid address.
+func (v ip4AddressValue) ValueIP4String() string {
+	ip, err := parseIP4Address(v.ValueString())
+	if err != nil {
+		return v.ValueString()
+	}
+
+	return formatIP4Address(ip)
+}
+
+// ip4CIDRType is the type of attributes that hold an IPv4 CIDR.
+type ip4CIDRType struct {
+	basetypes.StringType
+}
+
+func (t ip4CIDRType) Equal(o attr.Type) bool {
+	other, ok := o.(ip4CIDRType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t ip4CIDRType) String() string {
+	return "ip4CIDRType"
+}
+
+func (t ip4CIDRType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return ip4CIDRValue{StringValue: in}, nil
+}
+
+func (t ip4CIDRType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return ip4CIDRValue{StringValue: stringValue}, nil
+}
+
+func (t ip4CIDRType) ValueType(ctx context.Context) attr.Value {
+	return ip4CIDRValue{}
+}
+
+// ip4CIDRValue is an IPv4 CIDR. CIDRs are semantically equal if they are the
+// same network with the same prefix length, so leading zeros and host bits
+// are ignored, but 10.0.0.0/24 does not equal 10.0.0.0/25. Invalid CIDRs are
+// rejected when validating the configuration.
+type ip4CIDRValue struct {
+	basetypes.StringValue
+}
+
+// newIP4CIDRValue returns a known ip4CIDRValue of s.
+func newIP4CIDRValue(s string) ip4CIDRValue {
+	return ip4CIDRValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+// newIP4CIDRNull returns a null ip4CIDRValue.
+func newIP4CIDRNull() ip4CIDRValue {
+	return ip4CIDRValue{StringValue: basetypes.NewStringNull()}
+}
+
+// newIP4CIDRFromString returns the ip4CIDRValue of a string value.
+func newIP4CIDRFromString(s basetypes.StringValue) ip4CIDRValue {
+	return ip4CIDRValue{StringValue: s}
+}
+
+func (v ip4CIDRValue) Equal(o attr.Value) bool {
+	other, ok := o.(ip4CIDRValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v ip4CIDRValue) Type(ctx context.Context) attr.Type {
+	return ip4CIDRType{}
+}
+
+func (v ip4CIDRValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(ip4CIDRValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return ip4CIDREqual(v.ValueIP4String(), newValue.ValueIP4String()), diags
+}
+
+func (v ip4CIDRValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, _, err := parseIP4CIDR(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IPv4 CIDR", err.Error())
+	}
+}
+
+// ValueIP4String returns the CIDR without leading zeros as it is sent to the
+// API, or the value as is if it is not a valid CIDR.
+func (v ip4CIDRValue) ValueIP4String() string {
+	ip, prefix, err := parseIP4CIDR(v.ValueString())
+	if err != nil {
+		return v.ValueString()
+	}
+
+	return formatIP4Address(ip) + "/" + strconv.Itoa(prefix)
+}
+
+// ip4AddressValidator validates that a string is an IPv4 address. Values of
+// ip4AddressType validate themselves, but the elements of a set are not, so
+// sets of addresses use this validator for their elements.
+type ip4AddressValidator struct{}
+
+func (v ip4AddressValidator) Description(ctx context.Context) string {
+	return "value must be an IPv4 address"
+}
+
+func (v ip4AddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ip4AddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseIP4Address(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IPv4 Address", err.Error())
+	}
+}
+
+// ip4AddressStrings returns the addresses in a set of ip4AddressType without
+// leading zeros.
+func ip4AddressStrings(ctx context.Context, set basetypes.SetValue) ([]string, diag.Diagnostics) {
+	var values []ip4AddressValue
+	diags := set.ElementsAs(ctx, &values, false)
+
+	addresses := make([]string, 0, len(values))
+	for _, v := range values {
+		addresses = append(addresses, v.ValueIP4String())
+	}
+
+	return addresses, diags
+}
+
+// ip4AddressSet returns a set of strings as a set of ip4AddressType.
+func ip4AddressSet(set basetypes.SetValue) basetypes.SetValue {
+	if set.IsNull() {
+		return basetypes.NewSetNull(ip4AddressType{})
+	}
+	if set.IsUnknown() {
+		return basetypes.NewSetUnknown(ip4AddressType{})
+	}
+
+	elements := make([]attr.Value, 0, len(set.Elements()))
+	for _, element := range set.Elements() {
+		if s, ok := element.(basetypes.StringValue); ok {
+			elements = append(elements, ip4AddressValue{StringValue: s})
+		}
+	}
+
+	return basetypes.NewSetValueMust(ip4AddressType{}, elements)
+}
+
+// ip4ValuesEqual returns whether a and b are the same IPv4 address or the
+// same IPv4 CIDR in different formats.
+func ip4ValuesEqual(a, b string) bool {
+	if ipA, err := parseIP4Address(a); err == nil {
+		ipB, err := parseIP4Address(b)
+		return err == nil && ipA == ipB
+	}
+
+	return ip4CIDREqual(newIP4CIDRValue(a).ValueIP4String(), newIP4CIDRValue(b).ValueIP4String())
+}
+
+const ip4RequiresReplaceDescription = "If the value of this attribute changes to a different IPv4 address or CIDR, Terraform will destroy and recreate the resource. Changes in format alone, such as leading zeros, do not."
+
+// ip4RequiresReplace returns a plan modifier that forces a new resource when
+// an IPv4 address or CIDR changes, but not when only its format changes.
+func ip4RequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !ip4ValuesEqual(req.PlanValue.ValueString(), req.StateValue.ValueString())
+		},
+		ip4RequiresReplaceDescription,
+		ip4RequiresReplaceDescription,
+	)
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseIP4Address(t *testing.T) {
+	for s, expected := range map[string][4]byte{
+		"10.0.0.1":        {10, 0, 0, 1},
+		"010.000.000.001": {10, 0, 0, 1},
+		"255.255.255.255": {255, 255, 255, 255},
+	} {
+		ip, err := parseIP4Address(s)
+		if err != nil {
+			t.Errorf("%s: %s", s, err)
+		} else if ip != expected {
+			t.Errorf("%s: expected %v, got %v", s, expected, ip)
+		}
+	}
+
+	for _, s := range []string{"", "10.0.0", "10.0.0.1.2", "10.0.0.256", "10.0.0.-1", "10.0.0.0001", "10.0.0.a", "10.0.0.1/24", " 10.0.0.1"} {
+		if _, err := parseIP4Address(s); err == nil {
+			t.Errorf("expected %q to be rejected", s)
+		}
+	}
+}
+
+func TestParseIP4CIDR(t *testing.T) {
+	ip, prefix, err := parseIP4CIDR("010.0.0.0/024")
+	if err != nil || ip != [4]byte{10, 0, 0, 0} || prefix != 24 {
+		t.Errorf("unexpected %v/%d, %v", ip, prefix, err)
+	}
+
+	for _, s := range []string{"10.0.0.0", "10.0.0.0/33", "10.0.0.0/-1", "10.0.0.0/", "10.0.0.0/+8", "10.0.0/8"} {
+		if _, _, err := parseIP4CIDR(s); err == nil {
+			t.Errorf("expected %q to be rejected", s)
+		}
+	}
+}
+
+func TestIP4AddressValue(t *testing.T) {
+	ctx := context.Background()
+
+	for prior, proposed := range map[string]string{
+		"10.0.0.1":   "10.0.0.1",
+		"10.0.0.01":  "10.0.0.1",
+		"010.0.0.1":  "10.000.0.001",
+		"172.16.0.9": "172.016.000.009",
+	} {
+		equal, diags := newIP4AddressValue(prior).StringSemanticEquals(ctx, newIP4AddressValue(proposed))
+		if diags.HasError() || !equal {
+			t.Errorf("expected %s to equal %s", prior, proposed)
+		}
+	}
+
+	for prior, proposed := range map[string]string{
+		"10.0.0.1":     "10.0.0.2",
+		"not an ip":    "not an ip",
+		"10.0.0.3":     "",
+		"192.168.0.10": "192.168.0.1",
+	} {
+		if equal, _ := newIP4AddressValue(prior).StringSemanticEquals(ctx, newIP4AddressValue(proposed)); equal {
+			t.Errorf("expected %s not to equal %s", prior, proposed)
+		}
+	}
+
+	if v := newIP4AddressValue("010.000.000.001").ValueIP4String(); v != "10.0.0.1" {
+		t.Errorf("expected 10.0.0.1, got %s", v)
+	}
+
+	resp := &xattr.ValidateAttributeResponse{}
+	newIP4AddressValue("10.0.0.256").ValidateAttribute(ctx, xattr.ValidateAttributeRequest{Path: path.Root("address")}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an invalid address to be rejected")
+	}
+
+	resp = &xattr.ValidateAttributeResponse{}
+	newIP4AddressNull().ValidateAttribute(ctx, xattr.ValidateAttributeRequest{Path: path.Root("address")}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("expected a null address to be valid, got %s", resp.Diagnostics)
+	}
+}
+
+func TestIP4CIDRValue(t *testing.T) {
+	ctx := context.Background()
+
+	for prior, proposed := range map[string]string{
+		"10.0.0.0/24": "10.0.0.0/24",
+		"10.0.0.0/8":  "010.000.000.000/08",
+		"10.0.0.1/24": "10.0.0.0/24",
+	} {
+		equal, diags := newIP4CIDRValue(prior).StringSemanticEquals(ctx, newIP4CIDRValue(proposed))
+		if diags.HasError() || !equal {
+			t.Errorf("expected %s to equal %s", prior, proposed)
+		}
+	}
+
+	for prior, proposed := range map[string]string{
+		"10.0.0.0/24": "10.0.0.0/25",
+		"10.0.1.0/24": "10.0.0.0/24",
+		"10.0.0.0":    "10.0.0.0",
+	} {
+		if equal, _ := newIP4CIDRValue(prior).StringSemanticEquals(ctx, newIP4CIDRValue(proposed)); equal {
+			t.Errorf("expected %s not to equal %s", prior, proposed)
+		}
+	}
+
+	if v := newIP4CIDRValue("010.000.000.000/08").ValueIP4String(); v != "10.0.0.0/8" {
+		t.Errorf("expected 10.0.0.0/8, got %s", v)
+	}
+
+	resp := &xattr.ValidateAttributeResponse{}
+	newIP4CIDRValue("10.0.0.0/33").ValidateAttribute(ctx, xattr.ValidateAttributeRequest{Path: path.Root("cidr")}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an invalid CIDR to be rejected")
+	}
+}
+
+func TestIP4AddressSet(t *testing.T) {
+	ctx := context.Background()
+
+	set := ip4AddressSet(types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.1"), types.StringValue("10.0.0.2")}))
+	if !set.ElementType(ctx).Equal(ip4AddressType{}) || len(set.Elements()) != 2 {
+		t.Errorf("unexpected set %s", set)
+	}
+
+	if set := ip4AddressSet(types.SetNull(types.StringType)); !set.IsNull() || !set.ElementType(ctx).Equal(ip4AddressType{}) {
+		t.Errorf("expected a null set of addresses, got %s", set)
+	}
+
+	addresses, diags := ip4AddressStrings(ctx, types.SetValueMust(ip4AddressType{}, []attr.Value{newIP4AddressValue("010.0.0.1")}))
+	if diags.HasError() || len(addresses) != 1 || addresses[0] != "10.0.0.1" {
+		t.Errorf("unexpected addresses %v, %s", addresses, diags)
+	}
+}
+
+func TestIP4ValuesEqual(t *testing.T) {
+	for _, pair := range [][2]string{
+		{"10.0.0.1", "010.000.000.001"},
+		{"10.0.0.0/24", "10.0.0.0/024"},
+		{"10.0.0.0/24", "10.0.0.5/24"},
+	} {
+		if !ip4ValuesEqual(pair[0], pair[1]) {
+			t.Errorf("expected %s to equal %s", pair[0], pair[1])
+		}
+	}
+
+	for _, pair := range [][2]string{
+		{"10.0.0.1", "10.0.0.2"},
+		{"10.0.0.0/24", "10.0.0.0/25"},
+		{"10.0.0.1", ""},
+		{"", ""},
+	} {
+		if ip4ValuesEqual(pair[0], pair[1]) {
+			t.Errorf("expected %s not to equal %s", pair[0], pair[1])
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package provider
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/umich-vci/gobam"
+)
+
+// ip4NetworkInUseProperty is the property of an IPv4 network returned by
+// GetIP4NetworksByHint with the number of addresses in use, if the server
+// returns it.
+const ip4NetworkInUseProperty = "inUseAddresses"
+
+// ip4NetworkUsageCache holds the number of addresses in use in each IPv4
+// network so that a network is only counted once per run, even when several
+// resources and data sources look at it.
+type ip4NetworkUsageCache struct {
+	mu       sync.Mutex
+	inUse    map[int64]int64
+	dhcpFree map[int64]int64
+}
+
+// get returns the number of addresses in use in the IPv4 network id, counting
+// them with client if they have not been counted yet. A nil cache always
+// counts them.
+func (c *ip4NetworkUsageCache) get(client gobam.ProteusAPI, id int64, cidr string, size int64) (int64, error) {
+	if c == nil {
+		return getIP4NetworkInUse(client, id, cidr, size)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if inUse, ok := c.inUse[id]; ok {
+		return inUse, nil
+	}
+
+	inUse, err := getIP4NetworkInUse(client, id, cidr, size)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.inUse == nil {
+		c.inUse = make(map[int64]int64)
+	}
+	c.inUse[id] = inUse
+
+	return inUse, nil
+}
+
+// getDHCPFree returns the number of free addresses in the DHCP ranges of the
+// IPv4 network id, counting them with client if they have not been counted
+// yet. A nil cache always counts them.
+func (c *ip4NetworkUsageCache) getDHCPFree(client gobam.ProteusAPI, id int64) (int64, error) {
+	if c == nil {
+		return countIP4NetworkDHCPFreeAddresses(client, id)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if free, ok := c.dhcpFree[id]; ok {
+		return free, nil
+	}
+
+	free, err := countIP4NetworkDHCPFreeAddresses(client, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.dhcpFree == nil {
+		c.dhcpFree = make(map[int64]int64)
+	}
+	c.dhcpFree[id] = free
+
+	return free, nil
+}
+
+// getIP4NetworkAddressUsage returns the number of addresses in use and free in
+// the IPv4 network id.
+func getIP4NetworkAddressUsage(client gobam.ProteusAPI, usage *ip4NetworkUsageCache, id int64, cidr string) (int64, int64, error) {
+	size, err := cidrToSize(cidr)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	inUse, err := usage.get(client, id, cidr, size)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return inUse, max(size-inUse, 0), nil
+}
+
+// getIP4NetworkFreeAddressCount returns the number of free addresses in the
+// IPv4 network id, counted as accounting describes. freeAddressAccountingTotal
+// counts every address that is not in use. freeAddressAccountingAssignable
+// also leaves out the network and broadcast addresses, which are never
+// assigned. freeAddressAccountingStatic also leaves out the free addresses in
+// the DHCP ranges of the network, which cannot be assigned statically. The
+// gateway and reserved addresses are address objects, so they are always
+// counted as in use.
+func getIP4NetworkFreeAddressCount(client gobam.ProteusAPI, usage *ip4NetworkUsageCache, id int64, cidr string, accounting string) (int64, error) {
+	_, free, err := getIP4NetworkAddressUsage(client, usage, id, cidr)
+	if err != nil || accounting == freeAddressAccountingTotal || accounting == "" {
+		return free, err
+	}
+
+	size, err := cidrToSize(cidr)
+	if err != nil {
+		return 0, err
+	}
+
+	// /31 and /32 networks have no network or broadcast address
+	if size > 2 {
+		free -= 2
+	}
+
+	if accounting == freeAddressAccountingStatic {
+		dhcpFree, err := usage.getDHCPFree(client, id)
+		if err != nil {
+			return 0, err
+		}
+		free -= dhcpFree
+	}
+
+	return max(free, 0), nil
+}
+
+// countIP4NetworkDHCPFreeAddresses counts the addresses in the DHCP ranges of
+// the IPv4 network id that are not in use. Only the addresses in each range
+// are paged through, so the rest of a large network is not read.
+func countIP4NetworkDHCPFreeAddresses(client gobam.ProteusAPI, id int64) (int64, error) {
+	dhcpRanges, err := getAllEntities(client, id, "DHCP4Range")
+	if err != nil {
+		return 0, err
+	}
+
+	var free int64
+	for _, r := range dhcpRanges {
+		start, end, err := ip4EntityRange(r)
+		if err != nil {
+			return 0, err
+		}
+		size := int64(end) - int64(start) + 1
+
+		inUse, err := countIP4NetworkAddresses(client, *r.Id, size)
+		if err != nil {
+			return 0, err
+		}
+		free += size - inUse
+	}
+
+	return free, nil
+}
+
+// getIP4NetworkInUse returns the number of addresses in use in the IPv4
+// network id. The count the server returns with GetIP4NetworksByHint is used
+// if there is one, otherwise the addresses of the network are counted a page
+// at a time.
+func getIP4NetworkInUse(client gobam.ProteusAPI, id int64, cidr string, size int64) (int64, error) {
+	parent, err := client.GetParent(id)
+	if err != nil {
+		return 0, err
+	}
+
+	if parent.Id != nil && *parent.Id != 0 {
+		options := "hint=" + strings.Split(cidr, "/")[0]
+		networks, err := searchByHint(func(start int, count int) (*gobam.APIEntityArray, error) {
+			return client.GetIP4NetworksByHint(*parent.Id, start, count, options)
+		}, hintMaxSearchResults, 1, func(n *gobam.APIEntity) (*gobam.APIEntity, error) {
+			if *n.Id != id {
+				return nil, nil
+			}
+			return n, nil
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		if len(networks) == 1 && networks[0].Properties != nil {
+			if v, ok := parseProperties(*networks[0].Properties)[ip4NetworkInUseProperty]; ok {
+				return strconv.ParseInt(v, 10, 64)
+			}
+		}
+	}
+
+	return countIP4NetworkAddresses(client, id, size)
+}
+
+// countIP4NetworkAddresses counts the addresses in the IPv4 network or DHCP
+// range id a page at a time, stopping once size addresses have been counted.
+func countIP4NetworkAddresses(client gobam.ProteusAPI, id int64, size int64) (int64, error) {
+	var inUse int64
+
+	for start := 0; inUse < size; start += ip4BlocksPageSize {
+		page, err := client.GetEntities(id, "IP4Address", start, ip4BlocksPageSize)
+		if err != nil {
+			return 0, err
+		}
+
+		inUse += int64(len(page.Item))
+
+		if len(page.Item) < ip4BlocksPageSize {
+			break
+		}
+	}
+
+	return min(inUse, size), nil
+}
+
+// ip4BlockHasFreeRange returns whether the IPv4 block blockID has a free range
+// of size addresses, aligned to size like a network of that size would be,
+// that does not overlap any of the blocks and networks directly in it.
+func ip4BlockHasFreeRange(client gobam.ProteusAPI, blockID int64, size int64) (bool, error) {
+	block, err := client.GetEntityById(blockID)
+	if err != nil {
+		return false, err
+	}
+	if block.Type == nil || *block.Type != "IP4Block" || block.Properties == nil {
+		return false, fmt.Errorf("object %d is not an IPv4 block", blockID)
+	}
+
+	first, last, err := ip4EntityRange(block)
+	if err != nil {
+		return false, err
+	}
+
+	var used [][2]uint32
+	for _, objectType := range []string{"IP4Block", "IP4Network"} {
+		children, err := getAllEntities(client, blockID, objectType)
+		if err != nil {
+			return false, err
+		}
+
+		for _, child := range children {
+			start, end, err := ip4EntityRange(child)
+			if err != nil {
+				continue
+			}
+			used = append(used, [2]uint32{start, end})
+		}
+	}
+
+	return ip4RangeHasFreeRange(first, last, used, size), nil
+}
+
+// ip4EntityRange returns the first and last address of an IP4Block or
+// IP4Network entity as integers.
+func ip4EntityRange(e *gobam.APIEntity) (uint32, uint32, error) {
+	if e == nil || e.Properties == nil {
+		return 0, 0, fmt.Errorf("entity has no properties")
+	}
+
+	props := parseProperties(*e.Properties)
+	start, end := props["start"], props["end"]
+	if cidr := props["CIDR"]; cidr != "" {
+		var err error
+		start, end, err = ip4CIDRRange(cidr)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	startIP := net.ParseIP(start).To4()
+	endIP := net.ParseIP(end).To4()
+	if startIP == nil || endIP == nil {
+		return 0, 0, fmt.Errorf("entity has neither a CIDR nor a start and end")
+	}
+
+	return binary.BigEndian.Uint32(startIP), binary.BigEndian.Uint32(endIP), nil
+}
+
+// ip4RangeHasFreeRange returns whether there is a range of size addresses,
+// starting at a multiple of size, from first to last that does not overlap
+// any of the used ranges.
+func ip4RangeHasFreeRange(first, last uint32, used [][2]uint32, size int64) bool {
+	if size <= 0 {
+		return false
+	}
+
+	slices.SortFunc(used, func(a, b [2]uint32) int { return cmp.Compare(a[0], b[0]) })
+
+	// fits returns whether an aligned range fits between next and gapEnd
+	next := int64(first)
+	fits := func(gapEnd int64) bool {
+		start := (next + size - 1) / size * size
+		return start+size-1 <= gapEnd
+	}
+
+	// walk the gaps between the used ranges
+	for _, r := range used {
+		if int64(r[0]) > next && fits(min(int64(r[0])-1, int64(last))) {
+			return true
+		}
+
+		next = max(next, int64(r[1])+1)
+		if next > int64(last) {
+			return false
+		}
+	}
+
+	return fits(int64(last))
+}
@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/umich-vci/gobam"
+)
+
+// networkUsageClient returns a network in a block with an optional in use
+// count and the addresses in the network.
+type networkUsageClient struct {
+	gobam.ProteusAPI
+	inUse     string
+	addresses int
+	pages     int
+}
+
+func (c *networkUsageClient) GetParent(entityId int64) (*gobam.APIEntity, error) {
+	id := int64(1)
+	return &gobam.APIEntity{Id: &id}, nil
+}
+
+func (c *networkUsageClient) GetIP4NetworksByHint(containerId int64, start int, count int, options string) (*gobam.APIEntityArray, error) {
+	id := int64(2)
+	properties := "CIDR=10.0.0.0/24|"
+	if c.inUse != "" {
+		properties += ip4NetworkInUseProperty + "=" + c.inUse + "|"
+	}
+	return &gobam.APIEntityArray{Item: []*gobam.APIEntity{{Id: &id, Properties: &properties}}}, nil
+}
+
+func (c *networkUsageClient) GetEntities(parentId int64, _type string, start int, count int) (*gobam.APIEntityArray, error) {
+	c.pages++
+	entities := &gobam.APIEntityArray{}
+	for i := start; i < c.addresses && i < start+count; i++ {
+		id := int64(100 + i)
+		entities.Item = append(entities.Item, &gobam.APIEntity{Id: &id})
+	}
+	return entities, nil
+}
+
+func TestGetIP4NetworkInUse(t *testing.T) {
+	client := &networkUsageClient{inUse: "12", addresses: 3}
+	inUse, err := getIP4NetworkInUse(client, 2, "10.0.0.0/24", 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inUse != 12 || client.pages != 0 {
+		t.Errorf("expected the count from the server without listing addresses, got %d (%d pages)", inUse, client.pages)
+	}
+
+	client = &networkUsageClient{addresses: ip4BlocksPageSize + 5}
+	inUse, err = getIP4NetworkInUse(client, 2, "10.0.0.0/16", 65536)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inUse != ip4BlocksPageSize+5 || client.pages != 2 {
+		t.Errorf("expected all pages of addresses to be counted, got %d (%d pages)", inUse, client.pages)
+	}
+
+	client = &networkUsageClient{addresses: 2 * ip4BlocksPageSize}
+	if _, err := getIP4NetworkInUse(client, 2, "10.0.0.0/22", ip4BlocksPageSize); err != nil {
+		t.Fatal(err)
+	}
+	if client.pages != 1 {
+		t.Errorf("expected counting to stop once the network is full, got %d pages", client.pages)
+	}
+}
+
+func TestIP4NetworkUsageCache(t *testing.T) {
+	client := &networkUsageClient{addresses: 3}
+	cache := &ip4NetworkUsageCache{}
+
+	for range 2 {
+		inUse, free, err := getIP4NetworkAddressUsage(client, cache, 2, "10.0.0.0/24")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if inUse != 3 || free != 253 {
+			t.Errorf("expected 3 addresses in use and 253 free, got %d and %d", inUse, free)
+		}
+	}
+	if client.pages != 1 {
+		t.Errorf("expected the network to be counted once, got %d pages", client.pages)
+	}
+
+	if _, _, err := getIP4NetworkAddressUsage(client, nil, 2, "10.0.0.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	if client.pages != 2 {
+		t.Errorf("expected a nil cache to count the network again, got %d pages", client.pages)
+	}
+}
+
+func TestIP4RangeHasFreeRange(t *testing.T) {
+	// 10.0.0.0/24 with 10.0.0.0/26 and 10.0.0.128/26 in use
+	first, last := uint32(0x0a000000), uint32(0x0a0000ff)
+	used := [][2]uint32{{0x0a000080, 0x0a0000bf}, {0x0a000000, 0x0a00003f}}
+
+	if !ip4RangeHasFreeRange(first, last, used, 64) {
+		t.Error("expected a free /26")
+	}
+	if ip4RangeHasFreeRange(first, last, used, 128) {
+		t.Error("expected no free /25 as the free addresses are not aligned")
+	}
+	if !ip4RangeHasFreeRange(first, last, nil, 256) {
+		t.Error("expected an empty block to fit a network of its own size")
+	}
+	if ip4RangeHasFreeRange(first, last, nil, 512) {
+		t.Error("expected a network larger than the block not to fit")
+	}
+
+	// a block that does not start on a boundary of size
+	if ip4RangeHasFreeRange(0x0a000010, 0x0a00004f, nil, 64) {
+		t.Error("expected no aligned /26 in 10.0.0.16-10.0.0.79")
+	}
+	if !ip4RangeHasFreeRange(0x0a000010, 0x0a00004f, nil, 32) {
+		t.Error("expected the aligned /27 at 10.0.0.32 to fit")
+	}
+}
+
+func TestIP4EntityRange(t *testing.T) {
+	cidr := "CIDR=10.0.1.0/24|"
+	first, last, err := ip4EntityRange(&gobam.APIEntity{Properties: &cidr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != 0x0a000100 || last != 0x0a0001ff {
+		t.Errorf("expected 10.0.1.0-10.0.1.255, got %x-%x", first, last)
+	}
+
+	startEnd := "start=10.0.1.10|end=10.0.1.20|"
+	first, last, err = ip4EntityRange(&gobam.APIEntity{Properties: &startEnd})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != 0x0a00010a || last != 0x0a000114 {
+		t.Errorf("expected 10.0.1.10-10.0.1.20, got %x-%x", first, last)
+	}
+
+	none := "name=test|"
+	if _, _, err := ip4EntityRange(&gobam.APIEntity{Properties: &none}); err == nil {
+		t.Error("expected an error for an entity without a range")
+	}
+}
+
+// dhcpUsageClient returns a network with DHCP ranges and the addresses in
+// each range, and records the objects whose addresses were read.
+type dhcpUsageClient struct {
+	networkUsageClient
+	ranges    []string
+	addresses map[int64][]string
+	read      []int64
+}
+
+func (c *dhcpUsageClient) GetEntities(parentId int64, _type string, start int, count int) (*gobam.APIEntityArray, error) {
+	entities := &gobam.APIEntityArray{}
+	switch _type {
+	case "DHCP4Range":
+		for i := start; i < len(c.ranges) && i < start+count; i++ {
+			id := int64(100 + i)
+			bounds := strings.Split(c.ranges[i], "-")
+			properties := "start=" + bounds[0] + "|end=" + bounds[1] + "|"
+			entities.Item = append(entities.Item, &gobam.APIEntity{Id: &id, Properties: &properties})
+		}
+	case "IP4Address":
+		c.read = append(c.read, parentId)
+		addresses := c.addresses[parentId]
+		for i := start; i < len(addresses) && i < start+count; i++ {
+			id := parentId*1000 + int64(i)
+			properties := "address=" + addresses[i] + "|"
+			entities.Item = append(entities.Item, &gobam.APIEntity{Id: &id, Properties: &properties})
+		}
+	}
+	return entities, nil
+}
+
+func TestGetIP4NetworkFreeAddressCount(t *testing.T) {
+	client := &dhcpUsageClient{
+		networkUsageClient: networkUsageClient{inUse: "4"},
+		ranges:             []string{"10.0.0.100-10.0.0.149", "10.0.0.150-10.0.0.199"},
+		addresses: map[int64][]string{
+			2:   {"10.0.0.1", "10.0.0.10"},
+			100: {"10.0.0.100"},
+			101: {"10.0.0.150"},
+		},
+	}
+
+	tests := map[string]int64{
+		freeAddressAccountingTotal:      252,
+		freeAddressAccountingAssignable: 250,
+		freeAddressAccountingStatic:     152,
+	}
+
+	for accounting, expected := range tests {
+		free, err := getIP4NetworkFreeAddressCount(client, nil, 2, "10.0.0.0/24", accounting)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if free != expected {
+			t.Errorf("expected %d free addresses with %s accounting, got %d", expected, accounting, free)
+		}
+	}
+
+	// only the addresses in the DHCP ranges are read, and only once with a cache
+	client.read = nil
+	usage := &ip4NetworkUsageCache{}
+	for range 2 {
+		if _, err := getIP4NetworkFreeAddressCount(client, usage, 2, "10.0.0.0/24", freeAddressAccountingStatic); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !slices.Equal(client.read, []int64{100, 101}) {
+		t.Errorf("expected the addresses of each DHCP range to be read once, got %v", client.read)
+	}
+
+	client = &dhcpUsageClient{networkUsageClient: networkUsageClient{inUse: "0"}}
+	free, err := getIP4NetworkFreeAddressCount(client, nil, 2, "10.0.0.0/31", freeAddressAccountingAssignable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if free != 2 {
+		t.Errorf("expected both addresses of a /31 to be assignable, got %d", free)
+	}
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umich-vci/gobam"
+)
+
+// locationCodeRegexp matches a hierarchical location code such as "US DTW OF1".
+// The code is case-sensitive and must be in uppercase letters. The country code
+// and child location codes are alphanumeric strings separated by a space.
+var locationCodeRegexp = regexp.MustCompile(`^[A-Z0-9]+( [A-Z0-9]+)*$`)
+
+const locationCodeRegexpMessage = "must be an uppercase alphanumeric location code such as \"US DTW OF1\""
+
+// getLocationByCode returns the location with code, or nil if there is none.
+func getLocationByCode(client gobam.ProteusAPI, code string) (*gobam.APIEntity, error) {
+	location, err := client.GetLocationByCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	if location == nil || location.Id == nil || *location.Id == 0 {
+		return nil, nil
+	}
+
+	return location, nil
+}
+
+// locationParentCode returns the code of the location that contains the
+// location with code, or an empty string for a top level location.
+func locationParentCode(code string) string {
+	i := strings.LastIndex(code, " ")
+	if i < 0 {
+		return ""
+	}
+
+	return code[:i]
+}
+
+// modifyPlanLocationCode checks that a planned location_code that differs from
+// the state refers to a location that exists in BlueCat Address Manager.
+func modifyPlanLocationCode(ctx context.Context, loginClient *loginClient, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to validate when the resource is being destroyed
+	if loginClient == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planned, current types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("location_code"), &planned)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("location_code"), &current)...)
+	}
+	if resp.Diagnostics.HasError() || planned.IsNull() || planned.IsUnknown() || planned.ValueString() == "" || planned.Equal(current) {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, loginClient, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	location, err := getLocationByCode(client, planned.ValueString())
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get location by code", err.Error())
+		return
+	}
+
+	if location == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("location_code"),
+			"Unknown Location Code",
+			fmt.Sprintf("No location with code %q exists in BlueCat Address Manager.", planned.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/maps"
+)
+
+// propertyNameRegexp matches the names of properties, such as user-defined
+// fields. Unlike values, names cannot be escaped, so the separators of the
+// properties string are not allowed in them.
+var propertyNameRegexp = regexp.MustCompile(`^[^|=\\]+$`)
+
+const propertyNameRegexpMessage = "must not be empty or contain '|', '=', or '\\'"
+
+// propertyMap holds properties of an object to pass to the API. Use String to
+// get the pipe delimited properties string, which escapes '|' and '=' in
+// values so that a value cannot change the properties that are sent.
+type propertyMap map[string]string
+
+// set sets a string property.
+func (p propertyMap) set(key, value string) {
+	p[key] = value
+}
+
+// setBool sets a boolean property to true or false.
+func (p propertyMap) setBool(key string, value bool) {
+	p[key] = strconv.FormatBool(value)
+}
+
+// setInt64 sets an integer property such as an object ID.
+func (p propertyMap) setInt64(key string, value int64) {
+	p[key] = strconv.FormatInt(value, 10)
+}
+
+// setList sets a comma separated list property.
+func (p propertyMap) setList(key string, values []string) {
+	p[key] = strings.Join(values, ",")
+}
+
+// setAll sets all of values, such as user-defined fields.
+func (p propertyMap) setAll(values map[string]string) {
+	for k, v := range values {
+		p[k] = v
+	}
+}
+
+// setManaged sets the UDF that marks an object as managed by terraform, if
+// the provider is configured with one.
+func (p propertyMap) setManaged(loginClient *loginClient) {
+	if loginClient != nil && loginClient.ManagedUDF != "" {
+		p.setBool(loginClient.ManagedUDF, true)
+	}
+}
+
+// String returns the properties as a pipe delimited string with the keys
+// sorted, so the same properties always produce the same string.
+func (p propertyMap) String() string {
+	keys := maps.Keys(p)
+	slices.Sort(keys)
+
+	var properties strings.Builder
+	for _, k := range keys {
+		properties.WriteString(k + "=" + escapePropertyValue(p[k]) + "|")
+	}
+
+	return properties.String()
+}
+
+// stringPointer returns String as a pointer for the Properties of an APIEntity.
+func (p propertyMap) stringPointer() *string {
+	properties := p.String()
+	return &properties
+}
+
+// diffProperties returns the properties in desired that are missing from or
+// different in current, and sets the properties in current that are missing
+// from desired to an empty string so that an update removes them.
+func diffProperties(desired, current map[string]string) propertyMap {
+	diff := propertyMap{}
+
+	for k, v := range desired {
+		if old, ok := current[k]; !ok || old != v {
+			diff[k] = v
+		}
+	}
+
+	for k := range current {
+		if _, ok := desired[k]; !ok {
+			diff[k] = ""
+		}
+	}
+
+	return diff
+}
+
+// propertyEscaper escapes the characters of a property value that the API
+// would otherwise treat as separators.
+var propertyEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`, `=`, `\=`)
+
+// escapePropertyValue escapes a property value for a properties string.
+func escapePropertyValue(value string) string {
+	return propertyEscaper.Replace(value)
+}
+
+// parseProperties splits a pipe delimited properties string returned by the API into a map.
+// Separators escaped with a backslash are part of the key or value.
+func parseProperties(properties string) map[string]string {
+	props := make(map[string]string)
+
+	for _, p := range splitEscaped(properties, '|') {
+		if len(p) > 0 {
+			kv := splitEscaped(p, '=')
+			if len(kv) >= 2 {
+				props[unescapePropertyValue(kv[0])] = unescapePropertyValue(p[len(kv[0])+1:])
+			}
+		}
+	}
+
+	return props
+}
+
+// splitEscaped splits s at each sep that is not escaped with a backslash. The
+// escapes are kept in the returned strings.
+func splitEscaped(s string, sep byte) []string {
+	var parts []string
+
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+// unescapePropertyValue removes the backslash escapes from a property key or value.
+func unescapePropertyValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var unescaped strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			i++
+		}
+		unescaped.WriteByte(value[i])
+	}
+
+	return unescaped.String()
+}
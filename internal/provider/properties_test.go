@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestPropertyMapString(t *testing.T) {
+	properties := propertyMap{}
+	properties.set("name", "web")
+	properties.setBool("reverseRecord", true)
+	properties.setInt64("ttl", -1)
+	properties.setList("dnsRestrictions", []string{"1", "2"})
+	properties.setAll(map[string]string{"owner": "a|b=c"})
+	properties.setManaged(&loginClient{ManagedUDF: "terraform"})
+
+	expected := `dnsRestrictions=1,2|name=web|owner=a\|b\=c|reverseRecord=true|terraform=true|ttl=-1|`
+	if got := properties.String(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	if got := (propertyMap{}).String(); got != "" {
+		t.Errorf("expected no properties to be an empty string, got %q", got)
+	}
+}
+
+func TestParseProperties(t *testing.T) {
+	tests := map[string]struct {
+		properties string
+		expected   map[string]string
+	}{
+		"simple":        {properties: "name=web|CIDR=10.0.0.0/24|", expected: map[string]string{"name": "web", "CIDR": "10.0.0.0/24"}},
+		"empty value":   {properties: "gateway=|", expected: map[string]string{"gateway": ""}},
+		"unescaped '='": {properties: "note=a=b|", expected: map[string]string{"note": "a=b"}},
+		"escaped":       {properties: `note=a\|b\=c\\|x=y|`, expected: map[string]string{"note": `a|b=c\`, "x": "y"}},
+		"no separator":  {properties: "junk|name=web", expected: map[string]string{"name": "web"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := parseProperties(tc.properties); !maps.Equal(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPropertyMapRoundTrip(t *testing.T) {
+	properties := propertyMap{"note": `pipe | equals = backslash \ end\`, "name": "web"}
+
+	if got := parseProperties(properties.String()); !maps.Equal(got, properties) {
+		t.Errorf("expected %v, got %v", properties, got)
+	}
+}
+
+func TestDiffProperties(t *testing.T) {
+	current := map[string]string{"owner": "alice", "tenant": "a", "site": "east"}
+	desired := map[string]string{"owner": "bob", "site": "east", "rack": "12"}
+
+	expected := propertyMap{"owner": "bob", "rack": "12", "tenant": ""}
+	if got := diffProperties(desired, current); !maps.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if got := diffProperties(current, current); len(got) != 0 {
+		t.Errorf("expected no differences, got %v", got)
+	}
+}
+
+func TestPropertyNameRegexp(t *testing.T) {
+	for _, name := range []string{"owner", "Cost Center", "site-1"} {
+		if !propertyNameRegexp.MatchString(name) {
+			t.Errorf("expected %q to be a valid property name", name)
+		}
+	}
+
+	for _, name := range []string{"", "a|b", "a=b", `a\b`} {
+		if propertyNameRegexp.MatchString(name) {
+			t.Errorf("expected %q to be an invalid property name", name)
+		}
+	}
+}
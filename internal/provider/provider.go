@@ -2,31 +2,86 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/umich-vci/gobam"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type loginClient struct {
-	Client   gobam.ProteusAPI
+	Clients  *clientFactory
 	Username string
 	Password string
+	ReadOnly bool
+
+	// PreventDeleteTypes are the object types resources refuse to delete
+	// unless AllowProtectedDeletes is true.
+	PreventDeleteTypes    []string
+	AllowProtectedDeletes bool
+
+	// AuthMethod is authMethodToken if Token is sent with each request
+	// instead of logging in with Password.
+	AuthMethod string
+	Token      string
+
+	// AuthProfiles are the named credentials resources and data sources can
+	// use instead of Username and Password.
+	AuthProfiles map[string]authProfile
+
+	// DefaultConfiguration is the configuration used when configuration_id
+	// is not set.
+	DefaultConfiguration *defaultConfiguration
+
+	// ManagedUDF is the user-defined field set on objects created by resources
+	ManagedUDF string
+
+	// ValidateUDFs is true if user_defined_fields are checked against the
+	// fields defined in BlueCat Address Manager when planning.
+	ValidateUDFs   bool
+	UDFDefinitions *udfDefinitionCache
+
+	// ManagedUDFKeys is true if resources only manage the user-defined fields
+	// that are in their configuration.
+	ManagedUDFKeys bool
+
+	// NetworkUsage caches the number of addresses in use in IPv4 networks.
+	NetworkUsage *ip4NetworkUsageCache
+
+	// EnableRawAPI is true if the bluecat_api_call data source may send API
+	// calls the provider does not model.
+	EnableRawAPI bool
 }
 
 // Ensure blueCatProvider satisfies various provider interfaces.
-var _ provider.Provider = &blueCatProvider{}
+var (
+	_ provider.Provider                       = &blueCatProvider{}
+	_ provider.ProviderWithEphemeralResources = &blueCatProvider{}
+)
 
-var mutex = &sync.Mutex{}
+// allocationMutex serializes the API calls that allocate the next available
+// address or range, so that concurrent resources do not race for the same one.
+var allocationMutex = &sync.Mutex{}
 
 // blueCatProvider defines the provider implementation.
 type blueCatProvider struct {
@@ -42,6 +97,27 @@ type blueCatProviderModel struct {
 	Username        types.String `tfsdk:"username"`
 	Password        types.String `tfsdk:"password"`
 	SSLVerify       types.Bool   `tfsdk:"ssl_verify"`
+	ReadOnly        types.Bool   `tfsdk:"read_only"`
+	PreventDelete   types.List   `tfsdk:"prevent_delete_types"`
+	AllowDeletes    types.Bool   `tfsdk:"allow_protected_deletes"`
+	OTLPEndpoint    types.String `tfsdk:"otlp_endpoint"`
+	AuditLogPath    types.String `tfsdk:"audit_log_path"`
+	ManagedUDF      types.String `tfsdk:"managed_udf"`
+	ValidateUDFs    types.Bool   `tfsdk:"validate_user_defined_fields"`
+	ManagedUDFKeys  types.Bool   `tfsdk:"managed_udf_keys"`
+	EnableRawAPI    types.Bool   `tfsdk:"enable_raw_api"`
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryDelay      types.String `tfsdk:"retry_delay"`
+	APIVersion      types.String `tfsdk:"api_version"`
+	Timeout         types.String `tfsdk:"timeout"`
+	TLSMinVersion   types.String `tfsdk:"tls_min_version"`
+	CACertificate   types.String `tfsdk:"ca_certificate"`
+	ProxyURL        types.String `tfsdk:"proxy_url"`
+	AuthMethod      types.String `tfsdk:"auth_method"`
+	Token           types.String `tfsdk:"token"`
+	AuthProfiles    types.Map    `tfsdk:"auth_profiles"`
+	DefaultConfigID types.Int64  `tfsdk:"default_configuration_id"`
+	DefaultConfig   types.String `tfsdk:"default_configuration_name"`
 }
 
 func (p *blueCatProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -65,9 +141,117 @@ func (p *blueCatProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Sensitive:           true,
 				MarkdownDescription: "The BlueCat Address Manager password. Can also use the environment variable `BLUECAT_PASSWORD`",
 			},
+			"auth_method": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How to authenticate to BlueCat Address Manager. `password` logs in with `username` and `password` for each operation. `token` sends `username` and the API token `token` with each request instead of logging in. " + enumDescription(authMethods) + " Defaults to `password`. Can also use the environment variable `BLUECAT_AUTH_METHOD`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(authMethods...),
+				},
+			},
+			"token": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "A BlueCat Address Manager API token of `username`. Required if `auth_method` is `token`. Can also use the environment variable `BLUECAT_TOKEN`",
+			},
+			"auth_profiles": authProfilesSchemaAttribute(),
+			"default_configuration_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The object ID of the Configuration that resources and data sources use when their `configuration_id` is not set, for workspaces that only manage one configuration. Conflicts with `default_configuration_name`. Can also use the environment variable `BLUECAT_DEFAULT_CONFIGURATION_ID`",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.ConflictsWith(path.MatchRoot("default_configuration_name")),
+				},
+			},
+			"default_configuration_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the Configuration that resources and data sources use when their `configuration_id` is not set. The Configuration is looked up the first time it is needed. Conflicts with `default_configuration_id`. Can also use the environment variable `BLUECAT_DEFAULT_CONFIGURATION_NAME`",
+			},
 			"ssl_verify": schema.BoolAttribute{
 				Optional:    true,
-				Description: "Verify the SSL certificate of the BlueCat Address Manager endpoint?",
+				Description: "Verify the SSL certificate of the BlueCat Address Manager endpoint? Defaults to `true`. Set `ca_certificate` instead of disabling verification if the certificate is from an internal CA.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Put the provider in read-only mode. Data sources and plans continue to work, but resources will refuse to create, update, or delete objects. Resources that only exist in the state, such as `bluecat_deployment` and `bluecat_ip4_network_split`, can still be removed. Useful during BlueCat Address Manager maintenance windows, or to let auditors run `terraform plan` with shared modules against production to see what would change without any risk of applying it. Can also use the environment variable `BLUECAT_READ_ONLY`",
+			},
+			"prevent_delete_types": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Object types, such as `IP4Block` and `IP4Network`, that resources will refuse to delete unless `allow_protected_deletes` is enabled. A safety net against destroying address space by accident, for example when a resource is removed from the configuration or must be replaced. Can also use the environment variable `BLUECAT_PREVENT_DELETE_TYPES` with a comma separated list",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(gobam.ObjectTypes...)),
+				},
+			},
+			"allow_protected_deletes": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Allow resources to delete objects of the types in `prevent_delete_types`. Defaults to `false`. Can also use the environment variable `BLUECAT_ALLOW_PROTECTED_DELETES`",
+			},
+			"enable_raw_api": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Allow the `bluecat_api_call` data source to send read-only BlueCat Address Manager API calls that the provider does not otherwise support. Defaults to `false`. Can also use the environment variable `BLUECAT_ENABLE_RAW_API`",
+			},
+			"managed_udf": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of a boolean user-defined field that resources set to `true` on objects they create to mark them as managed by Terraform. The field must be defined in BlueCat Address Manager for each object type that is managed. It is not included in the `user_defined_fields` attribute of resources. Data sources can filter on the field with their `managed_by_terraform` argument. Can also use the environment variable `BLUECAT_MANAGED_UDF`",
+			},
+			"validate_user_defined_fields": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Check the `user_defined_fields` of resources against the user-defined fields defined in BlueCat Address Manager when planning, and reject fields that are not defined for the object type or values that are not valid for the field, such as a value that is not one of its predefined values. Defaults to `false`. Can also use the environment variable `BLUECAT_VALIDATE_USER_DEFINED_FIELDS`",
+			},
+			"managed_udf_keys": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only manage the keys of the `user_defined_fields` of resources that are in their configuration. User-defined fields set outside Terraform are left alone and are not shown as drift, and removing a key from the configuration stops managing it instead of clearing it. Set a key to an empty string to clear it. Defaults to `false`. Can also use the environment variable `BLUECAT_MANAGED_UDF_KEYS`",
+			},
+			"otlp_endpoint": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The URL of an OTLP/HTTP endpoint, such as `https://collector.example.com:4318/v1/traces`, to send OpenTelemetry traces of BlueCat Address Manager API calls to. If not set, tracing is enabled when the standard `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT` or `OTEL_EXPORTER_OTLP_ENDPOINT` environment variables are set.",
+			},
+			"audit_log_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The path of a file to append an audit log of every BlueCat Address Manager API call to. Each line is a JSON object with the time, operation, parameters sent, such as the entity ID and properties, and the result of a call. Passwords are not logged. Can also use the environment variable `BLUECAT_AUDIT_LOG_PATH`",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The number of times to retry API calls that only read data when they fail with a transient error, such as a connection error or a 5xx response from a load balancer in front of BlueCat Address Manager. Calls rejected because the session expired are sent again after logging in regardless of this setting. Defaults to `3`. Can also use the environment variable `BLUECAT_MAX_RETRIES`",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_delay": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long to wait before the first retry of a failed API call, as a duration such as `500ms` or `2s`. The delay doubles with each retry up to 30 seconds. Defaults to `1s`. Can also use the environment variable `BLUECAT_RETRY_DELAY`",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(durationRegexp, "must be a duration such as 500ms or 2s"),
+				},
+			},
+			"api_version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The BlueCat Address Manager API to use. `v1` uses the legacy API for everything. `v2` uses the RESTful v2 API of BlueCat Integrity 9.5 and later to list IPv4 blocks, networks, and addresses, which is much faster for large blocks, and the legacy API for everything else. If the v2 API is not available, the legacy API is used. " + enumDescription(apiVersions) + " Defaults to `v1`. Can also use the environment variable `BLUECAT_API_VERSION`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(apiVersions...),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long a BlueCat Address Manager API call may take, including retries, as a duration such as `30s` or `5m`. Defaults to no limit. Can also use the environment variable `BLUECAT_TIMEOUT`",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(durationRegexp, "must be a duration such as 30s or 5m"),
+				},
+			},
+			"tls_min_version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The minimum TLS version to use when connecting to BlueCat Address Manager. " + enumDescription(tlsMinVersions) + " Defaults to `1.2`. Can also use the environment variable `BLUECAT_TLS_MIN_VERSION`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(tlsMinVersions...),
+				},
+			},
+			"ca_certificate": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A PEM encoded CA certificate bundle to trust in addition to the system CAs when verifying the certificate of BlueCat Address Manager, for instances with a certificate from a private CA. Can also use the environment variable `BLUECAT_CA_CERTIFICATE`",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The URL of an HTTP proxy to connect to BlueCat Address Manager through, such as `http://proxy.example.com:3128`. Credentials can be included in the URL. If not set, the proxy is taken from the standard `HTTPS_PROXY` and `NO_PROXY` environment variables. Can also use the environment variable `BLUECAT_PROXY_URL`",
 			},
 		},
 	}
@@ -112,6 +296,24 @@ func (p *blueCatProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.AuthMethod.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_method"),
+			"Unknown BlueCat API Authentication Method",
+			"The provider cannot create the BlueCat SOAP client as there is an unknown configuration value for auth_method. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_AUTH_METHOD environment variable.",
+		)
+	}
+
+	if config.Token.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Unknown BlueCat API Token",
+			"The provider cannot create the BlueCat SOAP client as there is an unknown configuration value for the BlueCat API token. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_TOKEN environment variable.",
+		)
+	}
+
 	if config.SSLVerify.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("ssl_verify"),
@@ -121,6 +323,168 @@ func (p *blueCatProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.PreventDelete.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("prevent_delete_types"),
+			"Unknown Prevent Delete Types",
+			"The provider cannot determine which object types to protect from deletion as there is an unknown configuration value for prevent_delete_types. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_PREVENT_DELETE_TYPES environment variable.",
+		)
+	}
+
+	if config.AllowDeletes.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allow_protected_deletes"),
+			"Unknown Allow Protected Deletes",
+			"The provider cannot determine if protected object types may be deleted as there is an unknown configuration value for allow_protected_deletes. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_ALLOW_PROTECTED_DELETES environment variable.",
+		)
+	}
+
+	if config.EnableRawAPI.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("enable_raw_api"),
+			"Unknown Enable Raw API",
+			"The provider cannot determine if raw API calls are allowed as there is an unknown configuration value for enable_raw_api. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_ENABLE_RAW_API environment variable.",
+		)
+	}
+
+	if config.ReadOnly.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_only"),
+			"Unknown BlueCat Read-Only Mode",
+			"The provider cannot determine if it should run in read-only mode as there is an unknown configuration value for read_only. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_READ_ONLY environment variable.",
+		)
+	}
+
+	if config.ManagedUDF.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("managed_udf"),
+			"Unknown Managed User-Defined Field",
+			"The provider cannot determine which user-defined field marks objects as managed by Terraform as there is an unknown configuration value for managed_udf. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_MANAGED_UDF environment variable.",
+		)
+	}
+
+	if config.ValidateUDFs.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("validate_user_defined_fields"),
+			"Unknown User-Defined Field Validation",
+			"The provider cannot determine if it should validate user-defined fields as there is an unknown configuration value for validate_user_defined_fields. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_VALIDATE_USER_DEFINED_FIELDS environment variable.",
+		)
+	}
+
+	if config.ManagedUDFKeys.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("managed_udf_keys"),
+			"Unknown Managed User-Defined Field Keys",
+			"The provider cannot determine if it should only manage configured user-defined fields as there is an unknown configuration value for managed_udf_keys. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_MANAGED_UDF_KEYS environment variable.",
+		)
+	}
+
+	if config.OTLPEndpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("otlp_endpoint"),
+			"Unknown OTLP Endpoint",
+			"The provider cannot configure tracing as there is an unknown configuration value for otlp_endpoint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variable.",
+		)
+	}
+
+	if config.AuditLogPath.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("audit_log_path"),
+			"Unknown Audit Log Path",
+			"The provider cannot configure the audit log as there is an unknown configuration value for audit_log_path. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_AUDIT_LOG_PATH environment variable.",
+		)
+	}
+
+	if config.MaxRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown BlueCat API Max Retries",
+			"The provider cannot create the BlueCat SOAP client as there is an unknown configuration value for max_retries. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_MAX_RETRIES environment variable.",
+		)
+	}
+
+	if config.RetryDelay.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_delay"),
+			"Unknown BlueCat API Retry Delay",
+			"The provider cannot create the BlueCat SOAP client as there is an unknown configuration value for retry_delay. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_RETRY_DELAY environment variable.",
+		)
+	}
+
+	if config.APIVersion.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_version"),
+			"Unknown BlueCat API Version",
+			"The provider cannot create the BlueCat SOAP client as there is an unknown configuration value for api_version. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_API_VERSION environment variable.",
+		)
+	}
+
+	if config.Timeout.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("timeout"),
+			"Unknown BlueCat API Timeout",
+			"The provider cannot create the BlueCat SOAP client as there is an unknown configuration value for timeout. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_TIMEOUT environment variable.",
+		)
+	}
+
+	if config.TLSMinVersion.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls_min_version"),
+			"Unknown BlueCat API TLS Minimum Version",
+			"The provider cannot create the BlueCat SOAP client as there is an unknown configuration value for tls_min_version. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_TLS_MIN_VERSION environment variable.",
+		)
+	}
+
+	if config.CACertificate.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_certificate"),
+			"Unknown BlueCat API CA Certificate",
+			"The provider cannot create the BlueCat SOAP client as there is an unknown configuration value for ca_certificate. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_CA_CERTIFICATE environment variable.",
+		)
+	}
+
+	if config.ProxyURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown BlueCat API Proxy URL",
+			"The provider cannot create the BlueCat SOAP client as there is an unknown configuration value for proxy_url. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_PROXY_URL environment variable.",
+		)
+	}
+
+	if config.DefaultConfigID.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_configuration_id"),
+			"Unknown BlueCat Default Configuration ID",
+			"The provider cannot create the BlueCat SOAP client as there is an unknown configuration value for default_configuration_id. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_DEFAULT_CONFIGURATION_ID environment variable.",
+		)
+	}
+
+	if config.DefaultConfig.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_configuration_name"),
+			"Unknown BlueCat Default Configuration Name",
+			"The provider cannot create the BlueCat SOAP client as there is an unknown configuration value for default_configuration_name. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BLUECAT_DEFAULT_CONFIGURATION_NAME environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -131,7 +495,27 @@ func (p *blueCatProvider) Configure(ctx context.Context, req provider.ConfigureR
 	endpoint := os.Getenv("BLUECAT_ENDPOINT")
 	username := os.Getenv("BLUECAT_USERNAME")
 	password := os.Getenv("BLUECAT_PASSWORD")
+	authMethod := os.Getenv("BLUECAT_AUTH_METHOD")
+	token := os.Getenv("BLUECAT_TOKEN")
+	managedUDF := os.Getenv("BLUECAT_MANAGED_UDF")
+	auditLogPath := os.Getenv("BLUECAT_AUDIT_LOG_PATH")
+	apiVersion := os.Getenv("BLUECAT_API_VERSION")
+	tlsMinVersion := os.Getenv("BLUECAT_TLS_MIN_VERSION")
+	caCertificate := os.Getenv("BLUECAT_CA_CERTIFICATE")
+	timeoutValue := os.Getenv("BLUECAT_TIMEOUT")
+	proxyURLValue := os.Getenv("BLUECAT_PROXY_URL")
 	sslVerify := true
+	readOnly := false
+	validateUDFs := false
+	managedUDFKeys := false
+	allowProtectedDeletes := false
+	enableRawAPI := false
+	var preventDeleteTypes []string
+	maxRetries := int64(3)
+	retryDelay := time.Second
+	timeout := time.Duration(0)
+	var proxyURL *url.URL
+	defaultConfig := &defaultConfiguration{}
 
 	if !config.BlueCatEndpoint.IsNull() {
 		endpoint = config.BlueCatEndpoint.ValueString()
@@ -145,10 +529,237 @@ func (p *blueCatProvider) Configure(ctx context.Context, req provider.ConfigureR
 		password = config.Password.ValueString()
 	}
 
+	if !config.AuthMethod.IsNull() {
+		authMethod = config.AuthMethod.ValueString()
+	}
+
+	if authMethod == "" {
+		authMethod = authMethodPassword
+	} else if !slices.Contains(authMethods, authMethod) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_method"),
+			"Invalid BlueCat API Authentication Method",
+			fmt.Sprintf("The BLUECAT_AUTH_METHOD environment variable must be one of %s, got: %q", strings.Join(authMethods, ", "), authMethod),
+		)
+	}
+
+	if !config.Token.IsNull() {
+		token = config.Token.ValueString()
+	}
+
+	authProfiles, diags := expandAuthProfiles(ctx, config.AuthProfiles)
+	resp.Diagnostics.Append(diags...)
+
+	if !config.ManagedUDF.IsNull() {
+		managedUDF = config.ManagedUDF.ValueString()
+	}
+
+	if !config.DefaultConfigID.IsNull() {
+		defaultConfig.id = config.DefaultConfigID.ValueInt64()
+	} else if v := os.Getenv("BLUECAT_DEFAULT_CONFIGURATION_ID"); v != "" && config.DefaultConfig.IsNull() {
+		var err error
+		defaultConfig.id, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || defaultConfig.id < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_configuration_id"),
+				"Invalid BlueCat Default Configuration ID",
+				fmt.Sprintf("The BLUECAT_DEFAULT_CONFIGURATION_ID environment variable must be a positive integer, got: %q", v),
+			)
+		}
+	}
+
+	if !config.DefaultConfig.IsNull() {
+		defaultConfig.name = config.DefaultConfig.ValueString()
+	} else if defaultConfig.id == 0 {
+		defaultConfig.name = os.Getenv("BLUECAT_DEFAULT_CONFIGURATION_NAME")
+	}
+
+	if !config.AuditLogPath.IsNull() {
+		auditLogPath = config.AuditLogPath.ValueString()
+	}
+
+	if !config.APIVersion.IsNull() {
+		apiVersion = config.APIVersion.ValueString()
+	}
+
+	if apiVersion == "" {
+		apiVersion = apiVersionV1
+	} else if !slices.Contains(apiVersions, apiVersion) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_version"),
+			"Invalid BlueCat API Version",
+			fmt.Sprintf("The BLUECAT_API_VERSION environment variable must be one of %s, got: %q", strings.Join(apiVersions, ", "), apiVersion),
+		)
+	}
+
 	if !config.SSLVerify.IsNull() {
 		sslVerify = config.SSLVerify.ValueBool()
 	}
 
+	if !config.ReadOnly.IsNull() {
+		readOnly = config.ReadOnly.ValueBool()
+	} else if v := os.Getenv("BLUECAT_READ_ONLY"); v != "" {
+		var err error
+		readOnly, err = strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("read_only"),
+				"Invalid BlueCat Read-Only Mode",
+				"The BLUECAT_READ_ONLY environment variable must be a boolean value: "+err.Error(),
+			)
+		}
+	}
+
+	if !config.EnableRawAPI.IsNull() {
+		enableRawAPI = config.EnableRawAPI.ValueBool()
+	} else if v := os.Getenv("BLUECAT_ENABLE_RAW_API"); v != "" {
+		var err error
+		enableRawAPI, err = strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("enable_raw_api"),
+				"Invalid Enable Raw API",
+				"The BLUECAT_ENABLE_RAW_API environment variable must be a boolean value: "+err.Error(),
+			)
+		}
+	}
+
+	if !config.PreventDelete.IsNull() {
+		resp.Diagnostics.Append(config.PreventDelete.ElementsAs(ctx, &preventDeleteTypes, false)...)
+	} else if v := os.Getenv("BLUECAT_PREVENT_DELETE_TYPES"); v != "" {
+		for _, objectType := range strings.Split(v, ",") {
+			objectType = strings.TrimSpace(objectType)
+			if !slices.Contains(gobam.ObjectTypes, objectType) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("prevent_delete_types"),
+					"Invalid Prevent Delete Types",
+					fmt.Sprintf("The BLUECAT_PREVENT_DELETE_TYPES environment variable must be a comma separated list of object types, got: %q", objectType),
+				)
+				continue
+			}
+			preventDeleteTypes = append(preventDeleteTypes, objectType)
+		}
+	}
+
+	if !config.AllowDeletes.IsNull() {
+		allowProtectedDeletes = config.AllowDeletes.ValueBool()
+	} else if v := os.Getenv("BLUECAT_ALLOW_PROTECTED_DELETES"); v != "" {
+		var err error
+		allowProtectedDeletes, err = strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("allow_protected_deletes"),
+				"Invalid Allow Protected Deletes",
+				"The BLUECAT_ALLOW_PROTECTED_DELETES environment variable must be a boolean value: "+err.Error(),
+			)
+		}
+	}
+
+	if !config.ValidateUDFs.IsNull() {
+		validateUDFs = config.ValidateUDFs.ValueBool()
+	} else if v := os.Getenv("BLUECAT_VALIDATE_USER_DEFINED_FIELDS"); v != "" {
+		var err error
+		validateUDFs, err = strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("validate_user_defined_fields"),
+				"Invalid User-Defined Field Validation",
+				"The BLUECAT_VALIDATE_USER_DEFINED_FIELDS environment variable must be a boolean value: "+err.Error(),
+			)
+		}
+	}
+
+	if !config.ManagedUDFKeys.IsNull() {
+		managedUDFKeys = config.ManagedUDFKeys.ValueBool()
+	} else if v := os.Getenv("BLUECAT_MANAGED_UDF_KEYS"); v != "" {
+		var err error
+		managedUDFKeys, err = strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("managed_udf_keys"),
+				"Invalid Managed User-Defined Field Keys",
+				"The BLUECAT_MANAGED_UDF_KEYS environment variable must be a boolean value: "+err.Error(),
+			)
+		}
+	}
+
+	if !config.MaxRetries.IsNull() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	} else if v := os.Getenv("BLUECAT_MAX_RETRIES"); v != "" {
+		var err error
+		maxRetries, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || maxRetries < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid BlueCat API Max Retries",
+				fmt.Sprintf("The BLUECAT_MAX_RETRIES environment variable must be a non-negative integer, got: %q", v),
+			)
+		}
+	}
+
+	retryDelayValue := os.Getenv("BLUECAT_RETRY_DELAY")
+	if !config.RetryDelay.IsNull() {
+		retryDelayValue = config.RetryDelay.ValueString()
+	}
+	if retryDelayValue != "" {
+		var err error
+		retryDelay, err = time.ParseDuration(retryDelayValue)
+		if err != nil || retryDelay < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_delay"),
+				"Invalid BlueCat API Retry Delay",
+				fmt.Sprintf("The retry delay must be a duration such as 500ms or 2s, got: %q", retryDelayValue),
+			)
+		}
+	}
+
+	if !config.Timeout.IsNull() {
+		timeoutValue = config.Timeout.ValueString()
+	}
+	if timeoutValue != "" {
+		var err error
+		timeout, err = time.ParseDuration(timeoutValue)
+		if err != nil || timeout < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timeout"),
+				"Invalid BlueCat API Timeout",
+				fmt.Sprintf("The timeout must be a duration such as 30s or 5m, got: %q", timeoutValue),
+			)
+		}
+	}
+
+	if !config.TLSMinVersion.IsNull() {
+		tlsMinVersion = config.TLSMinVersion.ValueString()
+	}
+	if tlsMinVersion == "" {
+		tlsMinVersion = "1.2"
+	} else if _, ok := tlsVersions[tlsMinVersion]; !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls_min_version"),
+			"Invalid BlueCat API TLS Minimum Version",
+			fmt.Sprintf("The BLUECAT_TLS_MIN_VERSION environment variable must be one of %s, got: %q", strings.Join(tlsMinVersions, ", "), tlsMinVersion),
+		)
+	}
+
+	if !config.CACertificate.IsNull() {
+		caCertificate = config.CACertificate.ValueString()
+	}
+
+	if !config.ProxyURL.IsNull() {
+		proxyURLValue = config.ProxyURL.ValueString()
+	}
+	if proxyURLValue != "" {
+		var err error
+		proxyURL, err = url.Parse(proxyURLValue)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid BlueCat API Proxy URL",
+				"The proxy URL must be an absolute URL such as http://proxy.example.com:3128.",
+			)
+		}
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -172,7 +783,7 @@ func (p *blueCatProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
-	if password == "" {
+	if password == "" && authMethod == authMethodPassword {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("password"),
 			"Missing BlueCat SOAP Password",
@@ -182,12 +793,76 @@ func (p *blueCatProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if token == "" && authMethod == authMethodToken {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Missing BlueCat API Token",
+			"The provider cannot create the BlueCat SOAP client as auth_method is token and there is a missing or empty value for the BlueCat API token. "+
+				"Set the token value in the configuration or use the BLUECAT_TOKEN environment variable. "+
+				"If either is already set, ensure the value is not empty.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client := gobam.NewClient(endpoint, sslVerify)
-	loginClient := &loginClient{Client: client, Username: username, Password: password}
+	var tracer trace.Tracer
+	otlpEndpoint := config.OTLPEndpoint.ValueString()
+	if otlpEndpoint != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" {
+		var err error
+		tracer, err = newTracer(ctx, otlpEndpoint, p.version)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("otlp_endpoint"),
+				"Unable to Configure Tracing",
+				"An error occurred when configuring the OpenTelemetry trace exporter: "+err.Error(),
+			)
+			return
+		}
+		tflog.Debug(ctx, "OpenTelemetry tracing of BlueCat API calls is enabled")
+	}
+
+	var audit *auditLog
+	if auditLogPath != "" {
+		var err error
+		audit, err = openAuditLog(auditLogPath)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("audit_log_path"),
+				"Unable to Open Audit Log",
+				"An error occurred when opening the audit log: "+err.Error(),
+			)
+			return
+		}
+		tflog.Debug(ctx, "Audit logging of BlueCat API calls is enabled", map[string]interface{}{"path": auditLogPath})
+	}
+
+	clients, err := newClientFactory(clientConfig{
+		endpoint:      endpoint,
+		sslVerify:     sslVerify,
+		caCertificate: caCertificate,
+		tlsMinVersion: tlsVersions[tlsMinVersion],
+		proxyURL:      proxyURL,
+		timeout:       timeout,
+		tracer:        tracer,
+		auditLog:      audit,
+		maxRetries:    int(maxRetries),
+		retryDelay:    retryDelay,
+		apiVersion:    apiVersion,
+	})
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_certificate"),
+			"Unable to Create BlueCat API Client",
+			"An error occurred when creating the BlueCat API client: "+err.Error(),
+		)
+		return
+	}
+	loginClient := &loginClient{Clients: clients, Username: username, Password: password, AuthMethod: authMethod, Token: token, AuthProfiles: authProfiles, DefaultConfiguration: defaultConfig, ReadOnly: readOnly, PreventDeleteTypes: preventDeleteTypes, AllowProtectedDeletes: allowProtectedDeletes, ManagedUDF: managedUDF, ValidateUDFs: validateUDFs, UDFDefinitions: &udfDefinitionCache{}, ManagedUDFKeys: managedUDFKeys, NetworkUsage: &ip4NetworkUsageCache{}, EnableRawAPI: enableRawAPI}
+	if readOnly {
+		tflog.Info(ctx, "Provider is in read-only mode, resources will not be modified")
+	}
 	// err := client.Login(username, password)
 	// if err != nil {
 	// 	resp.Diagnostics.AddError(
@@ -199,29 +874,67 @@ func (p *blueCatProvider) Configure(ctx context.Context, req provider.ConfigureR
 	// 	return
 	// }
 
-	// Make the BlueCat client available during DataSource and Resource
-	// type Configure methods.
+	// Make the BlueCat client available during DataSource, Resource, and
+	// EphemeralResource type Configure methods.
 	resp.DataSourceData = loginClient
 	resp.ResourceData = loginClient
+	resp.EphemeralResourceData = loginClient
 }
 
 func (p *blueCatProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewHostRecordResource,
+		NewHostRecordSetResource,
 		NewIP4AddressResource,
+		NewIP4AddressBlockReservationResource,
 		NewIP4NetworkResource,
 		NewIP4AvailableNetworkResource,
+		NewIP4NetworkSplitResource,
+		NewIP4NetworkTemplateResource,
 		NewIP4BlockResource,
+		NewIP4DHCPReservationResource,
+		NewIP4DHCPExclusionRangeResource,
+		NewEntityLinkResource,
+		NewDeploymentRoleResource,
+		NewDHCPClientOptionResource,
+		NewDHCPServiceOptionResource,
+		NewDNSOptionResource,
+		NewDeploymentResource,
+		NewUserDefinedFieldResource,
+		NewEntityResource,
+		NewLocationResource,
 	}
 }
 
 func (p *blueCatProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewAPICallDataSource,
+		NewAliasRecordDataSource,
+		NewDeploymentStatusDataSource,
+		NewDNSRecordDefaultsDataSource,
+		NewDNSZoneTransferDataSource,
 		NewEntityDataSource,
+		NewEntitiesDataSource,
+		NewLocationDataSource,
+		NewEffectiveDNSOptionsDataSource,
 		NewHostRecordDataSource,
+		NewHostRecordsDataSource,
+		NewIP4BlocksDataSource,
 		NewIP4AddressDataSource,
+		NewIP4AddressesDataSource,
 		NewIP4NBRDataSource,
 		NewIP4NetworkDataSource,
+		NewIP4NetworkTemplateDataSource,
+		NewIP4NetworkUtilizationDataSource,
+		NewImportCandidatesDataSource,
+		NewResolvedRecordDataSource,
+		NewZoneDataSource,
+	}
+}
+
+func (p *blueCatProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewIP4AddressLeaseEphemeralResource,
 	}
 }
 
@@ -233,16 +946,34 @@ func New(version string) func() provider.Provider {
 	}
 }
 
-func clientLogin(ctx context.Context, loginClient *loginClient, mutex *sync.Mutex) (gobam.ProteusAPI, diag.Diagnostics) {
+// clientLogin returns a new API client logged in with the credentials of
+// loginClient. Each client has its own session, so it must be logged out with
+// clientLogout when it is no longer needed.
+func clientLogin(ctx context.Context, loginClient *loginClient) (gobam.ProteusAPI, diag.Diagnostics) {
 	var diag diag.Diagnostics
-	client := (*loginClient).Client
 	username := (*loginClient).Username
 	password := (*loginClient).Password
 
-	mutex.Lock()
-	err := client.Login(username, password)
+	if loginClient.AuthMethod == authMethodToken {
+		client, err := loginClient.Clients.newTokenClient(ctx, username, loginClient.Token)
+		if err != nil {
+			diag.AddError("login error", err.Error())
+			return nil, diag
+		}
+
+		tflog.Trace(ctx, "Client uses an API token")
+
+		return client, diag
+	}
+
+	client, err := loginClient.Clients.newClient(ctx)
+	if err != nil {
+		diag.AddError("login error", err.Error())
+		return nil, diag
+	}
+
+	err = client.Login(username, password)
 	if err != nil {
-		mutex.Unlock()
 		diag.AddError("login error", err.Error())
 		return nil, diag
 	}
@@ -252,12 +983,21 @@ func clientLogin(ctx context.Context, loginClient *loginClient, mutex *sync.Mute
 	return client, diag
 }
 
-func clientLogout(ctx context.Context, loginClient *gobam.ProteusAPI, mutex *sync.Mutex) diag.Diagnostics {
+func clientLogout(ctx context.Context, loginClient *gobam.ProteusAPI) diag.Diagnostics {
 	var diag diag.Diagnostics
 	client := *loginClient
 
+	// the client is nil if clientLogin failed
+	if client == nil {
+		return diag
+	}
+
+	// log out even if the operation timed out, so the session is not left open
+	ctx, cancel := cleanupContext(ctx)
+	defer cancel()
+	setClientContext(client, ctx)
+
 	err := client.Logout()
-	mutex.Unlock()
 	if err != nil {
 		diag.AddError("login error", err.Error())
 		return diag
@@ -266,3 +1006,35 @@ func clientLogout(ctx context.Context, loginClient *gobam.ProteusAPI, mutex *syn
 	tflog.Trace(ctx, "Client logged out")
 	return diag
 }
+
+// readOnlyCheck returns an error if the provider is in read-only mode so that
+// resources refuse to modify objects in BlueCat Address Manager.
+func readOnlyCheck(loginClient *loginClient, operation string) diag.Diagnostics {
+	var diag diag.Diagnostics
+
+	if loginClient != nil && loginClient.ReadOnly {
+		diag.AddError(
+			"Provider is in read-only mode",
+			fmt.Sprintf("The provider is configured with read_only enabled so the %s operation was not performed. "+
+				"Unset read_only and the BLUECAT_READ_ONLY environment variable once BlueCat Address Manager changes are allowed again.", operation),
+		)
+	}
+
+	return diag
+}
+
+// deleteProtectionCheck returns an error if objectType is one of the types
+// the provider is configured to protect from deletion.
+func deleteProtectionCheck(loginClient *loginClient, objectType string) diag.Diagnostics {
+	var diag diag.Diagnostics
+
+	if loginClient != nil && !loginClient.AllowProtectedDeletes && slices.Contains(loginClient.PreventDeleteTypes, objectType) {
+		diag.AddError(
+			"Deletion is prevented",
+			fmt.Sprintf("The provider is configured with %s in prevent_delete_types so the %s was not deleted. "+
+				"If the deletion is intended, set allow_protected_deletes or the BLUECAT_ALLOW_PROTECTED_DELETES environment variable to true and apply again.", objectType, objectType),
+		)
+	}
+
+	return diag
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -34,3 +35,50 @@ func validateObjectID(value string) error {
 	}
 	return nil
 }
+
+func TestDeleteProtectionCheck(t *testing.T) {
+	cases := []struct {
+		name       string
+		client     *loginClient
+		objectType string
+		wantError  bool
+	}{
+		{"unconfigured", nil, "IP4Block", false},
+		{"not protected", &loginClient{PreventDeleteTypes: []string{"IP4Block"}}, "IP4Network", false},
+		{"protected", &loginClient{PreventDeleteTypes: []string{"IP4Block", "IP4Network"}}, "IP4Network", true},
+		{"override", &loginClient{PreventDeleteTypes: []string{"IP4Block"}, AllowProtectedDeletes: true}, "IP4Block", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			diags := deleteProtectionCheck(c.client, c.objectType)
+			if diags.HasError() != c.wantError {
+				t.Errorf("deleteProtectionCheck(%q) error = %v, want %v", c.objectType, diags.HasError(), c.wantError)
+			}
+		})
+	}
+}
+
+func TestReadOnlyCheck(t *testing.T) {
+	cases := []struct {
+		name      string
+		client    *loginClient
+		wantError bool
+	}{
+		{"unconfigured", nil, false},
+		{"read-write", &loginClient{}, false},
+		{"read-only", &loginClient{ReadOnly: true}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			diags := readOnlyCheck(c.client, "create")
+			if diags.HasError() != c.wantError {
+				t.Errorf("readOnlyCheck() error = %v, want %v", diags.HasError(), c.wantError)
+			}
+			if c.wantError && !strings.Contains(diags[0].Detail(), "create operation was not performed") {
+				t.Errorf("expected the detail to name the operation, got %q", diags[0].Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fiorix/wsdl2go/soap"
+	"github.com/umich-vci/gobam"
+)
+
+// rawAPIMethodPrefixes are the prefixes of the API operations the
+// bluecat_api_call data source may send. They only read data, except for the
+// operations in rawAPIDeniedMethodPrefixes.
+var rawAPIMethodPrefixes = []string{"get", "search", "customSearch", "is"}
+
+// rawAPIDeniedMethodPrefixes are the prefixes of the API operations that start
+// with one of rawAPIMethodPrefixes but may create objects, such as
+// getNextAvailableIP4Network with autoCreate=true, so the bluecat_api_call
+// data source may not send them.
+var rawAPIDeniedMethodPrefixes = []string{"getNextAvailable"}
+
+// rawAPIMethodRegex matches the name of an API operation.
+var rawAPIMethodRegex = regexp.MustCompile(`^[a-z][A-Za-z0-9]*$`)
+
+// rawAPIClient is a BlueCat Address Manager API client that can also send
+// API calls that are not part of gobam.ProteusAPI.
+type rawAPIClient struct {
+	gobam.ProteusAPI
+
+	cli *soap.Client
+}
+
+func (c *rawAPIClient) setContext(ctx context.Context) {
+	if t, ok := c.cli.Config.Transport.(*contextTransport); ok {
+		t.setContext(ctx)
+	}
+}
+
+// rawAPIParameter is a parameter of a raw API call.
+type rawAPIParameter struct {
+	name  string
+	value string
+}
+
+// rawAPIRequest is the body of a raw API call. The parameters are encoded in
+// order as they are sent to an operation with a sequence of arguments.
+type rawAPIRequest struct {
+	method     string
+	parameters []rawAPIParameter
+}
+
+func (r rawAPIRequest) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	operation := xml.StartElement{Name: xml.Name{Local: "tns:" + r.method}}
+	if err := e.EncodeToken(operation); err != nil {
+		return err
+	}
+
+	for _, p := range r.parameters {
+		if err := e.EncodeElement(p.value, xml.StartElement{Name: xml.Name{Local: p.name}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(operation.End())
+}
+
+// rawAPIResponse captures the body of the response to a raw API call.
+type rawAPIResponse struct {
+	Body string `xml:",innerxml"`
+}
+
+// call sends the API operation method with parameters and returns the body
+// of the SOAP response as XML.
+func (c *rawAPIClient) call(method string, parameters []rawAPIParameter) (string, error) {
+	// gobam sends the operation name with its first letter in upper case as
+	// the SOAP action
+	r, size := utf8.DecodeRuneInString(method)
+	action := string(unicode.ToUpper(r)) + method[size:]
+
+	var out rawAPIResponse
+	if err := c.cli.RoundTripWithAction(action, rawAPIRequest{method: method, parameters: parameters}, &out); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(out.Body), nil
+}
+
+// rawAPICaller returns the rawAPIClient that client wraps.
+func rawAPICaller(client gobam.ProteusAPI) (*rawAPIClient, bool) {
+	for {
+		switch c := client.(type) {
+		case *rawAPIClient:
+			return c, true
+		case tokenClient:
+			client = c.ProteusAPI
+		case *restV2Client:
+			client = c.ProteusAPI
+		default:
+			return nil, false
+		}
+	}
+}
+
+// validateRawAPIMethod returns an error if method is not the name of an API
+// operation that only reads data.
+func validateRawAPIMethod(method string) error {
+	if !rawAPIMethodRegex.MatchString(method) {
+		return fmt.Errorf("%q is not the name of an API method", method)
+	}
+
+	for _, prefix := range rawAPIDeniedMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return fmt.Errorf("%q is not a read-only API method. Methods starting with %s may create objects and cannot be called", method, strings.Join(rawAPIDeniedMethodPrefixes, ", "))
+		}
+	}
+
+	for _, prefix := range rawAPIMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%q is not a read-only API method. Only methods starting with %s may be called", method, strings.Join(rawAPIMethodPrefixes, ", "))
+}
+
+// parseRawAPIParameters parses the parameters of a raw API call, keeping
+// them in the order they are given. They are either a JSON object, such as
+// `{"parentId": 1, "type": "IP4Block"}`, or form encoded, such as
+// `parentId=1&type=IP4Block`.
+func parseRawAPIParameters(parameters string) ([]rawAPIParameter, error) {
+	var parsed []rawAPIParameter
+	var err error
+
+	if strings.HasPrefix(strings.TrimSpace(parameters), "{") {
+		parsed, err = parseRawAPIJSONParameters(parameters)
+	} else {
+		parsed, err = parseRawAPIFormParameters(parameters)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range parsed {
+		if !rawAPIMethodRegex.MatchString(p.name) {
+			return nil, fmt.Errorf("%q is not a valid parameter name", p.name)
+		}
+	}
+
+	return parsed, nil
+}
+
+// parseRawAPIJSONParameters parses parameters given as a JSON object whose
+// values are strings, numbers, or booleans.
+func parseRawAPIJSONParameters(parameters string) ([]rawAPIParameter, error) {
+	decoder := json.NewDecoder(strings.NewReader(parameters))
+	decoder.UseNumber()
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	var parsed []rawAPIParameter
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		name := token.(string)
+
+		token, err = decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var value string
+		switch v := token.(type) {
+		case string:
+			value = v
+		case json.Number:
+			value = v.String()
+		case bool:
+			value = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("the value of parameter %s must be a string, number, or boolean", name)
+		}
+
+		parsed = append(parsed, rawAPIParameter{name: name, value: value})
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON object")
+	}
+
+	return parsed, nil
+}
+
+// parseRawAPIFormParameters parses form encoded parameters.
+func parseRawAPIFormParameters(parameters string) ([]rawAPIParameter, error) {
+	var parsed []rawAPIParameter
+
+	for _, pair := range strings.Split(parameters, "&") {
+		if pair == "" {
+			continue
+		}
+
+		rawName, rawValue, _ := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(rawName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter name %q: %w", rawName, err)
+		}
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for parameter %s: %w", name, err)
+		}
+
+		parsed = append(parsed, rawAPIParameter{name: name, value: value})
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,353 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeploymentResource{}
+
+func NewDeploymentResource() resource.Resource {
+	return &DeploymentResource{}
+}
+
+// DeploymentResource defines the resource implementation.
+type DeploymentResource struct {
+	client *loginClient
+}
+
+// DeploymentResourceModel describes the resource data model.
+type DeploymentResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	EntityIDs         types.Set    `tfsdk:"entity_ids"`
+	Scope             types.String `tfsdk:"scope"`
+	ServerID          types.Int64  `tfsdk:"server_id"`
+	Services          types.Set    `tfsdk:"services"`
+	FullDeployment    types.Bool   `tfsdk:"full_deployment"`
+	Triggers          types.Map    `tfsdk:"triggers"`
+	WaitForCompletion types.Bool   `tfsdk:"wait_for_completion"`
+	WaitTimeout       types.String `tfsdk:"wait_timeout"`
+	DeploymentToken   types.String `tfsdk:"deployment_token"`
+	Status            types.String `tfsdk:"status"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *DeploymentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment"
+}
+
+func (r *DeploymentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource to deploy changes from BlueCat Address Manager to the managed servers. A deployment is triggered when the resource is created and whenever it is replaced, for example when `triggers` changes. Destroying the resource does not change anything in BlueCat Address Manager.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Deployment identifier.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"entity_ids": schema.SetAttribute{
+				MarkdownDescription: "The object IDs of the DNS and DHCP entities, such as resource records, to selectively deploy. Exactly one of `entity_ids` or `server_id` must be set. If changed, a new deployment is triggered.",
+				Optional:            true,
+				ElementType:         types.Int64Type,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ExactlyOneOf(path.MatchRoot("server_id")),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "The scope of a selective deployment of `entity_ids`. " + enumDescription(selectiveDeploymentScopes) + " Defaults to the BlueCat Address Manager default of `related`. If changed, a new deployment is triggered.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(selectiveDeploymentScopes...),
+					stringvalidator.AlsoRequires(path.MatchRoot("entity_ids")),
+				},
+			},
+			"server_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the server to deploy. If changed, a new deployment is triggered.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"services": schema.SetAttribute{
+				MarkdownDescription: "The services to deploy to `server_id`. Each must be one of `DNS`, `DHCP`, `DHCPv6`, or `TFTP`. If not set, all services are deployed. If changed, a new deployment is triggered.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(serverDeploymentServices...)),
+					setvalidator.AlsoRequires(path.MatchRoot("server_id")),
+				},
+			},
+			"full_deployment": schema.BoolAttribute{
+				MarkdownDescription: "Perform a full deployment to `server_id` instead of a differential deployment. If changed, a new deployment is triggered.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("server_id")),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "An arbitrary map of values. When it changes a new deployment is triggered. This is typically set to the IDs or properties of the resources the deployment should follow.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the deployment to complete and fail if it does not succeed. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"wait_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the deployment to complete, as a duration such as `30s` or `10m`. Defaults to `10m`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("10m"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(durationRegexp, "must be a duration such as 30s or 10m"),
+				},
+			},
+			"deployment_token": schema.StringAttribute{
+				MarkdownDescription: "The token of the selective deployment task. Only set when `entity_ids` is used.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the deployment when it was triggered, or when it completed if `wait_for_completion` is `true`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DeploymentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DeploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *DeploymentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, err := time.ParseDuration(data.WaitTimeout.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("wait_timeout"), "Failed to parse wait_timeout", err.Error())
+		return
+	}
+
+	entityIDs := []int64{}
+	resp.Diagnostics.Append(data.EntityIDs.ElementsAs(ctx, &entityIDs, false)...)
+	services := []string{}
+	resp.Diagnostics.Append(data.Services.ElementsAs(ctx, &services, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	var status string
+	if len(entityIDs) > 0 {
+		ids := gobam.LongArray{}
+		for i := range entityIDs {
+			ids.Item = append(ids.Item, &entityIDs[i])
+		}
+
+		properties := propertyMap{}
+		if !data.Scope.IsNull() {
+			properties.set("scope", data.Scope.ValueString())
+		}
+
+		token, err := client.SelectiveDeploy(&ids, properties.String())
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to start selective deployment", err, "")
+			return
+		}
+
+		data.ID = types.StringValue(token)
+		data.DeploymentToken = types.StringValue(token)
+
+		status, _, err = selectiveDeploymentStatus(client, token)
+	} else {
+		serverID := data.ServerID.ValueInt64()
+
+		properties := propertyMap{}
+		if len(services) > 0 {
+			properties.setList("services", services)
+		}
+		if data.FullDeployment.ValueBool() {
+			properties.setBool("forceFullDeployment", true)
+		}
+
+		err = client.DeployServerConfig(serverID, properties.String())
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to deploy server", err, bamEntity("Deployment", data.ID.ValueString()))
+			return
+		}
+
+		data.ID = types.StringValue(fmt.Sprintf("%d:%d", serverID, time.Now().Unix()))
+		data.DeploymentToken = types.StringNull()
+
+		status, err = serverDeploymentStatus(client, serverID)
+	}
+
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get deployment status", err, bamEntity("Deployment", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	if data.WaitForCompletion.ValueBool() {
+		authProfile, credentials, diag := configCredentials(ctx, req.Config)
+		if diag.HasError() {
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+
+		session := newDeploymentSession(ctx, r.client, authProfile, credentials)
+		if data.DeploymentToken.IsNull() {
+			status, err = waitForServerDeployment(ctx, session, data.ServerID.ValueInt64(), timeout)
+		} else {
+			status, err = waitForSelectiveDeployment(ctx, session, data.DeploymentToken.ValueString(), timeout)
+		}
+		if err != nil {
+			addBAMError(&resp.Diagnostics, "Deployment did not complete", err, bamEntity("Deployment", data.ID.ValueString()))
+			return
+		}
+	}
+
+	data.Status = types.StringValue(status)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *DeploymentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A deployment is an event rather than an object, so there is nothing
+	// to refresh.
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *DeploymentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only wait_for_completion, wait_timeout, and credentials can be updated
+	// in place and they only affect future deployments.
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Deployments cannot be undone, so deleting the resource only removes it
+	// from the state.
+}
@@ -0,0 +1,459 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeploymentOptionResource{}
+var _ resource.ResourceWithImportState = &DeploymentOptionResource{}
+
+// deploymentOptionKind describes the API calls used to manage one kind of
+// deployment option. The add, get, update, and delete calls for DHCP client,
+// DHCP service, and DNS options all share the same signatures.
+type deploymentOptionKind struct {
+	typeName    string
+	description string
+	add         func(client gobam.ProteusAPI, entityId int64, name string, value string, properties string) (int64, error)
+	get         func(client gobam.ProteusAPI, entityId int64, name string, serverId int64) (*gobam.APIDeploymentOption, error)
+	update      func(client gobam.ProteusAPI, option *gobam.APIDeploymentOption) error
+	delete      func(client gobam.ProteusAPI, entityId int64, name string, serverId int64) error
+}
+
+func NewDHCPClientOptionResource() resource.Resource {
+	return &DeploymentOptionResource{
+		kind: deploymentOptionKind{
+			typeName:    "_dhcp_client_option",
+			description: "Resource to manage a DHCPv4 client deployment option, such as `router` or `domain-search`, on a configuration, block, network, range, or address.",
+			add:         gobam.ProteusAPI.AddDHCPClientDeploymentOption,
+			get:         gobam.ProteusAPI.GetDHCPClientDeploymentOption,
+			update:      gobam.ProteusAPI.UpdateDHCPClientDeploymentOption,
+			delete:      gobam.ProteusAPI.DeleteDHCPClientDeploymentOption,
+		},
+	}
+}
+
+func NewDHCPServiceOptionResource() resource.Resource {
+	return &DeploymentOptionResource{
+		kind: deploymentOptionKind{
+			typeName:    "_dhcp_service_option",
+			description: "Resource to manage a DHCPv4 service deployment option, such as `default-lease-time`, on a configuration, block, network, or range.",
+			add:         gobam.ProteusAPI.AddDHCPServiceDeploymentOption,
+			get:         gobam.ProteusAPI.GetDHCPServiceDeploymentOption,
+			update:      gobam.ProteusAPI.UpdateDHCPServiceDeploymentOption,
+			delete:      gobam.ProteusAPI.DeleteDHCPServiceDeploymentOption,
+		},
+	}
+}
+
+func NewDNSOptionResource() resource.Resource {
+	return &DeploymentOptionResource{
+		kind: deploymentOptionKind{
+			typeName:    "_dns_option",
+			description: "Resource to manage a DNS deployment option, such as `allow-query` or `forwarders`, on a configuration, view, zone, block, or network.",
+			add:         gobam.ProteusAPI.AddDNSDeploymentOption,
+			get:         gobam.ProteusAPI.GetDNSDeploymentOption,
+			update:      gobam.ProteusAPI.UpdateDNSDeploymentOption,
+			delete:      gobam.ProteusAPI.DeleteDNSDeploymentOption,
+		},
+	}
+}
+
+// DeploymentOptionResource defines the resource implementation.
+type DeploymentOptionResource struct {
+	client *loginClient
+	kind   deploymentOptionKind
+}
+
+// DeploymentOptionResourceModel describes the resource data model.
+type DeploymentOptionResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	EntityID   types.Int64  `tfsdk:"entity_id"`
+	Name       types.String `tfsdk:"name"`
+	Value      types.String `tfsdk:"value"`
+	Values     types.List   `tfsdk:"values"`
+	ServerID   types.Int64  `tfsdk:"server_id"`
+	Type       types.String `tfsdk:"type"`
+	Properties types.String `tfsdk:"properties"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *DeploymentOptionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.kind.typeName
+}
+
+func (r *DeploymentOptionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: r.kind.description,
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Deployment option identifier.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"entity_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the entity the deployment option is set on. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the deployment option as used by the BlueCat Address Manager API, for example `router`. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of an option that takes a single value, for example a hex string for `vendor-encapsulated-options` (option 43). Exactly one of `value` or `values` must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("values")),
+				},
+			},
+			"values": schema.ListAttribute{
+				MarkdownDescription: "The values of an option that takes a list of values, for example the addresses for `router` (option 3) or the domains for `domain-search` (option 119). The values are sent to the API comma separated, in order.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"server_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the server the deployment option is limited to. Defaults to `0`, which applies the option to all servers. If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the deployment option as returned by the API.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"properties": schema.StringAttribute{
+				MarkdownDescription: "The properties of the deployment option as returned by the API (pipe delimited).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *DeploymentOptionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DeploymentOptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *DeploymentOptionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value, diag := deploymentOptionValue(ctx, data)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	entityID := data.EntityID.ValueInt64()
+	name := data.Name.ValueString()
+	serverID := data.ServerID.ValueInt64()
+
+	properties := propertyMap{}
+	if serverID != 0 {
+		properties.setInt64("server", serverID)
+	}
+
+	id, err := r.kind.add(client, entityID, name, value, properties.String())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to add deployment option", err, "")
+		return
+	}
+
+	option, err := r.kind.get(client, entityID, name, serverID)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get deployment option", err, "")
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(id, 10))
+	data.Type = types.StringPointerValue(option.Type)
+	data.Properties = types.StringPointerValue(option.Properties)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentOptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *DeploymentOptionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	option, err := r.kind.get(client, data.EntityID.ValueInt64(), data.Name.ValueString(), data.ServerID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get deployment option", err, bamEntity("Deployment Option", data.ID.ValueString()))
+		return
+	}
+
+	if option.Id == nil || *option.Id == 0 {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(*option.Id, 10))
+	data.Type = types.StringPointerValue(option.Type)
+	data.Properties = types.StringPointerValue(option.Properties)
+
+	value := ""
+	if option.Value != nil {
+		value = *option.Value
+	}
+
+	// an option is read back into values when it was configured as a list,
+	// otherwise (including on import) it is read back into value
+	if !data.Values.IsNull() {
+		values := []string{}
+		for _, v := range strings.Split(value, ",") {
+			values = append(values, strings.TrimSpace(v))
+		}
+
+		data.Values, diag = types.ListValueFrom(ctx, types.StringType, values)
+		if diag.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+	} else {
+		data.Value = types.StringValue(value)
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentOptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *DeploymentOptionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value, diag := deploymentOptionValue(ctx, data)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	entityID := data.EntityID.ValueInt64()
+	name := data.Name.ValueString()
+	serverID := data.ServerID.ValueInt64()
+
+	option, err := r.kind.get(client, entityID, name, serverID)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get deployment option", err, bamEntity("Deployment Option", data.ID.ValueString()))
+		return
+	}
+
+	option.Value = &value
+
+	err = r.kind.update(client, option)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to update deployment option", err, bamEntity("Deployment Option", data.ID.ValueString()))
+		return
+	}
+
+	option, err = r.kind.get(client, entityID, name, serverID)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get deployment option", err, bamEntity("Deployment Option", data.ID.ValueString()))
+		return
+	}
+
+	data.Type = types.StringPointerValue(option.Type)
+	data.Properties = types.StringPointerValue(option.Properties)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentOptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *DeploymentOptionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	err := r.kind.delete(client, data.EntityID.ValueInt64(), data.Name.ValueString(), data.ServerID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to delete deployment option", err, bamEntity("Deployment Option", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+func (r *DeploymentOptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ids := strings.Split(req.ID, ":")
+	if len(ids) != 2 && len(ids) != 3 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <entity_id>:<name>[:<server_id>]. Got: %q", req.ID),
+		)
+		return
+	}
+
+	entityID, err := strconv.ParseInt(ids[0], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse entity_id", err.Error())
+		return
+	}
+
+	serverID := int64(0)
+	if len(ids) == 3 {
+		serverID, err = strconv.ParseInt(ids[2], 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to parse server_id", err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("entity_id"), entityID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), ids[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), serverID)...)
+}
+
+// deploymentOptionValue returns the value to send to the API for the value or
+// values attribute of data.
+func deploymentOptionValue(ctx context.Context, data *DeploymentOptionResourceModel) (string, diag.Diagnostics) {
+	if data.Values.IsNull() {
+		return data.Value.ValueString(), nil
+	}
+
+	values := []string{}
+	diags := data.Values.ElementsAs(ctx, &values, false)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	return strings.Join(values, ","), diags
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDHCPClientOptionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDHCPClientOptionResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("bluecat_dhcp_client_option.test", "id", validateObjectID),
+					resource.TestCheckResourceAttr("bluecat_dhcp_client_option.test", "values.#", "2"),
+					resource.TestCheckResourceAttr("bluecat_dhcp_client_option.test", "values.1", "corp.example.com"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "bluecat_dhcp_client_option.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"value", "values"},
+				ImportStateIdFunc:       testAccDHCPClientOptionImportStateIdFunc,
+			},
+		},
+	})
+}
+
+func testAccDHCPClientOptionImportStateIdFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["bluecat_dhcp_client_option.test"]
+	if !ok {
+		return "", fmt.Errorf("resource not found in state")
+	}
+
+	return fmt.Sprintf("%s:%s", rs.Primary.Attributes["entity_id"], rs.Primary.Attributes["name"]), nil
+}
+
+const testAccDHCPClientOptionResourceConfig = `
+variable "ip4_network_id" {
+	type = number
+}
+
+resource "bluecat_dhcp_client_option" "test" {
+	entity_id = var.ip4_network_id
+	name      = "domain-search"
+	values    = ["example.com", "corp.example.com"]
+}
+`
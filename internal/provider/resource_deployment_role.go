@@ -0,0 +1,500 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeploymentRoleResource{}
+var _ resource.ResourceWithImportState = &DeploymentRoleResource{}
+var _ resource.ResourceWithValidateConfig = &DeploymentRoleResource{}
+
+func NewDeploymentRoleResource() resource.Resource {
+	return &DeploymentRoleResource{}
+}
+
+// DeploymentRoleResource defines the resource implementation.
+type DeploymentRoleResource struct {
+	client *loginClient
+}
+
+// DeploymentRoleResourceModel describes the resource data model.
+type DeploymentRoleResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Service           types.String `tfsdk:"service"`
+	Type              types.String `tfsdk:"type"`
+	EntityID          types.Int64  `tfsdk:"entity_id"`
+	ServerInterfaceID types.Int64  `tfsdk:"server_interface_id"`
+	ViewID            types.Int64  `tfsdk:"view_id"`
+	Properties        types.String `tfsdk:"properties"`
+
+	// pairs a DHCP MASTER role with a secondary server for failover
+	SecondaryServerInterfaceID types.Int64 `tfsdk:"secondary_server_interface_id"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *DeploymentRoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment_role"
+}
+
+func (r *DeploymentRoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource to assign a DNS or DHCP deployment role for a view, zone, block, or network to a server interface.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Deployment role identifier.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service": schema.StringAttribute{
+				MarkdownDescription: "The service the deployment role is for. " + enumDescription(deploymentServices) + " If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(deploymentServices...),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of deployment role. For `DNS` roles: " + enumDescription(dnsDeploymentRoleTypes) + " For `DHCP` roles: " + enumDescription(dhcpDeploymentRoleTypes),
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(dnsDeploymentRoleTypes...),
+				},
+			},
+			"entity_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the view, zone, block, or network the deployment role is assigned to. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"server_interface_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the server interface the deployment role is assigned to. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"view_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the view a `DNS` deployment role for a block or network applies to. If changed, forces a new resource.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"secondary_server_interface_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the server interface of the secondary server of a DHCP failover pair. The server of `server_interface_id` is the primary server. Only valid for `DHCP` roles of type `MASTER`. The failover pair serves the DHCP ranges of the block or network the role is assigned to.",
+				Optional:            true,
+			},
+			"properties": schema.StringAttribute{
+				MarkdownDescription: "The properties of the deployment role as returned by the API (pipe delimited).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *DeploymentRoleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DeploymentRoleResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Service.ValueString() != deploymentServiceDHCP {
+		if !data.SecondaryServerInterfaceID.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("secondary_server_interface_id"),
+				"Invalid Attribute Combination",
+				"secondary_server_interface_id can only be set for DHCP deployment roles.",
+			)
+		}
+		return
+	}
+
+	if !data.Type.IsUnknown() && !data.Type.IsNull() && !slices.Contains(dhcpDeploymentRoleTypes, data.Type.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Invalid DHCP deployment role type",
+			fmt.Sprintf("The type of a DHCP deployment role must be one of %s, got: %s", strings.Join(dhcpDeploymentRoleTypes, ", "), data.Type.ValueString()),
+		)
+	}
+
+	if !data.ViewID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("view_id"),
+			"Invalid Attribute Combination",
+			"view_id can only be set for DNS deployment roles.",
+		)
+	}
+
+	if data.SecondaryServerInterfaceID.IsNull() || data.SecondaryServerInterfaceID.IsUnknown() {
+		return
+	}
+
+	if !data.Type.IsUnknown() && data.Type.ValueString() != "MASTER" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secondary_server_interface_id"),
+			"Invalid Attribute Combination",
+			"secondary_server_interface_id can only be set for DHCP deployment roles of type MASTER.",
+		)
+	}
+
+	if data.SecondaryServerInterfaceID.Equal(data.ServerInterfaceID) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secondary_server_interface_id"),
+			"Invalid DHCP Failover Server",
+			"secondary_server_interface_id must be a different server interface than server_interface_id.",
+		)
+	}
+}
+
+func (r *DeploymentRoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DeploymentRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *DeploymentRoleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	entityID := data.EntityID.ValueInt64()
+	serverInterfaceID := data.ServerInterfaceID.ValueInt64()
+	roleType := data.Type.ValueString()
+
+	var err error
+	switch data.Service.ValueString() {
+	case deploymentServiceDNS:
+		properties := propertyMap{}
+		if !data.ViewID.IsNull() {
+			properties.setInt64("view", data.ViewID.ValueInt64())
+		}
+		_, err = client.AddDNSDeploymentRole(entityID, serverInterfaceID, roleType, properties.String())
+	case deploymentServiceDHCP:
+		properties := propertyMap{}
+		if !data.SecondaryServerInterfaceID.IsNull() {
+			properties.setInt64("secondaryServerInterfaceId", data.SecondaryServerInterfaceID.ValueInt64())
+		}
+		_, err = client.AddDHCPDeploymentRole(entityID, serverInterfaceID, roleType, properties.String())
+	}
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to add deployment role", err, "")
+		return
+	}
+
+	role, err := getDeploymentRole(client, data)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get deployment role", err, "")
+		return
+	}
+
+	if role.Id == nil || *role.Id == 0 {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Failed to get deployment role", "The deployment role was not found after it was added")
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(*role.Id, 10))
+	data.Properties = types.StringPointerValue(role.Properties)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *DeploymentRoleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	role, err := getDeploymentRole(client, data)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get deployment role", err, bamEntity("Deployment Role", data.ID.ValueString()))
+		return
+	}
+
+	if role.Id == nil || *role.Id == 0 {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(*role.Id, 10))
+	data.Type = types.StringPointerValue(role.Type)
+	data.Properties = types.StringPointerValue(role.Properties)
+	data.SecondaryServerInterfaceID = secondaryServerInterfaceID(role)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *DeploymentRoleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	role, err := getDeploymentRole(client, data)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get deployment role", err, bamEntity("Deployment Role", data.ID.ValueString()))
+		return
+	}
+
+	roleType := data.Type.ValueString()
+	role.Type = &roleType
+
+	if data.Service.ValueString() == deploymentServiceDHCP {
+		properties := propertyMap{}
+		if role.Properties != nil {
+			properties.setAll(parseProperties(*role.Properties))
+		}
+		if data.SecondaryServerInterfaceID.IsNull() {
+			// an empty value removes the failover pair
+			if _, ok := properties["secondaryServerInterfaceId"]; ok {
+				properties.set("secondaryServerInterfaceId", "")
+			}
+		} else {
+			properties.setInt64("secondaryServerInterfaceId", data.SecondaryServerInterfaceID.ValueInt64())
+		}
+		role.Properties = properties.stringPointer()
+	}
+
+	switch data.Service.ValueString() {
+	case deploymentServiceDNS:
+		err = client.UpdateDNSDeploymentRole(role)
+	case deploymentServiceDHCP:
+		err = client.UpdateDHCPDeploymentRole(role)
+	}
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to update deployment role", err, bamEntity("Deployment Role", data.ID.ValueString()))
+		return
+	}
+
+	role, err = getDeploymentRole(client, data)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get deployment role", err, bamEntity("Deployment Role", data.ID.ValueString()))
+		return
+	}
+
+	data.Properties = types.StringPointerValue(role.Properties)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *DeploymentRoleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	entityID := data.EntityID.ValueInt64()
+	serverInterfaceID := data.ServerInterfaceID.ValueInt64()
+
+	var err error
+	switch {
+	case data.Service.ValueString() == deploymentServiceDHCP:
+		err = client.DeleteDHCPDeploymentRole(entityID, serverInterfaceID)
+	case !data.ViewID.IsNull():
+		err = client.DeleteDNSDeploymentRoleForView(entityID, serverInterfaceID, data.ViewID.ValueInt64())
+	default:
+		err = client.DeleteDNSDeploymentRole(entityID, serverInterfaceID)
+	}
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to delete deployment role", err, bamEntity("Deployment Role", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+func (r *DeploymentRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ids := strings.Split(req.ID, ":")
+	if len(ids) != 3 && len(ids) != 4 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <service>:<entity_id>:<server_interface_id>[:<view_id>]. Got: %q", req.ID),
+		)
+		return
+	}
+
+	service := strings.ToUpper(ids[0])
+	if !slices.Contains(deploymentServices, service) {
+		resp.Diagnostics.AddError("Failed to parse service", fmt.Sprintf("service must be one of %s, got: %s", strings.Join(deploymentServices, ", "), ids[0]))
+		return
+	}
+
+	entityID, err := strconv.ParseInt(ids[1], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse entity_id", err.Error())
+		return
+	}
+
+	serverInterfaceID, err := strconv.ParseInt(ids[2], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse server_interface_id", err.Error())
+		return
+	}
+
+	if len(ids) == 4 {
+		viewID, err := strconv.ParseInt(ids[3], 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to parse view_id", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("view_id"), viewID)...)
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service"), service)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("entity_id"), entityID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_interface_id"), serverInterfaceID)...)
+}
+
+// getDeploymentRole returns the deployment role described by data. The API
+// returns an empty role if there is no role for the entity and server interface.
+func getDeploymentRole(client gobam.ProteusAPI, data *DeploymentRoleResourceModel) (*gobam.APIDeploymentRole, error) {
+	entityID := data.EntityID.ValueInt64()
+	serverInterfaceID := data.ServerInterfaceID.ValueInt64()
+
+	switch {
+	case data.Service.ValueString() == deploymentServiceDHCP:
+		return client.GetDHCPDeploymentRole(entityID, serverInterfaceID)
+	case !data.ViewID.IsNull():
+		return client.GetDNSDeploymentRoleForView(entityID, serverInterfaceID, data.ViewID.ValueInt64())
+	default:
+		return client.GetDNSDeploymentRole(entityID, serverInterfaceID)
+	}
+}
+
+// secondaryServerInterfaceID returns the secondary server interface of the DHCP
+// failover pair of a deployment role, or null if the role is not part of one.
+func secondaryServerInterfaceID(role *gobam.APIDeploymentRole) types.Int64 {
+	if role.Properties == nil {
+		return types.Int64Null()
+	}
+
+	id, err := strconv.ParseInt(parseProperties(*role.Properties)["secondaryServerInterfaceId"], 10, 64)
+	if err != nil || id == 0 {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(id)
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccDeploymentRoleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDeploymentRoleResourceConfig("SLAVE"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("bluecat_deployment_role.test", "id", validateObjectID),
+					resource.TestCheckResourceAttr("bluecat_deployment_role.test", "type", "SLAVE"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bluecat_deployment_role.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccDeploymentRoleImportStateIdFunc,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccDeploymentRoleResourceConfig("SLAVE_STEALTH"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_deployment_role.test", "type", "SLAVE_STEALTH"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDeploymentRoleImportStateIdFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["bluecat_deployment_role.test"]
+	if !ok {
+		return "", fmt.Errorf("resource not found in state")
+	}
+
+	return fmt.Sprintf("DNS:%s:%s", rs.Primary.Attributes["entity_id"], rs.Primary.Attributes["server_interface_id"]), nil
+}
+
+func testAccDeploymentRoleResourceConfig(roleType string) string {
+	return fmt.Sprintf(`
+variable "dns_zone_id" {
+	type = number
+}
+
+variable "server_interface_id" {
+	type = number
+}
+
+resource "bluecat_deployment_role" "test" {
+	service             = "DNS"
+	type                = %q
+	entity_id           = var.dns_zone_id
+	server_interface_id = var.server_interface_id
+}
+`, roleType)
+}
+
+func TestSecondaryServerInterfaceID(t *testing.T) {
+	tests := map[string]types.Int64{
+		"":                                   types.Int64Null(),
+		"secondaryServerInterfaceId=|":       types.Int64Null(),
+		"secondaryServerInterfaceId=100905|": types.Int64Value(100905),
+		"inherited=false|secondaryServerInterfaceId=7|": types.Int64Value(7),
+	}
+
+	for properties, expected := range tests {
+		role := &gobam.APIDeploymentRole{Properties: &properties}
+		if id := secondaryServerInterfaceID(role); !id.Equal(expected) {
+			t.Errorf("expected %s for %q, got %s", expected, properties, id)
+		}
+	}
+
+	if id := secondaryServerInterfaceID(&gobam.APIDeploymentRole{}); !id.IsNull() {
+		t.Errorf("expected null without properties, got %s", id)
+	}
+}
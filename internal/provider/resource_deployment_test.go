@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccDeploymentResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDeploymentResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bluecat_deployment.test", "deployment_token"),
+					resource.TestCheckResourceAttrSet("bluecat_deployment.test", "status"),
+				),
+			},
+		},
+	})
+}
+
+// deploymentStatusClient is a client that only implements the deployment
+// status API calls.
+type deploymentStatusClient struct {
+	gobam.ProteusAPI
+	taskStatus   string
+	serverStatus int
+}
+
+// session is a deploymentSession that uses c without logging in.
+func (c deploymentStatusClient) session(f func(client gobam.ProteusAPI) error) error {
+	return f(c)
+}
+
+func (c deploymentStatusClient) GetDeploymentTaskStatus(deploymentTaskToken string) (string, error) {
+	return c.taskStatus, nil
+}
+
+func (c deploymentStatusClient) GetServerDeploymentStatus(serverId int64, properties string) (int, error) {
+	return c.serverStatus, nil
+}
+
+func TestSelectiveDeploymentStatus(t *testing.T) {
+	tests := map[string]struct {
+		taskStatus string
+		want       string
+	}{
+		"queued":    {taskStatus: `{"status":"QUEUED","response":{}}`, want: "QUEUED"},
+		"started":   {taskStatus: `{"status":"STARTED","response":{"status":"SUCCESSFUL"}}`, want: "STARTED"},
+		"finished":  {taskStatus: `{"status":"FINISHED","response":{"status":"SUCCESSFUL"}}`, want: "SUCCESSFUL"},
+		"no result": {taskStatus: `{"status":"FINISHED"}`, want: "FINISHED"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, _, err := selectiveDeploymentStatus(deploymentStatusClient{taskStatus: tc.taskStatus}, "token")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("selectiveDeploymentStatus() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWaitForServerDeployment(t *testing.T) {
+	status, err := waitForServerDeployment(context.Background(), deploymentStatusClient{serverStatus: 7}.session, 1, time.Minute)
+	if err != nil || status != "DONE" {
+		t.Errorf("waitForServerDeployment() = %s, %v, want DONE", status, err)
+	}
+
+	_, err = waitForServerDeployment(context.Background(), deploymentStatusClient{serverStatus: 3}.session, 1, time.Minute)
+	if err == nil {
+		t.Errorf("waitForServerDeployment() of a failed deployment did not return an error")
+	}
+
+	_, err = waitForServerDeployment(context.Background(), deploymentStatusClient{serverStatus: 1}.session, 1, time.Second)
+	if err == nil {
+		t.Errorf("waitForServerDeployment() of a queued deployment did not time out")
+	}
+}
+
+const testAccDeploymentResourceConfig = `
+variable "host_record_id" {
+	type = number
+}
+
+resource "bluecat_deployment" "test" {
+	entity_ids = [var.host_record_id]
+}
+`
@@ -0,0 +1,400 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &EntityResource{}
+var _ resource.ResourceWithImportState = &EntityResource{}
+
+func NewEntityResource() resource.Resource {
+	return &EntityResource{}
+}
+
+// EntityResource defines the resource implementation.
+type EntityResource struct {
+	client *loginClient
+}
+
+// EntityResourceModel describes the resource data model.
+type EntityResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ParentID      types.Int64  `tfsdk:"parent_id"`
+	Type          types.String `tfsdk:"type"`
+	Name          types.String `tfsdk:"name"`
+	Properties    types.Map    `tfsdk:"properties"`
+	AllProperties types.String `tfsdk:"all_properties"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *EntityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity"
+}
+
+func (r *EntityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource to create an entity of any type with the `addEntity` API call. This is an escape hatch for object types that do not have a dedicated resource; prefer the dedicated resource when one exists. Which properties are accepted for each type is defined by the BlueCat Address Manager API documentation.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Entity identifier.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the parent object that will contain the entity. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the entity. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(gobam.ObjectTypes...),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the entity.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"properties": schema.MapAttribute{
+				MarkdownDescription: "The properties to set on the entity. Only the properties in this map are managed; properties removed from it are cleared.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(propertyNameRegexp, propertyNameRegexpMessage)),
+				},
+			},
+			"all_properties": schema.StringAttribute{
+				MarkdownDescription: "The properties of the entity as returned by the API (pipe delimited).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *EntityResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *EntityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *EntityResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	properties, diag := userDefinedFieldsUpdateProperties(ctx, data.Properties, types.MapNull(types.StringType), nil)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	properties.setManaged(r.client)
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	// name is unknown when it is not configured
+	var name *string
+	if !data.Name.IsUnknown() {
+		name = data.Name.ValueStringPointer()
+	}
+
+	entity := gobam.APIEntity{
+		Name:       name,
+		Type:       data.Type.ValueStringPointer(),
+		Properties: properties.stringPointer(),
+	}
+
+	id, err := client.AddEntity(data.ParentID.ValueInt64(), &entity)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to create entity", err, "")
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(id, 10))
+
+	// we have an ID at this point so save the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	created, err := client.GetEntityById(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get entity by Id", err, bamEntity("Entity", data.ID.ValueString()))
+		return
+	}
+
+	data.flatten(created)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EntityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *EntityResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
+	}
+
+	entity, err := client.GetEntityById(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get entity by Id", err, bamEntity("Entity", data.ID.ValueString()))
+		return
+	}
+
+	if entity.Id == nil || *entity.Id == 0 {
+		tflog.Trace(ctx, "Entity was deleted outside terraform")
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// the type is not known yet when the entity is imported
+	if !data.Type.IsNull() {
+		if ok, diag := entityTypeMatches(entity, data.Type.ValueString()); !ok {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	}
+
+	// get the parent id of the entity so we can set it in the state so import works
+	parent, err := client.GetParent(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get parent entity", err, bamEntity("Entity", data.ID.ValueString()))
+		return
+	}
+	data.ParentID = types.Int64Value(*parent.Id)
+
+	data.flatten(entity)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EntityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data, state *EntityResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	properties, diag := userDefinedFieldsUpdateProperties(ctx, data.Properties, state.Properties, nil)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	update := gobam.APIEntity{
+		Id:         &id,
+		Name:       data.Name.ValueStringPointer(),
+		Properties: properties.stringPointer(),
+		Type:       state.Type.ValueStringPointer(),
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Attempting to update entity with properties: %s", properties))
+
+	err = client.Update(&update)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Entity Update failed", err, bamEntity("Entity", data.ID.ValueString()))
+		return
+	}
+
+	entity, err := client.GetEntityById(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get entity by Id", err, bamEntity("Entity", data.ID.ValueString()))
+		return
+	}
+
+	data.flatten(entity)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EntityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *EntityResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(deleteProtectionCheck(r.client, data.Type.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	err = client.Delete(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Delete failed", err, bamEntity("Entity", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+func (r *EntityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// flatten sets the model from an entity returned by the API. Only the
+// properties already in the model are refreshed so that properties set by
+// BlueCat Address Manager or outside terraform are not reported as drift.
+func (m *EntityResourceModel) flatten(e *gobam.APIEntity) {
+	m.Name = types.StringPointerValue(e.Name)
+	m.Type = types.StringPointerValue(e.Type)
+	m.AllProperties = types.StringPointerValue(e.Properties)
+
+	if m.Properties.IsNull() || m.Properties.IsUnknown() {
+		return
+	}
+
+	current := map[string]string{}
+	if e.Properties != nil {
+		current = parseProperties(*e.Properties)
+	}
+
+	properties := make(map[string]attr.Value)
+	for k := range m.Properties.Elements() {
+		if v, ok := current[k]; ok {
+			properties[k] = types.StringValue(v)
+		}
+	}
+	m.Properties = types.MapValueMust(types.StringType, properties)
+}
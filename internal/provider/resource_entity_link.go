@@ -0,0 +1,319 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+const entityLinkPageSize = 1000
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &EntityLinkResource{}
+var _ resource.ResourceWithImportState = &EntityLinkResource{}
+
+func NewEntityLinkResource() resource.Resource {
+	return &EntityLinkResource{}
+}
+
+// EntityLinkResource defines the resource implementation.
+type EntityLinkResource struct {
+	client *loginClient
+}
+
+// EntityLinkResourceModel describes the resource data model.
+type EntityLinkResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Entity1ID        types.Int64  `tfsdk:"entity1_id"`
+	Entity2ID        types.Int64  `tfsdk:"entity2_id"`
+	Properties       types.String `tfsdk:"properties"`
+	LinkedEntityType types.String `tfsdk:"linked_entity_type"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *EntityLinkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity_link"
+}
+
+func (r *EntityLinkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource to link two entities in BlueCat Address Manager. This can be used to model associations such as DNS restrictions, shared networks, and tags that do not have a dedicated resource. Which entity types can be linked, and which properties are accepted, is defined by the BlueCat Address Manager API documentation for `linkEntities`.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the link in the form `<entity1_id>:<entity2_id>`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"entity1_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the first entity in the link.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"entity2_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the second entity in the link.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"properties": schema.StringAttribute{
+				MarkdownDescription: "Properties to pass to the `linkEntities` and `unlinkEntities` API calls, in the form `key=value|key=value|`. Changing this forces a new resource to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"linked_entity_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the entity referenced by `entity2_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *EntityLinkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *EntityLinkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *EntityLinkResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	entity1ID := data.Entity1ID.ValueInt64()
+	entity2ID := data.Entity2ID.ValueInt64()
+
+	err := client.LinkEntities(entity1ID, entity2ID, data.Properties.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to link entities", err, "")
+		return
+	}
+
+	entity2, err := client.GetEntityById(entity2ID)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get linked entity by Id", err, "")
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d:%d", entity1ID, entity2ID))
+	data.LinkedEntityType = types.StringPointerValue(entity2.Type)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EntityLinkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *EntityLinkResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	entity2, err := client.GetEntityById(data.Entity2ID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get linked entity by Id", err, bamEntity("Entity Link", data.ID.ValueString()))
+		return
+	}
+
+	if *entity2.Id == 0 {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	linked, err := entitiesLinked(client, data.Entity1ID.ValueInt64(), entity2)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get linked entities", err, bamEntity("Entity Link", data.ID.ValueString()))
+		return
+	}
+
+	if !linked {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.LinkedEntityType = types.StringPointerValue(entity2.Type)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EntityLinkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *EntityLinkResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every attribute of the link forces replacement, so the only changes
+	// that can reach Update are to the credentials.
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EntityLinkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *EntityLinkResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	err := client.UnlinkEntities(data.Entity1ID.ValueInt64(), data.Entity2ID.ValueInt64(), data.Properties.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to unlink entities", err, bamEntity("Entity Link", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+func (r *EntityLinkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ids := strings.Split(req.ID, ":")
+	if len(ids) != 2 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <entity1_id>:<entity2_id>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	entity1ID, err := strconv.ParseInt(ids[0], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse entity1_id", err.Error())
+		return
+	}
+
+	entity2ID, err := strconv.ParseInt(ids[1], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse entity2_id", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("entity1_id"), entity1ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("entity2_id"), entity2ID)...)
+}
+
+// entitiesLinked pages through the entities of entity2's type that are linked
+// to entity1 and reports whether entity2 is one of them.
+func entitiesLinked(client gobam.ProteusAPI, entity1ID int64, entity2 *gobam.APIEntity) (bool, error) {
+	for start := 0; ; start += entityLinkPageSize {
+		linked, err := client.GetLinkedEntities(entity1ID, *entity2.Type, start, entityLinkPageSize)
+		if err != nil {
+			return false, err
+		}
+
+		for _, e := range linked.Item {
+			if e.Id != nil && *e.Id == *entity2.Id {
+				return true, nil
+			}
+		}
+
+		if len(linked.Item) < entityLinkPageSize {
+			return false, nil
+		}
+	}
+}
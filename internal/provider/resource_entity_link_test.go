@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEntityLinkResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccEntityLinkResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_entity_link.test", "linked_entity_type", "Tag"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bluecat_entity_link.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccEntityLinkResourceConfig = `
+variable "ip4_network_id" {
+	type = number
+}
+
+variable "tag_id" {
+	type = number
+}
+
+resource "bluecat_entity_link" "test" {
+	entity1_id = var.ip4_network_id
+	entity2_id = var.tag_id
+}
+`
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccEntityResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccEntityResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("bluecat_entity.test", "id", validateObjectID),
+					resource.TestCheckResourceAttr("bluecat_entity.test", "type", "TagGroup"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "bluecat_entity.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"properties"},
+			},
+		},
+	})
+}
+
+const testAccEntityResourceConfig = `
+resource "bluecat_entity" "test" {
+  parent_id = 0
+  type      = "TagGroup"
+  name      = "Terraform Acceptance Test Tag Group"
+}
+`
+
+func TestEntityResourceModelFlatten(t *testing.T) {
+	ctx := context.Background()
+	name := "test"
+	objectType := "TagGroup"
+	properties := "color=blue|owner=alice|"
+
+	managed, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"color": "red", "removed": "x"})
+	data := &EntityResourceModel{Properties: managed}
+	data.flatten(&gobam.APIEntity{Name: &name, Type: &objectType, Properties: &properties})
+
+	expected, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"color": "blue"})
+	if !data.Properties.Equal(expected) {
+		t.Errorf("expected only managed properties to be refreshed, got %s", data.Properties)
+	}
+	if data.AllProperties.ValueString() != properties {
+		t.Errorf("expected all properties to be %q, got %s", properties, data.AllProperties)
+	}
+
+	data = &EntityResourceModel{Properties: types.MapNull(types.StringType)}
+	data.flatten(&gobam.APIEntity{Name: &name, Type: &objectType, Properties: &properties})
+	if !data.Properties.IsNull() {
+		t.Errorf("expected unmanaged properties to stay null, got %s", data.Properties)
+	}
+}
+
+func TestEntityTypeMatches(t *testing.T) {
+	id := int64(42)
+	network := "IP4Network"
+
+	if ok, diags := entityTypeMatches(&gobam.APIEntity{Id: &id, Type: &network}, "IP4Block", "IP4Network"); !ok || diags.WarningsCount() != 0 {
+		t.Errorf("expected a matching type without a warning, got %v %v", ok, diags)
+	}
+
+	ok, diags := entityTypeMatches(&gobam.APIEntity{Id: &id, Type: &network}, "IP4Block")
+	if ok || diags.WarningsCount() != 1 || diags.HasError() {
+		t.Errorf("expected a mismatched type to return a warning, got %v %v", ok, diags)
+	}
+
+	if ok, _ := entityTypeMatches(&gobam.APIEntity{Id: &id}, "IP4Block"); ok {
+		t.Error("expected an entity without a type not to match")
+	}
+}
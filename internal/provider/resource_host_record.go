@@ -7,6 +7,12 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -15,17 +21,21 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/umich-vci/gobam"
-	"golang.org/x/exp/maps"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &HostRecordResource{}
 var _ resource.ResourceWithImportState = &HostRecordResource{}
+var _ resource.ResourceWithModifyPlan = &HostRecordResource{}
+var _ resource.ResourceWithUpgradeState = &HostRecordResource{}
+var _ resource.ResourceWithValidateConfig = &HostRecordResource{}
 
 func NewHostRecordResource() resource.Resource {
 	return &HostRecordResource{}
@@ -46,19 +56,35 @@ type HostRecordResourceModel struct {
 
 	// These are exposed via the entity properties field for objects of type IP4Address
 	TTL           types.Int64  `tfsdk:"ttl"`
+	UseZoneTTL    types.Bool   `tfsdk:"use_zone_default_ttl"`
 	AbsoluteName  types.String `tfsdk:"absolute_name"`
 	Addresses     types.Set    `tfsdk:"addresses"`
 	ReverseRecord types.Bool   `tfsdk:"reverse_record"`
+	Comments      types.String `tfsdk:"comments"`
 
-	// this is returned by the API but do not appear in the documentation
+	// the alias records pointing to the host record
+	Aliases types.Set `tfsdk:"aliases"`
+
+	// the object IDs of the addresses, which can be set instead of addresses
 	AddressIDs types.Set `tfsdk:"address_ids"`
 
 	// these are user defined fields that are not built-in
 	UserDefinedFields types.Map `tfsdk:"user_defined_fields"`
 
+	// the deprecated name of user_defined_fields used by the sdk based releases
+	CustomProperties types.Map `tfsdk:"custom_properties"`
+
 	// These fields are only used for creation
 	DNSZone types.String `tfsdk:"dns_zone"`
 	ViewID  types.Int64  `tfsdk:"view_id"`
+	ViewIDs types.Set    `tfsdk:"view_ids"`
+
+	// the host records managed in each of view_ids
+	RecordIDs types.Map `tfsdk:"record_ids"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
 }
 
 func (r *HostRecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -69,18 +95,22 @@ func (r *HostRecordResource) Schema(ctx context.Context, req resource.SchemaRequ
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Resource create a host record.",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
 			// These are exposed for Entity objects via the API
 			"id": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Host Record identifier",
+				MarkdownDescription: "Host Record identifier. If `view_ids` is set, this is the host record in the view with the lowest ID.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					hostRecordIDPlanModifier{},
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the host record to be created. Combined with `dns_zone` to make the fqdn.",
+				MarkdownDescription: "The name of the host record to be created. Combined with `dns_zone` to make the fqdn. Must be made of valid DNS labels without a trailing dot. Differences in case from the name stored in BlueCat Address Manager are ignored.",
 				Required:            true,
 			},
 			"type": schema.StringAttribute{
@@ -96,29 +126,73 @@ func (r *HostRecordResource) Schema(ctx context.Context, req resource.SchemaRequ
 			},
 			// These fields are only used for creation and are not exposed via the API entity
 			"dns_zone": schema.StringAttribute{
-				MarkdownDescription: "The DNS zone to create the host record in. Combined with `name` to make the fqdn.  If changed, forces a new resource.",
+				MarkdownDescription: "The DNS zone to create the host record in. Combined with `name` to make the fqdn. Must not have a trailing dot. If changed, forces a new resource.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"view_id": schema.Int64Attribute{
-				MarkdownDescription: "The object ID of the View that host record should be created in. If changed, forces a new resource.",
-				Required:            true,
+				MarkdownDescription: "The object ID of the View that host record should be created in. Exactly one of `view_id` or `view_ids` must be set. If changed, forces a new resource.",
+				Optional:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.RequiresReplaceIf(hostRecordViewIDPlanModifier, hostRecordViewIDPlanModifierDescription, hostRecordViewIDPlanModifierDescription),
 				},
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("view_ids")),
+				},
+			},
+			"view_ids": schema.SetAttribute{
+				MarkdownDescription: "The object IDs of the Views to create the same host record in, for example the internal and external views of split-horizon DNS. A host record is managed in each view. Adding or removing a view creates or deletes the host record in that view. Switching between `view_id` and `view_ids` forces a new resource.",
+				Optional:            true,
+				ElementType:         types.Int64Type,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplaceIf(hostRecordViewIDsPlanModifier, hostRecordViewIDsPlanModifierDescription, hostRecordViewIDsPlanModifierDescription),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"record_ids": schema.MapAttribute{
+				MarkdownDescription: "A map of the View IDs in `view_ids` to the object ID of the host record in that view. Only set if `view_ids` is set.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
 			},
 			// These are exposed via the API properties field for objects of type Host Record
 			"addresses": schema.SetAttribute{
-				MarkdownDescription: "The address(es) to be associated with the host record.",
-				Required:            true,
-				ElementType:         types.StringType,
+				MarkdownDescription: "The address(es) to be associated with the host record. Exactly one of `addresses` or `address_ids` must be set.",
+				Optional:            true,
+				Computed:            true,
+				ElementType:         ip4AddressType{},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(ip4AddressValidator{}),
+					setvalidator.ExactlyOneOf(path.MatchRoot("address_ids")),
+				},
 			},
 			"address_ids": schema.SetAttribute{
-				MarkdownDescription: "A set of all address ids associated with the host record.",
+				MarkdownDescription: "The object IDs of the IPv4 addresses to be associated with the host record, such as the `id` of `bluecat_ip4_address` resources. When an address is replaced, for example because it is allocated from a different network, the host record is updated with the new address. If `addresses` is set instead, this is the object IDs of those addresses.",
+				Optional:            true,
 				Computed:            true,
 				ElementType:         types.Int64Type,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"comments": schema.StringAttribute{
+				MarkdownDescription: "Comments about the host record.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"aliases": schema.SetAttribute{
+				MarkdownDescription: "The absolute names (fqdn) of alias records (CNAMEs) that point to the host record, such as `www.example.com`. The alias records are created in the same view(s) as the host record and use the zone default TTL. When set, every alias record pointing to the host record is managed, so aliases added outside of Terraform are removed on the next apply.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
 			},
 			"reverse_record": schema.BoolAttribute{
 				MarkdownDescription: "If a reverse record should be created for addresses.",
@@ -132,6 +206,12 @@ func (r *HostRecordResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Computed:            true,
 				Default:             int64default.StaticInt64(-1),
 			},
+			"use_zone_default_ttl": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, the host record does not have a TTL of its own and uses the default TTL of the zone. The API does not return a TTL for such records, so `ttl` is kept as configured, for example the zone default TTL, instead of showing a diff. If the host record gets a TTL outside of Terraform, it is removed on the next apply.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"absolute_name": schema.StringAttribute{
 				MarkdownDescription: "The absolute name (fqdn) of the host record.",
 				Computed:            true,
@@ -142,6 +222,19 @@ func (r *HostRecordResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Computed:            true,
 				ElementType:         types.StringType,
 				Default:             mapdefault.StaticValue(basetypes.NewMapValueMust(types.StringType, nil)),
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(propertyNameRegexp, propertyNameRegexpMessage)),
+				},
+			},
+			"custom_properties": schema.MapAttribute{
+				MarkdownDescription: "The previous name of `user_defined_fields`, which sets `user_defined_fields` when it is configured so that configurations written for releases before 0.4.0 keep working.",
+				DeprecationMessage:  "Use user_defined_fields instead. custom_properties will be removed in a future release.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ConflictsWith(path.MatchRoot("user_defined_fields")),
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(propertyNameRegexp, propertyNameRegexpMessage)),
+				},
 			},
 		},
 	}
@@ -168,6 +261,11 @@ func (r *HostRecordResource) Configure(ctx context.Context, req resource.Configu
 }
 
 func (r *HostRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data *HostRecordResourceModel
 
 	// Read Terraform plan data into the model
@@ -177,76 +275,97 @@ func (r *HostRecordResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	viewID := data.ViewID.ValueInt64()
-	absoluteName := data.Name.ValueString() + "." + data.DNSZone.ValueString()
-	ttl := data.TTL.ValueInt64()
-
-	var addresses []string
-	diag = data.Addresses.ElementsAs(ctx, &addresses, false)
+	diag = data.resolveAddresses(ctx, client)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	properties := ""
-	properties = properties + fmt.Sprintf("reverseRecord=%s|", strconv.FormatBool(data.ReverseRecord.ValueBool()))
-
-	var udfs map[string]string
-	resp.Diagnostics.Append(data.UserDefinedFields.ElementsAs(ctx, &udfs, false)...)
-	if resp.Diagnostics.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	viewIDs, diag := data.viewIDs(ctx)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
-	for k, v := range udfs {
-		properties = properties + fmt.Sprintf("%s=%s|", k, v)
-	}
 
-	host, err := client.AddHostRecord(viewID, absoluteName, strings.Join(addresses, ","), ttl, properties)
-	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("AddHostRecord failed", err.Error())
+	aliases, diag := data.aliases(ctx)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
 		return
 	}
 
+	absoluteName := data.Name.ValueString() + "." + data.DNSZone.ValueString()
+
+	recordIDs := make(map[string]int64)
+	for _, viewID := range viewIDs {
+		hostID, diag := addHostRecord(ctx, client, r.client, viewID, data)
+		if !diag.HasError() {
+			recordIDs[strconv.FormatInt(viewID, 10)] = hostID
+			if err := updateHostRecordAliases(client, r.client, viewID, hostID, absoluteName, nil, aliases); err != nil {
+				addBAMError(&diag, "Failed to add host record aliases", err, "")
+			}
+		}
+		if diag.HasError() {
+			// remove the host records created in other views so they are not left behind
+			for _, id := range recordIDs {
+				if err := deleteHostRecordWithAliases(client, r.client, id, aliases); err != nil {
+					resp.Diagnostics.AddWarning("Failed to delete host record after a failed create", fmt.Sprintf("Host record %d must be deleted manually: %s", id, err.Error()))
+				}
+			}
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+	}
+
+	host := recordIDs[strconv.FormatInt(viewIDs[0], 10)]
 	data.ID = types.StringValue(strconv.FormatInt(host, 10))
 
+	data.RecordIDs = types.MapNull(types.Int64Type)
+	if !data.ViewIDs.IsNull() {
+		data.RecordIDs, diag = types.MapValueFrom(ctx, types.Int64Type, recordIDs)
+		if diag.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+	}
+
 	entity, err := client.GetEntityById(host)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to get IP4 Address by Id after creation",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id after creation", err, bamEntity("Host Record", data.ID.ValueString()))
 		return
 	}
 
-	data.Name = types.StringPointerValue(entity.Name)
+	data.Name = caseInsensitiveValue(data.Name, entity.Name)
 	data.Properties = types.StringPointerValue(entity.Properties)
 	data.Type = types.StringPointerValue(entity.Type)
 
 	hrProperties, diag := flattenHostRecordProperties(entity)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
 	data.AbsoluteName = hrProperties.AbsoluteName
-	data.Addresses = hrProperties.Addresses
+	data.Addresses = ip4AddressSet(hrProperties.Addresses)
 	data.AddressIDs = hrProperties.AddressIDs
-	data.TTL = hrProperties.TTL
+	data.TTL = hostRecordTTL(data.TTL, hrProperties.TTL, data.UseZoneTTL.ValueBool())
 	data.ReverseRecord = hrProperties.ReverseRecord
-	data.UserDefinedFields = hrProperties.UserDefinedFields
+	data.Comments = hrProperties.Comments
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, hrProperties.UserDefinedFields, r.client)
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -266,61 +385,148 @@ func (r *HostRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
 	if diag.HasError() {
-		resp.Diagnostics.Append(diag...)
 		return
 	}
 
+	if !data.RecordIDs.IsNull() {
+		recordIDs := make(map[string]int64)
+		resp.Diagnostics.Append(data.RecordIDs.ElementsAs(ctx, &recordIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			return
+		}
+
+		// forget the host records that were deleted outside of Terraform so
+		// that they are created again
+		for view, recordID := range recordIDs {
+			entity, err := client.GetEntityById(recordID)
+			if err != nil {
+				resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+				addBAMError(&resp.Diagnostics, "Failed to get host record by Id", err, bamEntity("Host Record", data.ID.ValueString()))
+				return
+			}
+
+			if entity.Id == nil || *entity.Id == 0 {
+				delete(recordIDs, view)
+				continue
+			}
+
+			if ok, diag := entityTypeMatches(entity, "HostRecord"); !ok {
+				resp.Diagnostics.Append(diag...)
+				delete(recordIDs, view)
+			}
+		}
+
+		if len(recordIDs) == 0 {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		viewIDs := hostRecordViewIDs(recordIDs)
+		data.ID = types.StringValue(strconv.FormatInt(recordIDs[strconv.FormatInt(viewIDs[0], 10)], 10))
+		data.ViewIDs, diag = types.SetValueFrom(ctx, types.Int64Type, viewIDs)
+		resp.Diagnostics.Append(diag...)
+		data.RecordIDs, diag = types.MapValueFrom(ctx, types.Int64Type, recordIDs)
+		resp.Diagnostics.Append(diag...)
+		if resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			return
+		}
+	}
+
 	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
 		return
 	}
 
 	entity, err := client.GetEntityById(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to get host record by Id", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get host record by Id", err, bamEntity("Host Record", data.ID.ValueString()))
 		return
 	}
 
 	if *entity.Id == 0 {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	data.Name = types.StringPointerValue(entity.Name)
+	if ok, diag := entityTypeMatches(entity, "HostRecord"); !ok {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Name = caseInsensitiveValue(data.Name, entity.Name)
 	data.Properties = types.StringPointerValue(entity.Properties)
 	data.Type = types.StringPointerValue(entity.Type)
 
 	hostRecordProperties, diag := flattenHostRecordProperties(entity)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		return
 	}
 
+	if !data.Aliases.IsNull() {
+		prior, diag := data.aliases(ctx)
+		resp.Diagnostics.Append(diag...)
+
+		aliases, err := getHostRecordAliases(client, id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get host record aliases", err, bamEntity("Host Record", data.ID.ValueString()))
+			return
+		}
+
+		data.Aliases, diag = hostRecordAliasesValue(ctx, prior, aliases)
+		resp.Diagnostics.Append(diag...)
+	}
+
 	data.AbsoluteName = hostRecordProperties.AbsoluteName
-	data.Addresses = hostRecordProperties.Addresses
+	data.Addresses = ip4AddressSet(hostRecordProperties.Addresses)
 	data.AddressIDs = hostRecordProperties.AddressIDs
 	data.ReverseRecord = hostRecordProperties.ReverseRecord
-	data.TTL = hostRecordProperties.TTL
-	data.UserDefinedFields = hostRecordProperties.UserDefinedFields
+	data.Comments = hostRecordProperties.Comments
+	// imported or saved before use_zone_default_ttl was added
+	if data.UseZoneTTL.IsNull() {
+		data.UseZoneTTL = types.BoolValue(false)
+	}
+	data.TTL = hostRecordTTL(data.TTL, hostRecordProperties.TTL, data.UseZoneTTL.ValueBool())
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, hostRecordProperties.UserDefinedFields, r.client)
+	if !data.CustomProperties.IsNull() {
+		data.CustomProperties = data.UserDefinedFields
+	}
 
-	zone := []string{}
-	zone = append(zone, strings.Split(data.AbsoluteName.ValueString(), ".")[1:]...)
-	data.DNSZone = types.StringValue(strings.Join(zone, "."))
+	// keep the configured zone if the absolute name is in it, since the name
+	// may contain dots
+	absoluteName := strings.ToLower(data.AbsoluteName.ValueString())
+	if data.DNSZone.IsNull() || !strings.HasSuffix(absoluteName, "."+strings.ToLower(data.DNSZone.ValueString())) {
+		zone := []string{}
+		zone = append(zone, strings.Split(data.AbsoluteName.ValueString(), ".")[1:]...)
+		data.DNSZone = types.StringValue(strings.Join(zone, "."))
+	}
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *HostRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data, state *HostRecordResourceModel
 
 	// Read Terraform plan data into the model
@@ -331,110 +537,223 @@ func (r *HostRecordResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	if resp.Diagnostics.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	diag = data.resolveAddresses(ctx, client)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	properties := ""
+	priorAliases, diag := state.aliases(ctx)
+	resp.Diagnostics.Append(diag...)
+	aliases, diag := data.aliases(ctx)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		return
+	}
+
+	properties := propertyMap{}
 
 	// addresses must always be set
-	var addresses []string
-	resp.Diagnostics.Append(data.Addresses.ElementsAs(ctx, &addresses, false)...)
-	properties = properties + fmt.Sprintf("addresses=%s|", strings.Join(addresses, ","))
+	addresses, addressesDiag := ip4AddressStrings(ctx, data.Addresses)
+	resp.Diagnostics.Append(addressesDiag...)
+	properties.setList("addresses", addresses)
 
 	if !data.ReverseRecord.Equal(state.ReverseRecord) {
-		properties = properties + fmt.Sprintf("reverseRecord=%s|", strconv.FormatBool(data.ReverseRecord.ValueBool()))
+		properties.setBool("reverseRecord", data.ReverseRecord.ValueBool())
 	}
 
-	if !data.TTL.Equal(state.TTL) {
-		properties = properties + fmt.Sprintf("ttl=%d|", data.TTL.ValueInt64())
+	if !data.Comments.Equal(state.Comments) {
+		properties.set("comments", data.Comments.ValueString())
 	}
 
-	if !data.UserDefinedFields.Equal(state.UserDefinedFields) {
-		var udfs, oldudfs map[string]string
-		resp.Diagnostics.Append(data.UserDefinedFields.ElementsAs(ctx, &udfs, false)...)
-		resp.Diagnostics.Append(state.UserDefinedFields.ElementsAs(ctx, &oldudfs, false)...)
-
-		for k, v := range udfs {
-			properties = properties + fmt.Sprintf("%s=%s|", k, v)
-		}
-
-		// set keys that no longer exist to empty string
-		oldkeys := maps.Keys(oldudfs)
-		keys := maps.Keys(udfs)
-		for _, x := range oldkeys {
-			if !slices.Contains(keys, x) {
-				properties = properties + fmt.Sprintf("%s=|", x)
-			}
+	if data.UseZoneTTL.ValueBool() {
+		if !data.TTL.Equal(state.TTL) || !data.UseZoneTTL.Equal(state.UseZoneTTL) {
+			properties.setInt64("ttl", -1)
 		}
+	} else if !data.TTL.Equal(state.TTL) || !data.UseZoneTTL.Equal(state.UseZoneTTL) {
+		properties.setInt64("ttl", data.TTL.ValueInt64())
 	}
 
-	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	udfProperties, udfDiag := userDefinedFieldsUpdateProperties(ctx, data.UserDefinedFields, state.UserDefinedFields, r.client)
+	resp.Diagnostics.Append(udfDiag...)
+	properties.setAll(udfProperties)
+
+	id, err := strconv.ParseInt(state.ID.ValueString(), 10, 64)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
 		return
 	}
 
+	// the host records in each view, keyed by view ID
+	recordIDs := map[string]int64{"": id}
+	if !state.RecordIDs.IsNull() {
+		recordIDs = make(map[string]int64)
+		resp.Diagnostics.Append(state.RecordIDs.ElementsAs(ctx, &recordIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			return
+		}
+	}
+
+	if !data.ViewIDs.IsNull() {
+		viewIDs, diag := data.viewIDs(ctx)
+		if diag.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+
+		planned := make(map[string]bool)
+		for _, viewID := range viewIDs {
+			planned[strconv.FormatInt(viewID, 10)] = true
+		}
+
+		// delete the host records in views that were removed
+		for view, recordID := range recordIDs {
+			if planned[view] {
+				continue
+			}
+
+			if err := updateHostRecordAliases(client, r.client, 0, recordID, "", priorAliases, nil); err != nil {
+				resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+				addBAMError(&resp.Diagnostics, "Failed to delete host record aliases", err, bamEntity("Host Record", data.ID.ValueString()))
+				return
+			}
+
+			if err := client.Delete(recordID); err != nil {
+				resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+				addBAMError(&resp.Diagnostics, "Host Record Delete failed", err, bamEntity("Host Record", data.ID.ValueString()))
+				return
+			}
+			delete(recordIDs, view)
+		}
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Attempting to update HostRecord with properties: %s", properties))
 
-	update := gobam.APIEntity{
-		Id:         &id,
-		Name:       data.Name.ValueStringPointer(),
-		Properties: &properties,
-		Type:       state.Type.ValueStringPointer(),
+	for _, recordID := range recordIDs {
+		update := gobam.APIEntity{
+			Id:         &recordID,
+			Name:       data.Name.ValueStringPointer(),
+			Properties: properties.stringPointer(),
+			Type:       state.Type.ValueStringPointer(),
+		}
+
+		err = client.Update(&update)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Host Record Update failed", err, bamEntity("Host Record", data.ID.ValueString()))
+			return
+		}
 	}
 
-	err = client.Update(&update)
-	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Host Record Update failed", err.Error())
-		return
+	absoluteName := data.Name.ValueString() + "." + data.DNSZone.ValueString()
+
+	if !data.Aliases.Equal(state.Aliases) {
+		removeAliases, addAliases := hostRecordAliasChanges(priorAliases, aliases)
+		for view, recordID := range recordIDs {
+			viewID := data.ViewID.ValueInt64()
+			if view != "" {
+				viewID, _ = strconv.ParseInt(view, 10, 64)
+			}
+
+			if err := updateHostRecordAliases(client, r.client, viewID, recordID, absoluteName, removeAliases, addAliases); err != nil {
+				resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+				addBAMError(&resp.Diagnostics, "Failed to update host record aliases", err, bamEntity("Host Record", data.ID.ValueString()))
+				return
+			}
+		}
+	}
+
+	data.RecordIDs = types.MapNull(types.Int64Type)
+	if !data.ViewIDs.IsNull() {
+		viewIDs, diag := data.viewIDs(ctx)
+		if diag.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+
+		// create the host records in views that were added
+		for _, viewID := range viewIDs {
+			view := strconv.FormatInt(viewID, 10)
+			if _, ok := recordIDs[view]; ok {
+				continue
+			}
+
+			hostID, diag := addHostRecord(ctx, client, r.client, viewID, data)
+			if diag.HasError() {
+				resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+				resp.Diagnostics.Append(diag...)
+				return
+			}
+			recordIDs[view] = hostID
+
+			if err := updateHostRecordAliases(client, r.client, viewID, hostID, absoluteName, nil, aliases); err != nil {
+				resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+				addBAMError(&resp.Diagnostics, "Failed to add host record aliases", err, bamEntity("Host Record", data.ID.ValueString()))
+				return
+			}
+		}
+
+		id = recordIDs[strconv.FormatInt(viewIDs[0], 10)]
+		data.ID = types.StringValue(strconv.FormatInt(id, 10))
+		data.RecordIDs, diag = types.MapValueFrom(ctx, types.Int64Type, recordIDs)
+		if diag.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
 	}
 
 	entity, err := client.GetEntityById(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to get host record by Id after update",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get host record by Id after update", err, bamEntity("Host Record", data.ID.ValueString()))
 		return
 	}
 
-	data.Name = types.StringPointerValue(entity.Name)
+	data.Name = caseInsensitiveValue(data.Name, entity.Name)
 	data.Properties = types.StringPointerValue(entity.Properties)
 	data.Type = types.StringPointerValue(entity.Type)
 
 	hrProperties, diag := flattenHostRecordProperties(entity)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
 	data.AbsoluteName = hrProperties.AbsoluteName
-	data.Addresses = hrProperties.Addresses
+	data.Addresses = ip4AddressSet(hrProperties.Addresses)
 	data.AddressIDs = hrProperties.AddressIDs
-	data.TTL = hrProperties.TTL
+	data.TTL = hostRecordTTL(data.TTL, hrProperties.TTL, data.UseZoneTTL.ValueBool())
 	data.ReverseRecord = hrProperties.ReverseRecord
-	data.UserDefinedFields = hrProperties.UserDefinedFields
+	data.Comments = hrProperties.Comments
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, hrProperties.UserDefinedFields, r.client)
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *HostRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	resp.Diagnostics.Append(deleteProtectionCheck(r.client, "HostRecord")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data *HostRecordResourceModel
 
 	// Read Terraform prior state data into the model
@@ -444,46 +763,147 @@ func (r *HostRecordResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
 	if diag.HasError() {
-		resp.Diagnostics.Append(diag...)
 		return
 	}
 
 	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
 		return
 	}
 
-	entity, err := client.GetEntityById(id)
-	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to get host record by id", err.Error())
+	recordIDs := map[string]int64{"": id}
+	if !data.RecordIDs.IsNull() {
+		recordIDs = make(map[string]int64)
+		resp.Diagnostics.Append(data.RecordIDs.ElementsAs(ctx, &recordIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			return
+		}
+	}
+
+	aliases, diag := data.aliases(ctx)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	if *entity.Id == 0 {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	for _, recordID := range recordIDs {
+		entity, err := client.GetEntityById(recordID)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get host record by id", err, bamEntity("Host Record", data.ID.ValueString()))
+			return
+		}
+
+		if entity.Id == nil || *entity.Id == 0 {
+			continue
+		}
 
+		// delete the aliases first, as they cannot point to a host record
+		// that does not exist
+		if err := updateHostRecordAliases(client, r.client, 0, recordID, "", aliases, nil); err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to delete host record aliases", err, bamEntity("Host Record", data.ID.ValueString()))
+			return
+		}
+
+		err = client.Delete(recordID)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Host Record Delete failed", err, bamEntity("Host Record", data.ID.ValueString()))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+func (r *HostRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanCustomProperties(ctx, req, resp)
+	modifyPlanUserDefinedFields(ctx, r.client, "HostRecord", req, resp)
+
+	// nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
 		return
 	}
 
-	err = client.Delete(id)
-	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Host Record Delete failed", err.Error())
+	var plan, state *HostRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	// addresses and address_ids describe the same addresses, so the one that
+	// is not configured is only known if the other did not change
+	if !plan.AddressIDs.IsUnknown() && plan.Addresses.IsUnknown() {
+		if plan.AddressIDs.Equal(state.AddressIDs) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("addresses"), state.Addresses)...)
+		}
+	} else if !plan.Addresses.IsUnknown() && plan.AddressIDs.IsUnknown() {
+		if plan.Addresses.Equal(state.Addresses) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("address_ids"), state.AddressIDs)...)
+		}
+	}
+}
+
+func (r *HostRecordResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// version 0 is the state of the sdk based releases, and of the
+		// framework based releases until the schema had a version
+		0: {StateUpgrader: upgradeLegacyState},
+	}
 }
 
 func (r *HostRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+func (r HostRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data HostRecordResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// an empty name is a host record at the apex of the zone
+	if !data.Name.IsUnknown() && data.Name.ValueString() != "" {
+		if err := validateDNSName(data.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Invalid Host Record Name",
+				err.Error(),
+			)
+		}
+	}
+
+	if !data.DNSZone.IsUnknown() && !data.DNSZone.IsNull() {
+		if err := validateDNSName(data.DNSZone.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("dns_zone"),
+				"Invalid DNS Zone",
+				err.Error(),
+			)
+		}
+	}
+
+	if !data.Name.IsUnknown() && !data.DNSZone.IsUnknown() && len(data.Name.ValueString())+1+len(data.DNSZone.ValueString()) > 253 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Invalid Host Record Name",
+			"The absolute name of the host record is longer than 253 characters.",
+		)
+	}
+}
+
 const hostRecordViewIDPlanModifierDescription string = "View ID is required for creation and cannot be changed. Null values in the state are ignored to allow for import."
 
 func hostRecordViewIDPlanModifier(ctx context.Context, p planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
@@ -501,3 +921,189 @@ func hostRecordViewIDPlanModifier(ctx context.Context, p planmodifier.Int64Reque
 
 	resp.RequiresReplace = true
 }
+
+const hostRecordViewIDsPlanModifierDescription string = "Switching between view_id and view_ids forces a new resource."
+
+func hostRecordViewIDsPlanModifier(ctx context.Context, req planmodifier.SetRequest, resp *setplanmodifier.RequiresReplaceIfFuncResponse) {
+	var state *HostRecordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a host record managed with view_id (or imported) has no record_ids
+	resp.RequiresReplace = state.RecordIDs.IsNull() != req.PlanValue.IsNull()
+}
+
+// hostRecordIDPlanModifier marks id as unknown if a change to view_ids
+// changes the view with the lowest ID, as id is the host record in that view.
+type hostRecordIDPlanModifier struct{}
+
+func (m hostRecordIDPlanModifier) Description(ctx context.Context) string {
+	return "Marks id as unknown if the lowest view ID in view_ids changes."
+}
+
+func (m hostRecordIDPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m hostRecordIDPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan *HostRecordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || state.RecordIDs.IsNull() || plan.ViewIDs.IsNull() {
+		return
+	}
+
+	if plan.ViewIDs.IsUnknown() || slices.ContainsFunc(plan.ViewIDs.Elements(), attr.Value.IsUnknown) {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	recordIDs := make(map[string]int64)
+	resp.Diagnostics.Append(state.RecordIDs.ElementsAs(ctx, &recordIDs, false)...)
+	planned, diags := plan.viewIDs(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current := hostRecordViewIDs(recordIDs)
+	if len(current) == 0 || len(planned) == 0 || current[0] != planned[0] {
+		resp.PlanValue = types.StringUnknown()
+	}
+}
+
+// viewIDs returns the views the host record is managed in, either view_id or
+// view_ids sorted so that the first is the view of the record used as id.
+func (m *HostRecordResourceModel) viewIDs(ctx context.Context) ([]int64, diag.Diagnostics) {
+	if m.ViewIDs.IsNull() {
+		return []int64{m.ViewID.ValueInt64()}, nil
+	}
+
+	var viewIDs []int64
+	diags := m.ViewIDs.ElementsAs(ctx, &viewIDs, false)
+	slices.Sort(viewIDs)
+
+	return viewIDs, diags
+}
+
+// hostRecordViewIDs returns the sorted view IDs of a record_ids map.
+func hostRecordViewIDs(recordIDs map[string]int64) []int64 {
+	viewIDs := make([]int64, 0, len(recordIDs))
+	for view := range recordIDs {
+		viewID, err := strconv.ParseInt(view, 10, 64)
+		if err != nil {
+			continue
+		}
+		viewIDs = append(viewIDs, viewID)
+	}
+	slices.Sort(viewIDs)
+
+	return viewIDs
+}
+
+// resolveAddresses sets addresses from address_ids if they are not known yet
+// because address_ids is configured instead.
+func (m *HostRecordResourceModel) resolveAddresses(ctx context.Context, client gobam.ProteusAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !m.Addresses.IsUnknown() {
+		return diags
+	}
+
+	var addressIDs []int64
+	diags.Append(m.AddressIDs.ElementsAs(ctx, &addressIDs, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	addresses, err := getIP4AddressesByID(client, addressIDs)
+	if err != nil {
+		diags.AddAttributeError(path.Root("address_ids"), "Failed to get the addresses of address_ids", err.Error())
+		return diags
+	}
+
+	m.Addresses, diags = types.SetValueFrom(ctx, ip4AddressType{}, addresses)
+	return diags
+}
+
+// getIP4AddressesByID returns the IPv4 addresses with the object IDs ids.
+func getIP4AddressesByID(client gobam.ProteusAPI, ids []int64) ([]string, error) {
+	addresses := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		entity, err := client.GetEntityById(id)
+		if err != nil {
+			return nil, err
+		}
+		if entity.Id == nil || *entity.Id == 0 {
+			return nil, fmt.Errorf("IPv4 address %d was not found", id)
+		}
+		if entity.Type == nil || *entity.Type != "IP4Address" || entity.Properties == nil {
+			return nil, fmt.Errorf("object %d is not an IPv4 address", id)
+		}
+
+		address := parseProperties(*entity.Properties)["address"]
+		if address == "" {
+			return nil, fmt.Errorf("IPv4 address %d has no address", id)
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
+// addHostRecord creates the host record described by data in a view.
+func addHostRecord(ctx context.Context, client gobam.ProteusAPI, loginClient *loginClient, viewID int64, data *HostRecordResourceModel) (int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	absoluteName := data.Name.ValueString() + "." + data.DNSZone.ValueString()
+	ttl := data.TTL.ValueInt64()
+	if data.UseZoneTTL.ValueBool() {
+		ttl = -1
+	}
+
+	addresses, addressesDiags := ip4AddressStrings(ctx, data.Addresses)
+	diags.Append(addressesDiags...)
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	properties := propertyMap{}
+	properties.setBool("reverseRecord", data.ReverseRecord.ValueBool())
+	if !data.Comments.IsNull() {
+		properties.set("comments", data.Comments.ValueString())
+	}
+
+	var udfs map[string]string
+	diags.Append(data.UserDefinedFields.ElementsAs(ctx, &udfs, false)...)
+	if diags.HasError() {
+		return 0, diags
+	}
+	properties.setAll(udfs)
+	properties.setManaged(loginClient)
+
+	host, err := client.AddHostRecord(viewID, absoluteName, strings.Join(addresses, ","), ttl, properties.String())
+	if err != nil {
+		addBAMError(&diags, "AddHostRecord failed", err, fmt.Sprintf("View %d", viewID))
+		return 0, diags
+	}
+
+	return host, diags
+}
+
+// hostRecordTTL returns the ttl to save for a host record. The API does not
+// return a TTL for host records that use the zone default, so if
+// use_zone_default_ttl is set the prior ttl is kept for them.
+func hostRecordTTL(prior types.Int64, ttl types.Int64, useZoneDefault bool) types.Int64 {
+	if useZoneDefault && ttl.ValueInt64() == -1 && !prior.IsNull() && !prior.IsUnknown() {
+		return prior
+	}
+
+	return ttl
+}
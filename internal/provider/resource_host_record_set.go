@@ -0,0 +1,537 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+	"golang.org/x/exp/maps"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &HostRecordSetResource{}
+var _ resource.ResourceWithModifyPlan = &HostRecordSetResource{}
+var _ resource.ResourceWithValidateConfig = &HostRecordSetResource{}
+
+func NewHostRecordSetResource() resource.Resource {
+	return &HostRecordSetResource{}
+}
+
+// HostRecordSetResource defines the resource implementation.
+type HostRecordSetResource struct {
+	client *loginClient
+}
+
+// HostRecordSetResourceModel describes the resource data model.
+type HostRecordSetResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ViewID        types.Int64  `tfsdk:"view_id"`
+	DNSZone       types.String `tfsdk:"dns_zone"`
+	Records       types.Map    `tfsdk:"records"`
+	ReverseRecord types.Bool   `tfsdk:"reverse_record"`
+	TTL           types.Int64  `tfsdk:"ttl"`
+
+	// the object ID of the host record of each name in records
+	RecordIDs types.Map `tfsdk:"record_ids"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *HostRecordSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_record_set"
+}
+
+func (r *HostRecordSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource to manage many host records in the same DNS zone as one resource. All of the host records are created, updated, and deleted with a single API session, which is much faster than a `bluecat_host_record` resource for each of them.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the host record set, which is `<view_id>:<dns_zone>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"view_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the View that the host records are created in. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"dns_zone": schema.StringAttribute{
+				MarkdownDescription: "The DNS zone to create the host records in. Must not have a trailing dot. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"records": schema.MapAttribute{
+				MarkdownDescription: "A map of the names of the host records, which are combined with `dns_zone` to make the fqdn, to the addresses of each host record. Adding or removing a name creates or deletes that host record.",
+				Required:            true,
+				ElementType:         types.SetType{ElemType: types.StringType},
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.ValueSetsAre(setvalidator.SizeAtLeast(1)),
+				},
+			},
+			"reverse_record": schema.BoolAttribute{
+				MarkdownDescription: "If reverse records should be created for the addresses of the host records.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "The TTL for the host records. When set to -1, ignores the TTL.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(-1),
+			},
+			"record_ids": schema.MapAttribute{
+				MarkdownDescription: "A map of the names in `records` to the object ID of their host record.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+		},
+	}
+}
+
+func (r *HostRecordSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *HostRecordSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *HostRecordSetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records := make(map[string][]string)
+	resp.Diagnostics.Append(data.Records.ElementsAs(ctx, &records, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	recordIDs := make(map[string]int64)
+	for _, name := range sortedKeys(records) {
+		id, diag := data.addHostRecord(client, r.client, name, records[name])
+		if diag.HasError() {
+			// remove the host records that were created so they are not left behind
+			for _, id := range recordIDs {
+				if err := client.Delete(id); err != nil {
+					resp.Diagnostics.AddWarning("Failed to delete host record after a failed create", fmt.Sprintf("Host record %d must be deleted manually: %s", id, err.Error()))
+				}
+			}
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+		recordIDs[name] = id
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	data.ID = types.StringValue(fmt.Sprintf("%d:%s", data.ViewID.ValueInt64(), data.DNSZone.ValueString()))
+	data.RecordIDs, diag = types.MapValueFrom(ctx, types.Int64Type, recordIDs)
+	resp.Diagnostics.Append(diag...)
+
+	tflog.Trace(ctx, "created a resource", map[string]interface{}{"host_records": len(recordIDs)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostRecordSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *HostRecordSetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordIDs := make(map[string]int64)
+	resp.Diagnostics.Append(data.RecordIDs.ElementsAs(ctx, &recordIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	// forget the host records that were deleted outside of Terraform so
+	// that they are created again
+	records := make(map[string]attr.Value)
+	for name, id := range recordIDs {
+		entity, err := client.GetEntityById(id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get host record by Id", err, bamEntity("Host Record Set", data.ID.ValueString()))
+			return
+		}
+
+		if entity.Id == nil || *entity.Id == 0 {
+			delete(recordIDs, name)
+			continue
+		}
+
+		if ok, diag := entityTypeMatches(entity, "HostRecord"); !ok {
+			resp.Diagnostics.Append(diag...)
+			delete(recordIDs, name)
+			continue
+		}
+
+		hostRecordProperties, diag := flattenHostRecordProperties(entity)
+		if diag.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+		records[name] = hostRecordProperties.Addresses
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	if len(recordIDs) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Records, diag = types.MapValue(types.SetType{ElemType: types.StringType}, records)
+	resp.Diagnostics.Append(diag...)
+	data.RecordIDs, diag = types.MapValueFrom(ctx, types.Int64Type, recordIDs)
+	resp.Diagnostics.Append(diag...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostRecordSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data, state *HostRecordSetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := make(map[string][]string)
+	current := make(map[string][]string)
+	recordIDs := make(map[string]int64)
+	resp.Diagnostics.Append(data.Records.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(state.Records.ElementsAs(ctx, &current, false)...)
+	resp.Diagnostics.Append(state.RecordIDs.ElementsAs(ctx, &recordIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// every host record is updated if a setting shared by all of them changed
+	updateAll := !data.ReverseRecord.Equal(state.ReverseRecord) || !data.TTL.Equal(state.TTL)
+	changes := hostRecordSetChanges(planned, current, updateAll)
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	for _, name := range changes.remove {
+		if err := client.Delete(recordIDs[name]); err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Host Record Delete failed", err, "Host Record "+name)
+			return
+		}
+		delete(recordIDs, name)
+	}
+
+	for _, name := range changes.update {
+		properties := propertyMap{}
+		properties.setList("addresses", planned[name])
+		properties.setBool("reverseRecord", data.ReverseRecord.ValueBool())
+		properties.setInt64("ttl", data.TTL.ValueInt64())
+
+		id := recordIDs[name]
+		hostType := "HostRecord"
+		update := gobam.APIEntity{
+			Id:         &id,
+			Name:       &name,
+			Properties: properties.stringPointer(),
+			Type:       &hostType,
+		}
+
+		if err := client.Update(&update); err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Host Record Update failed", err, "Host Record "+name)
+			return
+		}
+	}
+
+	for _, name := range changes.add {
+		id, diag := data.addHostRecord(client, r.client, name, planned[name])
+		if diag.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+		recordIDs[name] = id
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	data.RecordIDs, diag = types.MapValueFrom(ctx, types.Int64Type, recordIDs)
+	resp.Diagnostics.Append(diag...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostRecordSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	resp.Diagnostics.Append(deleteProtectionCheck(r.client, "HostRecord")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *HostRecordSetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordIDs := make(map[string]int64)
+	resp.Diagnostics.Append(data.RecordIDs.ElementsAs(ctx, &recordIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	for _, name := range sortedKeys(recordIDs) {
+		entity, err := client.GetEntityById(recordIDs[name])
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get host record by id", err, bamEntity("Host Record Set", data.ID.ValueString()))
+			return
+		}
+
+		if entity.Id == nil || *entity.Id == 0 {
+			continue
+		}
+
+		if err := client.Delete(recordIDs[name]); err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Host Record Delete failed", err, "Host Record "+name)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+func (r *HostRecordSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state *HostRecordSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.Records.IsUnknown() {
+		return
+	}
+
+	// the IDs of the host records only change if names are added or removed
+	planned := maps.Keys(plan.Records.Elements())
+	current := maps.Keys(state.Records.Elements())
+	slices.Sort(planned)
+	slices.Sort(current)
+	if slices.Equal(planned, current) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("record_ids"), state.RecordIDs)...)
+	}
+}
+
+func (r HostRecordSetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data HostRecordSetResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.DNSZone.IsUnknown() && !data.DNSZone.IsNull() {
+		if err := validateDNSName(data.DNSZone.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("dns_zone"),
+				"Invalid DNS Zone",
+				err.Error(),
+			)
+		}
+	}
+
+	if data.Records.IsUnknown() || data.Records.IsNull() {
+		return
+	}
+
+	for name := range data.Records.Elements() {
+		// an empty name is a host record at the apex of the zone
+		if name == "" {
+			continue
+		}
+		if err := validateDNSName(name); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records").AtMapKey(name),
+				"Invalid Host Record Name",
+				err.Error(),
+			)
+		}
+	}
+}
+
+// addHostRecord creates the host record name of the set with addresses.
+func (m *HostRecordSetResourceModel) addHostRecord(client gobam.ProteusAPI, loginClient *loginClient, name string, addresses []string) (int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	absoluteName := m.DNSZone.ValueString()
+	if name != "" {
+		absoluteName = name + "." + absoluteName
+	}
+
+	properties := propertyMap{}
+	properties.setBool("reverseRecord", m.ReverseRecord.ValueBool())
+	properties.setManaged(loginClient)
+
+	id, err := client.AddHostRecord(m.ViewID.ValueInt64(), absoluteName, strings.Join(addresses, ","), m.TTL.ValueInt64(), properties.String())
+	if err != nil {
+		addBAMError(&diags, "AddHostRecord failed", err, "Host Record "+absoluteName)
+		return 0, diags
+	}
+
+	return id, diags
+}
+
+// hostRecordSetChange lists the names of the host records of a set that must
+// be added, updated, and removed, each sorted.
+type hostRecordSetChange struct {
+	add    []string
+	update []string
+	remove []string
+}
+
+// hostRecordSetChanges compares the planned records of a host record set to
+// the current records. Host records whose addresses changed are updated, or
+// every host record that is kept if updateAll is true.
+func hostRecordSetChanges(planned, current map[string][]string, updateAll bool) hostRecordSetChange {
+	var changes hostRecordSetChange
+
+	for _, name := range sortedKeys(planned) {
+		addresses, ok := current[name]
+		if !ok {
+			changes.add = append(changes.add, name)
+			continue
+		}
+
+		if updateAll || !sameAddresses(planned[name], addresses) {
+			changes.update = append(changes.update, name)
+		}
+	}
+
+	for _, name := range sortedKeys(current) {
+		if _, ok := planned[name]; !ok {
+			changes.remove = append(changes.remove, name)
+		}
+	}
+
+	return changes
+}
+
+// sameAddresses reports whether a and b contain the same addresses in any order.
+func sameAddresses(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(a, b)
+}
+
+// sortedKeys returns the keys of m sorted, so that API calls are made in the
+// same order every run.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := maps.Keys(m)
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHostRecordSetResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccHostRecordSetResourceConfig(`{
+    tfacc-set-a = ["192.168.1.10"]
+    tfacc-set-b = ["192.168.1.11"]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_host_record_set.test", "record_ids.%", "2"),
+					resource.TestCheckResourceAttrWith("bluecat_host_record_set.test", "record_ids.tfacc-set-a", validateObjectID),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccHostRecordSetResourceConfig(`{
+    tfacc-set-a = ["192.168.1.12"]
+    tfacc-set-c = ["192.168.1.13"]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_host_record_set.test", "record_ids.%", "2"),
+					resource.TestCheckTypeSetElemAttr("bluecat_host_record_set.test", "records.tfacc-set-a.*", "192.168.1.12"),
+					resource.TestCheckNoResourceAttr("bluecat_host_record_set.test", "record_ids.tfacc-set-b"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHostRecordSetResourceConfig(records string) string {
+	return testAccEntityDataSourceConfig + `
+variable "view_id" {
+	type = number
+}
+
+variable "dns_zone" {
+	type = string
+}
+
+resource "bluecat_host_record_set" "test" {
+  view_id  = var.view_id
+  dns_zone = var.dns_zone
+  records  = ` + records + `
+}
+`
+}
+
+func TestHostRecordSetChanges(t *testing.T) {
+	current := map[string][]string{
+		"kept":    {"10.0.0.1", "10.0.0.2"},
+		"changed": {"10.0.0.3"},
+		"removed": {"10.0.0.4"},
+	}
+	planned := map[string][]string{
+		"kept":    {"10.0.0.2", "10.0.0.1"},
+		"changed": {"10.0.0.5"},
+		"added":   {"10.0.0.6"},
+	}
+
+	changes := hostRecordSetChanges(planned, current, false)
+	if got := fmt.Sprint(changes.add, changes.update, changes.remove); got != "[added] [changed] [removed]" {
+		t.Errorf("expected added, changed, and removed records, got %s", got)
+	}
+
+	changes = hostRecordSetChanges(planned, current, true)
+	if got := fmt.Sprint(changes.update); got != "[changed kept]" {
+		t.Errorf("expected every kept record to be updated, got %s", got)
+	}
+}
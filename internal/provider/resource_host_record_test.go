@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/umich-vci/gobam"
+)
+
+func TestHostRecordViewIDs(t *testing.T) {
+	viewIDs := hostRecordViewIDs(map[string]int64{"30": 3, "10": 1, "20": 2})
+	if fmt.Sprint(viewIDs) != "[10 20 30]" {
+		t.Errorf("expected sorted view IDs, got %v", viewIDs)
+	}
+
+	set, _ := types.SetValueFrom(context.Background(), types.Int64Type, []int64{30, 10})
+	data := &HostRecordResourceModel{ViewIDs: set}
+	viewIDs, diags := data.viewIDs(context.Background())
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if fmt.Sprint(viewIDs) != "[10 30]" {
+		t.Errorf("expected sorted view_ids, got %v", viewIDs)
+	}
+
+	data = &HostRecordResourceModel{ViewID: types.Int64Value(5), ViewIDs: types.SetNull(types.Int64Type)}
+	viewIDs, diags = data.viewIDs(context.Background())
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if fmt.Sprint(viewIDs) != "[5]" {
+		t.Errorf("expected view_id, got %v", viewIDs)
+	}
+}
+
+func TestValidateDNSName(t *testing.T) {
+	tests := map[string]bool{
+		"www":                   true,
+		"www.example.com":       true,
+		"_dmarc.example.com":    true,
+		"*.example.com":         true,
+		"Mixed-Case":            true,
+		"www.":                  false,
+		"-www":                  false,
+		"www-":                  false,
+		"www..example.com":      false,
+		"www.*.example.com":     false,
+		"under score":           false,
+		strings.Repeat("a", 64): false,
+	}
+
+	for name, valid := range tests {
+		err := validateDNSName(name)
+		if valid && err != nil {
+			t.Errorf("expected %q to be valid, got %s", name, err)
+		}
+		if !valid && err == nil {
+			t.Errorf("expected %q to be invalid", name)
+		}
+	}
+}
+
+func TestCaseInsensitiveValue(t *testing.T) {
+	name := "www"
+
+	if v := caseInsensitiveValue(types.StringValue("WWW"), &name); v.ValueString() != "WWW" {
+		t.Errorf("expected the prior value to be kept, got %q", v.ValueString())
+	}
+
+	if v := caseInsensitiveValue(types.StringValue("web"), &name); v.ValueString() != "www" {
+		t.Errorf("expected the new value, got %q", v.ValueString())
+	}
+
+	if v := caseInsensitiveValue(types.StringNull(), &name); v.ValueString() != "www" {
+		t.Errorf("expected the new value when there is no prior value, got %q", v.ValueString())
+	}
+}
+
+func TestHostRecordTTL(t *testing.T) {
+	tests := map[string]struct {
+		prior          types.Int64
+		ttl            types.Int64
+		useZoneDefault bool
+		expected       types.Int64
+	}{
+		"ttl set":                    {prior: types.Int64Value(3600), ttl: types.Int64Value(300), useZoneDefault: false, expected: types.Int64Value(300)},
+		"omitted":                    {prior: types.Int64Value(3600), ttl: types.Int64Value(-1), useZoneDefault: false, expected: types.Int64Value(-1)},
+		"omitted using zone default": {prior: types.Int64Value(3600), ttl: types.Int64Value(-1), useZoneDefault: true, expected: types.Int64Value(3600)},
+		"set using zone default":     {prior: types.Int64Value(3600), ttl: types.Int64Value(300), useZoneDefault: true, expected: types.Int64Value(300)},
+		"no prior ttl":               {prior: types.Int64Null(), ttl: types.Int64Value(-1), useZoneDefault: true, expected: types.Int64Value(-1)},
+	}
+
+	for name, tc := range tests {
+		if got := hostRecordTTL(tc.prior, tc.ttl, tc.useZoneDefault); !got.Equal(tc.expected) {
+			t.Errorf("%s: expected %s, got %s", name, tc.expected, got)
+		}
+	}
+}
+
+// entityByIDClient returns the entities in entities by object ID.
+type entityByIDClient struct {
+	gobam.ProteusAPI
+	entities map[int64]*gobam.APIEntity
+}
+
+func (c *entityByIDClient) GetEntityById(id int64) (*gobam.APIEntity, error) {
+	if entity, ok := c.entities[id]; ok {
+		return entity, nil
+	}
+	return &gobam.APIEntity{}, nil
+}
+
+func TestGetIP4AddressesByID(t *testing.T) {
+	entity := func(id int64, objectType, properties string) *gobam.APIEntity {
+		return &gobam.APIEntity{Id: &id, Type: &objectType, Properties: &properties}
+	}
+	client := &entityByIDClient{entities: map[int64]*gobam.APIEntity{
+		1: entity(1, "IP4Address", "address=10.0.0.5|state=STATIC|"),
+		2: entity(2, "IP4Address", "address=10.0.0.6|state=STATIC|"),
+		3: entity(3, "IP4Network", "CIDR=10.0.0.0/24|"),
+	}}
+
+	addresses, err := getIP4AddressesByID(client, []int64{2, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(addresses, ",") != "10.0.0.6,10.0.0.5" {
+		t.Errorf("expected the addresses in order, got %v", addresses)
+	}
+
+	for _, ids := range [][]int64{{3}, {4}} {
+		if _, err := getIP4AddressesByID(client, ids); err == nil {
+			t.Errorf("expected an error for %v", ids)
+		}
+	}
+}
+
+func TestHostRecordResolveAddresses(t *testing.T) {
+	ctx := context.Background()
+	address := "IP4Address"
+	properties := "address=10.0.0.5|"
+	id := int64(1)
+	client := &entityByIDClient{entities: map[int64]*gobam.APIEntity{1: {Id: &id, Type: &address, Properties: &properties}}}
+
+	data := &HostRecordResourceModel{
+		Addresses:  types.SetUnknown(ip4AddressType{}),
+		AddressIDs: types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(1)}),
+	}
+	if diags := data.resolveAddresses(ctx, client); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if expected := types.SetValueMust(ip4AddressType{}, []attr.Value{newIP4AddressValue("10.0.0.5")}); !data.Addresses.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, data.Addresses)
+	}
+
+	// configured addresses are left alone
+	data.Addresses = types.SetValueMust(ip4AddressType{}, []attr.Value{newIP4AddressValue("10.0.0.9")})
+	if diags := data.resolveAddresses(ctx, client); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(data.Addresses.Elements()) != 1 || !data.Addresses.Elements()[0].Equal(newIP4AddressValue("10.0.0.9")) {
+		t.Errorf("expected configured addresses to be kept, got %s", data.Addresses)
+	}
+}
+
+func TestHostRecordCustomProperties(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&HostRecordResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	udfType := objectType.AttributeTypes["user_defined_fields"]
+	customProperties := tftypes.NewValue(udfType, map[string]tftypes.Value{
+		"Owner": tftypes.NewValue(tftypes.String, "me"),
+	})
+
+	// a config still using custom_properties, planned with the default of
+	// user_defined_fields
+	config := map[string]tftypes.Value{}
+	plan := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		config[name] = tftypes.NewValue(attributeType, nil)
+		plan[name] = tftypes.NewValue(attributeType, nil)
+	}
+	config["custom_properties"] = customProperties
+	plan["custom_properties"] = customProperties
+	plan["user_defined_fields"] = tftypes.NewValue(udfType, map[string]tftypes.Value{})
+
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, config)},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, plan)},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	(&HostRecordResource{}).ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data *HostRecordResourceModel
+	if diags := resp.Plan.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := types.MapValueMust(types.StringType, map[string]attr.Value{"Owner": types.StringValue("me")})
+	if !data.UserDefinedFields.Equal(expected) {
+		t.Errorf("expected user_defined_fields %s, got %s", expected, data.UserDefinedFields)
+	}
+}
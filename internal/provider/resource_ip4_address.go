@@ -2,14 +2,24 @@ package provider
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
-	"slices"
+	"net"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -20,12 +30,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/umich-vci/gobam"
-	"golang.org/x/exp/maps"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &IP4AddressResource{}
 var _ resource.ResourceWithImportState = &IP4AddressResource{}
+var _ resource.ResourceWithModifyPlan = &IP4AddressResource{}
+var _ resource.ResourceWithUpgradeState = &IP4AddressResource{}
 
 func NewIP4AddressResource() resource.Resource {
 	return &IP4AddressResource{}
@@ -46,6 +57,8 @@ type IP4AddressResourceModel struct {
 
 	// These are exposed via the entity properties field for objects of type IP4Address
 	Address               types.String `tfsdk:"address"`
+	PTRName               types.String `tfsdk:"ptr_name"`
+	ReverseZone           types.String `tfsdk:"reverse_zone"`
 	State                 types.String `tfsdk:"state"`
 	MACAddress            types.String `tfsdk:"mac_address"`
 	RouterPortInfo        types.String `tfsdk:"router_port_info"`
@@ -56,6 +69,7 @@ type IP4AddressResourceModel struct {
 	ParameterRequestList  types.String `tfsdk:"parameter_request_list"`
 	VendorClassIdentifier types.String `tfsdk:"vendor_class_identifier"`
 	LocationCode          types.String `tfsdk:"location_code"`
+	Comments              types.String `tfsdk:"comments"`
 	LocationInherited     types.Bool   `tfsdk:"location_inherited"`
 
 	// these are user defined fields that are not built-in
@@ -65,6 +79,25 @@ type IP4AddressResourceModel struct {
 	Action          types.String `tfsdk:"action"`
 	ConfigurationID types.Int64  `tfsdk:"configuration_id"`
 	ParentID        types.Int64  `tfsdk:"parent_id"`
+	ParentIDList    types.List   `tfsdk:"parent_id_list"`
+	Hostname        types.String `tfsdk:"hostname"`
+	ViewID          types.Int64  `tfsdk:"view_id"`
+	ReverseRecord   types.Bool   `tfsdk:"reverse_record"`
+
+	// these constrain which address of the network is allocated
+	Offset           ip4AddressValue `tfsdk:"offset"`
+	RangeEnd         ip4AddressValue `tfsdk:"range_end"`
+	ExcludeAddresses types.Set       `tfsdk:"exclude_addresses"`
+
+	// this is the host record created from hostname
+	HostRecordID types.Int64 `tfsdk:"host_record_id"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+
+	// limits how long creating, reading, and deleting may take
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *IP4AddressResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -75,8 +108,11 @@ func (r *IP4AddressResource) Schema(ctx context.Context, req resource.SchemaRequ
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Resource to reserve an IPv4 address.",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
 			// These are exposed for Entity objects via the API
 			"id": schema.StringAttribute{
 				MarkdownDescription: "IPv4 Address identifier.",
@@ -102,43 +138,128 @@ func (r *IP4AddressResource) Schema(ctx context.Context, req resource.SchemaRequ
 			},
 			// These fields are only used for creation and are not exposed via the API entity
 			"action": schema.StringAttribute{
-				MarkdownDescription: "The action to take on the next available IPv4 address.  Must be one of: \"MAKE_STATIC\", \"MAKE_RESERVED\", or \"MAKE_DHCP_RESERVED\". If changed, forces a new resource.",
+				MarkdownDescription: "The action to take on the next available IPv4 address. " + enumDescription(ipAssignmentActions) + " If changed, the state of the address is changed in place and the same address is kept. `MAKE_DHCP_RESERVED` requires `mac_address`.",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("MAKE_STATIC"),
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplaceIf(ip4AddressActionPlanModifier, ip4AddressActionPlanModifierDescription, ip4AddressActionPlanModifierDescription),
-				},
+				Default:             stringdefault.StaticString(ipAssignmentActionStatic),
 				Validators: []validator.String{
-					stringvalidator.OneOf(gobam.IPAssignmentActions...),
+					stringvalidator.OneOf(ipAssignmentActions...),
 				},
 			},
 			"configuration_id": schema.Int64Attribute{
-				MarkdownDescription: "The object ID of the Configuration that will hold the new address. If changed, forces a new resource.",
-				Required:            true,
+				MarkdownDescription: "The object ID of the Configuration that will hold the new address." + defaultConfigurationDescription + " If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
 					int64planmodifier.RequiresReplaceIf(ip4AddressConfigurationIDPlanModifier, ip4AddressConfigurationIDPlanModifierDescription, ip4AddressConfigurationIDPlanModifierDescription),
 				},
 			},
 			"parent_id": schema.Int64Attribute{
-				MarkdownDescription: "The object ID of the Configuration, Block, or Network to find the next available IPv4 address in. If changed, forces a new resource.",
-				Required:            true,
+				MarkdownDescription: "The object ID of the Configuration, Block, or Network to find the next available IPv4 address in. Exactly one of `parent_id` or `parent_id_list` must be set. If `parent_id_list` is set, this is the parent the address was allocated in. If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
 					int64planmodifier.RequiresReplace(),
 				},
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("parent_id_list")),
+				},
+			},
+			"parent_id_list": schema.ListAttribute{
+				MarkdownDescription: "The object IDs of Configurations, Blocks, or Networks to find the next available IPv4 address in, in order of preference. If a parent has no free address, the next parent is tried. Changing this argument only affects where a new address is allocated, so it does not recreate the resource.",
+				Optional:            true,
+				ElementType:         types.Int64Type,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"offset": schema.StringAttribute{
+				MarkdownDescription: "The first address of the network in `parent_id` that can be allocated, such as `10.0.0.20` to leave the first addresses of `10.0.0.0/24` for routers. Requires `parent_id` to be a Network. Changing this argument only affects where a new address is allocated, so it does not recreate the resource. Cannot be used with `parent_id_list`.",
+				Optional:            true,
+				CustomType:          ip4AddressType{},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("parent_id_list")),
+				},
+			},
+			"range_end": schema.StringAttribute{
+				MarkdownDescription: "The last address of the network in `parent_id` that can be allocated. With `offset`, this allocates the address in a small window, such as the first 10 addresses of the network. Requires `parent_id` to be a Network. Changing this argument only affects where a new address is allocated, so it does not recreate the resource. Cannot be used with `parent_id_list`.",
+				Optional:            true,
+				CustomType:          ip4AddressType{},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("parent_id_list")),
+				},
+			},
+			"exclude_addresses": schema.SetAttribute{
+				MarkdownDescription: "Addresses of the network in `parent_id` that are never allocated, even if they are free. Requires `parent_id` to be a Network. Changing this argument only affects where a new address is allocated, so it does not recreate the resource. Cannot be used with `parent_id_list`.",
+				Optional:            true,
+				ElementType:         ip4AddressType{},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(ip4AddressValidator{}),
+					setvalidator.ConflictsWith(path.MatchRoot("parent_id_list")),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified name of a host record to create with the address in the same API call, so the address never exists without its DNS record. Requires `view_id`. If changed, forces a new resource.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("view_id")),
+				},
+			},
+			"view_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the View to create the host record in. If changed, forces a new resource.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("hostname")),
+				},
+			},
+			"reverse_record": schema.BoolAttribute{
+				MarkdownDescription: "If a reverse record should be created for the host record. If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"host_record_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the host record created from `hostname`. The host record is deleted with the address.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			// These are exposed via the API properties field for objects of type IP4Address
 			"address": schema.StringAttribute{
 				MarkdownDescription: "The IPv4 address that was allocated.",
 				Computed:            true,
 			},
+			"ptr_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the PTR record of the address, such as `5.1.168.192.in-addr.arpa`.",
+				Computed:            true,
+			},
+			"reverse_zone": schema.StringAttribute{
+				MarkdownDescription: "The reverse zone that contains `ptr_name`, which is the zone for the /24 network of the address, such as `1.168.192.in-addr.arpa`.",
+				Computed:            true,
+			},
 			"state": schema.StringAttribute{
 				MarkdownDescription: "The state of the IPv4 address.",
 				Computed:            true,
 			},
 			"mac_address": schema.StringAttribute{
-				MarkdownDescription: "The MAC address to associate with the IPv4 address.",
+				MarkdownDescription: "The MAC address to associate with the IPv4 address. Differences in format from the MAC address returned by BlueCat Address Manager, such as colons instead of dashes, are ignored.",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(macAddressRegexp, macAddressRegexpMessage),
+				},
 			},
 			"router_port_info": schema.StringAttribute{
 				MarkdownDescription: "Connected router port information of the IPv4 address.",
@@ -168,13 +289,20 @@ func (r *IP4AddressResource) Schema(ctx context.Context, req resource.SchemaRequ
 				MarkdownDescription: "Time that IPv4 address lease expires.",
 				Computed:            true,
 			},
+			"comments": schema.StringAttribute{
+				MarkdownDescription: "Comments about the address.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"location_code": schema.StringAttribute{
-				MarkdownDescription: "The location code of the address.",
+				MarkdownDescription: "The location code of the address. The location must already exist, which is checked when planning.",
 				Computed:            true,
 				Optional:            true,
 				Default:             nil,
-				Validators:          []validator.String{
-					// The code is case-sensitive and must be in uppercase letters. The country code and child location code should be alphanumeric strings.
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(locationCodeRegexp, locationCodeRegexpMessage),
 				},
 			},
 			"location_inherited": schema.BoolAttribute{
@@ -187,8 +315,18 @@ func (r *IP4AddressResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Optional:            true,
 				Default:             mapdefault.StaticValue(basetypes.NewMapValueMust(types.StringType, nil)),
 				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(propertyNameRegexp, propertyNameRegexpMessage)),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -213,6 +351,11 @@ func (r *IP4AddressResource) Configure(ctx context.Context, req resource.Configu
 }
 
 func (r *IP4AddressResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data *IP4AddressResourceModel
 
 	// Read Terraform plan data into the model
@@ -222,46 +365,112 @@ func (r *IP4AddressResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	createTimeout, diags := data.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	configID := data.ConfigurationID.ValueInt64()
+	configID, diag := r.client.configurationID(client, data.ConfigurationID)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	data.ConfigurationID = types.Int64Value(configID)
+
 	parentID := data.ParentID.ValueInt64()
 	macAddress := data.MACAddress.ValueString()
-	hostInfo := "" // host records should be created as a separate resource
+	hostInfo := ip4AddressHostInfo(data.Hostname, data.ViewID, data.ReverseRecord)
 	action := data.Action.ValueString()
 	name := data.Name.ValueString()
-	properties := "name=" + name + "|"
+	properties := propertyMap{}
+	properties.set("name", name)
 
 	if !data.LocationCode.IsUnknown() && !data.LocationCode.IsNull() {
-		properties = properties + fmt.Sprintf("locationCode=%s|", data.LocationCode.ValueString())
+		properties.set("locationCode", data.LocationCode.ValueString())
+	}
+	if !data.Comments.IsNull() {
+		properties.set("comments", data.Comments.ValueString())
 	}
 
 	var udfs map[string]string
 	data.UserDefinedFields.ElementsAs(ctx, &udfs, false)
-	for k, v := range udfs {
-		properties = properties + k + "=" + v + "|"
+	properties.setAll(udfs)
+	properties.setManaged(r.client)
+
+	parentIDs := []int64{parentID}
+	if !data.ParentIDList.IsNull() {
+		resp.Diagnostics.Append(data.ParentIDList.ElementsAs(ctx, &parentIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			return
+		}
 	}
 
-	ip, err := client.AssignNextAvailableIP4Address(configID, parentID, macAddress, hostInfo, action, properties)
+	var ip *gobam.APIEntity
+	var err error
+	if data.hasAllocationConstraints() {
+		var constraints ip4AddressConstraints
+		constraints, diag = data.allocationConstraints(ctx)
+		if diag.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+		ip, err = assignConstrainedIP4Address(ctx, client, configID, parentID, constraints, macAddress, hostInfo, action, properties.String())
+	} else {
+		ip, err = assignNextAvailableIP4Address(ctx, client, configID, parentIDs, macAddress, hostInfo, action, properties.String())
+	}
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("AssignNextAvailableIP4Address failed", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "AssignNextAvailableIP4Address failed", err, "")
 		return
 	}
 
 	data.ID = types.StringValue(strconv.FormatInt(*ip.Id, 10))
 
+	// find the host record that was created from hostInfo
+	data.HostRecordID = types.Int64Null()
+	if !data.Hostname.IsNull() {
+		data.HostRecordID, err = findLinkedHostRecord(client, *ip.Id, data.Hostname.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get host records linked to IP4 Address", err, bamEntity("IP4 Address", data.ID.ValueString()))
+			return
+		}
+
+		if data.HostRecordID.IsNull() {
+			resp.Diagnostics.AddWarning(
+				"Host record not found",
+				fmt.Sprintf("The IP4 Address was assigned but a host record named %s linked to it could not be found.", data.Hostname.ValueString()),
+			)
+		}
+	}
+
+	if data.ParentID.IsUnknown() {
+		parent, err := client.GetParent(*ip.Id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get parent entity of IP4 address", err, bamEntity("IP4 Address", data.ID.ValueString()))
+			return
+		}
+		data.ParentID = types.Int64Value(*parent.Id)
+	}
+
 	entity, err := client.GetEntityById(*ip.Id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to get IP4 Address by Id after creation",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id after creation", err, bamEntity("IP4 Address", data.ID.ValueString()))
 		return
 	}
 
@@ -271,14 +480,15 @@ func (r *IP4AddressResource) Create(ctx context.Context, req resource.CreateRequ
 
 	addressProperties, diag := flattenIP4AddressProperties(entity)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
 	data.Address = addressProperties.Address
+	data.PTRName, data.ReverseZone = ip4ReverseNames(data.Address)
 	data.State = addressProperties.State
-	data.MACAddress = addressProperties.MACAddress
+	data.MACAddress = macAddressValue(data.MACAddress, addressProperties.MACAddress)
 	data.RouterPortInfo = addressProperties.RouterPortInfo
 	data.SwitchPortInfo = addressProperties.SwitchPortInfo
 	data.VLANInfo = addressProperties.VLANInfo
@@ -287,10 +497,11 @@ func (r *IP4AddressResource) Create(ctx context.Context, req resource.CreateRequ
 	data.ParameterRequestList = addressProperties.ParameterRequestList
 	data.VendorClassIdentifier = addressProperties.VendorClassIdentifier
 	data.LocationCode = addressProperties.LocationCode
+	data.Comments = addressProperties.Comments
 	data.LocationInherited = addressProperties.LocationInherited
-	data.UserDefinedFields = addressProperties.UserDefinedFields
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, addressProperties.UserDefinedFields, r.client)
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	tflog.Trace(ctx, "created a resource")
 
@@ -308,28 +519,44 @@ func (r *IP4AddressResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	readTimeout, diags := data.Timeouts.Read(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, readTimeout)
+	defer cancel()
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
 	if diag.HasError() {
-		resp.Diagnostics.Append(diag...)
 		return
 	}
 
 	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
 		return
 	}
 
 	entity, err := client.GetEntityById(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to get IP4 Address by Id", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id", err, bamEntity("IP4 Address", data.ID.ValueString()))
 		return
 	}
 
 	if *entity.Id == 0 {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if ok, diag := entityTypeMatches(entity, "IP4Address"); !ok {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -340,14 +567,15 @@ func (r *IP4AddressResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	addressProperties, diag := flattenIP4AddressProperties(entity)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
 	data.Address = addressProperties.Address
+	data.PTRName, data.ReverseZone = ip4ReverseNames(data.Address)
 	data.State = addressProperties.State
-	data.MACAddress = addressProperties.MACAddress
+	data.MACAddress = macAddressValue(data.MACAddress, addressProperties.MACAddress)
 	data.RouterPortInfo = addressProperties.RouterPortInfo
 	data.SwitchPortInfo = addressProperties.SwitchPortInfo
 	data.VLANInfo = addressProperties.VLANInfo
@@ -356,25 +584,69 @@ func (r *IP4AddressResource) Read(ctx context.Context, req resource.ReadRequest,
 	data.ParameterRequestList = addressProperties.ParameterRequestList
 	data.VendorClassIdentifier = addressProperties.VendorClassIdentifier
 	data.LocationCode = addressProperties.LocationCode
+	data.Comments = addressProperties.Comments
 	data.LocationInherited = addressProperties.LocationInherited
-	data.UserDefinedFields = addressProperties.UserDefinedFields
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, addressProperties.UserDefinedFields, r.client)
+
+	// if the host record was deleted outside terraform, clear hostname so it will be recreated
+	if !data.HostRecordID.IsNull() {
+		hostRecord, err := client.GetEntityById(data.HostRecordID.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get Host Record by Id", err, bamEntity("IP4 Address", data.ID.ValueString()))
+			return
+		}
+
+		if *hostRecord.Id == 0 {
+			tflog.Trace(ctx, "Host record was deleted outside terraform")
+			data.HostRecordID = types.Int64Null()
+			data.Hostname = types.StringNull()
+		} else {
+			props := parseProperties(*hostRecord.Properties)
+			data.Hostname = types.StringValue(props["absoluteName"])
+			if reverseRecord, err := strconv.ParseBool(props["reverseRecord"]); err == nil {
+				data.ReverseRecord = types.BoolValue(reverseRecord)
+			}
+		}
+	}
+
+	// reverse_record is only known by terraform so set the default when importing
+	if data.ReverseRecord.IsNull() {
+		data.ReverseRecord = types.BoolValue(false)
+	}
 
 	// get the parent id of the address so we can set it in the state so import works
 	parent, err := client.GetParent(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to get parent entity of IP4 address", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get parent entity of IP4 address", err, bamEntity("IP4 Address", data.ID.ValueString()))
 		return
 	}
 	data.ParentID = types.Int64Value(*parent.Id)
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	// imported by object ID
+	if data.ConfigurationID.IsNull() {
+		configID, err := getConfigurationID(client, id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get configuration of IP4 address", err, bamEntity("IP4 Address", data.ID.ValueString()))
+			return
+		}
+		data.ConfigurationID = types.Int64Value(configID)
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IP4AddressResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data, state *IP4AddressResourceModel
 
 	// Read Terraform plan data into the model
@@ -385,69 +657,66 @@ func (r *IP4AddressResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	properties := ""
-
-	if !data.MACAddress.Equal(state.MACAddress) {
-		properties = properties + fmt.Sprintf("macAddress=%s|", data.MACAddress.ValueString())
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
 	}
 
-	if !data.LocationCode.Equal(state.LocationCode) {
-		properties = properties + fmt.Sprintf("locationCode=%s|", data.LocationCode.ValueString())
+	// action is null after an import, when the state of the address is
+	// whatever it already was
+	if !state.Action.IsNull() && !data.Action.Equal(state.Action) {
+		diag = changeIP4AddressState(client, id, data.Action.ValueString(), data.MACAddress.ValueString())
+		if diag.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
 	}
 
-	if !data.UserDefinedFields.Equal(state.UserDefinedFields) {
-		var udfs, oldudfs map[string]string
-		resp.Diagnostics.Append(data.UserDefinedFields.ElementsAs(ctx, &udfs, false)...)
-		resp.Diagnostics.Append(state.UserDefinedFields.ElementsAs(ctx, &oldudfs, false)...)
+	properties := propertyMap{}
 
-		for k, v := range udfs {
-			properties = properties + fmt.Sprintf("%s=%s|", k, v)
-		}
+	if !data.MACAddress.Equal(state.MACAddress) {
+		properties.set("macAddress", data.MACAddress.ValueString())
+	}
 
-		// set keys that no longer exist to empty string
-		oldkeys := maps.Keys(oldudfs)
-		keys := maps.Keys(udfs)
-		for _, x := range oldkeys {
-			if !slices.Contains(keys, x) {
-				properties = properties + fmt.Sprintf("%s=|", x)
-			}
-		}
+	if !data.LocationCode.Equal(state.LocationCode) {
+		properties.set("locationCode", data.LocationCode.ValueString())
 	}
 
-	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
-	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
-		return
+	if !data.Comments.Equal(state.Comments) {
+		properties.set("comments", data.Comments.ValueString())
 	}
 
+	udfProperties, udfDiag := userDefinedFieldsUpdateProperties(ctx, data.UserDefinedFields, state.UserDefinedFields, r.client)
+	resp.Diagnostics.Append(udfDiag...)
+	properties.setAll(udfProperties)
+
 	update := gobam.APIEntity{
 		Id:         &id,
 		Name:       data.Name.ValueStringPointer(),
-		Properties: &properties,
+		Properties: properties.stringPointer(),
 		Type:       state.Type.ValueStringPointer(),
 	}
 
 	err = client.Update(&update)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to update IP4 Address", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to update IP4 Address", err, bamEntity("IP4 Address", data.ID.ValueString()))
 		return
 	}
 
 	entity, err := client.GetEntityById(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to get IP4 Address by Id after creation",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id after creation", err, bamEntity("IP4 Address", data.ID.ValueString()))
 		return
 	}
 
@@ -457,14 +726,15 @@ func (r *IP4AddressResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	addressProperties, diag := flattenIP4AddressProperties(entity)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
 	data.Address = addressProperties.Address
+	data.PTRName, data.ReverseZone = ip4ReverseNames(data.Address)
 	data.State = addressProperties.State
-	data.MACAddress = addressProperties.MACAddress
+	data.MACAddress = macAddressValue(data.MACAddress, addressProperties.MACAddress)
 	data.RouterPortInfo = addressProperties.RouterPortInfo
 	data.SwitchPortInfo = addressProperties.SwitchPortInfo
 	data.VLANInfo = addressProperties.VLANInfo
@@ -473,16 +743,23 @@ func (r *IP4AddressResource) Update(ctx context.Context, req resource.UpdateRequ
 	data.ParameterRequestList = addressProperties.ParameterRequestList
 	data.VendorClassIdentifier = addressProperties.VendorClassIdentifier
 	data.LocationCode = addressProperties.LocationCode
+	data.Comments = addressProperties.Comments
 	data.LocationInherited = addressProperties.LocationInherited
-	data.UserDefinedFields = addressProperties.UserDefinedFields
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, addressProperties.UserDefinedFields, r.client)
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IP4AddressResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	resp.Diagnostics.Append(deleteProtectionCheck(r.client, "IP4Address")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data *IP4AddressResourceModel
 
 	// Read Terraform prior state data into the model
@@ -492,52 +769,281 @@ func (r *IP4AddressResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
 	if diag.HasError() {
-		resp.Diagnostics.Append(diag...)
 		return
 	}
 
 	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
 		return
 	}
 
+	// remove the host record created with the address first
+	if !data.HostRecordID.IsNull() {
+		hostRecord, err := client.GetEntityById(data.HostRecordID.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get Host Record by Id", err, bamEntity("IP4 Address", data.ID.ValueString()))
+			return
+		}
+
+		if *hostRecord.Id != 0 {
+			err = client.Delete(*hostRecord.Id)
+			if err != nil {
+				resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+				addBAMError(&resp.Diagnostics, "Failed to delete Host Record", err, bamEntity("IP4 Address", data.ID.ValueString()))
+				return
+			}
+		}
+	}
+
 	err = client.Delete(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to delete IP4 Address", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to delete IP4 Address", err, bamEntity("IP4 Address", data.ID.ValueString()))
 		return
 	}
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 }
 
-func (r *IP4AddressResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+// assignNextAvailableIP4Address assigns the next available address in the
+// first of parentIDs that has one available.
+func assignNextAvailableIP4Address(ctx context.Context, client gobam.ProteusAPI, configID int64, parentIDs []int64, macAddress string, hostInfo string, action string, properties string) (*gobam.APIEntity, error) {
+	var errs []error
+
+	for _, parentID := range parentIDs {
+		ip, err := retryAllocation(ctx, func() (*gobam.APIEntity, error) {
+			allocationMutex.Lock()
+			defer allocationMutex.Unlock()
+			return client.AssignNextAvailableIP4Address(configID, parentID, macAddress, hostInfo, action, properties)
+		})
+		if err == nil && ip.Id != nil && *ip.Id != 0 {
+			return ip, nil
+		}
+
+		if err == nil {
+			err = fmt.Errorf("no address is available")
+		}
+		tflog.Warn(ctx, fmt.Sprintf("Failed to assign an address in parent %d, trying the next parent: %s", parentID, err))
+		errs = append(errs, fmt.Errorf("parent %d: %w", parentID, err))
+	}
+
+	return nil, errors.Join(errs...)
 }
 
-const ip4AddressActionPlanModifierDescription string = "action is required for creation and cannot be changed. Null values in the state are ignored to allow for import."
+// ip4AddressConstraints limit which address of a network is allocated.
+type ip4AddressConstraints struct {
+	offset   string
+	rangeEnd string
+	exclude  []string
+}
 
-func ip4AddressActionPlanModifier(ctx context.Context, p planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
-	var state *IP4AddressResourceModel
-	resp.Diagnostics.Append(p.State.Get(ctx, &state)...)
-	if resp.Diagnostics.HasError() {
+// hasAllocationConstraints returns whether any of offset, range_end, or
+// exclude_addresses is set.
+func (m *IP4AddressResourceModel) hasAllocationConstraints() bool {
+	return !m.Offset.IsNull() || !m.RangeEnd.IsNull() || !m.ExcludeAddresses.IsNull()
+}
+
+// allocationConstraints returns offset, range_end, and exclude_addresses.
+func (m *IP4AddressResourceModel) allocationConstraints(ctx context.Context) (ip4AddressConstraints, diag.Diagnostics) {
+	constraints := ip4AddressConstraints{
+		offset:   m.Offset.ValueIP4String(),
+		rangeEnd: m.RangeEnd.ValueIP4String(),
+	}
+
+	exclude, diags := ip4AddressStrings(ctx, m.ExcludeAddresses)
+	constraints.exclude = exclude
+
+	return constraints, diags
+}
+
+// assignConstrainedIP4Address assigns the first free address of the network
+// parentID that meets constraints. The address is found from the addresses
+// already in the network, so the search and assignment are retried if another
+// client assigns the same address first.
+func assignConstrainedIP4Address(ctx context.Context, client gobam.ProteusAPI, configID int64, parentID int64, constraints ip4AddressConstraints, macAddress string, hostInfo string, action string, properties string) (*gobam.APIEntity, error) {
+	network, err := client.GetEntityById(parentID)
+	if err != nil {
+		return nil, err
+	}
+	if network.Type == nil || *network.Type != "IP4Network" || network.Properties == nil {
+		return nil, fmt.Errorf("object %d is not an IPv4 network, which offset, range_end, and exclude_addresses require", parentID)
+	}
+	cidr := parseProperties(*network.Properties)["CIDR"]
+
+	return retryAllocation(ctx, func() (*gobam.APIEntity, error) {
+		allocationMutex.Lock()
+		defer allocationMutex.Unlock()
+
+		entities, err := getAllEntities(client, parentID, "IP4Address")
+		if err != nil {
+			return nil, err
+		}
+
+		used := make([]string, 0, len(entities)+len(constraints.exclude))
+		for _, e := range entities {
+			if e.Properties != nil {
+				used = append(used, parseProperties(*e.Properties)["address"])
+			}
+		}
+		used = append(used, constraints.exclude...)
+
+		address, err := findFreeIP4Address(cidr, used, constraints.offset, constraints.rangeEnd)
+		if err != nil {
+			return nil, err
+		}
+
+		id, err := client.AssignIP4Address(configID, address, macAddress, hostInfo, action, properties)
+		if err != nil {
+			return nil, err
+		}
+
+		return &gobam.APIEntity{Id: &id}, nil
+	})
+}
+
+// findFreeIP4Address returns the first address of the IPv4 network cidr from
+// offset to rangeEnd that is not in used. An empty offset or rangeEnd is the
+// first or last address of the network. The network and broadcast addresses
+// of networks larger than a /31 are never returned.
+func findFreeIP4Address(cidr string, used []string, offset string, rangeEnd string) (string, error) {
+	start, end, err := ip4CIDRRange(cidr)
+	if err != nil {
+		return "", err
+	}
+
+	first := binary.BigEndian.Uint32(net.ParseIP(start).To4())
+	last := binary.BigEndian.Uint32(net.ParseIP(end).To4())
+	if last-first > 1 {
+		first++
+		last--
+	}
+
+	if offset != "" {
+		ip := net.ParseIP(offset).To4()
+		if ip == nil {
+			return "", fmt.Errorf("offset %q is not a valid IPv4 address", offset)
+		}
+		first = max(first, binary.BigEndian.Uint32(ip))
+	}
+	if rangeEnd != "" {
+		ip := net.ParseIP(rangeEnd).To4()
+		if ip == nil {
+			return "", fmt.Errorf("range_end %q is not a valid IPv4 address", rangeEnd)
+		}
+		last = min(last, binary.BigEndian.Uint32(ip))
+	}
+
+	inUse := make(map[uint32]bool, len(used))
+	for _, address := range used {
+		if ip := net.ParseIP(address).To4(); ip != nil {
+			inUse[binary.BigEndian.Uint32(ip)] = true
+		}
+	}
+
+	address := func(a uint32) string {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, a)
+		return ip.String()
+	}
+
+	for a := uint64(first); a <= uint64(last); a++ {
+		if !inUse[uint32(a)] {
+			return address(uint32(a)), nil
+		}
+	}
+
+	return "", fmt.Errorf("%s has no free address from %s to %s", cidr, address(first), address(last))
+}
+
+// changeIP4AddressState changes the state of the address id to the state that
+// action would have assigned it, keeping the address itself.
+func changeIP4AddressState(client gobam.ProteusAPI, id int64, action string, macAddress string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if action == ipAssignmentActionDHCPReserved && macAddress == "" {
+		diags.AddAttributeError(path.Root("mac_address"), "Missing MAC address", fmt.Sprintf("mac_address must be set to change the state of an IPv4 address with %s.", ipAssignmentActionDHCPReserved))
+		return diags
+	}
+
+	if err := client.ChangeStateIP4Address(id, action, macAddress); err != nil {
+		addBAMError(&diags, "Failed to change the state of IP4 Address", err, "")
+	}
+
+	return diags
+}
+
+func (r *IP4AddressResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanUserDefinedFields(ctx, r.client, "IP4Address", req, resp)
+	modifyPlanLocationCode(ctx, r.client, req, resp)
+}
+
+func (r *IP4AddressResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// version 0 is the state of the sdk based releases, and of the
+		// framework based releases until the schema had a version
+		0: {StateUpgrader: upgradeLegacyState},
+	}
+}
+
+func (r *IP4AddressResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importID, err := parseIP4AddressImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Failed to parse import identifier %q: %s", req.ID, err.Error()),
+		)
 		return
 	}
 
-	if state.Action.IsNull() {
-		// Since this is a required field with required values, it should only be null when doing an import
-		resp.RequiresReplace = false
+	// configuration_id is set by Read
+	if importID.id != 0 {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 		return
 	}
 
-	resp.RequiresReplace = true
+	client, diag := clientLogin(ctx, r.client)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	entity, err := client.GetIP4Address(importID.configurationID, importID.address)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Address "+importID.address, err, "")
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	if entity.Id == nil || *entity.Id == 0 {
+		resp.Diagnostics.AddError(
+			"IP4 Address not found",
+			fmt.Sprintf("No IPv4 address %s was found in configuration %d", importID.address, importID.configurationID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.FormatInt(*entity.Id, 10))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("configuration_id"), importID.configurationID)...)
 }
 
-const ip4AddressConfigurationIDPlanModifierDescription string = "configuration_id is required for creation and cannot be changed. Null values in the state are ignored to allow for import."
+const ip4AddressConfigurationIDPlanModifierDescription string = "configuration_id cannot be changed. Null values in the state are ignored to allow for import."
 
 func ip4AddressConfigurationIDPlanModifier(ctx context.Context, p planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
 	var state *IP4AddressResourceModel
@@ -0,0 +1,590 @@
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// ip4AddressBlockReservationMaxCount limits how many addresses a single
+// reservation can hold.
+const ip4AddressBlockReservationMaxCount = 1024
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IP4AddressBlockReservationResource{}
+
+func NewIP4AddressBlockReservationResource() resource.Resource {
+	return &IP4AddressBlockReservationResource{}
+}
+
+// IP4AddressBlockReservationResource defines the resource implementation.
+type IP4AddressBlockReservationResource struct {
+	client *loginClient
+}
+
+// IP4AddressBlockReservationResourceModel describes the resource data model.
+type IP4AddressBlockReservationResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	ConfigurationID types.Int64  `tfsdk:"configuration_id"`
+	ParentID        types.Int64  `tfsdk:"parent_id"`
+	Count           types.Int64  `tfsdk:"address_count"`
+	Consecutive     types.Bool   `tfsdk:"consecutive"`
+	Action          types.String `tfsdk:"action"`
+	Name            types.String `tfsdk:"name"`
+	Addresses       types.List   `tfsdk:"addresses"`
+	AddressIDs      types.List   `tfsdk:"address_ids"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *IP4AddressBlockReservationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip4_address_block_reservation"
+}
+
+func (r *IP4AddressBlockReservationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource to reserve several IPv4 addresses at once, such as the addresses of the nodes of a cluster. Either all of the addresses are reserved or none of them are. If any of the addresses is deleted outside of Terraform, all of them are reserved again.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the reservation, which is the object ID of the first address.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"configuration_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the Configuration that will hold the addresses." + defaultConfigurationDescription + " If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"parent_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the Network to reserve the addresses in. If `consecutive` is `false`, this can also be a Configuration or Block. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"address_count": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The number of addresses to reserve, from 1 to %d. If changed, forces a new resource.", ip4AddressBlockReservationMaxCount),
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					ip4AddressBlockReservationCountPlanModifier{},
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, ip4AddressBlockReservationMaxCount),
+				},
+			},
+			"consecutive": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, the addresses are the first run of `address_count` consecutive free addresses in the network. Otherwise they are the next `address_count` available addresses. Defaults to `false`. If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"action": schema.StringAttribute{
+				MarkdownDescription: "The action to take on the addresses. Must be one of `MAKE_STATIC` or `MAKE_RESERVED`. Defaults to `MAKE_STATIC`. If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(ipAssignmentActionStatic),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(ipAssignmentActionStatic, ipAssignmentActionReserved),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the addresses. Each address is named `<name>-<index>`, starting at `1`.",
+				Optional:            true,
+			},
+			"addresses": schema.ListAttribute{
+				MarkdownDescription: "The IPv4 addresses that were reserved, in ascending order.",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"address_ids": schema.ListAttribute{
+				MarkdownDescription: "The object IDs of the addresses, in the same order as `addresses`.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *IP4AddressBlockReservationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IP4AddressBlockReservationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4AddressBlockReservationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	configID, diag := r.client.configurationID(client, data.ConfigurationID)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	data.ConfigurationID = types.Int64Value(configID)
+
+	// hold the allocation lock for the whole reservation so that other
+	// resources cannot take addresses between the ones reserved here
+	allocationMutex.Lock()
+	ids, diag := data.reserve(client, r.client)
+	allocationMutex.Unlock()
+	if diag.HasError() {
+		// release the addresses that were reserved so they are not left behind
+		for _, id := range ids {
+			if err := client.Delete(id); err != nil {
+				resp.Diagnostics.AddWarning("Failed to release IP4 Address after a failed reservation", fmt.Sprintf("IP4 Address %d must be deleted manually: %s", id, err.Error()))
+			}
+		}
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	addresses, err := getIP4AddressesByID(client, ids)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Addresses after reservation", err, "")
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	data.ID = types.StringValue(strconv.FormatInt(ids[0], 10))
+	resp.Diagnostics.Append(data.setAddresses(ctx, addresses, ids)...)
+
+	tflog.Trace(ctx, "created a resource", map[string]interface{}{"addresses": len(ids)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4AddressBlockReservationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *IP4AddressBlockReservationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []int64
+	resp.Diagnostics.Append(data.AddressIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	// forget the addresses that were deleted outside of Terraform, which
+	// makes address_count differ from the number of addresses and all of
+	// them to be reserved again
+	var addresses []string
+	var found []int64
+	for _, id := range ids {
+		entity, err := client.GetEntityById(id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id", err, bamEntity("IP4 Address Block Reservation", data.ID.ValueString()))
+			return
+		}
+
+		if entity.Id == nil || *entity.Id == 0 {
+			continue
+		}
+
+		if ok, diag := entityTypeMatches(entity, "IP4Address"); !ok {
+			resp.Diagnostics.Append(diag...)
+			continue
+		}
+
+		addresses = append(addresses, parseProperties(*entity.Properties)["address"])
+		found = append(found, id)
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	if len(found) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setAddresses(ctx, addresses, found)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4AddressBlockReservationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4AddressBlockReservationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []int64
+	resp.Diagnostics.Append(data.AddressIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	// only name can be changed without reserving the addresses again
+	for i, id := range ids {
+		entity, err := client.GetEntityById(id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id", err, bamEntity("IP4 Address Block Reservation", data.ID.ValueString()))
+			return
+		}
+
+		entity.Name = data.addressName(i)
+		if err := client.Update(entity); err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "IP4 Address Update failed", err, bamEntity("IP4 Address Block Reservation", data.ID.ValueString()))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4AddressBlockReservationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	resp.Diagnostics.Append(deleteProtectionCheck(r.client, "IP4Address")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4AddressBlockReservationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []int64
+	resp.Diagnostics.Append(data.AddressIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	for _, id := range ids {
+		entity, err := client.GetEntityById(id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id", err, bamEntity("IP4 Address Block Reservation", data.ID.ValueString()))
+			return
+		}
+
+		if entity.Id == nil || *entity.Id == 0 {
+			continue
+		}
+
+		if err := client.Delete(id); err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "IP4 Address Delete failed", err, bamEntity("IP4 Address Block Reservation", data.ID.ValueString()))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+// ip4AddressBlockReservationCountPlanModifier requires replacement if
+// address_count changes or any of the addresses was deleted outside of
+// Terraform, so that all of them are reserved again.
+type ip4AddressBlockReservationCountPlanModifier struct{}
+
+func (m ip4AddressBlockReservationCountPlanModifier) Description(ctx context.Context) string {
+	return "Changing address_count, or deleting any of the addresses outside of Terraform, reserves all of the addresses again."
+}
+
+func (m ip4AddressBlockReservationCountPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m ip4AddressBlockReservationCountPlanModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var addressIDs types.List
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("address_ids"), &addressIDs)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.RequiresReplace = int64(len(addressIDs.Elements())) != req.PlanValue.ValueInt64()
+}
+
+// reserve reserves the addresses of the reservation and returns their object
+// IDs. The IDs of the addresses reserved before an error are returned with it
+// so that they can be released.
+func (m *IP4AddressBlockReservationResourceModel) reserve(client gobam.ProteusAPI, loginClient *loginClient) ([]int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	configID := m.ConfigurationID.ValueInt64()
+	parentID := m.ParentID.ValueInt64()
+	count := int(m.Count.ValueInt64())
+	action := m.Action.ValueString()
+
+	var candidates []string
+	if m.Consecutive.ValueBool() {
+		network, err := client.GetEntityById(parentID)
+		if err != nil {
+			addBAMError(&diags, "Failed to get IP4 Network by Id", err, "")
+			return nil, diags
+		}
+		if network.Type == nil || *network.Type != "IP4Network" || network.Properties == nil {
+			diags.AddAttributeError(path.Root("parent_id"), "Invalid parent", fmt.Sprintf("Object %d is not an IPv4 network, which consecutive requires", parentID))
+			return nil, diags
+		}
+
+		used, err := getAllEntities(client, parentID, "IP4Address")
+		if err != nil {
+			addBAMError(&diags, "Failed to get the addresses of the IP4 Network", err, "")
+			return nil, diags
+		}
+
+		inUse := make([]string, 0, len(used))
+		for _, e := range used {
+			if e.Properties != nil {
+				inUse = append(inUse, parseProperties(*e.Properties)["address"])
+			}
+		}
+
+		candidates, err = findConsecutiveFreeIP4Addresses(parseProperties(*network.Properties)["CIDR"], inUse, count)
+		if err != nil {
+			addBAMError(&diags, "No consecutive addresses are available", err, "")
+			return nil, diags
+		}
+	}
+
+	ids := make([]int64, 0, count)
+	for i := range count {
+		properties := propertyMap{}
+		if name := m.addressName(i); name != nil {
+			properties.set("name", *name)
+		}
+		properties.setManaged(loginClient)
+
+		if candidates != nil {
+			id, err := client.AssignIP4Address(configID, candidates[i], "", "", action, properties.String())
+			if err != nil {
+				addBAMError(&diags, "AssignIP4Address failed", err, "IP4 Address "+candidates[i])
+				return ids, diags
+			}
+			ids = append(ids, id)
+			continue
+		}
+
+		ip, err := client.AssignNextAvailableIP4Address(configID, parentID, "", "", action, properties.String())
+		if err == nil && (ip.Id == nil || *ip.Id == 0) {
+			err = fmt.Errorf("no address is available")
+		}
+		if err != nil {
+			diags.AddError("AssignNextAvailableIP4Address failed", fmt.Sprintf("address %d of %d: %s", i+1, count, err.Error()))
+			return ids, diags
+		}
+		ids = append(ids, *ip.Id)
+	}
+
+	return ids, diags
+}
+
+// addressName returns the name of the address at index i of the reservation,
+// or nil if name is not set.
+func (m *IP4AddressBlockReservationResourceModel) addressName(i int) *string {
+	if m.Name.IsNull() || m.Name.IsUnknown() {
+		return nil
+	}
+
+	name := fmt.Sprintf("%s-%d", m.Name.ValueString(), i+1)
+	return &name
+}
+
+// setAddresses sets addresses and address_ids, sorted by address.
+func (m *IP4AddressBlockReservationResourceModel) setAddresses(ctx context.Context, addresses []string, ids []int64) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	sortIP4AddressesWithIDs(addresses, ids)
+
+	m.Addresses, d = types.ListValueFrom(ctx, types.StringType, addresses)
+	diags.Append(d...)
+	m.AddressIDs, d = types.ListValueFrom(ctx, types.Int64Type, ids)
+	diags.Append(d...)
+
+	return diags
+}
+
+// sortIP4AddressesWithIDs sorts addresses in ascending order and ids with them.
+func sortIP4AddressesWithIDs(addresses []string, ids []int64) {
+	key := func(i int) uint32 {
+		ip := net.ParseIP(addresses[i]).To4()
+		if ip == nil {
+			return 0
+		}
+		return binary.BigEndian.Uint32(ip)
+	}
+
+	// insertion sort keeps both slices in step and the lists are short
+	for i := 1; i < len(addresses); i++ {
+		for j := i; j > 0 && key(j) < key(j-1); j-- {
+			addresses[j], addresses[j-1] = addresses[j-1], addresses[j]
+			ids[j], ids[j-1] = ids[j-1], ids[j]
+		}
+	}
+}
+
+// findConsecutiveFreeIP4Addresses returns the first run of count consecutive
+// addresses in the IPv4 network cidr that are not in used. The network and
+// broadcast addresses of networks larger than a /31 are never returned.
+func findConsecutiveFreeIP4Addresses(cidr string, used []string, count int) ([]string, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	if network.IP.To4() == nil {
+		return nil, fmt.Errorf("%q is not an IPv4 CIDR", cidr)
+	}
+
+	ones, bits := network.Mask.Size()
+	first := binary.BigEndian.Uint32(network.IP.To4())
+	last := first + uint32(1<<(bits-ones)-1)
+	if bits-ones > 1 {
+		first++
+		last--
+	}
+
+	inUse := make(map[uint32]bool, len(used))
+	for _, address := range used {
+		if ip := net.ParseIP(address).To4(); ip != nil {
+			inUse[binary.BigEndian.Uint32(ip)] = true
+		}
+	}
+
+	run := 0
+	for a := uint64(first); a <= uint64(last); a++ {
+		if inUse[uint32(a)] {
+			run = 0
+			continue
+		}
+
+		run++
+		if run == count {
+			addresses := make([]string, 0, count)
+			for b := uint32(a) - uint32(count) + 1; b <= uint32(a); b++ {
+				ip := make(net.IP, net.IPv4len)
+				binary.BigEndian.PutUint32(ip, b)
+				addresses = append(addresses, ip.String())
+			}
+			return addresses, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s has no run of %d consecutive free addresses", cidr, count)
+}
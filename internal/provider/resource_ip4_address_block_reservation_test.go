@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIP4AddressBlockReservationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccIP4AddressBlockReservationResourceConfig("tfacc-block"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_ip4_address_block_reservation.test", "addresses.#", "3"),
+					resource.TestCheckResourceAttr("bluecat_ip4_address_block_reservation.test", "address_ids.#", "3"),
+					resource.TestCheckResourceAttrWith("bluecat_ip4_address_block_reservation.test", "id", validateObjectID),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccIP4AddressBlockReservationResourceConfig("tfacc-block-renamed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_ip4_address_block_reservation.test", "name", "tfacc-block-renamed"),
+					resource.TestCheckResourceAttr("bluecat_ip4_address_block_reservation.test", "addresses.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func testAccIP4AddressBlockReservationResourceConfig(name string) string {
+	return testAccEntityDataSourceConfig + fmt.Sprintf(`
+variable "ip4_network_id" {
+	type = number
+}
+
+resource "bluecat_ip4_address_block_reservation" "test" {
+  configuration_id = data.bluecat_entity.config.id
+  parent_id        = var.ip4_network_id
+  address_count    = 3
+  consecutive      = true
+  name             = %q
+}
+`, name)
+}
+
+func TestFindConsecutiveFreeIP4Addresses(t *testing.T) {
+	addresses, err := findConsecutiveFreeIP4Addresses("10.0.0.0/29", []string{"10.0.0.2", "10.0.0.5"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(addresses, ","); got != "10.0.0.3,10.0.0.4" {
+		t.Errorf("expected the first free run after the network address, got %s", got)
+	}
+
+	if _, err := findConsecutiveFreeIP4Addresses("10.0.0.0/29", []string{"10.0.0.2", "10.0.0.5"}, 3); err == nil {
+		t.Error("expected an error when no run is long enough")
+	}
+
+	addresses, err = findConsecutiveFreeIP4Addresses("10.0.0.0/29", nil, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(addresses, ","); got != "10.0.0.1,10.0.0.2,10.0.0.3,10.0.0.4,10.0.0.5,10.0.0.6" {
+		t.Errorf("expected the network and broadcast addresses to be skipped, got %s", got)
+	}
+
+	addresses, err = findConsecutiveFreeIP4Addresses("10.0.0.0/31", nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(addresses, ","); got != "10.0.0.0,10.0.0.1" {
+		t.Errorf("expected both addresses of a /31, got %s", got)
+	}
+
+	if _, err := findConsecutiveFreeIP4Addresses("2001:db8::/64", nil, 1); err == nil {
+		t.Error("expected an error for an IPv6 CIDR")
+	}
+}
+
+func TestSortIP4AddressesWithIDs(t *testing.T) {
+	addresses := []string{"10.0.0.10", "10.0.0.9", "10.0.0.100"}
+	ids := []int64{10, 9, 100}
+
+	sortIP4AddressesWithIDs(addresses, ids)
+
+	if got := fmt.Sprint(addresses, ids); got != "[10.0.0.9 10.0.0.10 10.0.0.100] [9 10 100]" {
+		t.Errorf("expected addresses sorted numerically with their IDs, got %s", got)
+	}
+}
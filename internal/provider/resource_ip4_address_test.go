@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umich-vci/gobam"
+)
+
+// nextAvailableAddressClient assigns an address in the parents that have one
+// available and records the parents it was asked to assign in.
+type nextAvailableAddressClient struct {
+	gobam.ProteusAPI
+	available map[int64]int64
+	tried     []int64
+}
+
+func (c *nextAvailableAddressClient) AssignNextAvailableIP4Address(configurationId int64, parentId int64, macAddress string, hostInfo string, action string, properties string) (*gobam.APIEntity, error) {
+	c.tried = append(c.tried, parentId)
+
+	id, ok := c.available[parentId]
+	if !ok {
+		return nil, fmt.Errorf("no address is available in %d", parentId)
+	}
+
+	return &gobam.APIEntity{Id: &id}, nil
+}
+
+func TestAssignNextAvailableIP4Address(t *testing.T) {
+	client := &nextAvailableAddressClient{available: map[int64]int64{2: 20, 3: 30}}
+
+	ip, err := assignNextAvailableIP4Address(context.Background(), client, 1, []int64{1, 2, 3}, "", "", ipAssignmentActionStatic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *ip.Id != 20 {
+		t.Errorf("expected the address in the first available parent, got %d", *ip.Id)
+	}
+	if fmt.Sprint(client.tried) != "[1 2]" {
+		t.Errorf("expected parents to be tried in order, got %v", client.tried)
+	}
+
+	client.tried = nil
+	if _, err := assignNextAvailableIP4Address(context.Background(), client, 1, []int64{4, 5}, "", "", ipAssignmentActionStatic, ""); err == nil {
+		t.Error("expected an error when no parent has an address available")
+	}
+	if fmt.Sprint(client.tried) != "[4 5]" {
+		t.Errorf("expected every parent to be tried, got %v", client.tried)
+	}
+}
+
+func TestIP4AddressHostInfo(t *testing.T) {
+	if hostInfo := ip4AddressHostInfo(types.StringNull(), types.Int64Null(), types.BoolValue(false)); hostInfo != "" {
+		t.Errorf("expected no hostInfo without a hostname, got %q", hostInfo)
+	}
+
+	hostInfo := ip4AddressHostInfo(types.StringValue("host.example.com"), types.Int64Value(10), types.BoolValue(true))
+	if hostInfo != "host.example.com,10,true,false" {
+		t.Errorf("unexpected hostInfo %q", hostInfo)
+	}
+}
+
+// linkedHostRecordClient returns the host records linked to an address.
+type linkedHostRecordClient struct {
+	gobam.ProteusAPI
+	linked []*gobam.APIEntity
+}
+
+func (c *linkedHostRecordClient) GetLinkedEntities(entityId int64, _type string, start int, count int) (*gobam.APIEntityArray, error) {
+	return &gobam.APIEntityArray{Item: c.linked}, nil
+}
+
+func TestFindLinkedHostRecord(t *testing.T) {
+	otherID, hostID := int64(1), int64(2)
+	otherProperties, hostProperties := "absoluteName=other.example.com|", "absoluteName=host.example.com|reverseRecord=true|"
+	client := &linkedHostRecordClient{linked: []*gobam.APIEntity{
+		{Id: &otherID, Properties: &otherProperties},
+		{Id: &hostID, Properties: &hostProperties},
+	}}
+
+	id, err := findLinkedHostRecord(client, 100, "host.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.ValueInt64() != hostID {
+		t.Errorf("expected host record %d, got %s", hostID, id)
+	}
+
+	id, err = findLinkedHostRecord(client, 100, "missing.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !id.IsNull() {
+		t.Errorf("expected no host record, got %s", id)
+	}
+}
+
+func TestIP4ReverseNames(t *testing.T) {
+	ptrName, reverseZone := ip4ReverseNames(types.StringValue("10.0.1.5"))
+	if ptrName.ValueString() != "5.1.0.10.in-addr.arpa" {
+		t.Errorf("expected ptr_name 5.1.0.10.in-addr.arpa, got %s", ptrName)
+	}
+	if reverseZone.ValueString() != "1.0.10.in-addr.arpa" {
+		t.Errorf("expected reverse_zone 1.0.10.in-addr.arpa, got %s", reverseZone)
+	}
+
+	for _, address := range []types.String{types.StringNull(), types.StringValue("2001:db8::1"), types.StringValue("junk")} {
+		if ptrName, reverseZone := ip4ReverseNames(address); !ptrName.IsNull() || !reverseZone.IsNull() {
+			t.Errorf("expected null names for %s, got %s and %s", address, ptrName, reverseZone)
+		}
+	}
+}
+
+// changeStateClient records the state changes it was asked to make.
+type changeStateClient struct {
+	gobam.ProteusAPI
+	changes []string
+}
+
+func (c *changeStateClient) ChangeStateIP4Address(addressId int64, targetState string, macAddress string) error {
+	c.changes = append(c.changes, fmt.Sprintf("%d:%s:%s", addressId, targetState, macAddress))
+	return nil
+}
+
+func TestChangeIP4AddressState(t *testing.T) {
+	client := &changeStateClient{}
+
+	if diags := changeIP4AddressState(client, 5, ipAssignmentActionDHCPReserved, "00-50-56-01-02-03"); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if diags := changeIP4AddressState(client, 5, ipAssignmentActionStatic, ""); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := fmt.Sprint(client.changes); got != "[5:MAKE_DHCP_RESERVED:00-50-56-01-02-03 5:MAKE_STATIC:]" {
+		t.Errorf("expected the state to be changed in place, got %s", got)
+	}
+
+	client.changes = nil
+	if diags := changeIP4AddressState(client, 5, ipAssignmentActionDHCPReserved, ""); !diags.HasError() {
+		t.Error("expected an error when a DHCP reservation has no MAC address")
+	}
+	if len(client.changes) != 0 {
+		t.Errorf("expected no state change without a MAC address, got %v", client.changes)
+	}
+}
+
+func TestFindFreeIP4Address(t *testing.T) {
+	used := []string{"10.0.0.1", "10.0.0.2", "10.0.0.4"}
+
+	address, err := findFreeIP4Address("10.0.0.0/24", used, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != "10.0.0.3" {
+		t.Errorf("expected the first free address, got %s", address)
+	}
+
+	address, err = findFreeIP4Address("10.0.0.0/24", used, "10.0.0.4", "10.0.0.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != "10.0.0.5" {
+		t.Errorf("expected the first free address from offset, got %s", address)
+	}
+
+	if _, err := findFreeIP4Address("10.0.0.0/24", used, "10.0.0.1", "10.0.0.2"); err == nil {
+		t.Error("expected an error when the window has no free address")
+	}
+
+	address, err = findFreeIP4Address("10.0.0.0/24", nil, "10.0.0.0", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != "10.0.0.1" {
+		t.Errorf("expected the network address to be skipped, got %s", address)
+	}
+
+	if _, err := findFreeIP4Address("10.0.0.0/30", nil, "10.0.0.3", ""); err == nil {
+		t.Error("expected the broadcast address to be skipped")
+	}
+}
+
+// constrainedAddressClient has a network with some addresses in use and
+// records the addresses it was asked to assign.
+type constrainedAddressClient struct {
+	gobam.ProteusAPI
+	assigned []string
+}
+
+func (c *constrainedAddressClient) GetEntityById(id int64) (*gobam.APIEntity, error) {
+	objectType, properties := "IP4Network", "CIDR=10.0.0.0/24|"
+	return &gobam.APIEntity{Id: &id, Type: &objectType, Properties: &properties}, nil
+}
+
+func (c *constrainedAddressClient) GetEntities(parentId int64, _type string, start int, count int) (*gobam.APIEntityArray, error) {
+	entities := &gobam.APIEntityArray{}
+	if start > 0 {
+		return entities, nil
+	}
+	for i, address := range []string{"10.0.0.1", "10.0.0.10"} {
+		id, objectType, properties := int64(100+i), "IP4Address", "address="+address+"|"
+		entities.Item = append(entities.Item, &gobam.APIEntity{Id: &id, Type: &objectType, Properties: &properties})
+	}
+	return entities, nil
+}
+
+func (c *constrainedAddressClient) AssignIP4Address(configurationId int64, ip4Address string, macAddress string, hostInfo string, action string, properties string) (int64, error) {
+	c.assigned = append(c.assigned, ip4Address)
+	return 200, nil
+}
+
+func TestAssignConstrainedIP4Address(t *testing.T) {
+	client := &constrainedAddressClient{}
+	constraints := ip4AddressConstraints{offset: "10.0.0.10", rangeEnd: "10.0.0.20", exclude: []string{"10.0.0.11"}}
+
+	ip, err := assignConstrainedIP4Address(context.Background(), client, 1, 2, constraints, "", "", ipAssignmentActionStatic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *ip.Id != 200 {
+		t.Errorf("expected the assigned address, got %d", *ip.Id)
+	}
+	if fmt.Sprint(client.assigned) != "[10.0.0.12]" {
+		t.Errorf("expected the first free address in the window that is not excluded, got %v", client.assigned)
+	}
+}
+
+func TestMACAddressValue(t *testing.T) {
+	api := types.StringValue("00-50-56-01-02-0A")
+
+	for _, prior := range []string{"00:50:56:01:02:0a", "00-50-56-01-02-0a", "0050.5601.020A", "00505601020a"} {
+		if v := macAddressValue(types.StringValue(prior), api); v.ValueString() != prior {
+			t.Errorf("expected the prior value %q to be kept, got %q", prior, v.ValueString())
+		}
+	}
+
+	if v := macAddressValue(types.StringValue("00:50:56:01:02:0b"), api); !v.Equal(api) {
+		t.Errorf("expected the new value, got %q", v.ValueString())
+	}
+
+	if v := macAddressValue(types.StringNull(), api); !v.Equal(api) {
+		t.Errorf("expected the new value when there is no prior value, got %q", v.ValueString())
+	}
+
+	if v := macAddressValue(types.StringValue("00:50:56:01:02:0a"), types.StringNull()); !v.IsNull() {
+		t.Errorf("expected null when the address has no MAC address, got %q", v.ValueString())
+	}
+}
+
+func TestMACAddressRegexp(t *testing.T) {
+	for _, mac := range []string{"00:50:56:01:02:0a", "00-50-56-01-02-0A", "0050.5601.020a", "00505601020a"} {
+		if !macAddressRegexp.MatchString(mac) {
+			t.Errorf("expected %q to be a valid MAC address", mac)
+		}
+	}
+
+	for _, mac := range []string{"", "00:50:56:01:02", "00:50:56:01:02:0g", "00:50-56:01:02:0a", "00:50:56:01:02:0a:0b", "0050.5601.020a.0b"} {
+		if macAddressRegexp.MatchString(mac) {
+			t.Errorf("expected %q to be rejected", mac)
+		}
+	}
+}
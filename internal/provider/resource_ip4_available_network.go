@@ -7,17 +7,25 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -39,8 +47,17 @@ type IP4AvailableNetworkResourceModel struct {
 	NetworkIDList types.List   `tfsdk:"network_id_list"`
 	Keepers       types.Map    `tfsdk:"keepers"`
 	Random        types.Bool   `tfsdk:"random"`
+	Strategy      types.String `tfsdk:"selection_strategy"`
 	Seed          types.String `tfsdk:"seed"`
 	NetworkID     types.Int64  `tfsdk:"network_id"`
+	Revalidate    types.Bool   `tfsdk:"revalidate"`
+	MinFree       types.Int64  `tfsdk:"min_free_addresses"`
+	Accounting    types.String `tfsdk:"free_address_accounting"`
+	UDFFilters    types.Map    `tfsdk:"udf_filters"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
 }
 
 func (r *IP4AvailableNetworkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,6 +70,8 @@ func (r *IP4AvailableNetworkResource) Schema(ctx context.Context, req resource.S
 		MarkdownDescription: "Resource to select an IPv4 network from a list of networks based on availability of IP addresses.",
 
 		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Example identifier",
@@ -78,6 +97,7 @@ func (r *IP4AvailableNetworkResource) Schema(ctx context.Context, req resource.S
 			},
 			"random": schema.BoolAttribute{
 				MarkdownDescription: "By default, the network with the most free IP addresses is returned. By setting this to `true` a random network from the list will be returned instead. The network will be validated to have at least 1 free IP address.",
+				DeprecationMessage:  "Use selection_strategy = \"random\" instead.",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
@@ -85,16 +105,70 @@ func (r *IP4AvailableNetworkResource) Schema(ctx context.Context, req resource.S
 					boolplanmodifier.RequiresReplace(),
 				},
 			},
+			"selection_strategy": schema.StringAttribute{
+				MarkdownDescription: "How a network is selected from the networks in `network_id_list` that match `min_free_addresses` and `udf_filters`. `\"most_free\"` selects the network with the most free addresses, `\"least_free\"` the network with the fewest free addresses, `\"random\"` a random network, and `\"first\"` the first network in the order of `network_id_list`. " + enumDescription(selectionStrategies) + " Defaults to `\"random\"` if `random` is `true` and `\"most_free\"` otherwise.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(selectionStrategies...),
+					stringvalidator.ConflictsWith(path.MatchRoot("random")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"seed": schema.StringAttribute{
-				MarkdownDescription: "A seed for the `random` argument's generator. Can be used to try to get more predictable results from the random selection. The results will not be fixed however.",
+				MarkdownDescription: "A seed for the generator of the `\"random\"` selection strategy. Can be used to try to get more predictable results from the random selection. The results will not be fixed however.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"revalidate": schema.BoolAttribute{
+				MarkdownDescription: "By default, if the selected network is deleted outside of Terraform the resource is removed from the state and a new network is selected on the next apply, and if the selected network runs out of free addresses a warning is shown. By setting this to `true`, a new network is selected from `network_id_list` during refresh in both cases instead.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"min_free_addresses": schema.Int64Attribute{
+				MarkdownDescription: "The minimum number of free IP addresses a network must have to be selected. Defaults to `1`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"free_address_accounting": schema.StringAttribute{
+				MarkdownDescription: "How the free addresses of a network are counted for `min_free_addresses` and `selection_strategy`. `\"total\"` counts every address that is not in use. `\"assignable\"` also leaves out the network and broadcast addresses. `\"static\"` also leaves out the free addresses in DHCP ranges, for networks that addresses will be assigned statically from. The gateway and reserved addresses are always counted as in use. " + enumDescription(freeAddressAccountings) + " Defaults to `\"total\"`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(freeAddressAccountingTotal),
+				Validators: []validator.String{
+					stringvalidator.OneOf(freeAddressAccountings...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"udf_filters": schema.MapAttribute{
+				MarkdownDescription: "A map of user-defined field names to values. Only networks where every listed user-defined field has the given value are selected, for example `{ environment = \"prod\" }`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
 			"network_id": schema.Int64Attribute{
 				MarkdownDescription: "The network ID of the network selected by the resource.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -121,6 +195,11 @@ func (r *IP4AvailableNetworkResource) Configure(ctx context.Context, req resourc
 }
 
 func (r *IP4AvailableNetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data *IP4AvailableNetworkResourceModel
 
 	// Read Terraform plan data into the model
@@ -130,150 +209,24 @@ func (r *IP4AvailableNetworkResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	result := int64(-1)
-
-	networkIDList := make([]int64, 0, len(data.NetworkIDList.Elements()))
-	diag = data.NetworkIDList.ElementsAs(ctx, &networkIDList, false)
+	result, diag := selectIP4AvailableNetwork(ctx, client, r.client.NetworkUsage, data)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
-		resp.Diagnostics.AddError(
-			"Parsing network ids failed",
-			"",
-		)
-		return
-	}
-
-	seed := data.Seed.ValueString()
-	random := data.Random.ValueBool()
-
-	if len(networkIDList) == 0 {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"network_id_list cannot be empty",
-			"",
-		)
-
-		return
-	}
-
-	if random {
-		rand := NewRand(seed)
-
-		// Keep producing permutations until we fill our result
-	Batches:
-		for {
-			perm := rand.Perm(len(networkIDList))
-
-			for _, i := range perm {
-				id := networkIDList[i]
-
-				entity, err := client.GetEntityById(id)
-				if err != nil {
-					resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-					resp.Diagnostics.AddError(
-						"Failed to get IP4 Network by Id",
-						err.Error(),
-					)
-
-					return
-				}
-
-				networkProperties, diag := parseIP4NetworkProperties(*entity.Properties)
-				if diag.HasError() {
-					resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-					resp.Diagnostics.Append(diag...)
-					return
-				}
-
-				_, addressesFree, err := getIP4NetworkAddressUsage(*entity.Id, networkProperties.cidr.ValueString(), client)
-				if err != nil {
-					resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-					resp.Diagnostics.AddError(
-						"Error calculating network usage",
-						err.Error(),
-					)
-
-					return
-				}
-
-				if addressesFree > 0 {
-					result = networkIDList[i]
-					break Batches
-				}
-			}
-		}
-
-	} else {
-
-		freeAddressMap := make(map[int64]int64)
-		for i := range networkIDList {
-			id := networkIDList[i]
-
-			entity, err := client.GetEntityById(id)
-			if err != nil {
-				resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-				resp.Diagnostics.AddError(
-					"Failed to get IP4 Network by Id",
-					err.Error(),
-				)
-
-				return
-			}
-
-			networkProperties, diag := parseIP4NetworkProperties(*entity.Properties)
-			if diag.HasError() {
-				resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-				resp.Diagnostics.Append(diag...)
-				return
-			}
-
-			_, addressesFree, err := getIP4NetworkAddressUsage(*entity.Id, networkProperties.cidr.ValueString(), client)
-			if err != nil {
-				resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-				resp.Diagnostics.AddError(
-					"Error calculating network usage",
-					err.Error(),
-				)
-
-				return
-			}
-
-			if addressesFree > 0 {
-				freeAddressMap[id] = addressesFree
-			}
-
-		}
-
-		freeCount := int64(0)
-		for k, v := range freeAddressMap {
-			if v > freeCount {
-				freeCount = v
-				result = k
-			}
-		}
-	}
-
-	if result == -1 {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"No networks had a free address",
-			"",
-		)
-
 		return
 	}
 
 	data.ID = types.StringValue("-")
 	data.NetworkID = types.Int64Value(result)
+	data.Strategy = types.StringValue(data.selectionStrategy())
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -293,19 +246,85 @@ func (r *IP4AvailableNetworkResource) Read(ctx context.Context, req resource.Rea
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
+	// state saved before selection_strategy was added
+	if data.Strategy.IsNull() {
+		data.Strategy = types.StringValue(data.selectionStrategy())
+	}
+
+	// nothing has been selected yet, for example right after an import
+	if data.NetworkID.IsNull() || data.NetworkID.IsUnknown() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	networkID := data.NetworkID.ValueInt64()
+	filter, diag := data.filter(ctx)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	addressesFree, properties, found, diag := getIP4NetworkFreeAddresses(client, r.client.NetworkUsage, networkID, filter.accounting)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	if found && filter.matches(addressesFree, properties) {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	if data.Revalidate.ValueBool() {
+		result, diag := selectIP4AvailableNetwork(ctx, client, r.client.NetworkUsage, data)
+		if diag.HasError() {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+
+		tflog.Info(ctx, "selected network is no longer available, selected a new network", map[string]interface{}{
+			"previous_network_id": networkID,
+			"network_id":          result,
+		})
+		data.NetworkID = types.Int64Value(result)
+
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Selected network is no longer available",
+		fmt.Sprintf("IPv4 network %d has fewer than %d free addresses or no longer matches udf_filters. Set `revalidate` to `true` to select a new network when this happens.", networkID, filter.minFreeAddresses),
+	)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IP4AvailableNetworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data *IP4AvailableNetworkResourceModel
 
 	// Read Terraform plan data into the model
@@ -328,6 +347,9 @@ func (r *IP4AvailableNetworkResource) Update(ctx context.Context, req resource.U
 }
 
 func (r *IP4AvailableNetworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Deleting the resource only removes it from the state, so it is allowed
+	// in read-only mode and when IP4Network is a protected type.
+
 	var data *IP4AvailableNetworkResourceModel
 
 	// Read Terraform prior state data into the model
@@ -361,3 +383,167 @@ func NewRand(seed string) *rand.Rand {
 	randSource := rand.NewSource(seedInt)
 	return rand.New(randSource)
 }
+
+// selectIP4AvailableNetwork selects a network with at least one free address
+// from the network_id_list of data, using the selection method set by the
+// random and seed arguments.
+func selectIP4AvailableNetwork(ctx context.Context, client gobam.ProteusAPI, usage *ip4NetworkUsageCache, data *IP4AvailableNetworkResourceModel) (int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	networkIDList := make([]int64, 0, len(data.NetworkIDList.Elements()))
+	diags.Append(data.NetworkIDList.ElementsAs(ctx, &networkIDList, false)...)
+	if diags.HasError() {
+		diags.AddError(
+			"Parsing network ids failed",
+			"",
+		)
+		return -1, diags
+	}
+
+	if len(networkIDList) == 0 {
+		diags.AddError(
+			"network_id_list cannot be empty",
+			"",
+		)
+		return -1, diags
+	}
+
+	filter, d := data.filter(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return -1, diags
+	}
+
+	result := int64(-1)
+
+	order := make([]int, len(networkIDList))
+	for i := range order {
+		order[i] = i
+	}
+
+	strategy := data.selectionStrategy()
+	if strategy == selectionStrategyRandom {
+		order = NewRand(data.Seed.ValueString()).Perm(len(networkIDList))
+	}
+
+	freeCount := int64(-1)
+	for _, i := range order {
+		id := networkIDList[i]
+
+		addressesFree, properties, found, d := getIP4NetworkFreeAddresses(client, usage, id, filter.accounting)
+		diags.Append(d...)
+		if diags.HasError() {
+			return -1, diags
+		}
+
+		if !found || !filter.matches(addressesFree, properties) {
+			continue
+		}
+
+		// random and first select the first matching network in their order
+		if strategy == selectionStrategyRandom || strategy == selectionStrategyFirst {
+			result = id
+			break
+		}
+
+		if freeCount == -1 ||
+			(strategy == selectionStrategyMostFree && addressesFree > freeCount) ||
+			(strategy == selectionStrategyLeastFree && addressesFree < freeCount) {
+			freeCount = addressesFree
+			result = id
+		}
+	}
+
+	if result == -1 {
+		diags.AddError(
+			"No networks had a free address",
+			fmt.Sprintf("No network in network_id_list has at least %d free addresses and matches udf_filters.", filter.minFreeAddresses),
+		)
+	}
+
+	return result, diags
+}
+
+// selectionStrategy returns the selection_strategy argument, or the strategy
+// implied by the deprecated random argument if it is not set.
+func (m *IP4AvailableNetworkResourceModel) selectionStrategy() string {
+	if !m.Strategy.IsNull() && !m.Strategy.IsUnknown() {
+		return m.Strategy.ValueString()
+	}
+
+	if m.Random.ValueBool() {
+		return selectionStrategyRandom
+	}
+
+	return selectionStrategyMostFree
+}
+
+// ip4NetworkFilter describes the networks bluecat_ip4_available_network may select.
+type ip4NetworkFilter struct {
+	minFreeAddresses int64
+	udfs             map[string]string
+	accounting       string
+}
+
+// filter returns the ip4NetworkFilter set by the min_free_addresses,
+// udf_filters, and free_address_accounting arguments.
+func (m *IP4AvailableNetworkResourceModel) filter(ctx context.Context) (ip4NetworkFilter, diag.Diagnostics) {
+	filter := ip4NetworkFilter{minFreeAddresses: 1, accounting: freeAddressAccountingTotal}
+	if !m.MinFree.IsNull() && !m.MinFree.IsUnknown() {
+		filter.minFreeAddresses = m.MinFree.ValueInt64()
+	}
+	if !m.Accounting.IsNull() && !m.Accounting.IsUnknown() {
+		filter.accounting = m.Accounting.ValueString()
+	}
+
+	diags := m.UDFFilters.ElementsAs(ctx, &filter.udfs, false)
+
+	return filter, diags
+}
+
+// matches returns true if a network with addressesFree free addresses and
+// the given properties can be selected.
+func (f ip4NetworkFilter) matches(addressesFree int64, properties map[string]string) bool {
+	if addressesFree < f.minFreeAddresses {
+		return false
+	}
+
+	for k, v := range f.udfs {
+		if properties[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getIP4NetworkFreeAddresses returns the number of free addresses, counted as
+// accounting describes, and the properties of the IPv4 network with the given
+// ID. found is false if the network no longer exists.
+func getIP4NetworkFreeAddresses(client gobam.ProteusAPI, usage *ip4NetworkUsageCache, id int64, accounting string) (int64, map[string]string, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	entity, err := client.GetEntityById(id)
+	if err != nil {
+		addBAMError(&diags, "Failed to get IP4 Network by Id", err, "")
+		return 0, nil, false, diags
+	}
+
+	if entity.Id == nil || *entity.Id == 0 || entity.Type == nil || *entity.Type != "IP4Network" || entity.Properties == nil {
+		return 0, nil, false, diags
+	}
+
+	networkProperties, d := parseIP4NetworkProperties(*entity.Properties)
+	diags.Append(d...)
+	if diags.HasError() {
+		return 0, nil, false, diags
+	}
+
+	addressesFree, err := getIP4NetworkFreeAddressCount(client, usage, *entity.Id, networkProperties.cidr.ValueString(), accounting)
+	if err != nil {
+		addBAMError(&diags, "Error calculating network usage", err, "")
+		return 0, nil, false, diags
+	}
+
+	return addressesFree, parseProperties(*entity.Properties), true, diags
+}
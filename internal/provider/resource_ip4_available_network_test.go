@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umich-vci/gobam"
+)
+
+// availableNetworkClient returns /29 networks with the given number of
+// addresses in use. Networks not in the map do not exist.
+type availableNetworkClient struct {
+	gobam.ProteusAPI
+	inUse map[int64]int
+}
+
+func (c *availableNetworkClient) GetEntityById(id int64) (*gobam.APIEntity, error) {
+	if _, ok := c.inUse[id]; !ok {
+		return &gobam.APIEntity{}, nil
+	}
+
+	objectType := "IP4Network"
+	properties := "CIDR=10.0.0.0/29|environment=prod|"
+	if id == 3 {
+		properties = "CIDR=10.0.0.0/29|environment=dev|"
+	}
+	return &gobam.APIEntity{Id: &id, Type: &objectType, Properties: &properties}, nil
+}
+
+func (c *availableNetworkClient) GetParent(entityId int64) (*gobam.APIEntity, error) {
+	return &gobam.APIEntity{}, nil
+}
+
+func (c *availableNetworkClient) GetEntities(parentId int64, _type string, start int, count int) (*gobam.APIEntityArray, error) {
+	return &gobam.APIEntityArray{Item: make([]*gobam.APIEntity, c.inUse[parentId])}, nil
+}
+
+func TestGetIP4NetworkFreeAddresses(t *testing.T) {
+	client := &availableNetworkClient{inUse: map[int64]int{1: 3}}
+
+	free, properties, found, diags := getIP4NetworkFreeAddresses(client, nil, 1, freeAddressAccountingTotal)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !found || free != 5 {
+		t.Errorf("expected 5 free addresses, got %d (found %t)", free, found)
+	}
+	if properties["environment"] != "prod" {
+		t.Errorf("expected the network properties, got %v", properties)
+	}
+
+	_, _, found, diags = getIP4NetworkFreeAddresses(client, nil, 2, freeAddressAccountingTotal)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if found {
+		t.Error("expected a deleted network to not be found")
+	}
+}
+
+func TestSelectIP4AvailableNetwork(t *testing.T) {
+	client := &availableNetworkClient{inUse: map[int64]int{1: 8, 2: 6, 3: 2}}
+	networkIDs, _ := types.ListValueFrom(context.Background(), types.Int64Type, []int64{1, 2, 3, 4})
+
+	data := &IP4AvailableNetworkResourceModel{NetworkIDList: networkIDs, Random: types.BoolValue(false), UDFFilters: types.MapNull(types.StringType)}
+	result, diags := selectIP4AvailableNetwork(context.Background(), client, nil, data)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if result != 3 {
+		t.Errorf("expected the network with the most free addresses, got %d", result)
+	}
+
+	data.Random = types.BoolValue(true)
+	data.Seed = types.StringValue("seed")
+	result, diags = selectIP4AvailableNetwork(context.Background(), client, nil, data)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if result != 2 && result != 3 {
+		t.Errorf("expected a network with a free address, got %d", result)
+	}
+
+	// filters exclude the network with the most free addresses
+	data.Random = types.BoolValue(false)
+	data.UDFFilters, _ = types.MapValueFrom(context.Background(), types.StringType, map[string]string{"environment": "prod"})
+	result, diags = selectIP4AvailableNetwork(context.Background(), client, nil, data)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if result != 2 {
+		t.Errorf("expected the network matching udf_filters, got %d", result)
+	}
+
+	data.MinFree = types.Int64Value(3)
+	if _, diags = selectIP4AvailableNetwork(context.Background(), client, nil, data); !diags.HasError() {
+		t.Error("expected an error when no network has enough free addresses")
+	}
+
+	data.MinFree = types.Int64Null()
+	data.UDFFilters = types.MapNull(types.StringType)
+	data.Random = types.BoolValue(true)
+	client.inUse = map[int64]int{1: 8}
+	if _, diags = selectIP4AvailableNetwork(context.Background(), client, nil, data); !diags.HasError() {
+		t.Error("expected an error when no network has a free address")
+	}
+}
+
+func TestSelectIP4AvailableNetworkStrategies(t *testing.T) {
+	client := &availableNetworkClient{inUse: map[int64]int{1: 8, 2: 6, 3: 2, 4: 7}}
+	networkIDs, _ := types.ListValueFrom(context.Background(), types.Int64Type, []int64{1, 2, 3, 4})
+
+	tests := map[string]int64{
+		selectionStrategyMostFree:  3,
+		selectionStrategyLeastFree: 4,
+		selectionStrategyFirst:     2,
+	}
+
+	for strategy, expected := range tests {
+		data := &IP4AvailableNetworkResourceModel{
+			NetworkIDList: networkIDs,
+			Strategy:      types.StringValue(strategy),
+			UDFFilters:    types.MapNull(types.StringType),
+		}
+
+		result, diags := selectIP4AvailableNetwork(context.Background(), client, nil, data)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		if result != expected {
+			t.Errorf("%s: expected network %d, got %d", strategy, expected, result)
+		}
+	}
+}
+
+func TestIP4AvailableNetworkSelectionStrategy(t *testing.T) {
+	data := &IP4AvailableNetworkResourceModel{Strategy: types.StringNull(), Random: types.BoolValue(true)}
+	if s := data.selectionStrategy(); s != selectionStrategyRandom {
+		t.Errorf("expected random to imply %q, got %q", selectionStrategyRandom, s)
+	}
+
+	data.Random = types.BoolValue(false)
+	if s := data.selectionStrategy(); s != selectionStrategyMostFree {
+		t.Errorf("expected the default %q, got %q", selectionStrategyMostFree, s)
+	}
+}
@@ -3,12 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
-	"math/big"
-	"slices"
 	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -24,13 +26,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/umich-vci/gobam"
-	"golang.org/x/exp/maps"
 )
 
+// ip4BlockCapacityPageSize is the number of child entities requested at a time when calculating block capacity.
+const ip4BlockCapacityPageSize = 1000
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &IP4BlockResource{}
 var _ resource.ResourceWithImportState = &IP4BlockResource{}
+var _ resource.ResourceWithModifyPlan = &IP4BlockResource{}
 
 func NewIP4BlockResource() resource.Resource {
 	return &IP4BlockResource{}
@@ -50,21 +54,22 @@ type IP4BlockResourceModel struct {
 	Properties types.String `tfsdk:"properties"`
 
 	// These are exposed via the entity properties field for objects of type IP4Block
-	CIDR                      types.String `tfsdk:"cidr"`
-	DefaultDomains            types.Set    `tfsdk:"default_domains"`
-	Start                     types.String `tfsdk:"start"`
-	End                       types.String `tfsdk:"end"`
-	DefaultView               types.Int64  `tfsdk:"default_view"`
-	DNSRestrictions           types.Set    `tfsdk:"dns_restrictions"`
-	AllowDuplicateHost        types.Bool   `tfsdk:"allow_duplicate_host"`
-	PingBeforeAssign          types.Bool   `tfsdk:"ping_before_assign"`
-	InheritAllowDuplicateHost types.Bool   `tfsdk:"inherit_allow_duplicate_host"`
-	InheritPingBeforeAssign   types.Bool   `tfsdk:"inherit_ping_before_assign"`
-	InheritDNSRestrictions    types.Bool   `tfsdk:"inherit_dns_restrictions"`
-	InheritDefaultDomains     types.Bool   `tfsdk:"inherit_default_domains"`
-	InheritDefaultView        types.Bool   `tfsdk:"inherit_default_view"`
-	LocationCode              types.String `tfsdk:"location_code"`
-	LocationInherited         types.Bool   `tfsdk:"location_inherited"`
+	CIDR                      ip4CIDRValue    `tfsdk:"cidr"`
+	DefaultDomains            types.Set       `tfsdk:"default_domains"`
+	Start                     ip4AddressValue `tfsdk:"start"`
+	End                       ip4AddressValue `tfsdk:"end"`
+	DefaultView               types.Int64     `tfsdk:"default_view"`
+	DNSRestrictions           types.Set       `tfsdk:"dns_restrictions"`
+	AllowDuplicateHost        types.Bool      `tfsdk:"allow_duplicate_host"`
+	PingBeforeAssign          types.Bool      `tfsdk:"ping_before_assign"`
+	InheritAllowDuplicateHost types.Bool      `tfsdk:"inherit_allow_duplicate_host"`
+	InheritPingBeforeAssign   types.Bool      `tfsdk:"inherit_ping_before_assign"`
+	InheritDNSRestrictions    types.Bool      `tfsdk:"inherit_dns_restrictions"`
+	InheritDefaultDomains     types.Bool      `tfsdk:"inherit_default_domains"`
+	InheritDefaultView        types.Bool      `tfsdk:"inherit_default_view"`
+	LocationCode              types.String    `tfsdk:"location_code"`
+	Comments                  types.String    `tfsdk:"comments"`
+	LocationInherited         types.Bool      `tfsdk:"location_inherited"`
 
 	// these are user defined fields that are not built-in
 	UserDefinedFields types.Map `tfsdk:"user_defined_fields"`
@@ -74,6 +79,29 @@ type IP4BlockResourceModel struct {
 	ParentID        types.Int64  `tfsdk:"parent_id"`
 	Size            types.Int64  `tfsdk:"size"`
 	TraversalMethod types.String `tfsdk:"traversal_method"`
+
+	// these are passed to the API when allocating the next available range
+	ExcludeDHCPRange     types.Bool      `tfsdk:"exclude_dhcp_range"`
+	Offset               ip4AddressValue `tfsdk:"offset"`
+	AllocationProperties types.Map       `tfsdk:"allocation_properties"`
+
+	// these are calculated from the children of the block
+	NetworkCount        types.Int64   `tfsdk:"network_count"`
+	AddressesAllocated  types.Int64   `tfsdk:"addresses_allocated"`
+	AllocatedPercentage types.Float64 `tfsdk:"allocated_percentage"`
+
+	// only used for creation
+	RollbackOnFailure types.Bool `tfsdk:"rollback_on_failure"`
+
+	// only used for deletion
+	ForceDelete types.Bool `tfsdk:"force_delete"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+
+	// limits how long creating, reading, and deleting may take
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *IP4BlockResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -86,6 +114,20 @@ func (r *IP4BlockResource) Schema(ctx context.Context, req resource.SchemaReques
 		MarkdownDescription: "Resource to create an IPv4 block.",
 
 		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"force_delete": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, the blocks and networks in the IPv4 block, and the addresses in them, are deleted with it. Otherwise deleting a block that still contains blocks or networks fails with an error listing them. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"rollback_on_failure": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, the IPv4 block is deleted again when it was allocated but could not be configured, so a failed apply does not leave it taking up space. Objects that were already in its range when it was allocated are never deleted. If `false`, or if it cannot be deleted, such as because it holds such objects, it is saved to the state as tainted and replaced on the next apply. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
 			// These are exposed for Entity objects via the API
 			"id": schema.StringAttribute{
 				MarkdownDescription: "IPv4 Block identifier.",
@@ -111,13 +153,16 @@ func (r *IP4BlockResource) Schema(ctx context.Context, req resource.SchemaReques
 			},
 			// These fields are only used for creation and are not exposed via the API entity
 			"is_larger_allowed": schema.BoolAttribute{
-				MarkdownDescription: "(Optional) Is it ok to return a block that is larger than the size specified?",
+				MarkdownDescription: "(Optional) Is it ok to return a block that is larger than the size specified? Cannot be used with `cidr` or `start` and `end`.",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.RequiresReplaceIf(ip4BlockIsLargerAllowedPlanModifier, ip4BlockIsLargerAllowedPlanModifierDescription, ip4BlockIsLargerAllowedPlanModifierDescription),
 				},
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("cidr"), path.MatchRoot("start")),
+				},
 			},
 			"parent_id": schema.Int64Attribute{
 				MarkdownDescription: "The object ID of the parent object that will contain the new IPv4 block. If this argument is changed, then the resource will be recreated.",
@@ -127,19 +172,52 @@ func (r *IP4BlockResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"size": schema.Int64Attribute{
-				MarkdownDescription: "The size of the IPv4 block expressed as a power of 2. For example, 256 would create a /24. If this argument is changed, then the resource will be recreated.",
-				Required:            true,
+				MarkdownDescription: "The size of the IPv4 block expressed as a power of 2. For example, 256 would create a /24. The next available block of this size will be allocated. Exactly one of `size`, `cidr`, or `start` and `end` must be set. When the block is created from `cidr` or `start` and `end`, this is the number of addresses in the block, which need not be a power of 2. If this argument is changed, then the resource will be recreated.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
 					int64planmodifier.RequiresReplace(),
 				},
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("cidr"), path.MatchRoot("start")),
+				},
+			},
+			"exclude_dhcp_range": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, ranges that overlap a DHCP range are not allocated. Changing this argument only affects where a new block is allocated, so it does not recreate the resource. Cannot be used with `cidr` or `start` and `end`.",
+				Optional:            true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("cidr"), path.MatchRoot("start")),
+				},
+			},
+			"offset": schema.StringAttribute{
+				MarkdownDescription: "The IPv4 address in the parent to start looking for the next available block at. Changing this argument only affects where a new block is allocated, so it does not recreate the resource. Cannot be used with `cidr` or `start` and `end`.",
+				Optional:            true,
+				CustomType:          ip4AddressType{},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("cidr"), path.MatchRoot("start")),
+				},
+			},
+			"allocation_properties": schema.MapAttribute{
+				MarkdownDescription: "Additional properties to pass to the API when allocating the next available block, for properties of `getNextAvailableIPRange` that have no argument of their own. Changing this argument only affects where a new block is allocated, so it does not recreate the resource. Cannot be used with `cidr` or `start` and `end`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(propertyNameRegexp, propertyNameRegexpMessage),
+						stringvalidator.NoneOf(reservedAllocationProperties...),
+					),
+					mapvalidator.ConflictsWith(path.MatchRoot("cidr"), path.MatchRoot("start")),
+				},
 			},
 			"traversal_method": schema.StringAttribute{
-				MarkdownDescription: "The traversal method used to find the range to allocate the block. Must be one of \"NO_TRAVERSAL\", \"DEPTH_FIRST\", or \"BREADTH_FIRST\".",
+				MarkdownDescription: "The traversal method used to find the range to allocate the block. " + enumDescription(traversalMethods) + " Cannot be used with `cidr` or `start` and `end`.",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("NO_TRAVERSAL"),
+				Default:             stringdefault.StaticString(traversalMethodNone),
 				Validators: []validator.String{
-					stringvalidator.OneOf("NO_TRAVERSAL", "DEPTH_FIRST", "BREADTH_FIRST"),
+					stringvalidator.OneOf(traversalMethods...),
+					stringvalidator.ConflictsWith(path.MatchRoot("cidr"), path.MatchRoot("start")),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplaceIf(ip4BlockTraversalMethodPlanModifier, ip4BlockTraversalMethodPlanModifierDescription, ip4BlockTraversalMethodPlanModifierDescription),
@@ -148,8 +226,14 @@ func (r *IP4BlockResource) Schema(ctx context.Context, req resource.SchemaReques
 
 			// These are exposed via the API properties field for objects of type IP4Block
 			"cidr": schema.StringAttribute{
-				MarkdownDescription: "The CIDR value of the block (if it forms a valid CIDR).",
+				MarkdownDescription: "The CIDR value of the block (if it forms a valid CIDR). If set, the block is created with this exact CIDR instead of allocating the next available block of `size`. If this argument is changed, then the resource will be recreated.",
 				Computed:            true,
+				Optional:            true,
+				CustomType:          ip4CIDRType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					ip4RequiresReplace(),
+				},
 			},
 			"default_domains": schema.SetAttribute{
 				MarkdownDescription: "The object ids of the default DNS domains.",
@@ -159,12 +243,31 @@ func (r *IP4BlockResource) Schema(ctx context.Context, req resource.SchemaReques
 				Default:             nil,
 			},
 			"start": schema.StringAttribute{
-				MarkdownDescription: "The start of the block (if it does not form a valid CIDR).",
+				MarkdownDescription: "The start of the block (if it does not form a valid CIDR). If set along with `end`, the block is created with exactly this range instead of allocating the next available block of `size`. If this argument is changed, then the resource will be recreated.",
 				Computed:            true,
+				Optional:            true,
+				CustomType:          ip4AddressType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					ip4RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("end")),
+					stringvalidator.ConflictsWith(path.MatchRoot("cidr")),
+				},
 			},
 			"end": schema.StringAttribute{
-				MarkdownDescription: "The end of the block (if it does not form a valid CIDR).",
+				MarkdownDescription: "The end of the block (if it does not form a valid CIDR). Must be set along with `start`. If this argument is changed, then the resource will be recreated.",
 				Computed:            true,
+				Optional:            true,
+				CustomType:          ip4AddressType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					ip4RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("start")),
+				},
 			},
 			"default_view": schema.Int64Attribute{
 				MarkdownDescription: "The object id of the default DNS View for the block.",
@@ -221,13 +324,20 @@ func (r *IP4BlockResource) Schema(ctx context.Context, req resource.SchemaReques
 				Optional:            true,
 				Default:             booldefault.StaticBool(true),
 			},
+			"comments": schema.StringAttribute{
+				MarkdownDescription: "Comments about the block.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"location_code": schema.StringAttribute{
-				MarkdownDescription: "The location code of the block.",
+				MarkdownDescription: "The location code of the block. The location must already exist, which is checked when planning.",
 				Computed:            true,
 				Optional:            true,
 				Default:             nil,
-				Validators:          []validator.String{
-					// The code is case-sensitive and must be in uppercase letters. The country code and child location code should be alphanumeric strings.
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(locationCodeRegexp, locationCodeRegexpMessage),
 				},
 			},
 			"location_inherited": schema.BoolAttribute{
@@ -240,7 +350,29 @@ func (r *IP4BlockResource) Schema(ctx context.Context, req resource.SchemaReques
 				Optional:            true,
 				Default:             mapdefault.StaticValue(basetypes.NewMapValueMust(types.StringType, nil)),
 				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(propertyNameRegexp, propertyNameRegexpMessage)),
+				},
 			},
+			"network_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of IPv4 networks that are direct children of the IP4 Block.",
+				Computed:            true,
+			},
+			"addresses_allocated": schema.Int64Attribute{
+				MarkdownDescription: "The number of addresses in the IP4 Block that are allocated to child IPv4 blocks and networks.",
+				Computed:            true,
+			},
+			"allocated_percentage": schema.Float64Attribute{
+				MarkdownDescription: "The percentage of the IP4 Block that is allocated to child IPv4 blocks and networks.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -266,6 +398,11 @@ func (r *IP4BlockResource) Configure(ctx context.Context, req resource.Configure
 }
 
 func (r *IP4BlockResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data *IP4BlockResourceModel
 
 	// Read Terraform plan data into the model
@@ -275,140 +412,243 @@ func (r *IP4BlockResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	createTimeout, diags := data.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	block, diag := r.allocate(ctx, client, data)
+	if block == nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(*block.Id, 10))
+	data.Properties = types.StringPointerValue(block.Properties)
+	data.Type = types.StringPointerValue(block.Type)
 
+	// the block exists from here on, so failures must either delete it or keep
+	// its ID in the state
+	var existing map[int64]bool
+	if !diag.HasError() && data.RollbackOnFailure.ValueBool() {
+		var err error
+		existing, err = getIP4ChildIDs(client, *block.Id, "IP4Block")
+		if err != nil {
+			addBAMError(&diag, "Failed to get the children of created IP4 Block", err, bamEntity("IP4 Block", data.ID.ValueString()))
+		}
+	}
+	if !diag.HasError() {
+		diag.Append(r.configure(ctx, client, data, *block.Id)...)
+	}
+	if diag.HasError() && data.RollbackOnFailure.ValueBool() {
+		// roll back even if the create timed out
+		cleanupCtx, cleanupCancel := cleanupContext(ctx)
+		defer cleanupCancel()
+		setClientContext(client, cleanupCtx)
+
+		err := rollbackIP4Create(client, *block.Id, "IP4Block", existing)
+		if err == nil {
+			diag.AddWarning(
+				fmt.Sprintf("Rolled back IP4 Block %s", data.ID.ValueString()),
+				"The IP4 Block was deleted because it could not be configured. Set rollback_on_failure to false to keep it in the state as tainted instead.",
+			)
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+		diag.AddWarning(fmt.Sprintf("Failed to roll back IP4 Block %s", data.ID.ValueString()), err.Error())
+	}
+	if !diag.HasError() {
+		diag.Append(r.refresh(ctx, client, data, *block.Id)...)
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		resp.Diagnostics.Append(setTaintedState(ctx, &resp.State, data, "IP4 Block", data.ID.ValueString())...)
 		return
 	}
 
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// allocate creates the static block requested by cidr or start and end, or
+// the next available block of size in the parent. The block is returned
+// along with any error once it exists so its ID is not lost.
+func (r *IP4BlockResource) allocate(ctx context.Context, client gobam.ProteusAPI, data *IP4BlockResourceModel) (*gobam.APIEntity, diag.Diagnostics) {
+	var diags diag.Diagnostics
 	parentID := data.ParentID.ValueInt64()
+
+	if (!data.CIDR.IsUnknown() && !data.CIDR.IsNull()) || (!data.Start.IsUnknown() && !data.Start.IsNull()) {
+		// a static CIDR or range was requested so create exactly that block
+		var blockID int64
+		var err error
+		if !data.CIDR.IsUnknown() && !data.CIDR.IsNull() {
+			blockID, err = client.AddIP4BlockByCIDR(parentID, data.CIDR.ValueIP4String(), "")
+		} else {
+			blockID, err = client.AddIP4BlockByRange(parentID, data.Start.ValueIP4String(), data.End.ValueIP4String(), "")
+		}
+		if err != nil {
+			addBAMError(&diags, "Failed to create IP4 Block", err, "")
+			return nil, diags
+		}
+
+		block, err := client.GetEntityById(blockID)
+		if err != nil {
+			addBAMError(&diags, "Failed to get IP4 Block by Id", err, "")
+			// the block was created, so keep its ID for the state
+			return &gobam.APIEntity{Id: &blockID}, diags
+		}
+
+		return block, diags
+	}
+
 	size := data.Size.ValueInt64()
 	isLargerAllowed := data.IsLargerAllowed.ValueBool()
 	traversalMethod := data.TraversalMethod.ValueString()
 	autoCreate := true     //we always want to create since this is a resource after all
 	reuseExisting := false //we never want to use an existing block created outside terraform
-	Type := "IP4Block"   //Since this is the ip4_block resource we are setting the type
-	properties := "reuseExisting=" + strconv.FormatBool(reuseExisting) + "|"
-	properties = properties + "isLargerAllowed=" + strconv.FormatBool(isLargerAllowed) + "|"
-	properties = properties + "autoCreate=" + strconv.FormatBool(autoCreate) + "|"
-	properties = properties + "traversalMethod=" + traversalMethod + "|"
-
-	block, err := client.GetNextAvailableIPRange(parentID, size, Type, properties)
+	Type := "IP4Block"     //Since this is the ip4_block resource we are setting the type
+	properties := propertyMap{}
+	properties.setBool("reuseExisting", reuseExisting)
+	properties.setBool("isLargerAllowed", isLargerAllowed)
+	properties.setBool("autoCreate", autoCreate)
+	properties.set("traversalMethod", traversalMethod)
+	diags.Append(setAllocationProperties(ctx, properties, data.ExcludeDHCPRange, data.Offset, data.AllocationProperties)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	block, err := retryAllocation(ctx, func() (*gobam.APIEntity, error) {
+		allocationMutex.Lock()
+		defer allocationMutex.Unlock()
+		return client.GetNextAvailableIPRange(parentID, size, Type, properties.String())
+	})
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to create IP4 Block",
-			err.Error(),
-		)
-		return
+		addBAMError(&diags, "Failed to create IP4 Block", err, "")
+		return nil, diags
 	}
 
-	data.ID = types.StringValue(strconv.FormatInt(*block.Id, 10))
-	data.Properties = types.StringPointerValue(block.Properties)
-	data.Type = types.StringPointerValue(block.Type)
-
-	// we have an ID at this point so save the state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return block, diags
+}
 
-	properties = ""
+// configure sets the name and properties of a newly allocated block.
+func (r *IP4BlockResource) configure(ctx context.Context, client gobam.ProteusAPI, data *IP4BlockResourceModel, id int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	properties := propertyMap{}
 
 	if !data.DefaultDomains.IsUnknown() {
 		var defaultDomains []string
 		data.DefaultDomains.ElementsAs(ctx, &defaultDomains, false)
-		properties = properties + "defaultDomains=" + strings.Join(defaultDomains, ",") + "|"
+		properties.setList("defaultDomains", defaultDomains)
 	}
 
 	if !data.DefaultView.IsUnknown() {
-		properties = properties + "defaultView=" + strconv.FormatInt(data.DefaultView.ValueInt64(), 10) + "|"
+		properties.setInt64("defaultView", data.DefaultView.ValueInt64())
 	}
 
 	if !data.DNSRestrictions.IsUnknown() {
 		var dnsRestrictions []string
 		data.DNSRestrictions.ElementsAs(ctx, &dnsRestrictions, false)
-		properties = properties + "dnsRestrictions=" + strings.Join(dnsRestrictions, ",") + "|"
+		properties.setList("dnsRestrictions", dnsRestrictions)
 	}
 
 	if !data.AllowDuplicateHost.IsUnknown() {
-		properties = properties + "allowDuplicateHost=" + boolToEnableDisable(data.AllowDuplicateHost.ValueBoolPointer()) + "|"
+		properties.set("allowDuplicateHost", boolToEnableDisable(data.AllowDuplicateHost.ValueBoolPointer()))
 	}
 
 	if !data.PingBeforeAssign.IsUnknown() {
-		properties = properties + "pingBeforeAssign=" + boolToEnableDisable(data.PingBeforeAssign.ValueBoolPointer()) + "|"
+		properties.set("pingBeforeAssign", boolToEnableDisable(data.PingBeforeAssign.ValueBoolPointer()))
 	}
 
 	if !data.InheritAllowDuplicateHost.IsUnknown() {
-		properties = properties + "inheritAllowDuplicateHost=" + strconv.FormatBool(data.InheritAllowDuplicateHost.ValueBool()) + "|"
+		properties.setBool("inheritAllowDuplicateHost", data.InheritAllowDuplicateHost.ValueBool())
 	}
 
 	if !data.InheritPingBeforeAssign.IsUnknown() {
-		properties = properties + "inheritPingBeforeAssign=" + strconv.FormatBool(data.InheritPingBeforeAssign.ValueBool()) + "|"
+		properties.setBool("inheritPingBeforeAssign", data.InheritPingBeforeAssign.ValueBool())
 	}
 
 	if !data.InheritDNSRestrictions.IsUnknown() {
-		properties = properties + "inheritDNSRestrictions=" + strconv.FormatBool(data.InheritDNSRestrictions.ValueBool()) + "|"
+		properties.setBool("inheritDNSRestrictions", data.InheritDNSRestrictions.ValueBool())
 	}
 
 	if !data.InheritDefaultDomains.IsUnknown() {
-		properties = properties + "inheritDefaultDomains=" + strconv.FormatBool(data.InheritDefaultDomains.ValueBool()) + "|"
+		properties.setBool("inheritDefaultDomains", data.InheritDefaultDomains.ValueBool())
 	}
 
 	if !data.InheritDefaultView.IsUnknown() {
-		properties = properties + "inheritDefaultView=" + strconv.FormatBool(data.InheritDefaultView.ValueBool()) + "|"
+		properties.setBool("inheritDefaultView", data.InheritDefaultView.ValueBool())
 	}
 
 	if !data.LocationCode.IsUnknown() {
-		properties = properties + "locationCode=" + data.LocationCode.ValueString() + "|"
+		properties.set("locationCode", data.LocationCode.ValueString())
+	}
+
+	if !data.Comments.IsNull() {
+		properties.set("comments", data.Comments.ValueString())
 	}
 
 	var udfs map[string]string
 	data.UserDefinedFields.ElementsAs(ctx, &udfs, false)
-	for k, v := range udfs {
-		properties = properties + k + "=" + v + "|"
-	}
+	properties.setAll(udfs)
+	properties.setManaged(r.client)
 
 	setName := gobam.APIEntity{
-		Id:         block.Id,
+		Id:         &id,
 		Name:       data.Name.ValueStringPointer(),
-		Properties: &properties,
+		Properties: properties.stringPointer(),
 		Type:       data.Type.ValueStringPointer(),
 	}
 
-	err = client.Update(&setName)
+	err := client.Update(&setName)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to update created IP4 Block",
-			err.Error(),
-		)
-
-		return
+		addBAMError(&diags, "Failed to update created IP4 Block", err, bamEntity("IP4 Block", data.ID.ValueString()))
 	}
 
-	entity, err := client.GetEntityById(*block.Id)
+	return diags
+}
+
+// refresh reads a newly created block back into data so computed attributes
+// are known.
+func (r *IP4BlockResource) refresh(ctx context.Context, client gobam.ProteusAPI, data *IP4BlockResourceModel, id int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	entity, err := client.GetEntityById(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to get IP4 Block by Id",
-			err.Error(),
-		)
-		return
+		addBAMError(&diags, "Failed to get IP4 Block by Id", err, bamEntity("IP4 Block", data.ID.ValueString()))
+		return diags
 	}
 
 	blockProperties, diag := flattenIP4BlockProperties(entity)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.Append(diag...)
-		return
+		diags.Append(diag...)
+		return diags
 	}
 
 	data.Name = types.StringPointerValue(entity.Name)
 	data.Properties = types.StringPointerValue(entity.Properties)
 	data.Type = types.StringPointerValue(entity.Type)
-	data.CIDR = blockProperties.CIDR
+	data.CIDR = newIP4CIDRFromString(blockProperties.CIDR)
 	data.DefaultDomains = blockProperties.DefaultDomains
-	data.Start = blockProperties.Start
-	data.End = blockProperties.End
+	data.Start = newIP4AddressFromString(blockProperties.Start)
+	data.End = newIP4AddressFromString(blockProperties.End)
 	data.DefaultView = blockProperties.DefaultView
 	data.DNSRestrictions = blockProperties.DNSRestrictions
 	data.AllowDuplicateHost = blockProperties.AllowDuplicateHost
@@ -419,17 +659,30 @@ func (r *IP4BlockResource) Create(ctx context.Context, req resource.CreateReques
 	data.InheritDefaultDomains = blockProperties.InheritDefaultDomains
 	data.InheritDefaultView = blockProperties.InheritDefaultView
 	data.LocationCode = blockProperties.LocationCode
+	data.Comments = blockProperties.Comments
 	data.LocationInherited = blockProperties.LocationInherited
-	data.UserDefinedFields = blockProperties.UserDefinedFields
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, blockProperties.UserDefinedFields, r.client)
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-
-	// Write logs using the tflog package
-	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, "created a resource")
+	networkCount, addressesAllocated, allocatedPercentage, err := getIP4BlockCapacity(entity, client)
+	if err != nil {
+		addBAMError(&diags, "Failed to calculate IP4 Block capacity", err, bamEntity("IP4 Block", data.ID.ValueString()))
+		return diags
+	}
+	data.NetworkCount = types.Int64Value(networkCount)
+	data.AddressesAllocated = types.Int64Value(addressesAllocated)
+	data.AllocatedPercentage = types.Float64Value(allocatedPercentage)
+
+	if data.Size.IsUnknown() {
+		// size is only computed when the block was created from a static CIDR or range
+		size, err := ip4EntitySize(entity)
+		if err != nil {
+			addBAMError(&diags, "Failed to calculate IP4 Block size", err, bamEntity("IP4 Block", data.ID.ValueString()))
+			return diags
+		}
+		data.Size = types.Int64Value(size)
+	}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return diags
 }
 
 func (r *IP4BlockResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -442,33 +695,45 @@ func (r *IP4BlockResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
-	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	readTimeout, diags := data.Timeouts.Read(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	ctx, cancel := withTimeout(ctx, readTimeout)
+	defer cancel()
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
 		return
 	}
 
 	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
 		return
 	}
 
 	entity, err := client.GetEntityById(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to get IP4 Block by Id",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Block by Id", err, bamEntity("IP4 Block", data.ID.ValueString()))
 		return
 	}
 
 	if *entity.Id == 0 {
 		tflog.Trace(ctx, "IP4 Block was deleted outside terraform")
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if ok, diag := entityTypeMatches(entity, "IP4Block"); !ok {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -479,15 +744,15 @@ func (r *IP4BlockResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	blockProperties, diag := flattenIP4BlockProperties(entity)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	data.CIDR = blockProperties.CIDR
+	data.CIDR = newIP4CIDRFromString(blockProperties.CIDR)
 	data.DefaultDomains = blockProperties.DefaultDomains
-	data.Start = blockProperties.Start
-	data.End = blockProperties.End
+	data.Start = newIP4AddressFromString(blockProperties.Start)
+	data.End = newIP4AddressFromString(blockProperties.End)
 	data.DefaultView = blockProperties.DefaultView
 	data.DNSRestrictions = blockProperties.DNSRestrictions
 	data.AllowDuplicateHost = blockProperties.AllowDuplicateHost
@@ -498,36 +763,60 @@ func (r *IP4BlockResource) Read(ctx context.Context, req resource.ReadRequest, r
 	data.InheritDefaultDomains = blockProperties.InheritDefaultDomains
 	data.InheritDefaultView = blockProperties.InheritDefaultView
 	data.LocationCode = blockProperties.LocationCode
+	data.Comments = blockProperties.Comments
 	data.LocationInherited = blockProperties.LocationInherited
-	data.UserDefinedFields = blockProperties.UserDefinedFields
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, blockProperties.UserDefinedFields, r.client)
+
+	networkCount, addressesAllocated, allocatedPercentage, err := getIP4BlockCapacity(entity, client)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to calculate IP4 Block capacity", err, bamEntity("IP4 Block", data.ID.ValueString()))
+		return
+	}
+	data.NetworkCount = types.Int64Value(networkCount)
+	data.AddressesAllocated = types.Int64Value(addressesAllocated)
+	data.AllocatedPercentage = types.Float64Value(allocatedPercentage)
 
 	// calculate the size of the block so we can set it in the state so import works
-	cidrNetmask, err := strconv.ParseInt(strings.Split(blockProperties.CIDR.ValueString(), "/")[1], 10, 64)
+	size, err := ip4EntitySize(entity)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to parse CIDR netmask to integer", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to calculate IP4 Block size", err, bamEntity("IP4 Block", data.ID.ValueString()))
 		return
 	}
-	var size, e = big.NewInt(2), big.NewInt(32 - cidrNetmask)
-	size.Exp(size, e, nil)
-	data.Size = types.Int64Value(size.Int64())
+	data.Size = types.Int64Value(size)
 
 	// get the parent id of the block so we can set it in the state so import works
 	parent, err := client.GetParent(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to get parent entity of IP4 Block", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get parent entity of IP4 Block", err, bamEntity("IP4 Block", data.ID.ValueString()))
 		return
 	}
 	data.ParentID = types.Int64Value(*parent.Id)
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	// imported or saved before force_delete was added
+	if data.ForceDelete.IsNull() {
+		data.ForceDelete = types.BoolValue(false)
+	}
+
+	// imported or saved before rollback_on_failure was added
+	if data.RollbackOnFailure.IsNull() {
+		data.RollbackOnFailure = types.BoolValue(true)
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IP4BlockResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data, state *IP4BlockResourceModel
 
 	// Read Terraform plan data into the model
@@ -539,26 +828,25 @@ func (r *IP4BlockResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-
+		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	properties := ""
+	properties := propertyMap{}
 
 	if !data.DefaultDomains.IsUnknown() && !data.DefaultDomains.Equal(state.DefaultDomains) {
 		var domains []string
 		data.DefaultDomains.ElementsAs(ctx, &domains, false)
 		if domains != nil {
-			properties = properties + fmt.Sprintf("defaultDomains=%s|", strings.Join(domains, ","))
+			properties.setList("defaultDomains", domains)
 		}
 	}
 
 	if !data.DefaultView.IsUnknown() && !data.DefaultView.Equal(state.DefaultView) {
 
-		properties = properties + fmt.Sprintf("defaultView=%s|", strconv.FormatInt(data.DefaultView.ValueInt64(), 10))
+		properties.setInt64("defaultView", data.DefaultView.ValueInt64())
 
 	}
 
@@ -566,67 +854,56 @@ func (r *IP4BlockResource) Update(ctx context.Context, req resource.UpdateReques
 		var dns []string
 		data.DNSRestrictions.ElementsAs(ctx, &dns, false)
 		if dns != nil {
-			properties = properties + fmt.Sprintf("dnsRestrictions=%s|", dns)
+			properties.setList("dnsRestrictions", dns)
 		}
 
 	}
 
 	if !data.AllowDuplicateHost.IsUnknown() && !data.AllowDuplicateHost.Equal(state.AllowDuplicateHost) {
-		properties = properties + fmt.Sprintf("allowDuplicateHost=%s|", boolToEnableDisable(data.AllowDuplicateHost.ValueBoolPointer()))
+		properties.set("allowDuplicateHost", boolToEnableDisable(data.AllowDuplicateHost.ValueBoolPointer()))
 
 	}
 
 	if !data.PingBeforeAssign.IsUnknown() && !data.PingBeforeAssign.Equal(state.PingBeforeAssign) {
-		properties = properties + fmt.Sprintf("pingBeforeAssign=%s|", boolToEnableDisable(data.PingBeforeAssign.ValueBoolPointer()))
+		properties.set("pingBeforeAssign", boolToEnableDisable(data.PingBeforeAssign.ValueBoolPointer()))
 	}
 
 	if !data.InheritAllowDuplicateHost.Equal(state.InheritAllowDuplicateHost) {
-		properties = properties + fmt.Sprintf("inheritAllowDuplicateHost=%s|", strconv.FormatBool(data.InheritAllowDuplicateHost.ValueBool()))
+		properties.setBool("inheritAllowDuplicateHost", data.InheritAllowDuplicateHost.ValueBool())
 	}
 
 	if !data.InheritPingBeforeAssign.Equal(state.InheritPingBeforeAssign) {
-		properties = properties + fmt.Sprintf("inheritPingBeforeAssign=%s|", strconv.FormatBool(data.InheritPingBeforeAssign.ValueBool()))
+		properties.setBool("inheritPingBeforeAssign", data.InheritPingBeforeAssign.ValueBool())
 	}
 
 	if !data.InheritDNSRestrictions.Equal(state.InheritDNSRestrictions) {
-		properties = properties + fmt.Sprintf("inheritDNSRestrictions=%s|", strconv.FormatBool(data.InheritDNSRestrictions.ValueBool()))
+		properties.setBool("inheritDNSRestrictions", data.InheritDNSRestrictions.ValueBool())
 	}
 
 	if !data.InheritDefaultDomains.Equal(state.InheritDefaultDomains) {
-		properties = properties + fmt.Sprintf("inheritDefaultDomains=%s|", strconv.FormatBool(data.InheritDefaultDomains.ValueBool()))
+		properties.setBool("inheritDefaultDomains", data.InheritDefaultDomains.ValueBool())
 
 	}
 
 	if !data.InheritDefaultView.Equal(state.InheritDefaultView) {
-		properties = properties + fmt.Sprintf("inheritDefaultView=%s|", strconv.FormatBool(data.InheritDefaultView.ValueBool()))
+		properties.setBool("inheritDefaultView", data.InheritDefaultView.ValueBool())
 	}
 
 	if !data.LocationCode.IsUnknown() && !data.LocationCode.Equal(state.LocationCode) {
-		properties = properties + fmt.Sprintf("locationCode=%s|", data.LocationCode.ValueString())
+		properties.set("locationCode", data.LocationCode.ValueString())
 	}
 
-	if !data.UserDefinedFields.Equal(state.UserDefinedFields) {
-		var udfs, oldudfs map[string]string
-		resp.Diagnostics.Append(data.UserDefinedFields.ElementsAs(ctx, &udfs, false)...)
-		resp.Diagnostics.Append(state.UserDefinedFields.ElementsAs(ctx, &oldudfs, false)...)
-
-		for k, v := range udfs {
-			properties = properties + fmt.Sprintf("%s=%s|", k, v)
-		}
-
-		// set keys that no longer exist to empty string
-		oldkeys := maps.Keys(oldudfs)
-		keys := maps.Keys(udfs)
-		for _, x := range oldkeys {
-			if !slices.Contains(keys, x) {
-				properties = properties + fmt.Sprintf("%s=|", x)
-			}
-		}
+	if !data.Comments.Equal(state.Comments) {
+		properties.set("comments", data.Comments.ValueString())
 	}
 
+	udfProperties, udfDiag := userDefinedFieldsUpdateProperties(ctx, data.UserDefinedFields, state.UserDefinedFields, r.client)
+	resp.Diagnostics.Append(udfDiag...)
+	properties.setAll(udfProperties)
+
 	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
 		return
 	}
@@ -634,7 +911,7 @@ func (r *IP4BlockResource) Update(ctx context.Context, req resource.UpdateReques
 	update := gobam.APIEntity{
 		Id:         &id,
 		Name:       data.Name.ValueStringPointer(),
-		Properties: &properties,
+		Properties: properties.stringPointer(),
 		Type:       state.Type.ValueStringPointer(),
 	}
 
@@ -642,21 +919,15 @@ func (r *IP4BlockResource) Update(ctx context.Context, req resource.UpdateReques
 
 	err = client.Update(&update)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"IP4 Block Update failed",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "IP4 Block Update failed", err, bamEntity("IP4 Block", data.ID.ValueString()))
 		return
 	}
 
 	entity, err := client.GetEntityById(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to get IP4 Block by Id",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Block by Id", err, bamEntity("IP4 Block", data.ID.ValueString()))
 		return
 	}
 
@@ -666,15 +937,15 @@ func (r *IP4BlockResource) Update(ctx context.Context, req resource.UpdateReques
 
 	blockProperties, diag := flattenIP4BlockProperties(entity)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	data.CIDR = blockProperties.CIDR
+	data.CIDR = newIP4CIDRFromString(blockProperties.CIDR)
 	data.DefaultDomains = blockProperties.DefaultDomains
-	data.Start = blockProperties.Start
-	data.End = blockProperties.End
+	data.Start = newIP4AddressFromString(blockProperties.Start)
+	data.End = newIP4AddressFromString(blockProperties.End)
 	data.DefaultView = blockProperties.DefaultView
 	data.DNSRestrictions = blockProperties.DNSRestrictions
 	data.AllowDuplicateHost = blockProperties.AllowDuplicateHost
@@ -685,16 +956,33 @@ func (r *IP4BlockResource) Update(ctx context.Context, req resource.UpdateReques
 	data.InheritDefaultDomains = blockProperties.InheritDefaultDomains
 	data.InheritDefaultView = blockProperties.InheritDefaultView
 	data.LocationCode = blockProperties.LocationCode
+	data.Comments = blockProperties.Comments
 	data.LocationInherited = blockProperties.LocationInherited
-	data.UserDefinedFields = blockProperties.UserDefinedFields
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, blockProperties.UserDefinedFields, r.client)
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	networkCount, addressesAllocated, allocatedPercentage, err := getIP4BlockCapacity(entity, client)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to calculate IP4 Block capacity", err, bamEntity("IP4 Block", data.ID.ValueString()))
+		return
+	}
+	data.NetworkCount = types.Int64Value(networkCount)
+	data.AddressesAllocated = types.Int64Value(addressesAllocated)
+	data.AllocatedPercentage = types.Float64Value(allocatedPercentage)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IP4BlockResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	resp.Diagnostics.Append(deleteProtectionCheck(r.client, "IP4Block")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data *IP4BlockResourceModel
 
 	// Read Terraform prior state data into the model
@@ -704,50 +992,77 @@ func (r *IP4BlockResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
-	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, deleteTimeout)
+	defer cancel()
 
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
 		return
 	}
 
 	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
 		return
 	}
 
 	entity, err := client.GetEntityById(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to get IP4 Block by Id",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Block by Id", err, bamEntity("IP4 Block", data.ID.ValueString()))
 		return
 	}
 
 	if *entity.Id == 0 {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		return
 	}
 
+	if data.ForceDelete.ValueBool() {
+		err = deleteIP4Children(client, id, "IP4Block")
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to delete the children of the IP4 Block", err, bamEntity("IP4 Block", data.ID.ValueString()))
+			return
+		}
+	}
+
 	err = client.Delete(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		fault := parseBAMFault(err)
+		detail := fault.detail(bamEntity("IP4 Block", data.ID.ValueString()))
+		// list what is in the way, as the API error does not say
+		children, childErr := getIP4BlockingChildren(client, id, "IP4Block")
+		if childErr == nil && len(children) > 0 {
+			detail += "\n\n" + ip4BlockingChildrenDetail("IP4Block", children)
+		}
+
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError(
-			"Delete failed",
-			err.Error(),
+			fault.summary("Delete failed"),
+			detail,
 		)
 		return
 	}
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+func (r *IP4BlockResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanUserDefinedFields(ctx, r.client, "IP4Block", req, resp)
+	modifyPlanLocationCode(ctx, r.client, req, resp)
 }
 
 func (r *IP4BlockResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importIP4Range(ctx, r.client, "IP4Block", req, resp)
 }
 
 func (r IP4BlockResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -886,4 +1201,43 @@ func ip4BlockTraversalMethodPlanModifier(ctx context.Context, p planmodifier.Str
 	resp.RequiresReplace = true
 }
 
+// getIP4BlockCapacity returns the number of child networks in an IP4 Block, the number of
+// addresses allocated to child blocks and networks, and the percentage of the block allocated.
+func getIP4BlockCapacity(block *gobam.APIEntity, client gobam.ProteusAPI) (int64, int64, float64, error) {
+	blockSize, err := ip4EntitySize(block)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	networkCount := int64(0)
+	addressesAllocated := int64(0)
+	for _, objectType := range []string{"IP4Block", "IP4Network"} {
+		start := 0
+		for {
+			children, err := client.GetEntities(*block.Id, objectType, start, ip4BlockCapacityPageSize)
+			if err != nil {
+				return 0, 0, 0, err
+			}
+
+			for _, child := range children.Item {
+				childSize, err := ip4EntitySize(child)
+				if err != nil {
+					return 0, 0, 0, fmt.Errorf("failed to calculate size of %s %d: %w", objectType, *child.Id, err)
+				}
+				addressesAllocated += childSize
+				if objectType == "IP4Network" {
+					networkCount++
+				}
+			}
 
+			if len(children.Item) < ip4BlockCapacityPageSize {
+				break
+			}
+			start += ip4BlockCapacityPageSize
+		}
+	}
+
+	allocatedPercentage := float64(addressesAllocated) / float64(blockSize) * 100
+
+	return networkCount, addressesAllocated, allocatedPercentage, nil
+}
@@ -1,9 +1,18 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"regexp"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/umich-vci/gobam"
 )
 
 func TestAccIP4BlockResource(t *testing.T) {
@@ -17,8 +26,84 @@ func TestAccIP4BlockResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrWith("bluecat_ip4_block.test", "id", validateObjectID),
 					resource.TestCheckResourceAttr("bluecat_ip4_block.test", "name", "Test IPv4 Block"),
+					resource.TestCheckResourceAttr("bluecat_ip4_block.test", "network_count", "0"),
+					resource.TestCheckResourceAttr("bluecat_ip4_block.test", "addresses_allocated", "0"),
+					resource.TestCheckResourceAttr("bluecat_ip4_block.test", "allocated_percentage", "0"),
 				),
 			},
+			// ImportState testing by CIDR
+			{
+				ResourceName:      "bluecat_ip4_block.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccIP4BlockImportStateIdFunc,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["name"] != "Test IPv4 Block" {
+						return fmt.Errorf("expected the test block to be imported, got: %v", states)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccIP4BlockImportStateIdFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["bluecat_ip4_block.test"]
+	if !ok {
+		return "", fmt.Errorf("resource not found in state")
+	}
+
+	return fmt.Sprintf("%s/%s", rs.Primary.Attributes["parent_id"], rs.Primary.Attributes["cidr"]), nil
+}
+
+func TestAccIP4BlockResourceRange(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccIP4BlockResourceRangeConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("bluecat_ip4_block.test", "id", validateObjectID),
+					resource.TestCheckNoResourceAttr("bluecat_ip4_block.test", "cidr"),
+					resource.TestCheckResourceAttrSet("bluecat_ip4_block.test", "size"),
+				),
+			},
+			// ImportState testing by range
+			{
+				ResourceName:      "bluecat_ip4_block.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccIP4BlockRangeImportStateIdFunc,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["name"] != "Test IPv4 Range Block" {
+						return fmt.Errorf("expected the test block to be imported, got: %v", states)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccIP4BlockRangeImportStateIdFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["bluecat_ip4_block.test"]
+	if !ok {
+		return "", fmt.Errorf("resource not found in state")
+	}
+
+	return fmt.Sprintf("%s/%s-%s", rs.Primary.Attributes["parent_id"], rs.Primary.Attributes["start"], rs.Primary.Attributes["end"]), nil
+}
+
+func TestAccIP4BlockResourceReadOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIP4BlockResourceReadOnlyConfig,
+				ExpectError: regexp.MustCompile("Provider is in read-only mode"),
+			},
 		},
 	})
 }
@@ -34,3 +119,111 @@ resource "bluecat_ip4_block" "test" {
 	size      = 256
   }
 `
+
+const testAccIP4BlockResourceRangeConfig = `
+variable "ip4_block_parent_id" {
+  type = number
+}
+
+variable "ip4_block_range_start" {
+  type = string
+}
+
+variable "ip4_block_range_end" {
+  type = string
+}
+
+resource "bluecat_ip4_block" "test" {
+  parent_id = var.ip4_block_parent_id
+  name      = "Test IPv4 Range Block"
+  start     = var.ip4_block_range_start
+  end       = var.ip4_block_range_end
+}
+`
+
+const testAccIP4BlockResourceReadOnlyConfig = `
+provider "bluecat" {
+  read_only = true
+}
+
+variable "ip4_block_parent_id" {
+  type = number
+}
+
+resource "bluecat_ip4_block" "test" {
+  parent_id = var.ip4_block_parent_id
+  name      = "Test IPv4 Block"
+  size      = 256
+}
+`
+
+func TestIP4EntitySize(t *testing.T) {
+	tests := map[string]struct {
+		properties string
+		expected   int64
+		wantErr    bool
+	}{
+		"cidr":       {properties: "CIDR=10.10.0.0/24|", expected: 256},
+		"range":      {properties: "start=10.10.48.0|end=10.10.48.99|", expected: 100},
+		"empty cidr": {properties: "CIDR=|start=10.10.48.0|end=10.10.48.99|", expected: 100},
+		"no range":   {properties: "name=test|", wantErr: true},
+		"end first":  {properties: "start=10.10.48.99|end=10.10.48.0|", wantErr: true},
+		"no end":     {properties: "start=10.10.48.0|", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			size, err := ip4EntitySize(&gobam.APIEntity{Properties: &tc.properties})
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %d", size)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if size != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, size)
+			}
+		})
+	}
+}
+
+func TestSetTaintedState(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	(&IP4BlockResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	// a planned block with every attribute still unknown
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, tftypes.UnknownValue)
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+
+	var data *IP4BlockResourceModel
+	if diags := plan.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	data.ID = types.StringValue("1234")
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	diags := setTaintedState(ctx, &state, data, "IP4 Block", data.ID.ValueString())
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "IP4 Block 1234 was created but not fully configured" {
+		t.Fatalf("expected only the tainted error, got %v", diags)
+	}
+	if !state.Raw.IsFullyKnown() {
+		t.Errorf("expected unknown values to be saved as null")
+	}
+
+	var saved *IP4BlockResourceModel
+	if diags := state.Get(ctx, &saved); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if saved.ID.ValueString() != "1234" || !saved.CIDR.IsNull() {
+		t.Errorf("expected ID 1234 and a null cidr, got %s and %s", saved.ID, saved.CIDR)
+	}
+}
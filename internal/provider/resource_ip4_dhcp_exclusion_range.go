@@ -0,0 +1,510 @@
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// ip4DHCPExclusionRangeMaxSize limits how many addresses a single exclusion
+// range can hold, as every address is reserved separately.
+const ip4DHCPExclusionRangeMaxSize = 1024
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IP4DHCPExclusionRangeResource{}
+
+func NewIP4DHCPExclusionRangeResource() resource.Resource {
+	return &IP4DHCPExclusionRangeResource{}
+}
+
+// IP4DHCPExclusionRangeResource defines the resource implementation.
+type IP4DHCPExclusionRangeResource struct {
+	client *loginClient
+}
+
+// IP4DHCPExclusionRangeResourceModel describes the resource data model.
+type IP4DHCPExclusionRangeResourceModel struct {
+	ID              types.String    `tfsdk:"id"`
+	ConfigurationID types.Int64     `tfsdk:"configuration_id"`
+	RangeID         types.Int64     `tfsdk:"range_id"`
+	Start           ip4AddressValue `tfsdk:"start"`
+	End             ip4AddressValue `tfsdk:"end"`
+	Name            types.String    `tfsdk:"name"`
+	RangeStart      types.String    `tfsdk:"range_start"`
+	RangeEnd        types.String    `tfsdk:"range_end"`
+	AddressIDs      types.List      `tfsdk:"address_ids"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *IP4DHCPExclusionRangeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip4_dhcp_exclusion_range"
+}
+
+func (r *IP4DHCPExclusionRangeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource to exclude a range of addresses inside an IPv4 DHCP range from being leased, such as a pool of printers with static addresses. Every address of the exclusion is reserved, which BlueCat Address Manager leaves out of the range when it is deployed. If any of the addresses is deleted outside of Terraform, all of them are reserved again.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the exclusion range, which is `<range_id>:<start>-<end>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"configuration_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the Configuration that holds the DHCP range." + defaultConfigurationDescription + " If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"range_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the IPv4 DHCP range to exclude the addresses from. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"start": schema.StringAttribute{
+				MarkdownDescription: "The first IPv4 address to exclude. It must be inside the DHCP range. If changed, forces a new resource.",
+				Required:            true,
+				CustomType:          ip4AddressType{},
+				PlanModifiers: []planmodifier.String{
+					ip4RequiresReplace(),
+				},
+			},
+			"end": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The last IPv4 address to exclude. It must be inside the DHCP range, and the exclusion can hold at most %d addresses. If changed, forces a new resource.", ip4DHCPExclusionRangeMaxSize),
+				Required:            true,
+				CustomType:          ip4AddressType{},
+				PlanModifiers: []planmodifier.String{
+					ip4DHCPExclusionRangeEndPlanModifier{},
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the excluded addresses.",
+				Optional:            true,
+			},
+			"range_start": schema.StringAttribute{
+				MarkdownDescription: "The first address of the DHCP range.",
+				Computed:            true,
+			},
+			"range_end": schema.StringAttribute{
+				MarkdownDescription: "The last address of the DHCP range.",
+				Computed:            true,
+			},
+			"address_ids": schema.ListAttribute{
+				MarkdownDescription: "The object IDs of the excluded addresses, from `start` to `end`.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *IP4DHCPExclusionRangeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IP4DHCPExclusionRangeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4DHCPExclusionRangeResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	configID, diag := r.client.configurationID(client, data.ConfigurationID)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	data.ConfigurationID = types.Int64Value(configID)
+
+	rangeStart, rangeEnd, diag := getIP4DHCPRangeBounds(client, data.RangeID.ValueInt64())
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	addresses, err := ip4DHCPExclusionRangeAddresses(data.Start.ValueIP4String(), data.End.ValueIP4String(), rangeStart, rangeEnd)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddAttributeError(path.Root("end"), "Invalid exclusion range", err.Error())
+		return
+	}
+
+	properties := propertyMap{}
+	if !data.Name.IsNull() {
+		properties.set("name", data.Name.ValueString())
+	}
+	properties.setManaged(r.client)
+
+	// hold the allocation lock so that other resources cannot take addresses
+	// of the exclusion while it is reserved
+	ids := make([]int64, 0, len(addresses))
+	allocationMutex.Lock()
+	for _, address := range addresses {
+		id, err := client.AssignIP4Address(configID, address, "", "", ipAssignmentActionReserved, properties.String())
+		if err != nil {
+			addBAMError(&diag, "AssignIP4Address failed", err, "IP4 Address "+address)
+			break
+		}
+		ids = append(ids, id)
+	}
+	allocationMutex.Unlock()
+	if diag.HasError() {
+		// release the addresses that were reserved so they are not left behind
+		for _, id := range ids {
+			if err := client.Delete(id); err != nil {
+				resp.Diagnostics.AddWarning("Failed to release IP4 Address after a failed exclusion", fmt.Sprintf("IP4 Address %d must be deleted manually: %s", id, err.Error()))
+			}
+		}
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	data.ID = types.StringValue(fmt.Sprintf("%d:%s-%s", data.RangeID.ValueInt64(), data.Start.ValueIP4String(), data.End.ValueIP4String()))
+	data.RangeStart = types.StringValue(rangeStart)
+	data.RangeEnd = types.StringValue(rangeEnd)
+	data.AddressIDs, diag = types.ListValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diag...)
+
+	tflog.Trace(ctx, "created a resource", map[string]interface{}{"addresses": len(ids)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4DHCPExclusionRangeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *IP4DHCPExclusionRangeResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []int64
+	resp.Diagnostics.Append(data.AddressIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	dhcpRange, err := client.GetEntityById(data.RangeID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get DHCP4 Range by Id", err, bamEntity("DHCP Exclusion Range", data.ID.ValueString()))
+		return
+	}
+
+	if dhcpRange.Id == nil || *dhcpRange.Id == 0 {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if ok, diag := entityTypeMatches(dhcpRange, "DHCP4Range"); !ok {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// forget the addresses that were deleted outside of Terraform, which
+	// makes end plan to reserve all of them again
+	var found []int64
+	for _, id := range ids {
+		entity, err := client.GetEntityById(id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id", err, bamEntity("DHCP Exclusion Range", data.ID.ValueString()))
+			return
+		}
+
+		if entity.Id == nil || *entity.Id == 0 {
+			continue
+		}
+
+		if ok, diag := entityTypeMatches(entity, "IP4Address"); !ok {
+			resp.Diagnostics.Append(diag...)
+			continue
+		}
+
+		found = append(found, id)
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	if len(found) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if dhcpRange.Properties != nil {
+		rangeProperties := parseProperties(*dhcpRange.Properties)
+		data.RangeStart = types.StringValue(rangeProperties["start"])
+		data.RangeEnd = types.StringValue(rangeProperties["end"])
+	}
+	data.AddressIDs, diag = types.ListValueFrom(ctx, types.Int64Type, found)
+	resp.Diagnostics.Append(diag...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4DHCPExclusionRangeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4DHCPExclusionRangeResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []int64
+	resp.Diagnostics.Append(data.AddressIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	// only name can be changed without reserving the addresses again
+	for _, id := range ids {
+		entity, err := client.GetEntityById(id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id", err, bamEntity("DHCP Exclusion Range", data.ID.ValueString()))
+			return
+		}
+
+		entity.Name = data.Name.ValueStringPointer()
+		if err := client.Update(entity); err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "IP4 Address Update failed", err, bamEntity("DHCP Exclusion Range", data.ID.ValueString()))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4DHCPExclusionRangeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	resp.Diagnostics.Append(deleteProtectionCheck(r.client, "IP4Address")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4DHCPExclusionRangeResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []int64
+	resp.Diagnostics.Append(data.AddressIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	for _, id := range ids {
+		entity, err := client.GetEntityById(id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id", err, bamEntity("DHCP Exclusion Range", data.ID.ValueString()))
+			return
+		}
+
+		if entity.Id == nil || *entity.Id == 0 {
+			continue
+		}
+
+		if err := client.Delete(id); err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "IP4 Address Delete failed", err, bamEntity("DHCP Exclusion Range", data.ID.ValueString()))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+// ip4DHCPExclusionRangeEndPlanModifier requires replacement if end changes or
+// any of the excluded addresses was deleted outside of Terraform, so that all
+// of them are reserved again.
+type ip4DHCPExclusionRangeEndPlanModifier struct{}
+
+func (m ip4DHCPExclusionRangeEndPlanModifier) Description(ctx context.Context) string {
+	return "Changing end, or deleting any of the excluded addresses outside of Terraform, reserves all of the addresses again."
+}
+
+func (m ip4DHCPExclusionRangeEndPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m ip4DHCPExclusionRangeEndPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if !ip4ValuesEqual(req.PlanValue.ValueString(), req.StateValue.ValueString()) {
+		resp.RequiresReplace = true
+		return
+	}
+
+	var start ip4AddressValue
+	var addressIDs types.List
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("start"), &start)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("address_ids"), &addressIDs)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	size, err := ip4RangeSize(start.ValueIP4String(), req.StateValue.ValueString())
+	if err != nil {
+		return
+	}
+
+	resp.RequiresReplace = int64(len(addressIDs.Elements())) != size
+}
+
+// getIP4DHCPRangeBounds returns the first and last address of the IPv4 DHCP
+// range rangeID.
+func getIP4DHCPRangeBounds(client gobam.ProteusAPI, rangeID int64) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	entity, err := client.GetEntityById(rangeID)
+	if err != nil {
+		addBAMError(&diags, "Failed to get DHCP4 Range by Id", err, "")
+		return "", "", diags
+	}
+
+	if entity.Id == nil || *entity.Id == 0 || entity.Type == nil || *entity.Type != "DHCP4Range" || entity.Properties == nil {
+		diags.AddAttributeError(path.Root("range_id"), "Invalid DHCP range", fmt.Sprintf("Object %d is not an IPv4 DHCP range", rangeID))
+		return "", "", diags
+	}
+
+	properties := parseProperties(*entity.Properties)
+
+	return properties["start"], properties["end"], diags
+}
+
+// ip4DHCPExclusionRangeAddresses returns the addresses from start to end,
+// checking that they are all inside the DHCP range from rangeStart to
+// rangeEnd and that there are no more than ip4DHCPExclusionRangeMaxSize.
+func ip4DHCPExclusionRangeAddresses(start, end, rangeStart, rangeEnd string) ([]string, error) {
+	size, err := ip4RangeSize(start, end)
+	if err != nil {
+		return nil, err
+	}
+	if size > ip4DHCPExclusionRangeMaxSize {
+		return nil, fmt.Errorf("the exclusion range %s-%s has %d addresses, more than the %d allowed", start, end, size, ip4DHCPExclusionRangeMaxSize)
+	}
+
+	if _, err := ip4RangeSize(rangeStart, start); err != nil {
+		return nil, fmt.Errorf("%s is not inside the DHCP range %s-%s", start, rangeStart, rangeEnd)
+	}
+	if _, err := ip4RangeSize(end, rangeEnd); err != nil {
+		return nil, fmt.Errorf("%s is not inside the DHCP range %s-%s", end, rangeStart, rangeEnd)
+	}
+
+	first := binary.BigEndian.Uint32(net.ParseIP(start).To4())
+	addresses := make([]string, 0, size)
+	for i := range uint32(size) {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, first+i)
+		addresses = append(addresses, ip.String())
+	}
+
+	return addresses, nil
+}
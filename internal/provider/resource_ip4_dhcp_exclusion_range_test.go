@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIP4DHCPExclusionRangeResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccIP4DHCPExclusionRangeResourceConfig("tfacc-printers"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bluecat_ip4_dhcp_exclusion_range.test", "range_start"),
+					resource.TestCheckResourceAttrSet("bluecat_ip4_dhcp_exclusion_range.test", "range_end"),
+					resource.TestCheckResourceAttrSet("bluecat_ip4_dhcp_exclusion_range.test", "address_ids.0"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccIP4DHCPExclusionRangeResourceConfig("tfacc-printers-renamed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_ip4_dhcp_exclusion_range.test", "name", "tfacc-printers-renamed"),
+				),
+			},
+		},
+	})
+}
+
+func testAccIP4DHCPExclusionRangeResourceConfig(name string) string {
+	return testAccEntityDataSourceConfig + fmt.Sprintf(`
+variable "ip4_dhcp_range_id" {
+	type = number
+}
+
+variable "ip4_dhcp_exclusion_start" {
+	type = string
+}
+
+variable "ip4_dhcp_exclusion_end" {
+	type = string
+}
+
+resource "bluecat_ip4_dhcp_exclusion_range" "test" {
+  configuration_id = data.bluecat_entity.config.id
+  range_id         = var.ip4_dhcp_range_id
+  start            = var.ip4_dhcp_exclusion_start
+  end              = var.ip4_dhcp_exclusion_end
+  name             = %q
+}
+`, name)
+}
+
+func TestIP4DHCPExclusionRangeAddresses(t *testing.T) {
+	addresses, err := ip4DHCPExclusionRangeAddresses("10.0.0.254", "10.0.1.1", "10.0.0.10", "10.0.1.200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(addresses, ","); got != "10.0.0.254,10.0.0.255,10.0.1.0,10.0.1.1" {
+		t.Errorf("expected every address from start to end, got %s", got)
+	}
+
+	if _, err := ip4DHCPExclusionRangeAddresses("10.0.0.5", "10.0.0.20", "10.0.0.10", "10.0.0.200"); err == nil {
+		t.Error("expected an error when start is before the DHCP range")
+	}
+
+	if _, err := ip4DHCPExclusionRangeAddresses("10.0.0.100", "10.0.0.201", "10.0.0.10", "10.0.0.200"); err == nil {
+		t.Error("expected an error when end is after the DHCP range")
+	}
+
+	if _, err := ip4DHCPExclusionRangeAddresses("10.0.0.20", "10.0.0.10", "10.0.0.10", "10.0.0.200"); err == nil {
+		t.Error("expected an error when end is before start")
+	}
+
+	if _, err := ip4DHCPExclusionRangeAddresses("10.0.0.0", "10.0.8.0", "10.0.0.0", "10.0.255.255"); err == nil {
+		t.Error("expected an error when the exclusion is too large")
+	}
+}
@@ -0,0 +1,603 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IP4DHCPReservationResource{}
+var _ resource.ResourceWithImportState = &IP4DHCPReservationResource{}
+
+func NewIP4DHCPReservationResource() resource.Resource {
+	return &IP4DHCPReservationResource{}
+}
+
+// IP4DHCPReservationResource defines the resource implementation.
+type IP4DHCPReservationResource struct {
+	client *loginClient
+}
+
+// IP4DHCPReservationResourceModel describes the resource data model.
+type IP4DHCPReservationResourceModel struct {
+	// These are exposed for a generic entity object in bluecat
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Type       types.String `tfsdk:"type"`
+	Properties types.String `tfsdk:"properties"`
+
+	// These are exposed via the entity properties field for objects of type IP4Address
+	Address    ip4AddressValue `tfsdk:"address"`
+	State      types.String    `tfsdk:"state"`
+	MACAddress types.String    `tfsdk:"mac_address"`
+
+	// these are user defined fields that are not built-in
+	UserDefinedFields types.Map `tfsdk:"user_defined_fields"`
+
+	// These fields are only used for creation
+	ConfigurationID types.Int64  `tfsdk:"configuration_id"`
+	ParentID        types.Int64  `tfsdk:"parent_id"`
+	Hostname        types.String `tfsdk:"hostname"`
+	ViewID          types.Int64  `tfsdk:"view_id"`
+	ReverseRecord   types.Bool   `tfsdk:"reverse_record"`
+
+	// this is the host record created from hostname
+	HostRecordID types.Int64 `tfsdk:"host_record_id"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *IP4DHCPReservationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip4_dhcp_reservation"
+}
+
+func (r *IP4DHCPReservationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource to create a DHCP reserved IPv4 address for a MAC address in one step, optionally with a host record.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			// These are exposed for Entity objects via the API
+			"id": schema.StringAttribute{
+				MarkdownDescription: "IPv4 Address identifier.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the IPv4 address.",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the resource.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"properties": schema.StringAttribute{
+				MarkdownDescription: "The properties of the resource as returned by the API (pipe delimited).",
+				Computed:            true,
+			},
+			"configuration_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the Configuration that will hold the new address." + defaultConfigurationDescription + " If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplaceIf(ip4DHCPReservationConfigurationIDPlanModifier, ip4DHCPReservationConfigurationIDPlanModifierDescription, ip4DHCPReservationConfigurationIDPlanModifierDescription),
+				},
+			},
+			"parent_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the Configuration, Block, or Network to find the next available IPv4 address in. Exactly one of `parent_id` or `address` must be set. If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("address")),
+				},
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "The IPv4 address to reserve. If not set, the next available address in `parent_id` is reserved. If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				CustomType:          ip4AddressType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					ip4RequiresReplace(),
+				},
+			},
+			"mac_address": schema.StringAttribute{
+				MarkdownDescription: "The MAC address the IPv4 address is reserved for. Differences in format from the MAC address returned by BlueCat Address Manager, such as colons instead of dashes, are ignored.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(macAddressRegexp, macAddressRegexpMessage),
+				},
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "The state of the IPv4 address.",
+				Computed:            true,
+			},
+			"hostname": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified name of a host record to create for the address. Requires `view_id`. If changed, forces a new resource.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("view_id")),
+				},
+			},
+			"view_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the View to create the host record in. If changed, forces a new resource.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("hostname")),
+				},
+			},
+			"reverse_record": schema.BoolAttribute{
+				MarkdownDescription: "If a reverse record should be created for the host record. If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"host_record_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the host record created from `hostname`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_defined_fields": schema.MapAttribute{
+				MarkdownDescription: "A map of all user-definied fields associated with the IPv4 address.",
+				Computed:            true,
+				Optional:            true,
+				Default:             mapdefault.StaticValue(basetypes.NewMapValueMust(types.StringType, nil)),
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(propertyNameRegexp, propertyNameRegexpMessage)),
+				},
+			},
+		},
+	}
+}
+
+func (r *IP4DHCPReservationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IP4DHCPReservationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4DHCPReservationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	configID, diag := r.client.configurationID(client, data.ConfigurationID)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	data.ConfigurationID = types.Int64Value(configID)
+
+	macAddress := data.MACAddress.ValueString()
+	action := ipAssignmentActionDHCPReserved
+	name := data.Name.ValueString()
+	properties := propertyMap{}
+	properties.set("name", name)
+
+	hostInfo := ip4AddressHostInfo(data.Hostname, data.ViewID, data.ReverseRecord)
+
+	var udfs map[string]string
+	data.UserDefinedFields.ElementsAs(ctx, &udfs, false)
+	properties.setAll(udfs)
+	properties.setManaged(r.client)
+
+	var id int64
+	if !data.Address.IsUnknown() && !data.Address.IsNull() {
+		var err error
+		id, err = client.AssignIP4Address(configID, data.Address.ValueIP4String(), macAddress, hostInfo, action, properties.String())
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "AssignIP4Address failed", err, "")
+			return
+		}
+	} else {
+		allocationMutex.Lock()
+		ip, err := client.AssignNextAvailableIP4Address(configID, data.ParentID.ValueInt64(), macAddress, hostInfo, action, properties.String())
+		allocationMutex.Unlock()
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "AssignNextAvailableIP4Address failed", err, "")
+			return
+		}
+		id = *ip.Id
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(id, 10))
+
+	entity, err := client.GetEntityById(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id after creation", err, bamEntity("DHCP Reservation", data.ID.ValueString()))
+		return
+	}
+
+	data.Name = types.StringPointerValue(entity.Name)
+	data.Properties = types.StringPointerValue(entity.Properties)
+	data.Type = types.StringPointerValue(entity.Type)
+
+	addressProperties, diag := flattenIP4AddressProperties(entity)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	data.Address = newIP4AddressFromString(addressProperties.Address)
+	data.State = addressProperties.State
+	data.MACAddress = macAddressValue(data.MACAddress, addressProperties.MACAddress)
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, addressProperties.UserDefinedFields, r.client)
+
+	// find the host record that was created from hostInfo
+	data.HostRecordID = types.Int64Null()
+	if !data.Hostname.IsNull() {
+		data.HostRecordID, err = findLinkedHostRecord(client, id, data.Hostname.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get host records linked to IP4 Address", err, bamEntity("DHCP Reservation", data.ID.ValueString()))
+			return
+		}
+
+		if data.HostRecordID.IsNull() {
+			resp.Diagnostics.AddWarning(
+				"Host record not found",
+				fmt.Sprintf("The IP4 Address was reserved but a host record named %s linked to it could not be found.", data.Hostname.ValueString()),
+			)
+		}
+	}
+
+	if data.ParentID.IsUnknown() {
+		parent, err := client.GetParent(id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get parent entity of IP4 address", err, bamEntity("DHCP Reservation", data.ID.ValueString()))
+			return
+		}
+		data.ParentID = types.Int64Value(*parent.Id)
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4DHCPReservationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *IP4DHCPReservationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
+	}
+
+	entity, err := client.GetEntityById(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id", err, bamEntity("DHCP Reservation", data.ID.ValueString()))
+		return
+	}
+
+	if *entity.Id == 0 {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if ok, diag := entityTypeMatches(entity, "IP4Address"); !ok {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Name = types.StringPointerValue(entity.Name)
+	data.Properties = types.StringPointerValue(entity.Properties)
+	data.Type = types.StringPointerValue(entity.Type)
+
+	addressProperties, diag := flattenIP4AddressProperties(entity)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	data.Address = newIP4AddressFromString(addressProperties.Address)
+	data.State = addressProperties.State
+	data.MACAddress = macAddressValue(data.MACAddress, addressProperties.MACAddress)
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, addressProperties.UserDefinedFields, r.client)
+
+	// if the host record was deleted outside terraform, clear hostname so it will be recreated
+	if !data.HostRecordID.IsNull() {
+		hostRecord, err := client.GetEntityById(data.HostRecordID.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get Host Record by Id", err, bamEntity("DHCP Reservation", data.ID.ValueString()))
+			return
+		}
+
+		if *hostRecord.Id == 0 {
+			tflog.Trace(ctx, "Host record was deleted outside terraform")
+			data.HostRecordID = types.Int64Null()
+			data.Hostname = types.StringNull()
+		} else {
+			props := parseProperties(*hostRecord.Properties)
+			data.Hostname = types.StringValue(props["absoluteName"])
+			if reverseRecord, err := strconv.ParseBool(props["reverseRecord"]); err == nil {
+				data.ReverseRecord = types.BoolValue(reverseRecord)
+			}
+		}
+	}
+
+	// reverse_record is only known by terraform so set the default when importing
+	if data.ReverseRecord.IsNull() {
+		data.ReverseRecord = types.BoolValue(false)
+	}
+
+	// get the parent id of the address so we can set it in the state so import works
+	parent, err := client.GetParent(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get parent entity of IP4 address", err, bamEntity("DHCP Reservation", data.ID.ValueString()))
+		return
+	}
+	data.ParentID = types.Int64Value(*parent.Id)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4DHCPReservationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data, state *IP4DHCPReservationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	properties := propertyMap{}
+
+	if !data.MACAddress.Equal(state.MACAddress) {
+		properties.set("macAddress", data.MACAddress.ValueString())
+	}
+
+	udfProperties, udfDiag := userDefinedFieldsUpdateProperties(ctx, data.UserDefinedFields, state.UserDefinedFields, r.client)
+	resp.Diagnostics.Append(udfDiag...)
+	properties.setAll(udfProperties)
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
+	}
+
+	update := gobam.APIEntity{
+		Id:         &id,
+		Name:       data.Name.ValueStringPointer(),
+		Properties: properties.stringPointer(),
+		Type:       state.Type.ValueStringPointer(),
+	}
+
+	err = client.Update(&update)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to update IP4 Address", err, bamEntity("DHCP Reservation", data.ID.ValueString()))
+		return
+	}
+
+	entity, err := client.GetEntityById(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Address by Id", err, bamEntity("DHCP Reservation", data.ID.ValueString()))
+		return
+	}
+
+	data.Name = types.StringPointerValue(entity.Name)
+	data.Properties = types.StringPointerValue(entity.Properties)
+	data.Type = types.StringPointerValue(entity.Type)
+
+	addressProperties, diag := flattenIP4AddressProperties(entity)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	data.Address = newIP4AddressFromString(addressProperties.Address)
+	data.State = addressProperties.State
+	data.MACAddress = macAddressValue(data.MACAddress, addressProperties.MACAddress)
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, addressProperties.UserDefinedFields, r.client)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4DHCPReservationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	resp.Diagnostics.Append(deleteProtectionCheck(r.client, "IP4Address")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4DHCPReservationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
+	}
+
+	// remove the host record created with the reservation first
+	if !data.HostRecordID.IsNull() {
+		hostRecord, err := client.GetEntityById(data.HostRecordID.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get Host Record by Id", err, bamEntity("DHCP Reservation", data.ID.ValueString()))
+			return
+		}
+
+		if *hostRecord.Id != 0 {
+			err = client.Delete(*hostRecord.Id)
+			if err != nil {
+				resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+				addBAMError(&resp.Diagnostics, "Failed to delete Host Record", err, bamEntity("DHCP Reservation", data.ID.ValueString()))
+				return
+			}
+		}
+	}
+
+	err = client.Delete(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to delete IP4 Address", err, bamEntity("DHCP Reservation", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+func (r *IP4DHCPReservationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+const ip4DHCPReservationConfigurationIDPlanModifierDescription string = "configuration_id cannot be changed. Null values in the state are ignored to allow for import."
+
+func ip4DHCPReservationConfigurationIDPlanModifier(ctx context.Context, p planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	var state *IP4DHCPReservationResourceModel
+	resp.Diagnostics.Append(p.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ConfigurationID.IsNull() {
+		// Since this is a required field, it should only be null when doing an import
+		resp.RequiresReplace = false
+		return
+	}
+
+	resp.RequiresReplace = true
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIP4DHCPReservationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccIP4DHCPReservationResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("bluecat_ip4_dhcp_reservation.test", "id", validateObjectID),
+					resource.TestCheckResourceAttr("bluecat_ip4_dhcp_reservation.test", "state", "DHCP_RESERVED"),
+					resource.TestCheckResourceAttr("bluecat_ip4_dhcp_reservation.test", "mac_address", "00:50:56:01:02:03"),
+				),
+			},
+		},
+	})
+}
+
+const testAccIP4DHCPReservationResourceConfig = testAccEntityDataSourceConfig + `
+variable "ip4_network_id" {
+	type = number
+}
+
+resource "bluecat_ip4_dhcp_reservation" "test" {
+	configuration_id = data.bluecat_entity.config.id
+	parent_id        = var.ip4_network_id
+	mac_address      = "00:50:56:01:02:03"
+	name             = "Test DHCP Reservation"
+}
+`
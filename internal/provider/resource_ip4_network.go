@@ -2,14 +2,18 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/big"
-	"regexp"
 	"slices"
 	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,12 +29,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/umich-vci/gobam"
-	"golang.org/x/exp/maps"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &IP4NetworkResource{}
 var _ resource.ResourceWithImportState = &IP4NetworkResource{}
+var _ resource.ResourceWithModifyPlan = &IP4NetworkResource{}
+var _ resource.ResourceWithUpgradeState = &IP4NetworkResource{}
 
 func NewIP4NetworkResource() resource.Resource {
 	return &IP4NetworkResource{}
@@ -50,31 +55,59 @@ type IP4NetworkResourceModel struct {
 	Properties types.String `tfsdk:"properties"`
 
 	// These are exposed via the entity properties field for objects of type IP4Network
-	CIDR                      types.String `tfsdk:"cidr"`
-	Template                  types.Int64  `tfsdk:"template"`
-	Gateway                   types.String `tfsdk:"gateway"`
-	DefaultDomains            types.Set    `tfsdk:"default_domains"`
-	DefaultView               types.Int64  `tfsdk:"default_view"`
-	DNSRestrictions           types.Set    `tfsdk:"dns_restrictions"`
-	AllowDuplicateHost        types.Bool   `tfsdk:"allow_duplicate_host"`
-	PingBeforeAssign          types.Bool   `tfsdk:"ping_before_assign"`
-	InheritAllowDuplicateHost types.Bool   `tfsdk:"inherit_allow_duplicate_host"`
-	InheritPingBeforeAssign   types.Bool   `tfsdk:"inherit_ping_before_assign"`
-	InheritDNSRestrictions    types.Bool   `tfsdk:"inherit_dns_restrictions"`
-	InheritDefaultDomains     types.Bool   `tfsdk:"inherit_default_domains"`
-	InheritDefaultView        types.Bool   `tfsdk:"inherit_default_view"`
-	LocationCode              types.String `tfsdk:"location_code"`
-	LocationInherited         types.Bool   `tfsdk:"location_inherited"`
-	SharedNetwork             types.String `tfsdk:"shared_network"`
+	CIDR                      ip4CIDRValue    `tfsdk:"cidr"`
+	Template                  types.Int64     `tfsdk:"template"`
+	Gateway                   ip4AddressValue `tfsdk:"gateway"`
+	GatewayAddressID          types.Int64     `tfsdk:"gateway_address_id"`
+	DefaultDomains            types.Set       `tfsdk:"default_domains"`
+	DefaultView               types.Int64     `tfsdk:"default_view"`
+	DNSRestrictions           types.Set       `tfsdk:"dns_restrictions"`
+	AllowDuplicateHost        types.Bool      `tfsdk:"allow_duplicate_host"`
+	PingBeforeAssign          types.Bool      `tfsdk:"ping_before_assign"`
+	InheritAllowDuplicateHost types.Bool      `tfsdk:"inherit_allow_duplicate_host"`
+	InheritPingBeforeAssign   types.Bool      `tfsdk:"inherit_ping_before_assign"`
+	InheritDNSRestrictions    types.Bool      `tfsdk:"inherit_dns_restrictions"`
+	InheritDefaultDomains     types.Bool      `tfsdk:"inherit_default_domains"`
+	InheritDefaultView        types.Bool      `tfsdk:"inherit_default_view"`
+	LocationCode              types.String    `tfsdk:"location_code"`
+	Comments                  types.String    `tfsdk:"comments"`
+	LocationInherited         types.Bool      `tfsdk:"location_inherited"`
+	SharedNetwork             types.String    `tfsdk:"shared_network"`
+	SharedNetworkTagID        types.Int64     `tfsdk:"shared_network_tag_id"`
+	DynamicUpdate             types.Bool      `tfsdk:"dynamic_update"`
 
 	// these are user defined fields that are not built-in
 	UserDefinedFields types.Map `tfsdk:"user_defined_fields"`
 
 	// These fields are only used for creation
+	CreateGateway   types.Bool   `tfsdk:"create_gateway"`
+	ReapplyTemplate types.Bool   `tfsdk:"reapply_template"`
 	IsLargerAllowed types.Bool   `tfsdk:"is_larger_allowed"`
 	ParentID        types.Int64  `tfsdk:"parent_id"`
+	ParentBlockIDs  types.List   `tfsdk:"parent_block_ids"`
 	Size            types.Int64  `tfsdk:"size"`
 	TraversalMethod types.String `tfsdk:"traversal_method"`
+
+	// only used for planning
+	FailIfInsufficient types.Bool `tfsdk:"fail_if_insufficient"`
+
+	// these are passed to the API when allocating the next available range
+	ExcludeDHCPRange     types.Bool      `tfsdk:"exclude_dhcp_range"`
+	Offset               ip4AddressValue `tfsdk:"offset"`
+	AllocationProperties types.Map       `tfsdk:"allocation_properties"`
+
+	// only used for creation
+	RollbackOnFailure types.Bool `tfsdk:"rollback_on_failure"`
+
+	// only used for deletion
+	ForceDelete types.Bool `tfsdk:"force_delete"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+
+	// limits how long creating, reading, and deleting may take
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *IP4NetworkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -85,8 +118,29 @@ func (r *IP4NetworkResource) Schema(ctx context.Context, req resource.SchemaRequ
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Resource to create an IPv4 network.",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"fail_if_insufficient": schema.BoolAttribute{
+				MarkdownDescription: "When a new network of `size` is planned, the parent blocks are checked for a free range of that size and a warning is shown if none of them has one. If `true`, planning fails instead. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"force_delete": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, the addresses in the IPv4 network other than the gateway are deleted with it, along with host records that are only linked to them. Otherwise deleting a network that still contains addresses fails with an error listing them. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"rollback_on_failure": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, the IPv4 network is deleted again when it was allocated but could not be configured, so a failed apply does not leave it taking up space. If `false`, or if it cannot be deleted, it is saved to the state as tainted and replaced on the next apply. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
 			// These are exposed for Entity objects via the API
 			"id": schema.StringAttribute{
 				MarkdownDescription: "IPv4 Network identifier.",
@@ -112,35 +166,86 @@ func (r *IP4NetworkResource) Schema(ctx context.Context, req resource.SchemaRequ
 			},
 			// These fields are only used for creation and are not exposed via the API entity
 			"is_larger_allowed": schema.BoolAttribute{
-				MarkdownDescription: "(Optional) Is it ok to return a network that is larger than the size specified?",
+				MarkdownDescription: "(Optional) Is it ok to return a network that is larger than the size specified? Cannot be used with `cidr`.",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.RequiresReplaceIf(ip4NetworkIsLargerAllowedPlanModifier, ip4NetworkIsLargerAllowedPlanModifierDescription, ip4NetworkIsLargerAllowedPlanModifierDescription),
 				},
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("cidr")),
+				},
 			},
 			"parent_id": schema.Int64Attribute{
-				MarkdownDescription: "The object ID of the parent object that will contain the new IPv4 network. If this argument is changed, then the resource will be recreated.",
-				Required:            true,
+				MarkdownDescription: "The object ID of the parent object that will contain the new IPv4 network. Exactly one of `parent_id` or `parent_block_ids` must be set. If `parent_block_ids` is set, this is the block the network was allocated in. If this argument is changed, then the resource will be recreated.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
 					int64planmodifier.RequiresReplace(),
 				},
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("parent_block_ids")),
+				},
+			},
+			"parent_block_ids": schema.ListAttribute{
+				MarkdownDescription: "The object IDs of IPv4 blocks to allocate the next available network of `size` in, in order of preference. If a block has no network of `size` available, the next block is tried. Changing this argument only affects where a new network is allocated, so it does not recreate the resource. Cannot be used with `cidr`.",
+				Optional:            true,
+				ElementType:         types.Int64Type,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ConflictsWith(path.MatchRoot("cidr")),
+				},
 			},
 			"size": schema.Int64Attribute{
-				MarkdownDescription: "The size of the IPv4 network expressed as a power of 2. For example, 256 would create a /24. If this argument is changed, then the resource will be recreated.",
-				Required:            true,
+				MarkdownDescription: "The size of the IPv4 network expressed as a power of 2. For example, 256 would create a /24. The next available network of this size will be allocated. Exactly one of `size` or `cidr` must be set. If this argument is changed, then the resource will be recreated.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
 					int64planmodifier.RequiresReplace(),
 				},
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("cidr")),
+				},
+			},
+			"exclude_dhcp_range": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, ranges that overlap a DHCP range are not allocated. Changing this argument only affects where a new network is allocated, so it does not recreate the resource. Cannot be used with `cidr`.",
+				Optional:            true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("cidr")),
+				},
+			},
+			"offset": schema.StringAttribute{
+				MarkdownDescription: "The IPv4 address in the parent to start looking for the next available network at. Changing this argument only affects where a new network is allocated, so it does not recreate the resource. Cannot be used with `cidr`.",
+				Optional:            true,
+				CustomType:          ip4AddressType{},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("cidr")),
+				},
+			},
+			"allocation_properties": schema.MapAttribute{
+				MarkdownDescription: "Additional properties to pass to the API when allocating the next available network, for properties of `getNextAvailableIPRange` that have no argument of their own. Changing this argument only affects where a new network is allocated, so it does not recreate the resource. Cannot be used with `cidr`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(propertyNameRegexp, propertyNameRegexpMessage),
+						stringvalidator.NoneOf(reservedAllocationProperties...),
+					),
+					mapvalidator.ConflictsWith(path.MatchRoot("cidr")),
+				},
 			},
 			"traversal_method": schema.StringAttribute{
-				MarkdownDescription: "The traversal method used to find the range to allocate the network. Must be one of \"NO_TRAVERSAL\", \"DEPTH_FIRST\", or \"BREADTH_FIRST\".",
+				MarkdownDescription: "The traversal method used to find the range to allocate the network. " + enumDescription(traversalMethods) + " Cannot be used with `cidr`.",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("NO_TRAVERSAL"),
+				Default:             stringdefault.StaticString(traversalMethodNone),
 				Validators: []validator.String{
-					stringvalidator.OneOf("NO_TRAVERSAL", "DEPTH_FIRST", "BREADTH_FIRST"),
+					stringvalidator.OneOf(traversalMethods...),
+					stringvalidator.ConflictsWith(path.MatchRoot("cidr")),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplaceIf(ip4NetworkTraversalMethodPlanModifier, ip4NetworkTraversalMethodPlanModifierDescription, ip4NetworkTraversalMethodPlanModifierDescription),
@@ -149,20 +254,44 @@ func (r *IP4NetworkResource) Schema(ctx context.Context, req resource.SchemaRequ
 
 			// These are exposed via the API properties field for objects of type IP4Network
 			"cidr": schema.StringAttribute{
-				MarkdownDescription: "The CIDR address of the IPv4 network.",
+				MarkdownDescription: "The CIDR address of the IPv4 network. If set, the network is created with this exact CIDR instead of allocating the next available network of `size`. Exactly one of `size` or `cidr` must be set. If this argument is changed, then the resource will be recreated.",
 				Computed:            true,
+				Optional:            true,
+				CustomType:          ip4CIDRType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					ip4RequiresReplace(),
+				},
 			},
 			"template": schema.Int64Attribute{
-				MarkdownDescription: "The ID of the linked template",
+				MarkdownDescription: "The ID of the IPv4 network template linked to the network. If set, the template is applied to the network when it is created and whenever this argument is changed.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"reapply_template": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, `template` is applied again whenever the network is updated, so that changes made to the template are applied to the network. Defaults to `false`.",
+				Optional:            true,
 				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"create_gateway": schema.BoolAttribute{
+				MarkdownDescription: "If the IPv4 network should have a gateway. BlueCat Address Manager reserves the first address of a new network as the gateway unless this is set to `false`. Setting this to `false` on an existing network removes its gateway, and setting it back to `true` makes the first address the gateway unless `gateway` is set. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
 			},
 			"gateway": schema.StringAttribute{
-				MarkdownDescription: "The gateway of the IPv4 network.",
+				MarkdownDescription: "The gateway of the IPv4 network. If changed, the address of the previous gateway is no longer reserved as a gateway. Cannot be set if `create_gateway` is `false`.",
 				Computed:            true,
 				Optional:            true,
-				Validators: []validator.String{
-					stringvalidator.RegexMatches(regexp.MustCompile(`^(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])$`), "Gateway must be a valid IPv4 address"),
-				},
+				CustomType:          ip4AddressType{},
+			},
+			"gateway_address_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the IPv4 address reserved as the gateway of the network.",
+				Computed:            true,
 			},
 			"default_domains": schema.SetAttribute{
 				MarkdownDescription: "The object ids of the default DNS domains for the network.",
@@ -226,13 +355,20 @@ func (r *IP4NetworkResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Optional:            true,
 				Default:             booldefault.StaticBool(true),
 			},
+			"comments": schema.StringAttribute{
+				MarkdownDescription: "Comments about the network.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"location_code": schema.StringAttribute{
-				MarkdownDescription: "The location code of the network.",
+				MarkdownDescription: "The location code of the network. The location must already exist, which is checked when planning.",
 				Computed:            true,
 				Optional:            true,
 				Default:             nil,
-				Validators:          []validator.String{
-					// The code is case-sensitive and must be in uppercase letters. The country code and child location code should be alphanumeric strings.
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(locationCodeRegexp, locationCodeRegexpMessage),
 				},
 			},
 			"location_inherited": schema.BoolAttribute{
@@ -243,14 +379,37 @@ func (r *IP4NetworkResource) Schema(ctx context.Context, req resource.SchemaRequ
 				MarkdownDescription: "The name of the shared network tag associated with the IP4 Network.",
 				Computed:            true,
 			},
+			"dynamic_update": schema.BoolAttribute{
+				MarkdownDescription: "Whether DHCP clients in the IP4 Network have their DNS records dynamically updated.",
+				Computed:            true,
+				Optional:            true,
+				Default:             nil,
+			},
+			"shared_network_tag_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of a tag in a shared network tag group to share the IP4 Network with. Removing this argument unshares the network.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 			"user_defined_fields": schema.MapAttribute{
 				MarkdownDescription: "A map of all user-definied fields associated with the IP4 Network.",
 				Computed:            true,
 				Optional:            true,
 				Default:             mapdefault.StaticValue(basetypes.NewMapValueMust(types.StringType, nil)),
 				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(propertyNameRegexp, propertyNameRegexpMessage)),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -275,6 +434,11 @@ func (r *IP4NetworkResource) Configure(ctx context.Context, req resource.Configu
 }
 
 func (r *IP4NetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data *IP4NetworkResourceModel
 
 	// Read Terraform plan data into the model
@@ -284,143 +448,296 @@ func (r *IP4NetworkResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	createTimeout, diags := data.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	network, diag := r.allocate(ctx, client, data)
+	if network == nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(*network.Id, 10))
+	data.Properties = types.StringPointerValue(network.Properties)
+	data.Type = types.StringPointerValue(network.Type)
+
+	// the network exists from here on, so failures must either delete it or keep
+	// its ID in the state
+	var existing map[int64]bool
+	if !diag.HasError() && data.RollbackOnFailure.ValueBool() {
+		var err error
+		existing, err = getIP4ChildIDs(client, *network.Id, "IP4Network")
+		if err != nil {
+			addBAMError(&diag, "Failed to get the children of created IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+		}
+	}
+	if !diag.HasError() {
+		diag.Append(r.configure(ctx, client, data, network)...)
+	}
+	if diag.HasError() && data.RollbackOnFailure.ValueBool() {
+		// roll back even if the create timed out
+		cleanupCtx, cleanupCancel := cleanupContext(ctx)
+		defer cleanupCancel()
+		setClientContext(client, cleanupCtx)
+
+		err := rollbackIP4Create(client, *network.Id, "IP4Network", existing)
+		if err == nil {
+			diag.AddWarning(
+				fmt.Sprintf("Rolled back IP4 Network %s", data.ID.ValueString()),
+				"The IP4 Network was deleted because it could not be configured. Set rollback_on_failure to false to keep it in the state as tainted instead.",
+			)
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+		diag.AddWarning(fmt.Sprintf("Failed to roll back IP4 Network %s", data.ID.ValueString()), err.Error())
+	}
+	if !diag.HasError() {
+		diag.Append(r.refresh(ctx, client, data, *network.Id)...)
+	}
 
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		resp.Diagnostics.Append(setTaintedState(ctx, &resp.State, data, "IP4 Network", data.ID.ValueString())...)
 		return
 	}
 
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// allocate creates the static network requested by cidr, or the next
+// available network of size in the parent blocks. The network is returned
+// along with any error once it exists so its ID is not lost.
+func (r *IP4NetworkResource) allocate(ctx context.Context, client gobam.ProteusAPI, data *IP4NetworkResourceModel) (*gobam.APIEntity, diag.Diagnostics) {
+	var diags diag.Diagnostics
 	parentID := data.ParentID.ValueInt64()
+
+	if !data.CIDR.IsUnknown() && !data.CIDR.IsNull() {
+		// a static CIDR was requested so create exactly that network
+		networkID, err := client.AddIP4Network(parentID, data.CIDR.ValueIP4String(), "")
+		if err != nil {
+			addBAMError(&diags, "Failed to create IP4 Network", err, "")
+			return nil, diags
+		}
+
+		network, err := client.GetEntityById(networkID)
+		if err != nil {
+			addBAMError(&diags, "Failed to get IP4 Network by Id", err, "")
+			// the network was created, so keep its ID for the state
+			return &gobam.APIEntity{Id: &networkID}, diags
+		}
+
+		return network, diags
+	}
+
 	size := data.Size.ValueInt64()
 	isLargerAllowed := data.IsLargerAllowed.ValueBool()
 	traversalMethod := data.TraversalMethod.ValueString()
 	autoCreate := true     //we always want to create since this is a resource after all
 	reuseExisting := false //we never want to use an existing network created outside terraform
 	Type := "IP4Network"   //Since this is the ip4_network resource we are setting the type
-	properties := "reuseExisting=" + strconv.FormatBool(reuseExisting) + "|"
-	properties = properties + "isLargerAllowed=" + strconv.FormatBool(isLargerAllowed) + "|"
-	properties = properties + "autoCreate=" + strconv.FormatBool(autoCreate) + "|"
-	properties = properties + "traversalMethod=" + traversalMethod + "|"
+	properties := propertyMap{}
+	properties.setBool("reuseExisting", reuseExisting)
+	properties.setBool("isLargerAllowed", isLargerAllowed)
+	properties.setBool("autoCreate", autoCreate)
+	properties.set("traversalMethod", traversalMethod)
+	diags.Append(setAllocationProperties(ctx, properties, data.ExcludeDHCPRange, data.Offset, data.AllocationProperties)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	parentIDs := []int64{parentID}
+	if !data.ParentBlockIDs.IsNull() {
+		diags.Append(data.ParentBlockIDs.ElementsAs(ctx, &parentIDs, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
 
-	network, err := client.GetNextAvailableIPRange(parentID, size, Type, properties)
+	network, err := getNextAvailableIP4Network(ctx, client, parentIDs, size, Type, properties.String())
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to create IP4 Network",
-			err.Error(),
-		)
-		return
+		addBAMError(&diags, "Failed to create IP4 Network", err, "")
+		return nil, diags
 	}
 
-	data.ID = types.StringValue(strconv.FormatInt(*network.Id, 10))
-	data.Properties = types.StringPointerValue(network.Properties)
-	data.Type = types.StringPointerValue(network.Type)
+	return network, diags
+}
 
-	// we have an ID at this point so save the state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// configure sets the name, properties, gateway, template, and shared network
+// tag of a newly allocated network.
+func (r *IP4NetworkResource) configure(ctx context.Context, client gobam.ProteusAPI, data *IP4NetworkResourceModel, network *gobam.APIEntity) diag.Diagnostics {
+	var diags diag.Diagnostics
+	properties := propertyMap{}
 
-	properties = ""
+	// the gateway BlueCat Address Manager reserved when creating the network
+	defaultGateway := ""
+	if network.Properties != nil {
+		defaultGateway = parseProperties(*network.Properties)["gateway"]
+	}
 
-	if !data.Gateway.IsUnknown() {
-		properties = properties + "gateway=" + data.Gateway.ValueString() + "|"
+	if !data.CreateGateway.ValueBool() {
+		properties.set("gateway", "")
+	} else if !data.Gateway.IsUnknown() {
+		properties.set("gateway", data.Gateway.ValueIP4String())
 	}
 
 	if !data.DefaultDomains.IsUnknown() {
 		var defaultDomains []string
 		data.DefaultDomains.ElementsAs(ctx, &defaultDomains, false)
-		properties = properties + "defaultDomains=" + strings.Join(defaultDomains, ",") + "|"
+		properties.setList("defaultDomains", defaultDomains)
 	}
 
 	if !data.DefaultView.IsUnknown() {
-		properties = properties + "defaultView=" + strconv.FormatInt(data.DefaultView.ValueInt64(), 10) + "|"
+		properties.setInt64("defaultView", data.DefaultView.ValueInt64())
 	}
 
 	if !data.DNSRestrictions.IsUnknown() {
 		var dnsRestrictions []string
 		data.DNSRestrictions.ElementsAs(ctx, &dnsRestrictions, false)
-		properties = properties + "dnsRestrictions=" + strings.Join(dnsRestrictions, ",") + "|"
+		properties.setList("dnsRestrictions", dnsRestrictions)
 	}
 
 	if !data.AllowDuplicateHost.IsUnknown() {
-		properties = properties + "allowDuplicateHost=" + boolToEnableDisable(data.AllowDuplicateHost.ValueBoolPointer()) + "|"
+		properties.set("allowDuplicateHost", boolToEnableDisable(data.AllowDuplicateHost.ValueBoolPointer()))
 	}
 
 	if !data.PingBeforeAssign.IsUnknown() {
-		properties = properties + "pingBeforeAssign=" + boolToEnableDisable(data.PingBeforeAssign.ValueBoolPointer()) + "|"
+		properties.set("pingBeforeAssign", boolToEnableDisable(data.PingBeforeAssign.ValueBoolPointer()))
 	}
 
 	if !data.InheritAllowDuplicateHost.IsUnknown() {
-		properties = properties + "inheritAllowDuplicateHost=" + strconv.FormatBool(data.InheritAllowDuplicateHost.ValueBool()) + "|"
+		properties.setBool("inheritAllowDuplicateHost", data.InheritAllowDuplicateHost.ValueBool())
 	}
 
 	if !data.InheritPingBeforeAssign.IsUnknown() {
-		properties = properties + "inheritPingBeforeAssign=" + strconv.FormatBool(data.InheritPingBeforeAssign.ValueBool()) + "|"
+		properties.setBool("inheritPingBeforeAssign", data.InheritPingBeforeAssign.ValueBool())
 	}
 
 	if !data.InheritDNSRestrictions.IsUnknown() {
-		properties = properties + "inheritDNSRestrictions=" + strconv.FormatBool(data.InheritDNSRestrictions.ValueBool()) + "|"
+		properties.setBool("inheritDNSRestrictions", data.InheritDNSRestrictions.ValueBool())
 	}
 
 	if !data.InheritDefaultDomains.IsUnknown() {
-		properties = properties + "inheritDefaultDomains=" + strconv.FormatBool(data.InheritDefaultDomains.ValueBool()) + "|"
+		properties.setBool("inheritDefaultDomains", data.InheritDefaultDomains.ValueBool())
 	}
 
 	if !data.InheritDefaultView.IsUnknown() {
-		properties = properties + "inheritDefaultView=" + strconv.FormatBool(data.InheritDefaultView.ValueBool()) + "|"
+		properties.setBool("inheritDefaultView", data.InheritDefaultView.ValueBool())
 	}
 
 	if !data.LocationCode.IsUnknown() {
-		properties = properties + "locationCode=" + data.LocationCode.ValueString() + "|"
+		properties.set("locationCode", data.LocationCode.ValueString())
+	}
+
+	if !data.Comments.IsNull() {
+		properties.set("comments", data.Comments.ValueString())
+	}
+
+	if !data.DynamicUpdate.IsUnknown() {
+		properties.setBool("dynamicUpdate", data.DynamicUpdate.ValueBool())
 	}
 
 	var udfs map[string]string
 	data.UserDefinedFields.ElementsAs(ctx, &udfs, false)
-	for k, v := range udfs {
-		properties = properties + k + "=" + v + "|"
-	}
+	properties.setAll(udfs)
+	properties.setManaged(r.client)
 
 	setName := gobam.APIEntity{
 		Id:         network.Id,
 		Name:       data.Name.ValueStringPointer(),
-		Properties: &properties,
+		Properties: properties.stringPointer(),
 		Type:       data.Type.ValueStringPointer(),
 	}
 
-	err = client.Update(&setName)
+	err := client.Update(&setName)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to update created IP4 Network",
-			err.Error(),
-		)
+		addBAMError(&diags, "Failed to update created IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+		return diags
+	}
 
-		return
+	if !data.CreateGateway.ValueBool() || (!data.Gateway.IsUnknown() && data.Gateway.ValueIP4String() != defaultGateway) {
+		err = removeIP4GatewayAddress(client, *network.Id, defaultGateway)
+		if err != nil {
+			addBAMError(&diags, "Failed to remove the default gateway of the created IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+			return diags
+		}
+	}
+
+	if !data.Template.IsUnknown() && !data.Template.IsNull() {
+		err = applyIP4NetworkTemplate(ctx, client, data.Template.ValueInt64(), *network.Id)
+		if err != nil {
+			addBAMError(&diags, "Failed to apply template to created IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+			return diags
+		}
 	}
 
-	entity, err := client.GetEntityById(*network.Id)
+	if !data.SharedNetworkTagID.IsNull() {
+		err = client.ShareNetwork(*network.Id, data.SharedNetworkTagID.ValueInt64())
+		if err != nil {
+			addBAMError(&diags, "Failed to share created IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// refresh reads a newly created network back into data so computed
+// attributes are known.
+func (r *IP4NetworkResource) refresh(ctx context.Context, client gobam.ProteusAPI, data *IP4NetworkResourceModel, id int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.ParentID.IsUnknown() {
+		parent, err := client.GetParent(id)
+		if err != nil {
+			addBAMError(&diags, "Failed to get IP4 Network parent", err, bamEntity("IP4 Network", data.ID.ValueString()))
+			return diags
+		}
+		data.ParentID = types.Int64Value(*parent.Id)
+	}
+
+	entity, err := client.GetEntityById(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to get IP4 Network by Id",
-			err.Error(),
-		)
-		return
+		addBAMError(&diags, "Failed to get IP4 Network by Id", err, bamEntity("IP4 Network", data.ID.ValueString()))
+		return diags
 	}
 
 	networkProperties, diag := flattenIP4NetworkProperties(entity)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.Append(diag...)
-		return
+		diags.Append(diag...)
+		return diags
 	}
 
 	data.Name = types.StringPointerValue(entity.Name)
 	data.Properties = types.StringPointerValue(entity.Properties)
 	data.Type = types.StringPointerValue(entity.Type)
-	data.CIDR = networkProperties.CIDR
+	data.CIDR = newIP4CIDRFromString(networkProperties.CIDR)
 	data.Template = networkProperties.Template
-	data.Gateway = networkProperties.Gateway
+	data.Gateway = newIP4AddressFromString(networkProperties.Gateway)
+	data.GatewayAddressID, err = getIP4GatewayAddressID(client, *entity.Id, data.Gateway.ValueString())
+	if err != nil {
+		addBAMError(&diags, "Failed to get the gateway address of the IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+		return diags
+	}
 	data.DefaultDomains = networkProperties.DefaultDomains
 	data.DefaultView = networkProperties.DefaultView
 	data.DNSRestrictions = networkProperties.DNSRestrictions
@@ -432,18 +749,23 @@ func (r *IP4NetworkResource) Create(ctx context.Context, req resource.CreateRequ
 	data.InheritDefaultDomains = networkProperties.InheritDefaultDomains
 	data.InheritDefaultView = networkProperties.InheritDefaultView
 	data.LocationCode = networkProperties.LocationCode
+	data.Comments = networkProperties.Comments
 	data.LocationInherited = networkProperties.LocationInherited
 	data.SharedNetwork = networkProperties.SharedNetwork
-	data.UserDefinedFields = networkProperties.UserDefinedFields
-
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-
-	// Write logs using the tflog package
-	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, "created a resource")
+	data.DynamicUpdate = networkProperties.DynamicUpdate
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, networkProperties.UserDefinedFields, r.client)
+
+	if data.Size.IsUnknown() {
+		// size is only computed when the network was created from a static CIDR
+		size, err := cidrToSize(networkProperties.CIDR.ValueString())
+		if err != nil {
+			diags.AddError("Failed to parse CIDR netmask to integer", err.Error())
+			return diags
+		}
+		data.Size = types.Int64Value(size)
+	}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return diags
 }
 
 func (r *IP4NetworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -456,33 +778,45 @@ func (r *IP4NetworkResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
-	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	readTimeout, diags := data.Timeouts.Read(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, readTimeout)
+	defer cancel()
 
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
 		return
 	}
 
 	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
 		return
 	}
 
 	entity, err := client.GetEntityById(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to get IP4 Network by Id",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Network by Id", err, bamEntity("IP4 Network", data.ID.ValueString()))
 		return
 	}
 
 	if *entity.Id == 0 {
 		tflog.Trace(ctx, "IP4 Network was deleted outside terraform")
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if ok, diag := entityTypeMatches(entity, "IP4Network"); !ok {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -493,14 +827,40 @@ func (r *IP4NetworkResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	networkProperties, diag := flattenIP4NetworkProperties(entity)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	data.CIDR = networkProperties.CIDR
+	data.CIDR = newIP4CIDRFromString(networkProperties.CIDR)
 	data.Template = networkProperties.Template
-	data.Gateway = networkProperties.Gateway
+	data.Gateway = newIP4AddressFromString(networkProperties.Gateway)
+	data.GatewayAddressID, err = getIP4GatewayAddressID(client, *entity.Id, data.Gateway.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get the gateway address of the IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+		return
+	}
+
+	// imported or saved before reapply_template was added
+	if data.ReapplyTemplate.IsNull() {
+		data.ReapplyTemplate = types.BoolValue(false)
+	}
+
+	// imported or saved before force_delete was added
+	if data.ForceDelete.IsNull() {
+		data.ForceDelete = types.BoolValue(false)
+	}
+
+	// imported or saved before rollback_on_failure was added
+	if data.RollbackOnFailure.IsNull() {
+		data.RollbackOnFailure = types.BoolValue(true)
+	}
+
+	// imported or saved before create_gateway was added
+	if data.CreateGateway.IsNull() {
+		data.CreateGateway = types.BoolValue(data.Gateway.ValueString() != "")
+	}
 	data.DefaultDomains = networkProperties.DefaultDomains
 	data.DefaultView = networkProperties.DefaultView
 	data.DNSRestrictions = networkProperties.DNSRestrictions
@@ -512,37 +872,48 @@ func (r *IP4NetworkResource) Read(ctx context.Context, req resource.ReadRequest,
 	data.InheritDefaultDomains = networkProperties.InheritDefaultDomains
 	data.InheritDefaultView = networkProperties.InheritDefaultView
 	data.LocationCode = networkProperties.LocationCode
+	data.Comments = networkProperties.Comments
 	data.LocationInherited = networkProperties.LocationInherited
 	data.SharedNetwork = networkProperties.SharedNetwork
-	data.UserDefinedFields = networkProperties.UserDefinedFields
+	data.DynamicUpdate = networkProperties.DynamicUpdate
+	data.SharedNetworkTagID, err = getIP4SharedNetworkTagID(client, data.SharedNetworkTagID, data.SharedNetwork.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get the shared network tag of the IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+		return
+	}
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, networkProperties.UserDefinedFields, r.client)
 
 	// calculate the size of the network so we can set it in the state so import works
-	cidrNetmask, err := strconv.ParseInt(strings.Split(networkProperties.CIDR.ValueString(), "/")[1], 10, 64)
+	size, err := cidrToSize(networkProperties.CIDR.ValueString())
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError("Failed to parse CIDR netmask to integer", err.Error())
 		return
 	}
-	var size, e = big.NewInt(2), big.NewInt(32 - cidrNetmask)
-	size.Exp(size, e, nil)
-	data.Size = types.Int64Value(size.Int64())
+	data.Size = types.Int64Value(size)
 
 	// get the parent id of the network so we can set it in the state so import works
 	parent, err := client.GetParent(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError("Failed to get parent entity of IP4 Network", err.Error())
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get parent entity of IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
 		return
 	}
 	data.ParentID = types.Int64Value(*parent.Id)
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IP4NetworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data, state *IP4NetworkResourceModel
 
 	// Read Terraform plan data into the model
@@ -554,30 +925,45 @@ func (r *IP4NetworkResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-
+		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	properties := ""
+	properties := propertyMap{}
 
-	if !data.Gateway.IsUnknown() && !data.Gateway.Equal(state.Gateway) {
-		properties = properties + fmt.Sprintf("gateway=%s|", data.Gateway.ValueString())
+	gatewayChanged := false
+	if !data.CreateGateway.ValueBool() {
+		if state.Gateway.ValueString() != "" {
+			properties.set("gateway", "")
+			gatewayChanged = true
+		}
+	} else if !data.Gateway.IsUnknown() && !data.Gateway.Equal(state.Gateway) {
+		properties.set("gateway", data.Gateway.ValueIP4String())
+		gatewayChanged = true
+	} else if data.Gateway.IsUnknown() && state.Gateway.ValueString() == "" {
+		// the gateway was removed with create_gateway, so restore the default gateway
+		gateway, err := ip4DefaultGateway(state.CIDR.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to determine the default gateway", err, bamEntity("IP4 Network", data.ID.ValueString()))
+			return
+		}
+		properties.set("gateway", gateway)
 	}
 
 	if !data.DefaultDomains.IsUnknown() && !data.DefaultDomains.Equal(state.DefaultDomains) {
 		var domains []string
 		data.DefaultDomains.ElementsAs(ctx, &domains, false)
 		if domains != nil {
-			properties = properties + fmt.Sprintf("defaultDomains=%s|", strings.Join(domains, ","))
+			properties.setList("defaultDomains", domains)
 		}
 	}
 
 	if !data.DefaultView.IsUnknown() && !data.DefaultView.Equal(state.DefaultView) {
 
-		properties = properties + fmt.Sprintf("defaultView=%s|", strconv.FormatInt(data.DefaultView.ValueInt64(), 10))
+		properties.setInt64("defaultView", data.DefaultView.ValueInt64())
 
 	}
 
@@ -585,67 +971,60 @@ func (r *IP4NetworkResource) Update(ctx context.Context, req resource.UpdateRequ
 		var dns []string
 		data.DNSRestrictions.ElementsAs(ctx, &dns, false)
 		if dns != nil {
-			properties = properties + fmt.Sprintf("dnsRestrictions=%s|", dns)
+			properties.setList("dnsRestrictions", dns)
 		}
 
 	}
 
 	if !data.AllowDuplicateHost.IsUnknown() && !data.AllowDuplicateHost.Equal(state.AllowDuplicateHost) {
-		properties = properties + fmt.Sprintf("allowDuplicateHost=%s|", boolToEnableDisable(data.AllowDuplicateHost.ValueBoolPointer()))
+		properties.set("allowDuplicateHost", boolToEnableDisable(data.AllowDuplicateHost.ValueBoolPointer()))
 
 	}
 
 	if !data.PingBeforeAssign.IsUnknown() && !data.PingBeforeAssign.Equal(state.PingBeforeAssign) {
-		properties = properties + fmt.Sprintf("pingBeforeAssign=%s|", boolToEnableDisable(data.PingBeforeAssign.ValueBoolPointer()))
+		properties.set("pingBeforeAssign", boolToEnableDisable(data.PingBeforeAssign.ValueBoolPointer()))
 	}
 
 	if !data.InheritAllowDuplicateHost.Equal(state.InheritAllowDuplicateHost) {
-		properties = properties + fmt.Sprintf("inheritAllowDuplicateHost=%s|", strconv.FormatBool(data.InheritAllowDuplicateHost.ValueBool()))
+		properties.setBool("inheritAllowDuplicateHost", data.InheritAllowDuplicateHost.ValueBool())
 	}
 
 	if !data.InheritPingBeforeAssign.Equal(state.InheritPingBeforeAssign) {
-		properties = properties + fmt.Sprintf("inheritPingBeforeAssign=%s|", strconv.FormatBool(data.InheritPingBeforeAssign.ValueBool()))
+		properties.setBool("inheritPingBeforeAssign", data.InheritPingBeforeAssign.ValueBool())
 	}
 
 	if !data.InheritDNSRestrictions.Equal(state.InheritDNSRestrictions) {
-		properties = properties + fmt.Sprintf("inheritDNSRestrictions=%s|", strconv.FormatBool(data.InheritDNSRestrictions.ValueBool()))
+		properties.setBool("inheritDNSRestrictions", data.InheritDNSRestrictions.ValueBool())
 	}
 
 	if !data.InheritDefaultDomains.Equal(state.InheritDefaultDomains) {
-		properties = properties + fmt.Sprintf("inheritDefaultDomains=%s|", strconv.FormatBool(data.InheritDefaultDomains.ValueBool()))
+		properties.setBool("inheritDefaultDomains", data.InheritDefaultDomains.ValueBool())
 
 	}
 
 	if !data.InheritDefaultView.Equal(state.InheritDefaultView) {
-		properties = properties + fmt.Sprintf("inheritDefaultView=%s|", strconv.FormatBool(data.InheritDefaultView.ValueBool()))
+		properties.setBool("inheritDefaultView", data.InheritDefaultView.ValueBool())
 	}
 
 	if !data.LocationCode.IsUnknown() && !data.LocationCode.Equal(state.LocationCode) {
-		properties = properties + fmt.Sprintf("locationCode=%s|", data.LocationCode.ValueString())
+		properties.set("locationCode", data.LocationCode.ValueString())
 	}
 
-	if !data.UserDefinedFields.Equal(state.UserDefinedFields) {
-		var udfs, oldudfs map[string]string
-		resp.Diagnostics.Append(data.UserDefinedFields.ElementsAs(ctx, &udfs, false)...)
-		resp.Diagnostics.Append(state.UserDefinedFields.ElementsAs(ctx, &oldudfs, false)...)
-
-		for k, v := range udfs {
-			properties = properties + fmt.Sprintf("%s=%s|", k, v)
-		}
+	if !data.Comments.Equal(state.Comments) {
+		properties.set("comments", data.Comments.ValueString())
+	}
 
-		// set keys that no longer exist to empty string
-		oldkeys := maps.Keys(oldudfs)
-		keys := maps.Keys(udfs)
-		for _, x := range oldkeys {
-			if !slices.Contains(keys, x) {
-				properties = properties + fmt.Sprintf("%s=|", x)
-			}
-		}
+	if !data.DynamicUpdate.IsUnknown() && !data.DynamicUpdate.Equal(state.DynamicUpdate) {
+		properties.setBool("dynamicUpdate", data.DynamicUpdate.ValueBool())
 	}
 
+	udfProperties, udfDiag := userDefinedFieldsUpdateProperties(ctx, data.UserDefinedFields, state.UserDefinedFields, r.client)
+	resp.Diagnostics.Append(udfDiag...)
+	properties.setAll(udfProperties)
+
 	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
 		return
 	}
@@ -653,7 +1032,7 @@ func (r *IP4NetworkResource) Update(ctx context.Context, req resource.UpdateRequ
 	update := gobam.APIEntity{
 		Id:         &id,
 		Name:       data.Name.ValueStringPointer(),
-		Properties: &properties,
+		Properties: properties.stringPointer(),
 		Type:       state.Type.ValueStringPointer(),
 	}
 
@@ -661,21 +1040,42 @@ func (r *IP4NetworkResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	err = client.Update(&update)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"IP4 Network Update failed",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "IP4 Network Update failed", err, bamEntity("IP4 Network", data.ID.ValueString()))
 		return
 	}
 
+	if gatewayChanged {
+		err = removeIP4GatewayAddress(client, id, state.Gateway.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to remove the previous gateway of the IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+			return
+		}
+	}
+
+	if !data.Template.IsUnknown() && !data.Template.IsNull() && (!data.Template.Equal(state.Template) || data.ReapplyTemplate.ValueBool()) {
+		err = applyIP4NetworkTemplate(ctx, client, data.Template.ValueInt64(), id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to apply template to IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+			return
+		}
+	}
+
+	if !data.SharedNetworkTagID.Equal(state.SharedNetworkTagID) {
+		err = updateIP4SharedNetwork(client, id, state.SharedNetwork.ValueString(), data.SharedNetworkTagID)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to update the shared network of the IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+			return
+		}
+	}
+
 	entity, err := client.GetEntityById(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to get IP4 Network by Id",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Network by Id", err, bamEntity("IP4 Network", data.ID.ValueString()))
 		return
 	}
 
@@ -685,14 +1085,20 @@ func (r *IP4NetworkResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	networkProperties, diag := flattenIP4NetworkProperties(entity)
 	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	data.CIDR = networkProperties.CIDR
+	data.CIDR = newIP4CIDRFromString(networkProperties.CIDR)
 	data.Template = networkProperties.Template
-	data.Gateway = networkProperties.Gateway
+	data.Gateway = newIP4AddressFromString(networkProperties.Gateway)
+	data.GatewayAddressID, err = getIP4GatewayAddressID(client, *entity.Id, data.Gateway.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get the gateway address of the IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+		return
+	}
 	data.DefaultDomains = networkProperties.DefaultDomains
 	data.DefaultView = networkProperties.DefaultView
 	data.DNSRestrictions = networkProperties.DNSRestrictions
@@ -704,17 +1110,25 @@ func (r *IP4NetworkResource) Update(ctx context.Context, req resource.UpdateRequ
 	data.InheritDefaultDomains = networkProperties.InheritDefaultDomains
 	data.InheritDefaultView = networkProperties.InheritDefaultView
 	data.LocationCode = networkProperties.LocationCode
+	data.Comments = networkProperties.Comments
 	data.LocationInherited = networkProperties.LocationInherited
 	data.SharedNetwork = networkProperties.SharedNetwork
-	data.UserDefinedFields = networkProperties.UserDefinedFields
+	data.DynamicUpdate = networkProperties.DynamicUpdate
+	data.UserDefinedFields = userDefinedFieldsState(data.UserDefinedFields, networkProperties.UserDefinedFields, r.client)
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IP4NetworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	resp.Diagnostics.Append(deleteProtectionCheck(r.client, "IP4Network")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data *IP4NetworkResourceModel
 
 	// Read Terraform prior state data into the model
@@ -724,50 +1138,251 @@ func (r *IP4NetworkResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	client, diag := clientLogin(ctx, r.client, mutex)
-	if diag.HasError() {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, deleteTimeout)
+	defer cancel()
 
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
 		return
 	}
 
 	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
 		return
 	}
 
 	entity, err := client.GetEntityById(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
-		resp.Diagnostics.AddError(
-			"Failed to get IP4 Network by Id",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Network by Id", err, bamEntity("IP4 Network", data.ID.ValueString()))
 		return
 	}
 
 	if *entity.Id == 0 {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		return
 	}
 
+	if data.ForceDelete.ValueBool() {
+		err = deleteIP4Children(client, id, "IP4Network")
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to delete the children of the IP4 Network", err, bamEntity("IP4 Network", data.ID.ValueString()))
+			return
+		}
+	}
+
 	err = client.Delete(id)
 	if err != nil {
-		resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+		fault := parseBAMFault(err)
+		detail := fault.detail(bamEntity("IP4 Network", data.ID.ValueString()))
+		// list what is in the way, as the API error does not say
+		children, childErr := getIP4BlockingChildren(client, id, "IP4Network")
+		if childErr == nil && len(children) > 0 {
+			detail += "\n\n" + ip4BlockingChildrenDetail("IP4Network", children)
+		}
+
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
 		resp.Diagnostics.AddError(
-			"Delete failed",
-			err.Error(),
+			fault.summary("Delete failed"),
+			detail,
 		)
 		return
 	}
 
-	resp.Diagnostics.Append(clientLogout(ctx, &client, mutex)...)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+// getNextAvailableIP4Network allocates the next available network in the first
+// of parentIDs that has one available.
+func getNextAvailableIP4Network(ctx context.Context, client gobam.ProteusAPI, parentIDs []int64, size int64, objectType string, properties string) (*gobam.APIEntity, error) {
+	var errs []error
+
+	for _, parentID := range parentIDs {
+		network, err := retryAllocation(ctx, func() (*gobam.APIEntity, error) {
+			allocationMutex.Lock()
+			defer allocationMutex.Unlock()
+			return client.GetNextAvailableIPRange(parentID, size, objectType, properties)
+		})
+		if err == nil && network.Id != nil && *network.Id != 0 {
+			return network, nil
+		}
+
+		if err == nil {
+			err = fmt.Errorf("no network of size %d is available", size)
+		}
+		tflog.Warn(ctx, fmt.Sprintf("Failed to allocate a network in parent %d, trying the next parent: %s", parentID, err))
+		errs = append(errs, fmt.Errorf("parent %d: %w", parentID, err))
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// getIP4GatewayAddressID returns the object ID of the address reserved as the
+// gateway of a network, or null if the network has no gateway.
+func getIP4GatewayAddressID(client gobam.ProteusAPI, networkID int64, gateway string) (types.Int64, error) {
+	if gateway == "" {
+		return types.Int64Null(), nil
+	}
+
+	address, err := client.GetIP4Address(networkID, gateway)
+	if err != nil {
+		return types.Int64Null(), err
+	}
+
+	if address.Id == nil || *address.Id == 0 {
+		return types.Int64Null(), nil
+	}
+
+	return types.Int64Value(*address.Id), nil
+}
+
+// removeIP4GatewayAddress deletes the address that was the gateway of a network
+// if it is still reserved as a gateway.
+func removeIP4GatewayAddress(client gobam.ProteusAPI, networkID int64, gateway string) error {
+	if gateway == "" {
+		return nil
+	}
+
+	address, err := client.GetIP4Address(networkID, gateway)
+	if err != nil {
+		return err
+	}
+
+	if address.Id == nil || *address.Id == 0 || address.Properties == nil {
+		return nil
+	}
+
+	if parseProperties(*address.Properties)["state"] != "GATEWAY" {
+		return nil
+	}
+
+	return client.Delete(*address.Id)
+}
+
+// updateIP4SharedNetwork shares a network with the shared network tag tagID,
+// or unshares it if tagID is null. sharedNetwork is the name of the tag the
+// network is currently shared with, if any.
+func updateIP4SharedNetwork(client gobam.ProteusAPI, networkID int64, sharedNetwork string, tagID types.Int64) error {
+	if sharedNetwork != "" {
+		err := client.UnshareNetwork(networkID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if tagID.IsNull() {
+		return nil
+	}
+
+	return client.ShareNetwork(networkID, tagID.ValueInt64())
+}
+
+// getIP4SharedNetworkTagID returns tagID if it is still the shared network tag
+// named sharedNetwork, or null if the network was unshared or shared with a
+// different tag outside of terraform.
+func getIP4SharedNetworkTagID(client gobam.ProteusAPI, tagID types.Int64, sharedNetwork string) (types.Int64, error) {
+	if tagID.IsNull() || sharedNetwork == "" {
+		return types.Int64Null(), nil
+	}
+
+	tag, err := client.GetEntityById(tagID.ValueInt64())
+	if err != nil {
+		return types.Int64Null(), err
+	}
+
+	if tag.Id == nil || *tag.Id == 0 || tag.Name == nil || *tag.Name != sharedNetwork {
+		return types.Int64Null(), nil
+	}
+
+	return tagID, nil
+}
+
+func (r *IP4NetworkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanUserDefinedFields(ctx, r.client, "IP4Network", req, resp)
+	modifyPlanLocationCode(ctx, r.client, req, resp)
+	r.modifyPlanCapacity(ctx, req, resp)
+}
+
+// modifyPlanCapacity checks that a network of size can fit in one of its
+// parent blocks when a new network is planned, so that a full block is found
+// when planning instead of failing the apply.
+func (r *IP4NetworkResource) modifyPlanCapacity(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// only new networks of size are allocated
+	if r.client == nil || req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan IP4NetworkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !plan.CIDR.IsNull() || plan.Size.IsNull() || plan.Size.IsUnknown() {
+		return
+	}
+
+	parentIDs := []int64{}
+	if !plan.ParentBlockIDs.IsNull() {
+		if plan.ParentBlockIDs.IsUnknown() {
+			return
+		}
+		resp.Diagnostics.Append(plan.ParentBlockIDs.ElementsAs(ctx, &parentIDs, true)...)
+	} else if !plan.ParentID.IsUnknown() {
+		parentIDs = append(parentIDs, plan.ParentID.ValueInt64())
+	}
+	if resp.Diagnostics.HasError() || len(parentIDs) == 0 || slices.Contains(parentIDs, 0) {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	size := plan.Size.ValueInt64()
+	for _, parentID := range parentIDs {
+		fits, err := ip4BlockHasFreeRange(client, parentID, size)
+		if err != nil {
+			// the apply reports problems with the parent itself
+			tflog.Warn(ctx, fmt.Sprintf("Failed to check the capacity of parent %d: %s", parentID, err))
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			return
+		}
+		if fits {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	summary := "Insufficient Capacity"
+	detail := fmt.Sprintf("None of the parent blocks %v has a free range of %d addresses, so allocating the network is expected to fail.", parentIDs, size)
+	if plan.FailIfInsufficient.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("size"), summary, detail)
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(path.Root("size"), summary, detail+" Set fail_if_insufficient to true to fail the plan instead.")
+}
+
+func (r *IP4NetworkResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// version 0 is the state of the sdk based releases, and of the
+		// framework based releases until the schema had a version
+		0: {StateUpgrader: upgradeLegacyState},
+	}
 }
 
 func (r *IP4NetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importIP4Range(ctx, r.client, "IP4Network", req, resp)
 }
 
 func (r IP4NetworkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -779,6 +1394,15 @@ func (r IP4NetworkResource) ValidateConfig(ctx context.Context, req resource.Val
 		return
 	}
 
+	// a gateway cannot be set on a network without a gateway
+	if !data.CreateGateway.IsNull() && !data.CreateGateway.ValueBool() && !data.Gateway.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("gateway"),
+			"Attribute Conflict",
+			"gateway cannot be configured if create_gateway is false.",
+		)
+	}
+
 	// if inherit_allow_duplicate_host is true, allow_duplicate_host must be unset
 	if data.InheritAllowDuplicateHost.ValueBool() && !data.AllowDuplicateHost.IsNull() {
 		resp.Diagnostics.AddAttributeError(
@@ -0,0 +1,374 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IP4NetworkSplitResource{}
+var _ resource.ResourceWithValidateConfig = &IP4NetworkSplitResource{}
+
+func NewIP4NetworkSplitResource() resource.Resource {
+	return &IP4NetworkSplitResource{}
+}
+
+// IP4NetworkSplitResource defines the resource implementation.
+type IP4NetworkSplitResource struct {
+	client *loginClient
+}
+
+// IP4NetworkSplitResourceModel describes the resource data model.
+type IP4NetworkSplitResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	NetworkID            types.Int64  `tfsdk:"network_id"`
+	NumberOfParts        types.Int64  `tfsdk:"number_of_parts"`
+	AssignDefaultGateway types.Bool   `tfsdk:"assign_default_gateway"`
+	OverwriteConflicts   types.Bool   `tfsdk:"overwrite_conflicts"`
+	NetworkIDs           types.List   `tfsdk:"network_ids"`
+	CIDRs                types.List   `tfsdk:"cidrs"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *IP4NetworkSplitResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip4_network_split"
+}
+
+func (r *IP4NetworkSplitResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource to split an IPv4 network into equally sized networks, for example a /23 into two /24s, without recreating the addresses in it. The network that was split no longer exists afterwards, so if it is managed by a `bluecat_ip4_network` resource, that resource should be removed from the state. Destroying this resource only removes it from the state; the networks are not merged again.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The object ID of the network that was split.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"network_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the IPv4 network to split. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"number_of_parts": schema.Int64Attribute{
+				MarkdownDescription: "The number of networks to split the network into. Must be a power of 2. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(2),
+				},
+			},
+			"assign_default_gateway": schema.BoolAttribute{
+				MarkdownDescription: "If the first address of each new network should be made its gateway. Defaults to `true`. If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"overwrite_conflicts": schema.BoolAttribute{
+				MarkdownDescription: "If addresses that conflict with the new gateways should be overwritten. Defaults to `false`. If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"network_ids": schema.ListAttribute{
+				MarkdownDescription: "The object IDs of the networks the network was split into, ordered by address. Networks that have since been deleted are removed from the list.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cidrs": schema.ListAttribute{
+				MarkdownDescription: "The CIDRs of the networks in `network_ids`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *IP4NetworkSplitResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IP4NetworkSplitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4NetworkSplitResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	networkID := data.NetworkID.ValueInt64()
+
+	network, err := client.GetEntityById(networkID)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Network by Id", err, "")
+		return
+	}
+
+	if network.Id == nil || *network.Id == 0 || network.Type == nil || *network.Type != "IP4Network" {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddAttributeError(
+			path.Root("network_id"),
+			"IP4 Network not found",
+			fmt.Sprintf("Object %d is not an IPv4 network.", networkID),
+		)
+		return
+	}
+
+	options := propertyMap{}
+	options.setBool("assignDefaultGateway", data.AssignDefaultGateway.ValueBool())
+	options.setBool("overwriteConflicts", data.OverwriteConflicts.ValueBool())
+
+	networks, err := client.SplitIP4Network(networkID, int(data.NumberOfParts.ValueInt64()), options.String())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to split IP4 Network", err, "")
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(networkID, 10))
+	resp.Diagnostics.Append(data.setNetworks(ctx, networks.Item)...)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4NetworkSplitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *IP4NetworkSplitResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	var networkIDs []int64
+	resp.Diagnostics.Append(data.NetworkIDs.ElementsAs(ctx, &networkIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		return
+	}
+
+	networks := []*gobam.APIEntity{}
+	for _, id := range networkIDs {
+		network, err := client.GetEntityById(id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get IP4 Network by Id", err, bamEntity("IP4 Network", data.ID.ValueString()))
+			return
+		}
+
+		if network.Id == nil || *network.Id == 0 {
+			continue
+		}
+
+		ok, diag := entityTypeMatches(network, "IP4Network")
+		resp.Diagnostics.Append(diag...)
+		if ok {
+			networks = append(networks, network)
+		}
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	if len(networks) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setNetworks(ctx, networks)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4NetworkSplitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4NetworkSplitResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every argument of the split forces replacement, so the only changes
+	// that can reach Update are to the credentials.
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4NetworkSplitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Deleting the split only removes it from the state, so it is allowed in
+	// read-only mode.
+
+	var data *IP4NetworkSplitResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A split cannot be undone, so the networks are left as they are.
+	tflog.Warn(ctx, fmt.Sprintf("Removing the split of IP4 Network %s from the state; the networks it was split into are not merged", data.ID.ValueString()))
+}
+
+func (r IP4NetworkSplitResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data IP4NetworkSplitResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if parts := data.NumberOfParts.ValueInt64(); !data.NumberOfParts.IsUnknown() && parts&(parts-1) != 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("number_of_parts"),
+			"Invalid Number of Parts",
+			fmt.Sprintf("number_of_parts must be a power of 2, got %d.", parts),
+		)
+	}
+}
+
+// setNetworks sets network_ids and cidrs from networks, ordered by address.
+func (m *IP4NetworkSplitResourceModel) setNetworks(ctx context.Context, networks []*gobam.APIEntity) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sortIP4NetworksByAddress(networks)
+
+	networkIDs := make([]int64, 0, len(networks))
+	cidrs := make([]string, 0, len(networks))
+	for _, network := range networks {
+		networkIDs = append(networkIDs, *network.Id)
+
+		cidr := ""
+		if network.Properties != nil {
+			cidr = parseProperties(*network.Properties)["CIDR"]
+		}
+		cidrs = append(cidrs, cidr)
+	}
+
+	var d diag.Diagnostics
+	m.NetworkIDs, d = types.ListValueFrom(ctx, types.Int64Type, networkIDs)
+	diags.Append(d...)
+	m.CIDRs, d = types.ListValueFrom(ctx, types.StringType, cidrs)
+	diags.Append(d...)
+
+	return diags
+}
+
+// sortIP4NetworksByAddress sorts IPv4 networks by the network address of
+// their CIDR property. Networks without a valid CIDR are sorted last.
+func sortIP4NetworksByAddress(networks []*gobam.APIEntity) {
+	address := func(network *gobam.APIEntity) uint64 {
+		if network.Properties == nil {
+			return 1 << 32
+		}
+
+		ip, _, err := net.ParseCIDR(parseProperties(*network.Properties)["CIDR"])
+		if err != nil || ip.To4() == nil {
+			return 1 << 32
+		}
+
+		return uint64(binary.BigEndian.Uint32(ip.To4()))
+	}
+
+	slices.SortStableFunc(networks, func(a, b *gobam.APIEntity) int {
+		return cmp.Compare(address(a), address(b))
+	})
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccIP4NetworkSplitResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccIP4NetworkSplitResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_ip4_network_split.test", "network_ids.#", "2"),
+					resource.TestCheckResourceAttr("bluecat_ip4_network_split.test", "cidrs.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccIP4NetworkSplitResourceConfig = `
+variable "ip4_network_id" {
+	type = number
+}
+
+resource "bluecat_ip4_network_split" "test" {
+	network_id      = var.ip4_network_id
+	number_of_parts = 2
+}
+`
+
+func TestSortIP4NetworksByAddress(t *testing.T) {
+	network := func(id int64, properties string) *gobam.APIEntity {
+		return &gobam.APIEntity{Id: &id, Properties: &properties}
+	}
+
+	networks := []*gobam.APIEntity{
+		network(1, "CIDR=10.0.1.128/25|"),
+		network(2, "name=no cidr|"),
+		network(3, "CIDR=10.0.1.0/25|"),
+		network(4, "CIDR=10.0.0.0/24|"),
+	}
+
+	sortIP4NetworksByAddress(networks)
+
+	expected := []int64{4, 3, 1, 2}
+	for i, id := range expected {
+		if *networks[i].Id != id {
+			t.Fatalf("expected networks in order %v, got %d at position %d", expected, *networks[i].Id, i)
+		}
+	}
+}
+
+func TestIP4NetworkSplitDeleteReadOnly(t *testing.T) {
+	ctx := context.Background()
+	r := &IP4NetworkSplitResource{client: &loginClient{ReadOnly: true}}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	attributes["id"] = tftypes.NewValue(tftypes.String, "1234")
+
+	req := fwresource.DeleteRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}}
+	resp := &fwresource.DeleteResponse{State: req.State}
+
+	// deleting the split only removes it from the state
+	r.Delete(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("expected the split to be removed from the state in read-only mode, got %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,421 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IP4NetworkTemplateResource{}
+var _ resource.ResourceWithImportState = &IP4NetworkTemplateResource{}
+var _ resource.ResourceWithModifyPlan = &IP4NetworkTemplateResource{}
+
+func NewIP4NetworkTemplateResource() resource.Resource {
+	return &IP4NetworkTemplateResource{}
+}
+
+// IP4NetworkTemplateResource defines the resource implementation.
+type IP4NetworkTemplateResource struct {
+	client *loginClient
+}
+
+// IP4NetworkTemplateResourceModel describes the resource data model.
+type IP4NetworkTemplateResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ConfigurationID   types.Int64  `tfsdk:"configuration_id"`
+	Name              types.String `tfsdk:"name"`
+	Comments          types.String `tfsdk:"comments"`
+	UserDefinedFields types.Map    `tfsdk:"user_defined_fields"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *IP4NetworkTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip4_network_template"
+}
+
+func (r *IP4NetworkTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource to create an IPv4 network template that can be linked to networks with the `template` argument of `bluecat_ip4_network`. DHCP and DNS options can be added to the template with `bluecat_dhcp_client_option`, `bluecat_dhcp_service_option`, and `bluecat_dns_option`.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "IPv4 network template identifier.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"configuration_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the Configuration that holds the template." + defaultConfigurationDescription + " If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the template.",
+				Required:            true,
+			},
+			"comments": schema.StringAttribute{
+				MarkdownDescription: "Comments about the template.",
+				Optional:            true,
+			},
+			"user_defined_fields": schema.MapAttribute{
+				MarkdownDescription: "A map of all user-definied fields associated with the template.",
+				Computed:            true,
+				Optional:            true,
+				Default:             mapdefault.StaticValue(basetypes.NewMapValueMust(types.StringType, nil)),
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(propertyNameRegexp, propertyNameRegexpMessage)),
+				},
+			},
+		},
+	}
+}
+
+func (r *IP4NetworkTemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IP4NetworkTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4NetworkTemplateResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	configID, diag := r.client.configurationID(client, data.ConfigurationID)
+	if diag.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	data.ConfigurationID = types.Int64Value(configID)
+
+	var udfs map[string]string
+	resp.Diagnostics.Append(data.UserDefinedFields.ElementsAs(ctx, &udfs, false)...)
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		return
+	}
+
+	properties := propertyMap{}
+	if !data.Comments.IsNull() {
+		properties.set("comments", data.Comments.ValueString())
+	}
+	properties.setAll(udfs)
+	properties.setManaged(r.client)
+
+	id, err := client.AddIP4NetworkTemplate(configID, data.Name.ValueString(), properties.String())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to create IP4 Network Template", err, "")
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(id, 10))
+
+	resp.Diagnostics.Append(r.refresh(client, data, id)...)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(setTaintedState(ctx, &resp.State, data, "IP4 Network Template", data.ID.ValueString())...)
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4NetworkTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *IP4NetworkTemplateResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
+	}
+
+	entity, err := client.GetEntityById(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get IP4 Network Template by Id", err, bamEntity("IP4 Network Template", data.ID.ValueString()))
+		return
+	}
+
+	if entity.Id == nil || *entity.Id == 0 {
+		tflog.Trace(ctx, "IP4 Network Template was deleted outside terraform")
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if ok, diag := entityTypeMatches(entity, "IP4NetworkTemplate"); !ok {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// imported templates only have an ID
+	if data.ConfigurationID.IsNull() || data.ConfigurationID.IsUnknown() {
+		configID, err := getConfigurationID(client, id)
+		if err != nil {
+			resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+			addBAMError(&resp.Diagnostics, "Failed to get configuration of IP4 Network Template", err, bamEntity("IP4 Network Template", data.ID.ValueString()))
+			return
+		}
+		data.ConfigurationID = types.Int64Value(configID)
+	}
+
+	resp.Diagnostics.Append(data.flatten(client, r.client, entity)...)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4NetworkTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4NetworkTemplateResourceModel
+	var state *IP4NetworkTemplateResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	properties := propertyMap{}
+	if !data.Comments.Equal(state.Comments) {
+		properties.set("comments", data.Comments.ValueString())
+	}
+
+	udfProperties, udfDiag := userDefinedFieldsUpdateProperties(ctx, data.UserDefinedFields, state.UserDefinedFields, r.client)
+	resp.Diagnostics.Append(udfDiag...)
+	properties.setAll(udfProperties)
+
+	objectType := "IP4NetworkTemplate"
+	update := gobam.APIEntity{
+		Id:         &id,
+		Name:       data.Name.ValueStringPointer(),
+		Type:       &objectType,
+		Properties: properties.stringPointer(),
+	}
+
+	err = client.Update(&update)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "IP4 Network Template Update failed", err, bamEntity("IP4 Network Template", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(r.refresh(client, data, id)...)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IP4NetworkTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	resp.Diagnostics.Append(deleteProtectionCheck(r.client, "IP4NetworkTemplate")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *IP4NetworkTemplateResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	err = client.Delete(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Delete failed", err, bamEntity("IP4 Network Template", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+func (r *IP4NetworkTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanUserDefinedFields(ctx, r.client, "IP4NetworkTemplate", req, resp)
+}
+
+func (r *IP4NetworkTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// refresh reads the template with id back into data after it is created or
+// updated.
+func (r *IP4NetworkTemplateResource) refresh(client gobam.ProteusAPI, data *IP4NetworkTemplateResourceModel, id int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	entity, err := client.GetEntityById(id)
+	if err != nil {
+		addBAMError(&diags, "Failed to get IP4 Network Template by Id", err, bamEntity("IP4 Network Template", data.ID.ValueString()))
+		return diags
+	}
+
+	diags.Append(data.flatten(client, r.client, entity)...)
+
+	return diags
+}
+
+// flatten sets the model from a template returned by the API. Only the
+// properties that are user-defined fields of IP4NetworkTemplate objects are
+// reported in user_defined_fields, as templates have built-in properties for
+// their settings that are not managed by this resource.
+func (m *IP4NetworkTemplateResourceModel) flatten(client gobam.ProteusAPI, loginClient *loginClient, e *gobam.APIEntity) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.Name = types.StringPointerValue(e.Name)
+
+	props := map[string]string{}
+	if e.Properties != nil {
+		props = parseProperties(*e.Properties)
+	}
+
+	m.Comments = optionalStringProperty(props, "comments")
+
+	definitions, err := loginClient.UDFDefinitions.get(client, "IP4NetworkTemplate")
+	if err != nil {
+		addBAMError(&diags, "Failed to get user-defined fields of IP4NetworkTemplate objects", err, "")
+		return diags
+	}
+
+	udfs := make(map[string]attr.Value)
+	for _, definition := range definitions {
+		if definition == nil || definition.Name == nil {
+			continue
+		}
+		if v, ok := props[*definition.Name]; ok {
+			udfs[*definition.Name] = types.StringValue(v)
+		}
+	}
+
+	m.UserDefinedFields = userDefinedFieldsState(m.UserDefinedFields, types.MapValueMust(types.StringType, udfs), loginClient)
+
+	return diags
+}
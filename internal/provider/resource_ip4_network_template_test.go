@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccIP4NetworkTemplateResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccIP4NetworkTemplateResourceConfig("Terraform Acceptance Test"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("bluecat_ip4_network_template.test", "id", validateObjectID),
+					resource.TestCheckResourceAttrSet("bluecat_ip4_network_template.test", "configuration_id"),
+					resource.TestCheckResourceAttr("bluecat_ip4_network_template.test", "comments", "Created by the terraform acceptance tests"),
+					resource.TestCheckResourceAttrPair("data.bluecat_ip4_network_template.test", "id", "bluecat_ip4_network_template.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bluecat_ip4_network_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccIP4NetworkTemplateResourceConfig("Terraform Acceptance Test Updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_ip4_network_template.test", "name", "Terraform Acceptance Test Updated"),
+				),
+			},
+		},
+	})
+}
+
+func testAccIP4NetworkTemplateResourceConfig(name string) string {
+	return `
+resource "bluecat_ip4_network_template" "test" {
+  name     = "` + name + `"
+  comments = "Created by the terraform acceptance tests"
+}
+
+data "bluecat_ip4_network_template" "test" {
+  name = bluecat_ip4_network_template.test.name
+}
+`
+}
+
+func TestIP4NetworkTemplateResourceModelFlatten(t *testing.T) {
+	name := "Office Template"
+	properties := "comments=office networks|gatewayOffset=1|owner=netops|"
+	client := &udfDefinitionClient{fields: map[string][]*gobam.APIUserDefinedField{
+		"IP4NetworkTemplate": {testUDFDefinition("owner", "TEXT", "")},
+	}}
+	loginClient := &loginClient{UDFDefinitions: &udfDefinitionCache{}}
+
+	data := &IP4NetworkTemplateResourceModel{UserDefinedFields: types.MapNull(types.StringType)}
+	diags := data.flatten(client, loginClient, &gobam.APIEntity{Name: &name, Properties: &properties})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if data.Name.ValueString() != name || data.Comments.ValueString() != "office networks" {
+		t.Errorf("unexpected name or comments: %s %s", data.Name, data.Comments)
+	}
+	expected := types.MapValueMust(types.StringType, map[string]attr.Value{"owner": types.StringValue("netops")})
+	if !data.UserDefinedFields.Equal(expected) {
+		t.Errorf("expected only user-defined fields to be reported, got %s", data.UserDefinedFields)
+	}
+}
@@ -0,0 +1,333 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+// nextAvailableRangeClient allocates ranges only in the parents in available.
+type nextAvailableRangeClient struct {
+	gobam.ProteusAPI
+	available map[int64]int64
+	tried     []int64
+}
+
+func (c *nextAvailableRangeClient) GetNextAvailableIPRange(parentId int64, size int64, _type string, properties string) (*gobam.APIEntity, error) {
+	c.tried = append(c.tried, parentId)
+
+	id, ok := c.available[parentId]
+	if !ok {
+		return nil, fmt.Errorf("no range of size %d is available in %d", size, parentId)
+	}
+
+	return &gobam.APIEntity{Id: &id, Type: &_type}, nil
+}
+
+func TestGetNextAvailableIP4Network(t *testing.T) {
+	client := &nextAvailableRangeClient{available: map[int64]int64{3: 30, 4: 40}}
+
+	network, err := getNextAvailableIP4Network(context.Background(), client, []int64{1, 2, 3, 4}, 256, "IP4Network", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *network.Id != 30 {
+		t.Errorf("expected the network in the first available parent, got %d", *network.Id)
+	}
+	if fmt.Sprint(client.tried) != "[1 2 3]" {
+		t.Errorf("expected parents to be tried in order, got %v", client.tried)
+	}
+
+	client = &nextAvailableRangeClient{}
+	if _, err := getNextAvailableIP4Network(context.Background(), client, []int64{1, 2}, 256, "IP4Network", ""); err == nil {
+		t.Error("expected an error when no parent has a network available")
+	}
+}
+
+// gatewayAddressClient returns addresses in the given states and records the
+// addresses that were deleted.
+type gatewayAddressClient struct {
+	gobam.ProteusAPI
+	states  map[string]string
+	deleted []int64
+}
+
+func (c *gatewayAddressClient) GetIP4Address(containerId int64, address string) (*gobam.APIEntity, error) {
+	state, ok := c.states[address]
+	if !ok {
+		return &gobam.APIEntity{}, nil
+	}
+
+	id := int64(len(address))
+	properties := "address=" + address + "|state=" + state + "|"
+	return &gobam.APIEntity{Id: &id, Properties: &properties}, nil
+}
+
+func (c *gatewayAddressClient) Delete(objectId int64) error {
+	c.deleted = append(c.deleted, objectId)
+	return nil
+}
+
+func TestRemoveIP4GatewayAddress(t *testing.T) {
+	client := &gatewayAddressClient{states: map[string]string{"10.0.0.1": "GATEWAY", "10.0.0.10": "STATIC"}}
+
+	for _, gateway := range []string{"", "10.0.0.10", "10.0.0.99"} {
+		if err := removeIP4GatewayAddress(client, 1, gateway); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(client.deleted) != 0 {
+		t.Errorf("expected addresses that are not gateways to be kept, deleted %v", client.deleted)
+	}
+
+	if err := removeIP4GatewayAddress(client, 1, "10.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.deleted) != 1 {
+		t.Errorf("expected the gateway address to be deleted, deleted %v", client.deleted)
+	}
+}
+
+func TestIP4DefaultGateway(t *testing.T) {
+	gateway, err := ip4DefaultGateway("10.0.255.0/23")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gateway != "10.0.254.1" {
+		t.Errorf("expected 10.0.254.1, got %s", gateway)
+	}
+}
+
+func TestGetIP4GatewayAddressID(t *testing.T) {
+	client := &gatewayAddressClient{states: map[string]string{"10.0.0.1": "GATEWAY"}}
+
+	id, err := getIP4GatewayAddressID(client, 1, "10.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.ValueInt64() != int64(len("10.0.0.1")) {
+		t.Errorf("expected the ID of the gateway address, got %s", id)
+	}
+
+	for _, gateway := range []string{"", "10.0.0.99"} {
+		id, err := getIP4GatewayAddressID(client, 1, gateway)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !id.IsNull() {
+			t.Errorf("expected no gateway address for %q, got %s", gateway, id)
+		}
+	}
+}
+
+// sharedNetworkClient records the shared network calls made for a network
+// and returns tags by ID.
+type sharedNetworkClient struct {
+	gobam.ProteusAPI
+	tags  map[int64]string
+	calls []string
+}
+
+func (c *sharedNetworkClient) GetEntityById(id int64) (*gobam.APIEntity, error) {
+	name, ok := c.tags[id]
+	if !ok {
+		return &gobam.APIEntity{}, nil
+	}
+
+	return &gobam.APIEntity{Id: &id, Name: &name}, nil
+}
+
+func (c *sharedNetworkClient) ShareNetwork(networkId int64, tagId int64) error {
+	c.calls = append(c.calls, fmt.Sprintf("share %d", tagId))
+	return nil
+}
+
+func (c *sharedNetworkClient) UnshareNetwork(networkId int64) error {
+	c.calls = append(c.calls, "unshare")
+	return nil
+}
+
+func TestUpdateIP4SharedNetwork(t *testing.T) {
+	tests := map[string]struct {
+		sharedNetwork string
+		tagID         types.Int64
+		expected      []string
+	}{
+		"share":   {tagID: types.Int64Value(5), expected: []string{"share 5"}},
+		"reshare": {sharedNetwork: "vlan10", tagID: types.Int64Value(5), expected: []string{"unshare", "share 5"}},
+		"unshare": {sharedNetwork: "vlan10", tagID: types.Int64Null(), expected: []string{"unshare"}},
+		"noop":    {tagID: types.Int64Null()},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &sharedNetworkClient{}
+			if err := updateIP4SharedNetwork(client, 1, tc.sharedNetwork, tc.tagID); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(client.calls, tc.expected) {
+				t.Errorf("expected calls %v, got %v", tc.expected, client.calls)
+			}
+		})
+	}
+}
+
+func TestGetIP4SharedNetworkTagID(t *testing.T) {
+	client := &sharedNetworkClient{tags: map[int64]string{5: "vlan10"}}
+
+	tests := map[string]struct {
+		tagID         types.Int64
+		sharedNetwork string
+		expected      types.Int64
+	}{
+		"shared":      {tagID: types.Int64Value(5), sharedNetwork: "vlan10", expected: types.Int64Value(5)},
+		"unshared":    {tagID: types.Int64Value(5), expected: types.Int64Null()},
+		"other tag":   {tagID: types.Int64Value(5), sharedNetwork: "vlan20", expected: types.Int64Null()},
+		"deleted tag": {tagID: types.Int64Value(6), sharedNetwork: "vlan10", expected: types.Int64Null()},
+		"not managed": {tagID: types.Int64Null(), sharedNetwork: "vlan10", expected: types.Int64Null()},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tagID, err := getIP4SharedNetworkTagID(client, tc.tagID, tc.sharedNetwork)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tagID.Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, tagID)
+			}
+		})
+	}
+}
+
+func TestFlattenIP4NetworkPropertiesDynamicUpdate(t *testing.T) {
+	objectType := "IP4Network"
+	properties := "CIDR=10.0.0.0/24|dynamicUpdate=true|environment=prod|"
+
+	network, diags := flattenIP4NetworkProperties(&gobam.APIEntity{Type: &objectType, Properties: &properties})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !network.DynamicUpdate.Equal(types.BoolValue(true)) {
+		t.Errorf("expected dynamic_update to be true, got %s", network.DynamicUpdate)
+	}
+	if _, ok := network.UserDefinedFields.Elements()["dynamicUpdate"]; ok {
+		t.Error("expected dynamicUpdate to not be reported as a user-defined field")
+	}
+}
+
+func TestFlattenIP4NetworkPropertiesComments(t *testing.T) {
+	objectType := "IP4Network"
+	properties := "CIDR=10.0.0.0/24|comments=lab network|environment=prod|"
+
+	network, diags := flattenIP4NetworkProperties(&gobam.APIEntity{Type: &objectType, Properties: &properties})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !network.Comments.Equal(types.StringValue("lab network")) {
+		t.Errorf("expected comments to be %q, got %s", "lab network", network.Comments)
+	}
+	if _, ok := network.UserDefinedFields.Elements()["comments"]; ok {
+		t.Error("expected comments to not be reported as a user-defined field")
+	}
+}
+
+func TestUserDefinedFieldsUpdateProperties(t *testing.T) {
+	ctx := context.Background()
+	state, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"owner": "alice", "tenant": "a"})
+	plan, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"owner": "bob", "site": "east"})
+
+	properties, diags := userDefinedFieldsUpdateProperties(ctx, plan, state, nil)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if expected := "owner=bob|site=east|tenant=|"; properties.String() != expected {
+		t.Errorf("expected %q, got %q", expected, properties)
+	}
+
+	properties, diags = userDefinedFieldsUpdateProperties(ctx, state, state, nil)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(properties) != 0 {
+		t.Errorf("expected no properties for unchanged fields, got %q", properties)
+	}
+}
+
+func TestUserDefinedFieldsManagedKeys(t *testing.T) {
+	ctx := context.Background()
+	loginClient := &loginClient{ManagedUDF: "managed", ManagedUDFKeys: true}
+	state, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"owner": "alice", "tenant": "a"})
+	plan, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"owner": "bob", "site": "east"})
+
+	properties, diags := userDefinedFieldsUpdateProperties(ctx, plan, state, loginClient)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if expected := "owner=bob|site=east|"; properties.String() != expected {
+		t.Errorf("expected %q, got %q", expected, properties)
+	}
+
+	read, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"owner": "bob", "site": "east", "tenant": "a", "managed": "true"})
+	expected, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"owner": "bob", "site": "east"})
+	if udfs := userDefinedFieldsState(plan, read, loginClient); !udfs.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, udfs)
+	}
+
+	all, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"owner": "bob", "site": "east", "tenant": "a"})
+	if udfs := userDefinedFieldsState(types.MapNull(types.StringType), read, loginClient); !udfs.Equal(all) {
+		t.Errorf("expected every field when importing, got %s", udfs)
+	}
+
+	loginClient.ManagedUDFKeys = false
+	if udfs := userDefinedFieldsState(plan, read, loginClient); !udfs.Equal(all) {
+		t.Errorf("expected every field without managed_udf_keys, got %s", udfs)
+	}
+}
+
+func TestAccIP4NetworkResourceUserDefinedFields(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccIP4NetworkResourceUserDefinedFieldsConfig(`{ (var.udf_name) = "test" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_ip4_network.test", "user_defined_fields.%", "1"),
+				),
+			},
+			// Update testing that removed keys are cleared
+			{
+				Config: testAccIP4NetworkResourceUserDefinedFieldsConfig(`{}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_ip4_network.test", "user_defined_fields.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccIP4NetworkResourceUserDefinedFieldsConfig(udfs string) string {
+	return fmt.Sprintf(`
+variable "ip4_block_parent_id" {
+  type = number
+}
+
+variable "udf_name" {
+  type = string
+}
+
+resource "bluecat_ip4_network" "test" {
+  parent_id           = var.ip4_block_parent_id
+  name                = "Test IPv4 Network"
+  size                = 256
+  user_defined_fields = %s
+}
+`, udfs)
+}
@@ -0,0 +1,379 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LocationResource{}
+var _ resource.ResourceWithImportState = &LocationResource{}
+
+func NewLocationResource() resource.Resource {
+	return &LocationResource{}
+}
+
+// LocationResource defines the resource implementation.
+type LocationResource struct {
+	client *loginClient
+}
+
+// LocationResourceModel describes the resource data model.
+type LocationResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Code        types.String `tfsdk:"code"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Latitude    types.String `tfsdk:"latitude"`
+	Longitude   types.String `tfsdk:"longitude"`
+	ParentID    types.Int64  `tfsdk:"parent_id"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *LocationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_location"
+}
+
+func (r *LocationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource to create a custom child location under an existing location.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Location identifier.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"code": schema.StringAttribute{
+				MarkdownDescription: "The full hierarchical code of the location, such as `US DTW OF1`. The location with the code minus its last part must already exist and will contain the new location. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(locationCodeRegexp, locationCodeRegexpMessage),
+					stringvalidator.RegexMatches(regexp.MustCompile(` `), "must contain the code of a parent location"),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the location.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A description of the location.",
+				Optional:            true,
+			},
+			"latitude": schema.StringAttribute{
+				MarkdownDescription: "The latitude of the location.",
+				Optional:            true,
+			},
+			"longitude": schema.StringAttribute{
+				MarkdownDescription: "The longitude of the location.",
+				Optional:            true,
+			},
+			"parent_id": schema.Int64Attribute{
+				MarkdownDescription: "The object ID of the location that contains this location.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *LocationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *LocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *LocationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	parentCode := locationParentCode(data.Code.ValueString())
+	parent, err := getLocationByCode(client, parentCode)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get parent location by code", err, "")
+		return
+	}
+
+	if parent == nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddAttributeError(
+			path.Root("code"),
+			"Parent location not found",
+			fmt.Sprintf("No location with code %q exists to contain %q.", parentCode, data.Code.ValueString()),
+		)
+		return
+	}
+
+	objectType := "Location"
+	properties := data.properties()
+	entity := gobam.APIEntity{
+		Name:       data.Name.ValueStringPointer(),
+		Type:       &objectType,
+		Properties: properties.stringPointer(),
+	}
+
+	id, err := client.AddEntity(*parent.Id, &entity)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to create location", err, "")
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(id, 10))
+	data.ParentID = types.Int64Value(*parent.Id)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *LocationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
+	}
+
+	entity, err := client.GetEntityById(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get location by Id", err, bamEntity("Location", data.ID.ValueString()))
+		return
+	}
+
+	if entity.Id == nil || *entity.Id == 0 {
+		tflog.Trace(ctx, "Location was deleted outside terraform")
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if ok, diag := entityTypeMatches(entity, "Location"); !ok {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		resp.Diagnostics.Append(diag...)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	parent, err := client.GetParent(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get parent location", err, bamEntity("Location", data.ID.ValueString()))
+		return
+	}
+	data.ParentID = types.Int64PointerValue(parent.Id)
+
+	data.flatten(entity)
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *LocationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	objectType := "Location"
+	properties := data.properties()
+	update := gobam.APIEntity{
+		Id:         &id,
+		Name:       data.Name.ValueStringPointer(),
+		Type:       &objectType,
+		Properties: properties.stringPointer(),
+	}
+
+	err = client.Update(&update)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Location Update failed", err, bamEntity("Location", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	resp.Diagnostics.Append(deleteProtectionCheck(r.client, "Location")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *LocationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse ID", err.Error())
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	err = client.Delete(id)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Delete failed", err, bamEntity("Location", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+func (r *LocationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// properties returns the properties of the location. Unset optional
+// properties are sent empty so that they are cleared on update.
+func (m *LocationResourceModel) properties() propertyMap {
+	return propertyMap{
+		"code":        m.Code.ValueString(),
+		"description": m.Description.ValueString(),
+		"latitude":    m.Latitude.ValueString(),
+		"longitude":   m.Longitude.ValueString(),
+	}
+}
+
+// flatten sets the model from a location returned by the API.
+func (m *LocationResourceModel) flatten(e *gobam.APIEntity) {
+	m.Name = types.StringPointerValue(e.Name)
+
+	props := map[string]string{}
+	if e.Properties != nil {
+		props = parseProperties(*e.Properties)
+	}
+
+	m.Code = types.StringValue(props["code"])
+	m.Description = optionalStringProperty(props, "description")
+	m.Latitude = optionalStringProperty(props, "latitude")
+	m.Longitude = optionalStringProperty(props, "longitude")
+}
+
+// optionalStringProperty returns a property as a string value that is null
+// when the property is not set.
+func optionalStringProperty(props map[string]string, name string) types.String {
+	if v := props[name]; v != "" {
+		return types.StringValue(v)
+	}
+
+	return types.StringNull()
+}
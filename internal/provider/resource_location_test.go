@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccLocationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccLocationResourceConfig("Terraform Acceptance Test"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("bluecat_location.test", "id", validateObjectID),
+					resource.TestCheckResourceAttr("bluecat_location.test", "code", "US DTW TFA"),
+					resource.TestCheckResourceAttrSet("bluecat_location.test", "parent_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bluecat_location.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccLocationResourceConfig("Terraform Acceptance Test Updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_location.test", "name", "Terraform Acceptance Test Updated"),
+				),
+			},
+		},
+	})
+}
+
+func testAccLocationResourceConfig(name string) string {
+	return `
+resource "bluecat_location" "test" {
+  code        = "US DTW TFA"
+  name        = "` + name + `"
+  description = "Created by the terraform acceptance tests"
+}
+`
+}
+
+// locationClient returns locations by code.
+type locationClient struct {
+	gobam.ProteusAPI
+	locations map[string]*gobam.APIEntity
+}
+
+func (c *locationClient) GetLocationByCode(code string) (*gobam.APIEntity, error) {
+	if location, ok := c.locations[code]; ok {
+		return location, nil
+	}
+	return &gobam.APIEntity{}, nil
+}
+
+func TestGetLocationByCode(t *testing.T) {
+	id := int64(10)
+	client := &locationClient{locations: map[string]*gobam.APIEntity{"US DTW": {Id: &id}}}
+
+	location, err := getLocationByCode(client, "US DTW")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location == nil || *location.Id != id {
+		t.Errorf("expected location %d, got %v", id, location)
+	}
+
+	location, err = getLocationByCode(client, "US DTW OF1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location != nil {
+		t.Errorf("expected no location, got %d", *location.Id)
+	}
+}
+
+func TestLocationParentCode(t *testing.T) {
+	tests := map[string]string{
+		"US":         "",
+		"US DTW":     "US",
+		"US DTW OF1": "US DTW",
+	}
+
+	for code, expected := range tests {
+		if parent := locationParentCode(code); parent != expected {
+			t.Errorf("expected parent of %q to be %q, got %q", code, expected, parent)
+		}
+	}
+}
+
+func TestLocationCodeRegexp(t *testing.T) {
+	for _, code := range []string{"US", "US DTW", "US DTW OF1"} {
+		if !locationCodeRegexp.MatchString(code) {
+			t.Errorf("expected %q to be a valid location code", code)
+		}
+	}
+
+	for _, code := range []string{"", "us dtw", "US  DTW", "US DTW ", "US-DTW"} {
+		if locationCodeRegexp.MatchString(code) {
+			t.Errorf("expected %q to be an invalid location code", code)
+		}
+	}
+}
+
+func TestLocationResourceModelFlatten(t *testing.T) {
+	name := "Office"
+	properties := "code=US DTW OF1|latitude=42.2|"
+
+	data := &LocationResourceModel{}
+	data.flatten(&gobam.APIEntity{Name: &name, Properties: &properties})
+
+	if data.Code.ValueString() != "US DTW OF1" || data.Name.ValueString() != name {
+		t.Errorf("unexpected code or name: %s %s", data.Code, data.Name)
+	}
+	if data.Latitude.ValueString() != "42.2" {
+		t.Errorf("expected latitude 42.2, got %s", data.Latitude)
+	}
+	if !data.Description.IsNull() || !data.Longitude.IsNull() {
+		t.Errorf("expected unset properties to be null, got %s %s", data.Description, data.Longitude)
+	}
+}
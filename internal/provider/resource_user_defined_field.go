@@ -0,0 +1,400 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umich-vci/gobam"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserDefinedFieldResource{}
+var _ resource.ResourceWithImportState = &UserDefinedFieldResource{}
+
+func NewUserDefinedFieldResource() resource.Resource {
+	return &UserDefinedFieldResource{}
+}
+
+// UserDefinedFieldResource defines the resource implementation.
+type UserDefinedFieldResource struct {
+	client *loginClient
+}
+
+// UserDefinedFieldResourceModel describes the resource data model.
+type UserDefinedFieldResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	ObjectType          types.String `tfsdk:"object_type"`
+	Name                types.String `tfsdk:"name"`
+	DisplayName         types.String `tfsdk:"display_name"`
+	DataType            types.String `tfsdk:"data_type"`
+	DefaultValue        types.String `tfsdk:"default_value"`
+	PredefinedValues    types.List   `tfsdk:"predefined_values"`
+	Required            types.Bool   `tfsdk:"required"`
+	HideFromSearch      types.Bool   `tfsdk:"hide_from_search"`
+	RenderAsRadioButton types.Bool   `tfsdk:"render_as_radio_button"`
+
+	// these override the provider credentials
+	AuthProfile types.String `tfsdk:"auth_profile"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (r *UserDefinedFieldResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_defined_field"
+}
+
+func (r *UserDefinedFieldResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource to define a user-defined field of an object type in BlueCat Address Manager.",
+
+		Attributes: map[string]schema.Attribute{
+			"auth_profile": authProfileSchemaAttribute(),
+			"credentials":  credentialsSchemaAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user-defined field in the form `<object_type>:<name>`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"object_type": schema.StringAttribute{
+				MarkdownDescription: "The object type the field is defined for, such as `IP4Network` or `HostRecord`. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the field, used as the key in `user_defined_fields`. If changed, forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(propertyNameRegexp, propertyNameRegexpMessage),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the field shown in the BlueCat Address Manager user interface.",
+				Required:            true,
+			},
+			"data_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the field. " + enumDescription(udfTypes) + " Defaults to `" + udfTypeText + "`. If changed, forces a new resource.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(udfTypeText),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(udfTypes...),
+				},
+			},
+			"default_value": schema.StringAttribute{
+				MarkdownDescription: "The value of the field for objects that do not set it.",
+				Optional:            true,
+			},
+			"predefined_values": schema.ListAttribute{
+				MarkdownDescription: "The values the field is limited to.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(
+						stringvalidator.LengthAtLeast(1),
+						// the values are sent to the API separated by '|'
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^|]*$`), "must not contain '|'"),
+					),
+				},
+			},
+			"required": schema.BoolAttribute{
+				MarkdownDescription: "If the field must be set on every object of `object_type`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"hide_from_search": schema.BoolAttribute{
+				MarkdownDescription: "If the field is hidden from search in the BlueCat Address Manager user interface. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"render_as_radio_button": schema.BoolAttribute{
+				MarkdownDescription: "If `predefined_values` are shown as radio buttons instead of a list in the BlueCat Address Manager user interface. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *UserDefinedFieldResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loginClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loginClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *UserDefinedFieldResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *UserDefinedFieldResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	udf, diag := data.expand(ctx)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	err := client.AddUserDefinedField(data.ObjectType.ValueString(), udf)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to create user-defined field", err, "")
+		return
+	}
+
+	data.ID = types.StringValue(data.ObjectType.ValueString() + ":" + data.Name.ValueString())
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserDefinedFieldResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *UserDefinedFieldResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	udf, err := getUserDefinedField(client, data.ObjectType.ValueString(), data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Failed to get user-defined fields", err, bamEntity("User-Defined Field", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	if udf == nil {
+		tflog.Trace(ctx, "User-defined field was deleted outside terraform")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, udf)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserDefinedFieldResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *UserDefinedFieldResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	udf, diag := data.expand(ctx)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, r.client, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	err := client.UpdateUserDefinedField(data.ObjectType.ValueString(), udf)
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "User-defined field Update failed", err, bamEntity("User-Defined Field", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserDefinedFieldResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(readOnlyCheck(r.client, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data *UserDefinedFieldResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diag := clientLoginWithCredentials(ctx, r.client, data.AuthProfile, data.Credentials)
+	resp.Diagnostics.Append(diag...)
+	if diag.HasError() {
+		return
+	}
+
+	err := client.DeleteUserDefinedField(data.ObjectType.ValueString(), data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+		addBAMError(&resp.Diagnostics, "Delete failed", err, bamEntity("User-Defined Field", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+}
+
+func (r *UserDefinedFieldResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	objectType, name, ok := strings.Cut(req.ID, ":")
+	if !ok || objectType == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <object_type>:<name>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object_type"), objectType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// expand returns the API representation of the user-defined field.
+func (m *UserDefinedFieldResourceModel) expand(ctx context.Context) (*gobam.APIUserDefinedField, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	predefinedValues := ""
+	if !m.PredefinedValues.IsNull() {
+		var values []string
+		diags.Append(m.PredefinedValues.ElementsAs(ctx, &values, false)...)
+		predefinedValues = strings.Join(values, "|") + "|"
+	}
+
+	return &gobam.APIUserDefinedField{
+		Name:                m.Name.ValueStringPointer(),
+		DisplayName:         m.DisplayName.ValueStringPointer(),
+		Type:                m.DataType.ValueStringPointer(),
+		DefaultValue:        m.DefaultValue.ValueStringPointer(),
+		PredefinedValues:    &predefinedValues,
+		Required:            m.Required.ValueBoolPointer(),
+		HideFromSearch:      m.HideFromSearch.ValueBoolPointer(),
+		RenderAsRadioButton: m.RenderAsRadioButton.ValueBoolPointer(),
+	}, diags
+}
+
+// flatten sets the model from the API representation of the user-defined field.
+func (m *UserDefinedFieldResourceModel) flatten(ctx context.Context, udf *gobam.APIUserDefinedField) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.DisplayName = types.StringPointerValue(udf.DisplayName)
+	m.DataType = types.StringPointerValue(udf.Type)
+	m.Required = types.BoolValue(udf.Required != nil && *udf.Required)
+	m.HideFromSearch = types.BoolValue(udf.HideFromSearch != nil && *udf.HideFromSearch)
+	m.RenderAsRadioButton = types.BoolValue(udf.RenderAsRadioButton != nil && *udf.RenderAsRadioButton)
+
+	m.DefaultValue = types.StringNull()
+	if udf.DefaultValue != nil && *udf.DefaultValue != "" {
+		m.DefaultValue = types.StringValue(*udf.DefaultValue)
+	}
+
+	m.PredefinedValues = types.ListNull(types.StringType)
+	if values := udfPredefinedValues(udf.PredefinedValues); len(values) > 0 {
+		var d diag.Diagnostics
+		m.PredefinedValues, d = types.ListValueFrom(ctx, types.StringType, values)
+		diags.Append(d...)
+	}
+
+	return diags
+}
+
+// getUserDefinedField returns the user-defined field named name of objectType,
+// or nil if it is not defined.
+func getUserDefinedField(client gobam.ProteusAPI, objectType string, name string) (*gobam.APIUserDefinedField, error) {
+	fields, err := client.GetUserDefinedFields(objectType, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, udf := range fields.Item {
+		if udf != nil && udf.Name != nil && *udf.Name == name {
+			return udf, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/umich-vci/gobam"
+)
+
+func TestAccUserDefinedFieldResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccUserDefinedFieldResourceConfig("Test Field"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_user_defined_field.test", "id", "IP4Network:tfAccTestField"),
+					resource.TestCheckResourceAttr("bluecat_user_defined_field.test", "data_type", "TEXT"),
+					resource.TestCheckResourceAttr("bluecat_user_defined_field.test", "predefined_values.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bluecat_user_defined_field.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccUserDefinedFieldResourceConfig("Updated Test Field"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bluecat_user_defined_field.test", "display_name", "Updated Test Field"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserDefinedFieldResourceConfig(displayName string) string {
+	return `
+resource "bluecat_user_defined_field" "test" {
+  object_type       = "IP4Network"
+  name              = "tfAccTestField"
+  display_name      = "` + displayName + `"
+  predefined_values = ["prod", "dev"]
+}
+`
+}
+
+func TestUserDefinedFieldResourceModelExpandFlatten(t *testing.T) {
+	ctx := context.Background()
+	values, _ := types.ListValueFrom(ctx, types.StringType, []string{"prod", "dev"})
+
+	data := &UserDefinedFieldResourceModel{
+		Name:                types.StringValue("environment"),
+		DisplayName:         types.StringValue("Environment"),
+		DataType:            types.StringValue(udfTypeText),
+		DefaultValue:        types.StringNull(),
+		PredefinedValues:    values,
+		Required:            types.BoolValue(true),
+		HideFromSearch:      types.BoolValue(false),
+		RenderAsRadioButton: types.BoolValue(false),
+	}
+
+	udf, diags := data.expand(ctx)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if *udf.PredefinedValues != "prod|dev|" {
+		t.Errorf("expected pipe delimited predefined values, got %q", *udf.PredefinedValues)
+	}
+
+	flattened := &UserDefinedFieldResourceModel{}
+	diags = flattened.flatten(ctx, udf)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !flattened.PredefinedValues.Equal(values) || !flattened.Required.Equal(types.BoolValue(true)) || !flattened.DefaultValue.IsNull() {
+		t.Errorf("expected the field to round trip, got %+v", flattened)
+	}
+}
+
+func TestGetUserDefinedField(t *testing.T) {
+	client := &udfDefinitionClient{fields: map[string][]*gobam.APIUserDefinedField{
+		"IP4Network": {testUDFDefinition("owner", udfTypeText, "")},
+	}}
+
+	udf, err := getUserDefinedField(client, "IP4Network", "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if udf == nil {
+		t.Fatal("expected the field to be found")
+	}
+
+	udf, err = getUserDefinedField(client, "IP4Block", "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if udf != nil {
+		t.Error("expected a field of another object type to not be found")
+	}
+}
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRetryDelay caps the exponential backoff between retries.
+const maxRetryDelay = 30 * time.Second
+
+// idempotentOperationPrefixes are the prefixes of the API operations that do
+// not modify anything in BlueCat Address Manager and are safe to send again
+// after a transient error.
+var idempotentOperationPrefixes = []string{"get", "search", "customSearch", "is", "login", "logout"}
+
+// createOperationPrefixes are the prefixes of the API operations that start
+// with one of idempotentOperationPrefixes but may create objects, such as
+// getNextAvailableIP4Network with autoCreate=true. Sending one again after the
+// server committed it would allocate a second object.
+var createOperationPrefixes = []string{"getNextAvailable"}
+
+// sessionExpiredFaults are substrings of the SOAP faults returned when the
+// session of a request is no longer valid.
+var sessionExpiredFaults = []string{"session expired", "session has expired", "not logged in", "invalid session"}
+
+// retryTransport is a http.RoundTripper that retries BlueCat Address Manager
+// API calls that fail with a transient error and logs in again when the
+// session has expired.
+type retryTransport struct {
+	base       http.RoundTripper
+	jar        http.CookieJar
+	maxRetries int
+	delay      time.Duration
+
+	// loginRequest is the last successful login request, which is replayed
+	// to get a new session.
+	mu           sync.Mutex
+	loginRequest *http.Request
+	loginBody    []byte
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	action := req.Header.Get("SOAPAction")
+	action = action[strings.LastIndex(action, "/")+1:]
+
+	body, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	idempotent := isIdempotentOperation(action)
+	relogged := false
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(cloneRequest(req, body))
+
+		var fault []byte
+		if err == nil && resp.StatusCode != http.StatusOK {
+			fault, err = io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(fault))
+		}
+
+		if err == nil && resp.StatusCode == http.StatusOK {
+			if action == "login" {
+				t.mu.Lock()
+				t.loginRequest = req
+				t.loginBody = body
+				t.mu.Unlock()
+			}
+			return resp, nil
+		}
+
+		// A request rejected because the session expired was not performed,
+		// so it is sent again after logging in regardless of the operation.
+		if err == nil && !relogged && action != "login" && action != "logout" && isSessionExpiredFault(fault) {
+			relogged = true
+			if loginErr := t.login(req); loginErr == nil {
+				req = requestWithCookies(req, t.jar)
+				attempt--
+				continue
+			}
+		}
+
+		if !idempotent || attempt >= t.maxRetries || (err == nil && !isTransientResponse(resp.StatusCode, fault)) {
+			return resp, err
+		}
+
+		if err := retryWait(req.Context(), retryDelay(t.delay, attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// login replays the last login request to get a new session and stores the
+// session cookie in the cookie jar of the client.
+func (t *retryTransport) login(req *http.Request) error {
+	t.mu.Lock()
+	login, body := t.loginRequest, t.loginBody
+	t.mu.Unlock()
+
+	if login == nil {
+		return fmt.Errorf("no login request to replay")
+	}
+
+	loginReq := cloneRequest(login.WithContext(req.Context()), body)
+	loginReq.Header.Del("Cookie")
+
+	resp, err := t.base.RoundTrip(loginReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("login failed with status %s", resp.Status)
+	}
+
+	if t.jar != nil {
+		t.jar.SetCookies(req.URL, resp.Cookies())
+	}
+
+	return nil
+}
+
+// requestBody reads the body of req so that it can be sent more than once.
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		b, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer b.Close()
+		return io.ReadAll(b)
+	}
+
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+// cloneRequest returns a copy of req with body.
+func cloneRequest(req *http.Request, body []byte) *http.Request {
+	r := req.Clone(req.Context())
+	if body != nil {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		r.ContentLength = int64(len(body))
+	}
+
+	return r
+}
+
+// requestWithCookies returns a copy of req with the cookies from jar instead
+// of the cookies it was sent with.
+func requestWithCookies(req *http.Request, jar http.CookieJar) *http.Request {
+	if jar == nil {
+		return req
+	}
+
+	r := req.Clone(req.Context())
+	r.Header.Del("Cookie")
+	for _, c := range jar.Cookies(req.URL) {
+		r.AddCookie(c)
+	}
+
+	return r
+}
+
+// isIdempotentOperation returns whether the API operation only reads data.
+func isIdempotentOperation(action string) bool {
+	for _, prefix := range createOperationPrefixes {
+		if strings.HasPrefix(action, prefix) {
+			return false
+		}
+	}
+
+	for _, prefix := range idempotentOperationPrefixes {
+		if strings.HasPrefix(action, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSessionExpiredFault returns whether a response body is a SOAP fault for
+// a session that is no longer valid.
+func isSessionExpiredFault(body []byte) bool {
+	lower := strings.ToLower(string(body))
+	if !strings.Contains(lower, "fault") {
+		return false
+	}
+
+	for _, s := range sessionExpiredFaults {
+		if strings.Contains(lower, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTransientResponse returns whether a response with status code and body
+// is worth retrying. SOAP faults are errors returned by the API itself and
+// are not retried, while errors from a load balancer or proxy in front of it
+// are.
+func isTransientResponse(statusCode int, body []byte) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout, http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError:
+		return !strings.Contains(strings.ToLower(string(body)), "fault")
+	}
+
+	return false
+}
+
+// retryDelay returns the exponential backoff before retry attempt+1.
+func retryDelay(delay time.Duration, attempt int) time.Duration {
+	for i := 0; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
+
+// retryWait waits for delay unless the context is cancelled first.
+func retryWait(ctx context.Context, delay time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
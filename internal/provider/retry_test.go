@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRoundTripper returns the responses for each SOAP action in order and
+// records the requests it was sent.
+type fakeRoundTripper struct {
+	responses map[string][]*http.Response
+	requests  []*http.Request
+}
+
+func (f *fakeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.requests = append(f.requests, req)
+
+	action := req.Header.Get("SOAPAction")
+	responses := f.responses[action]
+	if len(responses) == 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	f.responses[action] = responses[1:]
+
+	return responses[0], nil
+}
+
+func fakeResponse(status int, body string, cookies ...string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	for _, c := range cookies {
+		resp.Header.Add("Set-Cookie", c)
+	}
+
+	return resp
+}
+
+func fakeRequest(t *testing.T, action string, cookie string) *http.Request {
+	req, err := http.NewRequest(http.MethodPost, "https://bam.example.com/Services/API", strings.NewReader("<"+action+"/>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("SOAPAction", action)
+	if cookie != "" {
+		req.Header.Set("Cookie", cookie)
+	}
+
+	return req
+}
+
+func TestRetryTransport(t *testing.T) {
+	const sessionExpired = "<soap:Fault><faultstring>Session expired</faultstring></soap:Fault>"
+	const otherFault = "<soap:Fault><faultstring>Object was not found</faultstring></soap:Fault>"
+
+	tests := map[string]struct {
+		action       string
+		responses    map[string][]*http.Response
+		expectStatus int
+		expectCalls  int
+	}{
+		"success": {
+			action:       "getEntityById",
+			responses:    map[string][]*http.Response{"getEntityById": {fakeResponse(200, "ok")}},
+			expectStatus: 200,
+			expectCalls:  1,
+		},
+		"transient error retried": {
+			action:       "getEntityById",
+			responses:    map[string][]*http.Response{"getEntityById": {fakeResponse(502, ""), fakeResponse(500, "<html>error</html>"), fakeResponse(200, "ok")}},
+			expectStatus: 200,
+			expectCalls:  3,
+		},
+		"retries exhausted": {
+			action:       "searchByObjectTypes",
+			responses:    map[string][]*http.Response{"searchByObjectTypes": {fakeResponse(503, ""), fakeResponse(503, ""), fakeResponse(503, ""), fakeResponse(503, "")}},
+			expectStatus: 503,
+			expectCalls:  3,
+		},
+		"fault not retried": {
+			action:       "getEntityById",
+			responses:    map[string][]*http.Response{"getEntityById": {fakeResponse(500, otherFault), fakeResponse(200, "ok")}},
+			expectStatus: 500,
+			expectCalls:  1,
+		},
+		"not idempotent": {
+			action:       "addEntity",
+			responses:    map[string][]*http.Response{"addEntity": {fakeResponse(502, ""), fakeResponse(200, "ok")}},
+			expectStatus: 502,
+			expectCalls:  1,
+		},
+		"may create objects": {
+			action:       "getNextAvailableIPRange",
+			responses:    map[string][]*http.Response{"getNextAvailableIPRange": {fakeResponse(503, ""), fakeResponse(200, "ok")}},
+			expectStatus: 503,
+			expectCalls:  1,
+		},
+		"session expired": {
+			action: "addEntity",
+			responses: map[string][]*http.Response{
+				"addEntity": {fakeResponse(500, sessionExpired), fakeResponse(200, "ok")},
+				"login":     {fakeResponse(200, "ok", "JSESSIONID=new")},
+			},
+			expectStatus: 200,
+			expectCalls:  3,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			jar, err := cookiejar.New(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fake := &fakeRoundTripper{responses: tc.responses}
+			transport := &retryTransport{base: fake, jar: jar, maxRetries: 2, delay: time.Millisecond}
+
+			// log in first so that there is a login request to replay
+			fake.responses["login"] = append([]*http.Response{fakeResponse(200, "ok", "JSESSIONID=old")}, fake.responses["login"]...)
+			if _, err := transport.RoundTrip(fakeRequest(t, "login", "")); err != nil {
+				t.Fatal(err)
+			}
+			fake.requests = nil
+
+			resp, err := transport.RoundTrip(fakeRequest(t, tc.action, "JSESSIONID=old"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != tc.expectStatus {
+				t.Errorf("expected status %d, got %d", tc.expectStatus, resp.StatusCode)
+			}
+			if len(fake.requests) != tc.expectCalls {
+				t.Errorf("expected %d calls, got %d", tc.expectCalls, len(fake.requests))
+			}
+
+			last := fake.requests[len(fake.requests)-1]
+			if body, _ := io.ReadAll(last.Body); string(body) != "<"+tc.action+"/>" {
+				t.Errorf("expected the request body to be sent again, got %q", body)
+			}
+			if name == "session expired" && last.Header.Get("Cookie") != "JSESSIONID=new" {
+				t.Errorf("expected the new session cookie, got %q", last.Header.Get("Cookie"))
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{attempt: 0, expected: time.Second},
+		{attempt: 1, expected: 2 * time.Second},
+		{attempt: 3, expected: 8 * time.Second},
+		{attempt: 10, expected: maxRetryDelay},
+	}
+
+	for _, tc := range tests {
+		if got := retryDelay(time.Second, tc.attempt); got != tc.expected {
+			t.Errorf("attempt %d: expected %s, got %s", tc.attempt, tc.expected, got)
+		}
+	}
+}
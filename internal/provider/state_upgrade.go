@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// renamedAttributes maps attribute names used by the sdk based releases to
+// the names used by the framework based releases.
+var renamedAttributes = map[string]string{
+	"custom_properties": "user_defined_fields",
+}
+
+// MigrateLegacyAttributes returns the attributes of a resource instance
+// converted to the types of the attributes of t, the object type of the
+// current schema. Attributes that no longer exist are dropped and attributes
+// that did not exist are set to null. A renamed attribute is copied to its new
+// name, and also kept under its old name if the schema still has it as a
+// deprecated alias, so that configurations using the alias show no changes.
+func MigrateLegacyAttributes(old map[string]interface{}, t tftypes.Object) (map[string]interface{}, error) {
+	renamed := make(map[string]interface{}, len(old))
+	for k, v := range old {
+		if newName, ok := renamedAttributes[k]; ok {
+			if _, exists := old[newName]; !exists {
+				renamed[newName] = v
+			}
+		}
+		renamed[k] = v
+	}
+
+	migrated := make(map[string]interface{}, len(t.AttributeTypes))
+	for name, attributeType := range t.AttributeTypes {
+		converted, err := convertValue(renamed[name], attributeType)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		migrated[name] = converted
+	}
+
+	return migrated, nil
+}
+
+// upgradeLegacyState upgrades version 0 of the state of a resource, which is
+// either the state of the sdk based releases or the state of the framework
+// based releases before the schema had a version, to the current schema so
+// that the remote object is not replaced.
+func upgradeLegacyState(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The prior state has no JSON representation to upgrade.")
+		return
+	}
+
+	var old map[string]interface{}
+	if err := json.Unmarshal(req.RawState.JSON, &old); err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Failed to parse the prior state: %s", err))
+		return
+	}
+
+	t, ok := resp.State.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The resource schema is not an object. Please report this issue to the provider developers.")
+		return
+	}
+
+	migrated, err := MigrateLegacyAttributes(old, t)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Failed to convert the prior state: %s", err))
+		return
+	}
+
+	upgraded, err := json.Marshal(migrated)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Failed to encode the upgraded state: %s", err))
+		return
+	}
+
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+}
+
+// convertValue converts a JSON state value to the JSON representation of t.
+// The sdk based releases stored object IDs as strings and some sets as comma
+// separated strings.
+func convertValue(v interface{}, t tftypes.Type) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	switch {
+	case t.Is(tftypes.String):
+		switch x := v.(type) {
+		case string:
+			return x, nil
+		case float64:
+			return strconv.FormatFloat(x, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(x), nil
+		}
+	case t.Is(tftypes.Number):
+		switch x := v.(type) {
+		case float64:
+			return x, nil
+		case string:
+			if x == "" {
+				return nil, nil
+			}
+			f, err := strconv.ParseFloat(x, 64)
+			if err != nil {
+				return nil, err
+			}
+			return f, nil
+		}
+	case t.Is(tftypes.Bool):
+		switch x := v.(type) {
+		case bool:
+			return x, nil
+		case string:
+			switch strings.ToLower(x) {
+			case "":
+				return nil, nil
+			case "enable":
+				return true, nil
+			case "disable":
+				return false, nil
+			}
+			return strconv.ParseBool(x)
+		}
+	case t.Is(tftypes.List{}) || t.Is(tftypes.Set{}):
+		var elementType tftypes.Type
+		if l, ok := t.(tftypes.List); ok {
+			elementType = l.ElementType
+		} else {
+			elementType = t.(tftypes.Set).ElementType
+		}
+
+		var elements []interface{}
+		switch x := v.(type) {
+		case []interface{}:
+			elements = x
+		case string:
+			for _, e := range strings.Split(x, ",") {
+				if e != "" {
+					elements = append(elements, e)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("cannot convert %T to %s", v, t)
+		}
+
+		converted := make([]interface{}, 0, len(elements))
+		for _, e := range elements {
+			c, err := convertValue(e, elementType)
+			if err != nil {
+				return nil, err
+			}
+			converted = append(converted, c)
+		}
+		return converted, nil
+	case t.Is(tftypes.Object{}):
+		x, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %T to %s", v, t)
+		}
+
+		converted := make(map[string]interface{}, len(t.(tftypes.Object).AttributeTypes))
+		for k, attributeType := range t.(tftypes.Object).AttributeTypes {
+			c, err := convertValue(x[k], attributeType)
+			if err != nil {
+				return nil, err
+			}
+			converted[k] = c
+		}
+		return converted, nil
+	case t.Is(tftypes.Map{}):
+		x, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %T to %s", v, t)
+		}
+
+		converted := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			c, err := convertValue(e, t.(tftypes.Map).ElementType)
+			if err != nil {
+				return nil, err
+			}
+			converted[k] = c
+		}
+		return converted, nil
+	}
+
+	return nil, fmt.Errorf("cannot convert %T to %s", v, t)
+}
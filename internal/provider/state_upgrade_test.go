@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMigrateLegacyAttributes(t *testing.T) {
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":                   tftypes.String,
+		"parent_id":            tftypes.Number,
+		"allow_duplicate_host": tftypes.Bool,
+		"default_domains":      tftypes.Set{ElementType: tftypes.Number},
+		"user_defined_fields":  tftypes.Map{ElementType: tftypes.String},
+		"location_code":        tftypes.String,
+		"credentials":          tftypes.Object{AttributeTypes: map[string]tftypes.Type{"username": tftypes.String}},
+	}}
+
+	old := map[string]interface{}{
+		"id":                   "123",
+		"parent_id":            "456",
+		"allow_duplicate_host": "enable",
+		"default_domains":      "1,2",
+		"custom_properties":    map[string]interface{}{"Owner": "me"},
+		"computed_parent_id":   "456",
+	}
+
+	expected := map[string]interface{}{
+		"id":                   "123",
+		"parent_id":            float64(456),
+		"allow_duplicate_host": true,
+		"default_domains":      []interface{}{float64(1), float64(2)},
+		"user_defined_fields":  map[string]interface{}{"Owner": "me"},
+		"location_code":        nil,
+		"credentials":          nil,
+	}
+
+	migrated, err := MigrateLegacyAttributes(old, objectType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(migrated, expected) {
+		t.Errorf("expected %v, got %v", expected, migrated)
+	}
+
+	// the state of the framework based releases is already converted
+	current := map[string]interface{}{
+		"id":                   "123",
+		"parent_id":            float64(456),
+		"allow_duplicate_host": true,
+		"default_domains":      []interface{}{float64(1), float64(2)},
+		"user_defined_fields":  map[string]interface{}{"Owner": "me"},
+		"location_code":        nil,
+		"credentials":          map[string]interface{}{"username": "admin"},
+	}
+
+	migrated, err = MigrateLegacyAttributes(current, objectType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(migrated, current) {
+		t.Errorf("expected %v, got %v", current, migrated)
+	}
+}
+
+func TestHostRecordUpgradeState(t *testing.T) {
+	ctx := context.Background()
+	r := &HostRecordResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	if !ok {
+		t.Fatal("expected an upgrader for version 0")
+	}
+
+	req := resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{
+			"id": "1001",
+			"name": "host",
+			"dns_zone": "example.com",
+			"view_id": "2002",
+			"ttl": "-1",
+			"reverse_record": "true",
+			"addresses": ["10.0.0.10"],
+			"custom_properties": {"Owner": "me"}
+		}`)},
+	}
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	upgrader.StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	v, err := resp.DynamicValue.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: v}
+
+	var data HostRecordResourceModel
+	if diags := state.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if data.ID.ValueString() != "1001" {
+		t.Errorf("expected id 1001, got %s", data.ID)
+	}
+	if data.ViewID.ValueInt64() != 2002 {
+		t.Errorf("expected view_id 2002, got %s", data.ViewID)
+	}
+	if data.TTL.ValueInt64() != -1 {
+		t.Errorf("expected ttl -1, got %s", data.TTL)
+	}
+	if !data.ReverseRecord.ValueBool() {
+		t.Errorf("expected reverse_record true, got %s", data.ReverseRecord)
+	}
+	expected := types.MapValueMust(types.StringType, map[string]attr.Value{"Owner": types.StringValue("me")})
+	if !data.UserDefinedFields.Equal(expected) {
+		t.Errorf("expected user_defined_fields %s, got %s", expected, data.UserDefinedFields)
+	}
+	// custom_properties is still a deprecated alias, so configurations using it
+	// show no changes after the upgrade
+	if !data.CustomProperties.Equal(expected) {
+		t.Errorf("expected custom_properties %s, got %s", expected, data.CustomProperties)
+	}
+	if !data.Credentials.IsNull() {
+		t.Errorf("expected null credentials, got %s", data.Credentials)
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/umich-vci/gobam"
+)
+
+// templateTaskPollInterval is how often the status of a template task is
+// checked while waiting for it to complete.
+var templateTaskPollInterval = 2 * time.Second
+
+// templateTaskTimeout is how long to wait for a template task to complete.
+const templateTaskTimeout = 5 * time.Minute
+
+// templateTaskPending contains the statuses of a template task that has not
+// completed yet.
+var templateTaskPending = []string{"QUEUED", "PENDING", "STARTED", "RUNNING"}
+
+// templateTaskFailed contains the statuses of a template task that did not succeed.
+var templateTaskFailed = []string{"CANCELLED", "FAILED", "ERROR"}
+
+// templateTaskStatus is the JSON document returned by getTemplateTaskStatus.
+type templateTaskStatus struct {
+	Status string `json:"status"`
+}
+
+// applyIP4NetworkTemplate applies an IPv4 network template to a network and
+// waits for the template task to complete.
+func applyIP4NetworkTemplate(ctx context.Context, client gobam.ProteusAPI, templateID int64, networkID int64) error {
+	taskID, err := client.ApplyIP4NetworkTemplate(templateID, networkID, "")
+	if err != nil {
+		return err
+	}
+
+	// older versions of BlueCat Address Manager apply templates synchronously
+	if taskID == "" {
+		return nil
+	}
+
+	deadline := time.Now().Add(templateTaskTimeout)
+	for {
+		s, err := client.GetTemplateTaskStatus(taskID)
+		if err != nil {
+			return err
+		}
+
+		var status templateTaskStatus
+		if err := json.Unmarshal([]byte(s), &status); err != nil {
+			return fmt.Errorf("failed to parse template task status %q: %w", s, err)
+		}
+
+		if slices.Contains(templateTaskFailed, status.Status) {
+			return fmt.Errorf("applying template %d to network %d ended with status %s", templateID, networkID, status.Status)
+		}
+
+		if !slices.Contains(templateTaskPending, status.Status) {
+			return nil
+		}
+
+		if time.Now().Add(templateTaskPollInterval).After(deadline) {
+			return fmt.Errorf("applying template %d to network %d did not complete: timed out", templateID, networkID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(templateTaskPollInterval):
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/umich-vci/gobam"
+)
+
+// templateTaskClient returns the given template task statuses in order.
+type templateTaskClient struct {
+	gobam.ProteusAPI
+	taskID   string
+	statuses []string
+	polls    int
+}
+
+func (c *templateTaskClient) ApplyIP4NetworkTemplate(templateId int64, networkId int64, properties string) (string, error) {
+	return c.taskID, nil
+}
+
+func (c *templateTaskClient) GetTemplateTaskStatus(taskId string) (string, error) {
+	status := c.statuses[c.polls]
+	c.polls++
+	return `{"status":"` + status + `"}`, nil
+}
+
+func TestApplyIP4NetworkTemplate(t *testing.T) {
+	templateTaskPollInterval = time.Millisecond
+
+	tests := map[string]struct {
+		client      *templateTaskClient
+		expectError bool
+		expectPolls int
+	}{
+		"synchronous": {
+			client:      &templateTaskClient{},
+			expectPolls: 0,
+		},
+		"completed": {
+			client:      &templateTaskClient{taskID: "task", statuses: []string{"QUEUED", "RUNNING", "COMPLETED"}},
+			expectPolls: 3,
+		},
+		"failed": {
+			client:      &templateTaskClient{taskID: "task", statuses: []string{"RUNNING", "FAILED"}},
+			expectError: true,
+			expectPolls: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		err := applyIP4NetworkTemplate(context.Background(), tc.client, 1, 2)
+		if tc.expectError && err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if !tc.expectError && err != nil {
+			t.Errorf("%s: unexpected error %s", name, err)
+		}
+		if tc.client.polls != tc.expectPolls {
+			t.Errorf("%s: expected %d polls, got %d", name, tc.expectPolls, tc.client.polls)
+		}
+	}
+}
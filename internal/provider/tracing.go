@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/umich-vci/terraform-provider-bluecat"
+
+// soapTraceParameters maps the names of API call parameters that identify
+// entities to the span attribute they are recorded as.
+var soapTraceParameters = map[string]string{
+	"id":              "bluecat.entity.id",
+	"entityId":        "bluecat.entity.id",
+	"objectId":        "bluecat.entity.id",
+	"type":            "bluecat.entity.type",
+	"parentId":        "bluecat.parent.id",
+	"containerId":     "bluecat.parent.id",
+	"configurationId": "bluecat.configuration.id",
+}
+
+// newTracer returns a tracer that exports spans to an OTLP/HTTP endpoint.
+// If endpoint is empty, the standard OTEL_EXPORTER_OTLP_* environment
+// variables are used to configure the exporter.
+// Spans are exported synchronously because the provider has no shutdown hook
+// where batched spans could be flushed.
+func newTracer(ctx context.Context, endpoint string, version string) (trace.Tracer, error) {
+	var opts []otlptracehttp.Option
+	if endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+	}
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := sdkresource.Merge(
+		sdkresource.Default(),
+		sdkresource.NewSchemaless(
+			semconv.ServiceName("terraform-provider-bluecat"),
+			semconv.ServiceVersion(version),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	return tp.Tracer(tracerName, trace.WithInstrumentationVersion(version)), nil
+}
+
+// tracingTransport is a http.RoundTripper that records a span for each
+// BlueCat Address Manager API call.
+type tracingTransport struct {
+	base   http.RoundTripper
+	tracer trace.Tracer
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	action := req.Header.Get("SOAPAction")
+	action = action[strings.LastIndex(action, "/")+1:]
+
+	attrs := []attribute.KeyValue{
+		attribute.String("bluecat.operation", action),
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			attrs = append(attrs, soapRequestAttributes(body)...)
+			body.Close()
+		}
+	}
+
+	ctx, span := t.tracer.Start(req.Context(), "BlueCat "+action,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode != http.StatusOK {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
+
+// soapRequestAttributes returns span attributes for the parameters of a SOAP
+// request that identify the entities the call operates on.
+func soapRequestAttributes(body io.Reader) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	var text bytes.Buffer
+	seen := make(map[string]bool)
+	depth := 0
+
+	decoder := xml.NewDecoder(body)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return attrs
+			}
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			text.Reset()
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			// parameters are children of the operation element in the
+			// envelope body: Envelope > Body > operation > parameter
+			if depth == 4 {
+				key, ok := soapTraceParameters[t.Name.Local]
+				value := strings.TrimSpace(text.String())
+				if ok && value != "" && !seen[key] {
+					seen[key] = true
+					if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+						attrs = append(attrs, attribute.Int64(key, i))
+					} else {
+						attrs = append(attrs, attribute.String(key, value))
+					}
+				}
+			}
+			depth--
+		}
+	}
+
+	return attrs
+}
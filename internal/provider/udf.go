@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umich-vci/gobam"
+	"golang.org/x/exp/maps"
+)
+
+// udfDefinitionCache holds the user-defined fields defined in BlueCat Address
+// Manager for each object type so that they are only fetched once per run.
+type udfDefinitionCache struct {
+	mu          sync.Mutex
+	definitions map[string][]*gobam.APIUserDefinedField
+}
+
+// get returns the user-defined fields defined for objectType, fetching them
+// with client if they have not been fetched yet.
+func (c *udfDefinitionCache) get(client gobam.ProteusAPI, objectType string) ([]*gobam.APIUserDefinedField, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if definitions, ok := c.definitions[objectType]; ok {
+		return definitions, nil
+	}
+
+	fields, err := client.GetUserDefinedFields(objectType, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.definitions == nil {
+		c.definitions = make(map[string][]*gobam.APIUserDefinedField)
+	}
+	c.definitions[objectType] = fields.Item
+
+	return fields.Item, nil
+}
+
+// modifyPlanCustomProperties plans user_defined_fields of a resource with the
+// deprecated custom_properties attribute as the value of custom_properties
+// when it is configured, so that configurations written for the sdk based
+// releases keep working.
+func modifyPlanCustomProperties(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var customProperties types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("custom_properties"), &customProperties)...)
+	if resp.Diagnostics.HasError() || customProperties.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("user_defined_fields"), customProperties)...)
+}
+
+// modifyPlanUserDefinedFields validates the planned user_defined_fields of a
+// resource managing objects of objectType against the user-defined fields
+// defined in BlueCat Address Manager, if the provider is configured to.
+func modifyPlanUserDefinedFields(ctx context.Context, loginClient *loginClient, objectType string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to validate when the resource is being destroyed
+	if loginClient == nil || !loginClient.ValidateUDFs || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var udfs types.Map
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("user_defined_fields"), &udfs)...)
+	if resp.Diagnostics.HasError() || udfs.IsNull() || udfs.IsUnknown() {
+		return
+	}
+
+	values := make(map[string]string)
+	for k, v := range udfs.Elements() {
+		s, ok := v.(types.String)
+		if !ok || s.IsUnknown() || s.IsNull() {
+			continue
+		}
+		values[k] = s.ValueString()
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	client, diag := clientLoginWithConfigCredentials(ctx, loginClient, req.Config)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	definitions, err := loginClient.UDFDefinitions.get(client, objectType)
+	resp.Diagnostics.Append(clientLogout(ctx, &client)...)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to get the user-defined fields of %s", objectType), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(checkUserDefinedFields(definitions, values, objectType)...)
+}
+
+// checkUserDefinedFields returns an error for each user-defined field in udfs
+// that is not defined for objectType or has a value that is not valid for its
+// definition.
+func checkUserDefinedFields(definitions []*gobam.APIUserDefinedField, udfs map[string]string, objectType string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	defined := make(map[string]*gobam.APIUserDefinedField, len(definitions))
+	for _, definition := range definitions {
+		if definition != nil && definition.Name != nil {
+			defined[*definition.Name] = definition
+		}
+	}
+
+	keys := maps.Keys(udfs)
+	slices.Sort(keys)
+	for _, k := range keys {
+		attributePath := path.Root("user_defined_fields").AtMapKey(k)
+
+		definition, ok := defined[k]
+		if !ok {
+			names := maps.Keys(defined)
+			slices.Sort(names)
+			diags.AddAttributeError(
+				attributePath,
+				"Unknown User-Defined Field",
+				fmt.Sprintf("%q is not a user-defined field of %s. Defined fields are: %s", k, objectType, strings.Join(names, ", ")),
+			)
+			continue
+		}
+
+		if err := checkUserDefinedFieldValue(definition, udfs[k]); err != nil {
+			diags.AddAttributeError(
+				attributePath,
+				"Invalid User-Defined Field Value",
+				fmt.Sprintf("Invalid value for user-defined field %q of %s: %s", k, objectType, err.Error()),
+			)
+		}
+	}
+
+	return diags
+}
+
+// checkUserDefinedFieldValue returns an error if value is not valid for the
+// type or predefined values of a user-defined field. Empty values clear the
+// field and are always valid.
+func checkUserDefinedFieldValue(definition *gobam.APIUserDefinedField, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	if allowed := udfPredefinedValues(definition.PredefinedValues); len(allowed) > 0 && !slices.Contains(allowed, value) {
+		return fmt.Errorf("%q is not one of the predefined values %s", value, strings.Join(allowed, ", "))
+	}
+
+	if definition.Type == nil {
+		return nil
+	}
+
+	switch *definition.Type {
+	case udfTypeBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+	case udfTypeInteger:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+	}
+
+	return nil
+}
+
+// udfPredefinedValues splits the pipe delimited predefined values of a
+// user-defined field.
+func udfPredefinedValues(predefinedValues *string) []string {
+	var values []string
+	if predefinedValues == nil {
+		return values
+	}
+
+	for _, v := range strings.Split(*predefinedValues, "|") {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
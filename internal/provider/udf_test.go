@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/umich-vci/gobam"
+)
+
+// udfDefinitionClient returns the user-defined fields of each object type and
+// counts how many times they were requested.
+type udfDefinitionClient struct {
+	gobam.ProteusAPI
+	fields map[string][]*gobam.APIUserDefinedField
+	calls  int
+}
+
+func (c *udfDefinitionClient) GetUserDefinedFields(_type string, requiredFieldsOnly bool) (*gobam.APIUserDefinedFieldArray, error) {
+	c.calls++
+	return &gobam.APIUserDefinedFieldArray{Item: c.fields[_type]}, nil
+}
+
+func testUDFDefinition(name, fieldType, predefinedValues string) *gobam.APIUserDefinedField {
+	return &gobam.APIUserDefinedField{Name: &name, Type: &fieldType, PredefinedValues: &predefinedValues}
+}
+
+func TestCheckUserDefinedFields(t *testing.T) {
+	definitions := []*gobam.APIUserDefinedField{
+		testUDFDefinition("owner", "TEXT", ""),
+		testUDFDefinition("environment", "TEXT", "prod|dev|"),
+		testUDFDefinition("vlan", "INTEGER", ""),
+		testUDFDefinition("monitored", "BOOLEAN", ""),
+	}
+
+	tests := map[string]struct {
+		udfs    map[string]string
+		wantErr bool
+	}{
+		"valid":           {udfs: map[string]string{"owner": "alice", "environment": "prod", "vlan": "10", "monitored": "true"}},
+		"cleared":         {udfs: map[string]string{"environment": "", "vlan": ""}},
+		"unknown key":     {udfs: map[string]string{"tenant": "a"}, wantErr: true},
+		"not predefined":  {udfs: map[string]string{"environment": "test"}, wantErr: true},
+		"invalid integer": {udfs: map[string]string{"vlan": "ten"}, wantErr: true},
+		"invalid boolean": {udfs: map[string]string{"monitored": "sometimes"}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := checkUserDefinedFields(definitions, tc.udfs, "IP4Network")
+			if diags.HasError() != tc.wantErr {
+				t.Errorf("expected error %t, got %v", tc.wantErr, diags)
+			}
+		})
+	}
+}
+
+func TestUDFDefinitionCache(t *testing.T) {
+	client := &udfDefinitionClient{fields: map[string][]*gobam.APIUserDefinedField{
+		"IP4Network": {testUDFDefinition("owner", "TEXT", "")},
+	}}
+	cache := &udfDefinitionCache{}
+
+	for range 2 {
+		definitions, err := cache.get(client, "IP4Network")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(definitions) != 1 {
+			t.Errorf("expected 1 definition, got %d", len(definitions))
+		}
+	}
+	if client.calls != 1 {
+		t.Errorf("expected the definitions to be fetched once, fetched %d times", client.calls)
+	}
+}